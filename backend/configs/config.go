@@ -14,6 +14,9 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	Reports  ReportsConfig
+	Telegram TelegramConfig
+	Jobs     JobsConfig
 	Logger   *logrus.Logger
 }
 
@@ -42,6 +45,24 @@ type JWTConfig struct {
 	ExpiresIn time.Duration
 }
 
+// ReportsConfig содержит настройки формирования PDF-отчетов
+type ReportsConfig struct {
+	FontPath     string
+	BoldFontPath string
+}
+
+// TelegramConfig содержит настройки отправки уведомлений через Telegram Bot API
+type TelegramConfig struct {
+	BotToken string
+	APIURL   string
+}
+
+// JobsConfig содержит настройки фоновых задач
+type JobsConfig struct {
+	NetWorthSnapshotInterval time.Duration
+	AnomalyCheckInterval     time.Duration
+}
+
 // LoadConfig загружает конфигурацию из переменных окружения
 func LoadConfig() *Config {
 	// Загрузка переменных окружения из .env файла, если он существует
@@ -99,6 +120,9 @@ func LoadConfig() *Config {
 		Server:   serverConfig,
 		Database: dbConfig,
 		JWT:      jwtConfig,
+		Reports:  loadReportsConfig(),
+		Telegram: loadTelegramConfig(),
+		Jobs:     loadJobsConfig(),
 		Logger:   logger,
 	}
 }
@@ -172,6 +196,46 @@ func LoadFromEnv() (*Config, error) {
 		Server:   serverConfig,
 		Database: *dbConfig,
 		JWT:      jwtConfig,
+		Reports:  loadReportsConfig(),
+		Telegram: loadTelegramConfig(),
+		Jobs:     loadJobsConfig(),
 		Logger:   logger,
 	}, nil
 }
+
+// loadReportsConfig загружает настройки PDF-отчетов.
+// По умолчанию используются шрифты DejaVu, которые поддерживают кириллицу
+func loadReportsConfig() ReportsConfig {
+	return ReportsConfig{
+		FontPath:     getEnv("REPORT_FONT_PATH", "/usr/share/fonts/dejavu/DejaVuSans.ttf"),
+		BoldFontPath: getEnv("REPORT_FONT_BOLD_PATH", "/usr/share/fonts/dejavu/DejaVuSans-Bold.ttf"),
+	}
+}
+
+// loadTelegramConfig загружает настройки уведомлений в Telegram.
+// Если токен бота не указан, уведомления не отправляются
+func loadTelegramConfig() TelegramConfig {
+	return TelegramConfig{
+		BotToken: os.Getenv("TELEGRAM_BOT_TOKEN"),
+		APIURL:   getEnv("TELEGRAM_API_URL", "https://api.telegram.org"),
+	}
+}
+
+// loadJobsConfig загружает настройки фоновых задач.
+// Интервал задается в часах, нулевое значение отключает задачу
+func loadJobsConfig() JobsConfig {
+	snapshotInterval, err := strconv.Atoi(getEnv("NETWORTH_SNAPSHOT_INTERVAL", "24"))
+	if err != nil {
+		snapshotInterval = 24
+	}
+
+	anomalyInterval, err := strconv.Atoi(getEnv("ANOMALY_CHECK_INTERVAL", "6"))
+	if err != nil {
+		anomalyInterval = 6
+	}
+
+	return JobsConfig{
+		NetWorthSnapshotInterval: time.Duration(snapshotInterval) * time.Hour,
+		AnomalyCheckInterval:     time.Duration(anomalyInterval) * time.Hour,
+	}
+}
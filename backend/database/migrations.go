@@ -83,6 +83,408 @@ CREATE TABLE IF NOT EXISTS telegram_users (
 );
 CREATE INDEX IF NOT EXISTS idx_telegram_users_user_id ON telegram_users(user_id);
 CREATE INDEX IF NOT EXISTS idx_telegram_users_telegram_id ON telegram_users(telegram_id);
+`,
+	// Миграция для импорта банковских выписок с предпросмотром и откатом
+	`
+CREATE TABLE IF NOT EXISTS imports (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255),
+    account VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'preview',
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    duplicate_rows INTEGER NOT NULL DEFAULT 0,
+    committed_rows INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS import_rows (
+    id SERIAL PRIMARY KEY,
+    import_id INTEGER REFERENCES imports(id) ON DELETE CASCADE,
+    external_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    category VARCHAR(50),
+    source VARCHAR(50),
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    description TEXT,
+    duplicate BOOLEAN NOT NULL DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS idx_imports_user_id ON imports(user_id);
+CREATE INDEX IF NOT EXISTS idx_import_rows_import_id ON import_rows(import_id);
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS import_id INTEGER REFERENCES imports(id) ON DELETE SET NULL;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS import_id INTEGER REFERENCES imports(id) ON DELETE SET NULL;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_expenses_user_external_id ON expenses(user_id, external_id) WHERE external_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incomes_user_external_id ON incomes(user_id, external_id) WHERE external_id IS NOT NULL;
+`,
+	// Миграция для сопоставления категорий и источников счетам hledger и Beancount
+	`
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    key VARCHAR(50) NOT NULL,
+    account VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE (user_id, kind, key)
+);
+`,
+	// Миграция для целей накоплений и взносов в них
+	`
+CREATE TABLE IF NOT EXISTS goals (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    target_amount DECIMAL(12, 2) NOT NULL,
+    deadline DATE,
+    account VARCHAR(100),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS goal_contributions (
+    id SERIAL PRIMARY KEY,
+    goal_id INTEGER REFERENCES goals(id) ON DELETE CASCADE,
+    amount DECIMAL(12, 2) NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_goals_user_id ON goals(user_id);
+CREATE INDEX IF NOT EXISTS idx_goal_contributions_goal_id ON goal_contributions(goal_id);
+`,
+	// Миграция для резервирования денег под элементы списка желаний
+	`
+CREATE TABLE IF NOT EXISTS wishlist_allocations (
+    id SERIAL PRIMARY KEY,
+    wishlist_id INTEGER REFERENCES wishlist(id) ON DELETE CASCADE,
+    amount DECIMAL(12, 2) NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_wishlist_allocations_wishlist_id ON wishlist_allocations(wishlist_id);
+`,
+	// Миграция для отметки покупки элемента списка желаний и связанной траты
+	`
+ALTER TABLE wishlist ADD COLUMN IF NOT EXISTS purchased_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE wishlist ADD COLUMN IF NOT EXISTS expense_id INTEGER REFERENCES expenses(id) ON DELETE SET NULL;
+`,
+	// Миграция для истории цен и целевой цены элементов списка желаний
+	`
+ALTER TABLE wishlist ADD COLUMN IF NOT EXISTS target_price DECIMAL(12, 2);
+CREATE TABLE IF NOT EXISTS wishlist_prices (
+    id SERIAL PRIMARY KEY,
+    wishlist_id INTEGER REFERENCES wishlist(id) ON DELETE CASCADE,
+    price DECIMAL(12, 2) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    observed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_wishlist_prices_wishlist_id ON wishlist_prices(wishlist_id, observed_at);
+`,
+	// Миграция для публичных ссылок на список желаний и анонимного резервирования подарков
+	`
+ALTER TABLE wishlist ADD COLUMN IF NOT EXISTS shared BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE TABLE IF NOT EXISTS wishlist_shares (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    hide_prices BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS wishlist_reservations (
+    id SERIAL PRIMARY KEY,
+    wishlist_id INTEGER UNIQUE REFERENCES wishlist(id) ON DELETE CASCADE,
+    reserver_name VARCHAR(100),
+    cancel_token VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+`,
+	// Миграция для домохозяйств с участниками, приглашениями и общими бюджетами
+	`
+CREATE TABLE IF NOT EXISTS households (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS household_members (
+    household_id INTEGER REFERENCES households(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (household_id, user_id)
+);
+CREATE TABLE IF NOT EXISTS household_invitations (
+    id SERIAL PRIMARY KEY,
+    household_id INTEGER REFERENCES households(id) ON DELETE CASCADE,
+    invited_by INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    invitee_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255),
+    telegram_username VARCHAR(100),
+    role VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+CREATE TABLE IF NOT EXISTS household_budgets (
+    household_id INTEGER REFERENCES households(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    PRIMARY KEY (household_id, category)
+);
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS household_id INTEGER REFERENCES households(id) ON DELETE SET NULL;
+ALTER TABLE incomes ADD COLUMN IF NOT EXISTS household_id INTEGER REFERENCES households(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_household_members_user_id ON household_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_household_invitations_invitee_id ON household_invitations(invitee_id, status);
+CREATE INDEX IF NOT EXISTS idx_expenses_household_id ON expenses(household_id, date);
+CREATE INDEX IF NOT EXISTS idx_incomes_household_id ON incomes(household_id, date);
+`,
+	// Миграция для разделения общих трат и учета долгов между участниками
+	`
+CREATE TABLE IF NOT EXISTS split_groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS split_participants (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER REFERENCES split_groups(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE (group_id, user_id)
+);
+CREATE TABLE IF NOT EXISTS split_expenses (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER REFERENCES split_groups(id) ON DELETE CASCADE,
+    paid_by INTEGER REFERENCES split_participants(id),
+    title VARCHAR(100) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    description TEXT,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS split_expense_shares (
+    split_expense_id INTEGER REFERENCES split_expenses(id) ON DELETE CASCADE,
+    participant_id INTEGER REFERENCES split_participants(id),
+    value DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    amount DECIMAL(12, 2) NOT NULL,
+    PRIMARY KEY (split_expense_id, participant_id)
+);
+CREATE TABLE IF NOT EXISTS split_settlements (
+    id SERIAL PRIMARY KEY,
+    group_id INTEGER REFERENCES split_groups(id) ON DELETE CASCADE,
+    from_participant INTEGER REFERENCES split_participants(id),
+    to_participant INTEGER REFERENCES split_participants(id),
+    amount DECIMAL(12, 2) NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    note VARCHAR(255),
+    expense_id INTEGER REFERENCES expenses(id) ON DELETE SET NULL,
+    income_id INTEGER REFERENCES incomes(id) ON DELETE SET NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_split_participants_user_id ON split_participants(user_id);
+CREATE INDEX IF NOT EXISTS idx_split_expenses_group_id ON split_expenses(group_id, date);
+CREATE INDEX IF NOT EXISTS idx_split_settlements_group_id ON split_settlements(group_id, date);
+`,
+	// Миграция для учета кредитов и платежей по ним
+	`
+CREATE TABLE IF NOT EXISTS loans (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    lender VARCHAR(100),
+    principal DECIMAL(12, 2) NOT NULL,
+    rate DECIMAL(6, 3) NOT NULL DEFAULT 0,
+    term_months INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    category VARCHAR(50) NOT NULL DEFAULT 'other',
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS loan_payments (
+    id SERIAL PRIMARY KEY,
+    loan_id INTEGER REFERENCES loans(id) ON DELETE CASCADE,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    principal DECIMAL(12, 2) NOT NULL,
+    interest DECIMAL(12, 2) NOT NULL,
+    early BOOLEAN NOT NULL DEFAULT false,
+    strategy VARCHAR(20),
+    expense_id INTEGER REFERENCES expenses(id) ON DELETE SET NULL,
+    expense_created BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_loans_user_id ON loans(user_id);
+CREATE INDEX IF NOT EXISTS idx_loan_payments_loan_id ON loan_payments(loan_id, date);
+`,
+	// Миграция для учета активов, обязательств и чистой стоимости капитала
+	`
+CREATE TABLE IF NOT EXISTS assets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    liability BOOLEAN NOT NULL DEFAULT false,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS asset_valuations (
+    id SERIAL PRIMARY KEY,
+    asset_id INTEGER REFERENCES assets(id) ON DELETE CASCADE,
+    value DECIMAL(14, 2) NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    assets DECIMAL(14, 2) NOT NULL,
+    liabilities DECIMAL(14, 2) NOT NULL,
+    net_worth DECIMAL(14, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (user_id, date)
+);
+CREATE INDEX IF NOT EXISTS idx_assets_user_id ON assets(user_id);
+CREATE INDEX IF NOT EXISTS idx_asset_valuations_asset_id ON asset_valuations(asset_id, date);
+`,
+	// Миграция для учета инвестиционного портфеля и цен ценных бумаг
+	`
+CREATE TABLE IF NOT EXISTS investment_transactions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    ticker VARCHAR(20) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    quantity DECIMAL(18, 6) NOT NULL DEFAULT 0,
+    price DECIMAL(14, 4) NOT NULL DEFAULT 0,
+    fee DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    amount DECIMAL(14, 2) NOT NULL DEFAULT 0,
+    note VARCHAR(255),
+    income_id INTEGER REFERENCES incomes(id) ON DELETE SET NULL,
+    income_created BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS security_prices (
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    ticker VARCHAR(20) NOT NULL,
+    date DATE NOT NULL,
+    price DECIMAL(14, 4) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (user_id, ticker, date)
+);
+CREATE INDEX IF NOT EXISTS idx_investment_transactions_user_id ON investment_transactions(user_id, ticker, date);
+`,
+	// Миграция для правил автоматической категоризации, меток и счетов трат
+	`
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS account VARCHAR(100);
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    title_pattern VARCHAR(255),
+    description_pattern VARCHAR(255),
+    min_amount DECIMAL(12, 2),
+    max_amount DECIMAL(12, 2),
+    account VARCHAR(100),
+    weekdays INTEGER[] NOT NULL DEFAULT '{}',
+    category VARCHAR(50),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    clean_title VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS rule_id INTEGER REFERENCES categorization_rules(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_user_id ON categorization_rules(user_id, priority);
+CREATE INDEX IF NOT EXISTS idx_expenses_tags ON expenses USING GIN (tags);
+`,
+	// Миграция для получателей платежей с псевдонимами и категорией по умолчанию
+	`
+CREATE TABLE IF NOT EXISTS payees (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    default_category VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payees_user_name ON payees(user_id, LOWER(name));
+CREATE TABLE IF NOT EXISTS payee_aliases (
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    payee_id INTEGER NOT NULL REFERENCES payees(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, alias)
+);
+CREATE INDEX IF NOT EXISTS idx_payee_aliases_payee_id ON payee_aliases(payee_id);
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS payee_id INTEGER REFERENCES payees(id) ON DELETE SET NULL;
+ALTER TABLE import_rows ADD COLUMN IF NOT EXISTS payee_id INTEGER REFERENCES payees(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_expenses_payee_id ON expenses(user_id, payee_id);
+`,
+	// Миграция для подтвержденных подписок и других регулярных списаний
+	`
+CREATE TABLE IF NOT EXISTS recurring_rules (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    match_key VARCHAR(150) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    payee_id INTEGER REFERENCES payees(id) ON DELETE SET NULL,
+    category VARCHAR(50) NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    cadence VARCHAR(20) NOT NULL,
+    next_date DATE NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE (user_id, match_key)
+);
+`,
+	// Миграция для предупреждений о необычных тратах
+	`
+CREATE TABLE IF NOT EXISTS alerts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL,
+    expense_id INTEGER REFERENCES expenses(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    period DATE NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    expected DECIMAL(12, 2) NOT NULL,
+    score DECIMAL(8, 2) NOT NULL,
+    message TEXT NOT NULL,
+    notified BOOLEAN NOT NULL DEFAULT FALSE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_alerts_expense ON alerts(expense_id) WHERE kind = 'unusual_amount';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_alerts_category_period ON alerts(user_id, category, period) WHERE kind = 'category_spike';
+CREATE INDEX IF NOT EXISTS idx_alerts_user_id ON alerts(user_id, created_at);
+`,
+	// Миграция для часового пояса пользователя, в котором считаются дни и месяцы
+	`
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
 `,
 }
 
@@ -0,0 +1,56 @@
+package exporters
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvWriter записывает таблицу в формате CSV
+type csvWriter struct {
+	writer   *csv.Writer
+	location *time.Location
+}
+
+// newCSVWriter создает CSV-писатель. Метка порядка байтов нужна, чтобы Excel корректно открыл UTF-8
+func newCSVWriter(w io.Writer, location *time.Location) *csvWriter {
+	io.WriteString(w, "\ufeff")
+	return &csvWriter{writer: csv.NewWriter(w), location: location}
+}
+
+// WriteRow записывает строку таблицы
+func (c *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatCSVValue(value, c.location)
+	}
+	return c.writer.Write(record)
+}
+
+// WriteSummary ничего не делает: CSV не поддерживает несколько листов
+func (c *csvWriter) WriteSummary(title string, rows [][]interface{}) error {
+	return nil
+}
+
+// Close дописывает буферизованные данные
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// formatCSVValue преобразует значение ячейки в строку. Дата выводится в часовом поясе location
+func formatCSVValue(value interface{}, location *time.Location) string {
+	switch v := value.(type) {
+	case string:
+		return escapeFormula(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.In(location).Format("2006-01-02")
+	default:
+		return ""
+	}
+}
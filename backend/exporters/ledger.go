@@ -0,0 +1,163 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// LedgerEntry описывает операцию для текстовой бухгалтерии: сумма переходит со счета From на счет To.
+// Для траты From - актив, To - счет расходов; для накопления From - счет доходов, To - актив;
+// для перевода оба счета являются активами.
+type LedgerEntry struct {
+	ID        int64
+	Date      time.Time
+	Payee     string
+	Narration string
+	From      string
+	To        string
+	Amount    float64
+}
+
+// WriteLedger записывает операции в формате hledger или Beancount. Даты операций выводятся
+// в часовом поясе пользователя location.
+// Операции упорядочиваются по дате, счетам и ID, поэтому повторная выгрузка тех же данных дает идентичный файл.
+func WriteLedger(w io.Writer, format models.LedgerFormat, currency string, location *time.Location, entries []LedgerEntry) error {
+	sorted := make([]LedgerEntry, len(entries))
+	copy(sorted, entries)
+	for i := range sorted {
+		sorted[i].Date = sorted[i].Date.In(location)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		dayA, dayB := a.Date.Format("2006-01-02"), b.Date.Format("2006-01-02")
+		if dayA != dayB {
+			return dayA < dayB
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.ID < b.ID
+	})
+
+	switch format {
+	case models.LedgerFormatHledger:
+		return writeHledger(w, currency, sorted)
+	case models.LedgerFormatBeancount:
+		return writeBeancount(w, currency, sorted)
+	default:
+		return fmt.Errorf("неподдерживаемый формат бухгалтерской книги: %s", format)
+	}
+}
+
+// writeHledger записывает журнал hledger
+func writeHledger(w io.Writer, currency string, entries []LedgerEntry) error {
+	var sb strings.Builder
+
+	// Объявляем все используемые счета
+	for _, account := range ledgerAccounts(entries) {
+		fmt.Fprintf(&sb, "account %s\n", account.name)
+	}
+	if len(entries) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, entry := range entries {
+		description := singleLine(entry.Payee)
+		if narration := singleLine(entry.Narration); narration != "" {
+			if description != "" {
+				description += " | " + narration
+			} else {
+				description = narration
+			}
+		}
+
+		fmt.Fprintf(&sb, "%s %s\n", entry.Date.Format("2006-01-02"), description)
+		fmt.Fprintf(&sb, "    %s  %s %s\n", entry.To, formatLedgerAmount(entry.Amount), currency)
+		fmt.Fprintf(&sb, "    %s  %s %s\n\n", entry.From, formatLedgerAmount(-entry.Amount), currency)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeBeancount записывает файл Beancount с директивами открытия счетов
+func writeBeancount(w io.Writer, currency string, entries []LedgerEntry) error {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "option \"operating_currency\" \"%s\"\n\n", currency)
+
+	// Открываем каждый счет датой его первой операции
+	for _, account := range ledgerAccounts(entries) {
+		fmt.Fprintf(&sb, "%s open %s %s\n", account.opened.Format("2006-01-02"), account.name, currency)
+	}
+	if len(entries) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, entry := range entries {
+		fmt.Fprintf(&sb, "%s * %s %s\n",
+			entry.Date.Format("2006-01-02"),
+			beancountString(entry.Payee),
+			beancountString(entry.Narration),
+		)
+		fmt.Fprintf(&sb, "  %s  %s %s\n", entry.To, formatLedgerAmount(entry.Amount), currency)
+		fmt.Fprintf(&sb, "  %s  %s %s\n\n", entry.From, formatLedgerAmount(-entry.Amount), currency)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ledgerAccount содержит название счета и дату его первой операции
+type ledgerAccount struct {
+	name   string
+	opened time.Time
+}
+
+// ledgerAccounts возвращает все счета операций, упорядоченные по названию.
+// Операции должны быть заранее отсортированы по дате.
+func ledgerAccounts(entries []LedgerEntry) []ledgerAccount {
+	opened := make(map[string]time.Time)
+	for _, entry := range entries {
+		for _, name := range []string{entry.From, entry.To} {
+			if _, ok := opened[name]; !ok {
+				opened[name] = entry.Date
+			}
+		}
+	}
+
+	accounts := make([]ledgerAccount, 0, len(opened))
+	for name, date := range opened {
+		accounts = append(accounts, ledgerAccount{name: name, opened: date})
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].name < accounts[j].name
+	})
+
+	return accounts
+}
+
+// formatLedgerAmount форматирует сумму с двумя знаками после точки
+func formatLedgerAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// beancountString заключает текст в кавычки по правилам Beancount
+func beancountString(value string) string {
+	value = strings.ReplaceAll(singleLine(value), `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// singleLine заменяет переводы строк и повторяющиеся пробелы одним пробелом
+func singleLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
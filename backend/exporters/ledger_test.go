@@ -0,0 +1,156 @@
+package exporters
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestWriteLedger(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Операции переданы не по порядку: выгрузка сортирует их по дате, счетам и ID
+	entries := []LedgerEntry{
+		{ID: 7, Date: date(5), Payee: "Пятерочка", Narration: "продукты", From: "Assets:Cash", To: "Expenses:Food", Amount: 450.5},
+		{ID: 3, Date: date(1), Payee: "", Narration: "зарплата", From: "Income:Salary", To: "Assets:Cash", Amount: 100000},
+		{ID: 2, Date: date(5), Payee: "Кафе \"Луна\"", Narration: "обед\nс коллегами", From: "Assets:Cash", To: "Expenses:Food", Amount: 820},
+		{ID: 4, Date: date(5), Payee: "Метро", From: "Assets:Cash", To: "Expenses:Transport", Amount: 62},
+	}
+
+	tests := []struct {
+		name   string
+		format models.LedgerFormat
+		want   string
+	}{
+		{
+			name:   "hledger",
+			format: models.LedgerFormatHledger,
+			want: `account Assets:Cash
+account Expenses:Food
+account Expenses:Transport
+account Income:Salary
+
+2024-03-01 зарплата
+    Assets:Cash  100000.00 RUB
+    Income:Salary  -100000.00 RUB
+
+2024-03-05 Кафе "Луна" | обед с коллегами
+    Expenses:Food  820.00 RUB
+    Assets:Cash  -820.00 RUB
+
+2024-03-05 Пятерочка | продукты
+    Expenses:Food  450.50 RUB
+    Assets:Cash  -450.50 RUB
+
+2024-03-05 Метро
+    Expenses:Transport  62.00 RUB
+    Assets:Cash  -62.00 RUB
+
+`,
+		},
+		{
+			name:   "beancount",
+			format: models.LedgerFormatBeancount,
+			want: `option "operating_currency" "RUB"
+
+2024-03-01 open Assets:Cash RUB
+2024-03-05 open Expenses:Food RUB
+2024-03-05 open Expenses:Transport RUB
+2024-03-01 open Income:Salary RUB
+
+2024-03-01 * "" "зарплата"
+  Assets:Cash  100000.00 RUB
+  Income:Salary  -100000.00 RUB
+
+2024-03-05 * "Кафе \"Луна\"" "обед с коллегами"
+  Expenses:Food  820.00 RUB
+  Assets:Cash  -820.00 RUB
+
+2024-03-05 * "Пятерочка" "продукты"
+  Expenses:Food  450.50 RUB
+  Assets:Cash  -450.50 RUB
+
+2024-03-05 * "Метро" ""
+  Expenses:Transport  62.00 RUB
+  Assets:Cash  -62.00 RUB
+
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := WriteLedger(&sb, tt.format, "RUB", time.UTC, entries); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("получено:\n%s\nожидалось:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if err := WriteLedger(&strings.Builder{}, models.LedgerFormat("ledger"), "RUB", time.UTC, entries); err == nil {
+		t.Error("ожидалась ошибка для неизвестного формата")
+	}
+}
+
+func TestWriteLedgerTimezone(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("не удалось загрузить часовой пояс: %v", err)
+	}
+
+	// По UTC первая трата сделана 31 марта, а в часовом поясе пользователя - уже 1 апреля
+	entries := []LedgerEntry{
+		{ID: 2, Date: time.Date(2024, time.April, 1, 9, 0, 0, 0, time.UTC), Payee: "Кофейня", From: "Assets:Cash", To: "Expenses:Food", Amount: 250},
+		{ID: 1, Date: time.Date(2024, time.March, 31, 22, 30, 0, 0, time.UTC), Payee: "Такси", From: "Assets:Cash", To: "Expenses:Food", Amount: 600},
+	}
+
+	var sb strings.Builder
+	if err := WriteLedger(&sb, models.LedgerFormatBeancount, "RUB", moscow, entries); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	want := `option "operating_currency" "RUB"
+
+2024-04-01 open Assets:Cash RUB
+2024-04-01 open Expenses:Food RUB
+
+2024-04-01 * "Такси" ""
+  Expenses:Food  600.00 RUB
+  Assets:Cash  -600.00 RUB
+
+2024-04-01 * "Кофейня" ""
+  Expenses:Food  250.00 RUB
+  Assets:Cash  -250.00 RUB
+
+`
+	if got := sb.String(); got != want {
+		t.Errorf("получено:\n%s\nожидалось:\n%s", got, want)
+	}
+}
+
+func TestFormatLedgerAmount(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{amount: 0, want: "0.00"},
+		{amount: 12.5, want: "12.50"},
+		{amount: -1234.567, want: "-1234.57"},
+		{amount: 1e6, want: "1000000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatLedgerAmount(tt.amount); got != tt.want {
+				t.Errorf("получено %s, ожидалось %s", got, tt.want)
+			}
+		})
+	}
+}
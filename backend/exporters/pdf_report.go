@@ -0,0 +1,355 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// reportFontFamily название семейства шрифтов, под которым подключается шрифт с поддержкой кириллицы
+const reportFontFamily = "ReportSans"
+
+// monthNames содержит названия месяцев для заголовка отчета
+var monthNames = [...]string{
+	"январь", "февраль", "март", "апрель", "май", "июнь",
+	"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь",
+}
+
+// PDFFonts содержит пути к TTF-файлам шрифтов отчета
+type PDFFonts struct {
+	Regular string
+	Bold    string
+}
+
+// WriteMonthlyReportPDF формирует PDF-отчет за месяц: сводку, состояние бюджета,
+// таблицу по категориям, диаграмму трат по дням и крупнейшие траты
+func WriteMonthlyReportPDF(w io.Writer, report *models.MonthlyReport, fonts PDFFonts) error {
+	regular, err := os.ReadFile(fonts.Regular)
+	if err != nil {
+		return fmt.Errorf("не удалось загрузить шрифт отчета: %w", err)
+	}
+	bold, err := os.ReadFile(fonts.Bold)
+	if err != nil {
+		return fmt.Errorf("не удалось загрузить шрифт отчета: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(reportFontFamily, "", regular)
+	pdf.AddUTF8FontFromBytes(reportFontFamily, "B", bold)
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("не удалось загрузить шрифт отчета: %w", err)
+	}
+
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(reportFontFamily, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Сформировано %s · стр. %d из {nb}", time.Now().Format("02.01.2006 15:04"), pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// Заголовок
+	pdf.SetFont(reportFontFamily, "B", 18)
+	pdf.CellFormat(0, 10, fmt.Sprintf("Финансовый отчет за %s %d", monthNames[report.Month-1], report.Year), "", 1, "L", false, 0, "")
+	if report.UserName != "" {
+		pdf.SetFont(reportFontFamily, "", 11)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(0, 6, report.UserName, "", 1, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(4)
+
+	writeReportSummary(pdf, report)
+	writeReportBudget(pdf, report)
+	writeReportCategories(pdf, report)
+	writeReportDailyChart(pdf, report)
+	writeReportTopExpenses(pdf, report)
+
+	return pdf.Output(w)
+}
+
+// writeReportSummary выводит итоговые суммы за месяц
+func writeReportSummary(pdf *fpdf.Fpdf, report *models.MonthlyReport) {
+	reportSectionTitle(pdf, "Сводка")
+
+	items := []struct {
+		title string
+		value float64
+	}{
+		{"Поступления", report.Incomes},
+		{"Расходы", report.Expenses},
+		{"Баланс", report.Balance},
+	}
+
+	width := 180.0 / float64(len(items))
+	pdf.SetFont(reportFontFamily, "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	for _, item := range items {
+		pdf.CellFormat(width, 6, item.title, "", 0, "L", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	pdf.SetFont(reportFontFamily, "B", 14)
+	for _, item := range items {
+		if item.title == "Баланс" && item.value < 0 {
+			pdf.SetTextColor(200, 40, 40)
+		} else {
+			pdf.SetTextColor(0, 0, 0)
+		}
+		pdf.CellFormat(width, 8, formatMoney(item.value), "", 0, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(12)
+}
+
+// writeReportBudget выводит выполнение месячного лимита трат и цели накоплений
+func writeReportBudget(pdf *fpdf.Fpdf, report *models.MonthlyReport) {
+	reportSectionTitle(pdf, "Состояние бюджета")
+
+	if report.MonthlyLimit <= 0 && report.SavingsGoal <= 0 {
+		reportNote(pdf, "Месячный лимит и цель накоплений не заданы.")
+		return
+	}
+
+	if report.MonthlyLimit > 0 {
+		remaining := report.MonthlyLimit - report.Expenses
+		caption := fmt.Sprintf("Лимит трат: %s, потрачено %s, осталось %s",
+			formatMoney(report.MonthlyLimit), formatMoney(report.Expenses), formatMoney(remaining))
+		reportProgress(pdf, caption, report.Expenses/report.MonthlyLimit, false)
+	}
+
+	if report.SavingsGoal > 0 {
+		caption := fmt.Sprintf("Цель накоплений: %s, накоплено %s",
+			formatMoney(report.SavingsGoal), formatMoney(report.Incomes))
+		reportProgress(pdf, caption, report.Incomes/report.SavingsGoal, true)
+	}
+
+	pdf.Ln(4)
+}
+
+// writeReportCategories выводит таблицу трат по категориям
+func writeReportCategories(pdf *fpdf.Fpdf, report *models.MonthlyReport) {
+	reportSectionTitle(pdf, "Траты по категориям")
+
+	if len(report.Categories) == 0 {
+		reportNote(pdf, "За месяц нет трат.")
+		return
+	}
+
+	widths := []float64{100, 50, 30}
+	reportTableHeader(pdf, widths, []string{"Категория", "Сумма", "Доля"})
+
+	pdf.SetFont(reportFontFamily, "", 10)
+	for i, category := range report.Categories {
+		fill := i%2 == 1
+		pdf.SetFillColor(245, 245, 245)
+		pdf.CellFormat(widths[0], 7, categoryTitle(category.Category), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[1], 7, formatMoney(category.Amount), "", 0, "R", fill, 0, "")
+		pdf.CellFormat(widths[2], 7, fmt.Sprintf("%.1f%%", category.Share), "", 1, "R", fill, 0, "")
+	}
+
+	pdf.SetFont(reportFontFamily, "B", 10)
+	pdf.CellFormat(widths[0], 7, "Итого", "T", 0, "L", false, 0, "")
+	pdf.CellFormat(widths[1], 7, formatMoney(report.Expenses), "T", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[2], 7, "100%", "T", 1, "R", false, 0, "")
+	pdf.Ln(6)
+}
+
+// writeReportDailyChart рисует столбчатую диаграмму трат по дням месяца
+func writeReportDailyChart(pdf *fpdf.Fpdf, report *models.MonthlyReport) {
+	const chartHeight = 50.0
+	const chartLeft = 30.0
+	const chartWidth = 165.0
+
+	// Диаграмма вместе с подписями не должна разрываться между страницами
+	if _, pageHeight := pdf.GetPageSize(); pdf.GetY()+chartHeight+25 > pageHeight-15 {
+		pdf.AddPage()
+	}
+	reportSectionTitle(pdf, "Траты по дням")
+
+	maxValue := 0.0
+	for _, value := range report.DailyExpenses {
+		maxValue = math.Max(maxValue, value)
+	}
+	if maxValue == 0 {
+		reportNote(pdf, "За месяц нет трат.")
+		return
+	}
+	maxValue = niceCeiling(maxValue)
+
+	top := pdf.GetY() + 2
+	bottom := top + chartHeight
+
+	// Сетка и подписи оси значений
+	pdf.SetFont(reportFontFamily, "", 7)
+	pdf.SetDrawColor(220, 220, 220)
+	pdf.SetTextColor(90, 90, 90)
+	for i := 0; i <= 4; i++ {
+		y := bottom - chartHeight*float64(i)/4
+		pdf.Line(chartLeft, y, chartLeft+chartWidth, y)
+		label := fmt.Sprintf("%.0f", maxValue*float64(i)/4)
+		pdf.Text(chartLeft-2-pdf.GetStringWidth(label), y+1, label)
+	}
+
+	// Столбцы
+	days := len(report.DailyExpenses)
+	slot := chartWidth / float64(days)
+	pdf.SetFillColor(66, 133, 244)
+	for day, value := range report.DailyExpenses {
+		x := chartLeft + slot*float64(day)
+		if value > 0 {
+			height := chartHeight * value / maxValue
+			pdf.Rect(x+slot*0.15, bottom-height, slot*0.7, height, "F")
+		}
+		if day == 0 || (day+1)%5 == 0 || day == days-1 {
+			label := fmt.Sprintf("%d", day+1)
+			pdf.Text(x+(slot-pdf.GetStringWidth(label))/2, bottom+4, label)
+		}
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(bottom + 10)
+}
+
+// writeReportTopExpenses выводит крупнейшие траты месяца
+func writeReportTopExpenses(pdf *fpdf.Fpdf, report *models.MonthlyReport) {
+	reportSectionTitle(pdf, "Крупнейшие траты")
+
+	if len(report.TopExpenses) == 0 {
+		reportNote(pdf, "За месяц нет трат.")
+		return
+	}
+
+	widths := []float64{25, 85, 40, 30}
+	reportTableHeader(pdf, widths, []string{"Дата", "Название", "Категория", "Сумма"})
+
+	pdf.SetFont(reportFontFamily, "", 10)
+	for i, expense := range report.TopExpenses {
+		fill := i%2 == 1
+		pdf.SetFillColor(245, 245, 245)
+		pdf.CellFormat(widths[0], 7, expense.Date.Format("02.01.2006"), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[1], 7, fitText(pdf, expense.Title, widths[1]-2), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[2], 7, categoryTitle(expense.Category), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(widths[3], 7, formatMoney(expense.Amount), "", 1, "R", fill, 0, "")
+	}
+}
+
+// reportSectionTitle выводит заголовок раздела
+func reportSectionTitle(pdf *fpdf.Fpdf, title string) {
+	pdf.SetFont(reportFontFamily, "B", 13)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+	pdf.Ln(1)
+}
+
+// reportNote выводит поясняющий текст вместо пустого раздела
+func reportNote(pdf *fpdf.Fpdf, text string) {
+	pdf.SetFont(reportFontFamily, "", 10)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.CellFormat(0, 6, text, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(4)
+}
+
+// reportTableHeader выводит строку заголовков таблицы
+func reportTableHeader(pdf *fpdf.Fpdf, widths []float64, titles []string) {
+	pdf.SetFont(reportFontFamily, "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, title := range titles {
+		align := "L"
+		if i == len(titles)-1 {
+			align = "R"
+		}
+		pdf.CellFormat(widths[i], 7, title, "", 0, align, true, 0, "")
+	}
+	pdf.Ln(7)
+}
+
+// reportProgress выводит подпись и полосу выполнения.
+// Для лимита трат превышение выделяется красным, для цели накоплений - достижение зеленым
+func reportProgress(pdf *fpdf.Fpdf, caption string, ratio float64, higherIsBetter bool) {
+	pdf.SetFont(reportFontFamily, "", 10)
+	pdf.CellFormat(150, 6, caption, "", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 6, fmt.Sprintf("%.1f%%", ratio*100), "", 1, "R", false, 0, "")
+
+	switch {
+	case higherIsBetter && ratio >= 1, !higherIsBetter && ratio < 0.7:
+		pdf.SetFillColor(52, 168, 83)
+	case !higherIsBetter && ratio >= 0.9:
+		pdf.SetFillColor(219, 68, 55)
+	default:
+		pdf.SetFillColor(244, 180, 0)
+	}
+
+	x, y := 15.0, pdf.GetY()
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Rect(x, y, 180, 4, "D")
+	if ratio > 0 {
+		pdf.Rect(x, y, 180*math.Min(ratio, 1), 4, "F")
+	}
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Ln(7)
+}
+
+// categoryTitle возвращает название категории для отображения
+func categoryTitle(category models.ExpenseCategory) string {
+	if title, ok := models.ExpenseCategoryTitles[category]; ok {
+		return title
+	}
+	return string(category)
+}
+
+// fitText обрезает текст, чтобы он поместился в ячейку заданной ширины
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// formatMoney форматирует сумму с разделением разрядов
+func formatMoney(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	whole := fmt.Sprintf("%.2f", amount)
+	intPart, fracPart := whole[:len(whole)-3], whole[len(whole)-2:]
+
+	var sb strings.Builder
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%s%s,%s руб.", sign, sb.String(), fracPart)
+}
+
+// niceCeiling округляет максимум шкалы вверх до 1, 2 или 5, умноженных на степень десяти
+func niceCeiling(value float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if value <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
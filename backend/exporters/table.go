@@ -0,0 +1,69 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// TableFormat перечисляет поддерживаемые табличные форматы выгрузки
+type TableFormat string
+
+const (
+	TableFormatCSV  TableFormat = "csv"
+	TableFormatXLSX TableFormat = "xlsx"
+)
+
+// TableWriter построчно записывает табличные данные.
+// Значения ячеек могут быть строками, числами или time.Time.
+// Даты записываются в часовом поясе, переданном при создании писателя.
+type TableWriter interface {
+	WriteRow(values ...interface{}) error
+	// WriteSummary записывает итоговую таблицу. Форматы без поддержки листов ее пропускают.
+	WriteSummary(title string, rows [][]interface{}) error
+	Close() error
+}
+
+// NewTableWriter создает TableWriter для указанного формата и записывает заголовок таблицы.
+// Даты выводятся в часовом поясе пользователя location
+func NewTableWriter(format TableFormat, w io.Writer, location *time.Location, sheetName string, header ...string) (TableWriter, error) {
+	var writer TableWriter
+	switch format {
+	case TableFormatCSV:
+		writer = newCSVWriter(w, location)
+	case TableFormatXLSX:
+		writer = newXLSXWriter(w, location, sheetName)
+	default:
+		return nil, fmt.Errorf("неподдерживаемый формат выгрузки: %s", format)
+	}
+
+	values := make([]interface{}, len(header))
+	for i, column := range header {
+		values[i] = column
+	}
+	if err := writer.WriteRow(values...); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// ContentType возвращает MIME-тип для табличного формата
+func ContentType(format TableFormat) string {
+	switch format {
+	case TableFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// escapeFormula защищает текст ячейки от выполнения как формулы: табличные редакторы
+// считают формулой значение, которое начинается с =, +, - или @
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTableWriterRows(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("не удалось загрузить часовой пояс: %v", err)
+	}
+	// По UTC трата сделана 31 марта, а в часовом поясе пользователя - уже 1 апреля
+	date := time.Date(2024, time.March, 31, 22, 30, 0, 0, time.UTC)
+
+	rows := [][]interface{}{
+		{date, "=HYPERLINK(\"http://example.com\")", -150.5},
+		{date, "+79001234567", 0.0},
+		{date, "-скидка", 10.0},
+		{date, "@SUM(A1:A2)", 20.0},
+		{date, "Кофе", 250.0},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, err := NewTableWriter(TableFormatCSV, &buf, moscow, "Траты", "Дата", "Название", "Сумма")
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		for _, row := range rows {
+			if err := writer.WriteRow(row...); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+
+		want := "\ufeffДата,Название,Сумма\n" +
+			"2024-04-01,\"'=HYPERLINK(\"\"http://example.com\"\")\",-150.50\n" +
+			"2024-04-01,'+79001234567,0.00\n" +
+			"2024-04-01,'-скидка,10.00\n" +
+			"2024-04-01,'@SUM(A1:A2),20.00\n" +
+			"2024-04-01,Кофе,250.00\n"
+		if got := buf.String(); got != want {
+			t.Errorf("получено:\n%s\nожидалось:\n%s", got, want)
+		}
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, err := NewTableWriter(TableFormatXLSX, &buf, moscow, "Траты", "Дата", "Название", "Сумма")
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		for _, row := range rows {
+			if err := writer.WriteRow(row...); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+
+		sheet := readXLSXPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+		for _, want := range []string{
+			// 1 апреля 2024 года 01:30 - 45383-й день Excel
+			`<c r="A2" s="1"><v>45383.0625</v></c>`,
+			`<t xml:space="preserve">&#39;=HYPERLINK(&#34;http://example.com&#34;)</t>`,
+			`<t xml:space="preserve">&#39;+79001234567</t>`,
+			`<t xml:space="preserve">&#39;-скидка</t>`,
+			`<t xml:space="preserve">&#39;@SUM(A1:A2)</t>`,
+			`<t xml:space="preserve">Кофе</t>`,
+			`<c r="C2"><v>-150.5</v></c>`,
+		} {
+			if !strings.Contains(sheet, want) {
+				t.Errorf("лист не содержит %s:\n%s", want, sheet)
+			}
+		}
+	})
+}
+
+// readXLSXPart возвращает содержимое части пакета XLSX
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("не удалось открыть книгу: %v", err)
+	}
+	part, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("в книге нет части %s: %v", name, err)
+	}
+	defer part.Close()
+
+	content, err := io.ReadAll(part)
+	if err != nil {
+		t.Fatalf("не удалось прочитать часть %s: %v", name, err)
+	}
+	return string(content)
+}
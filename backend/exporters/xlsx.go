@@ -0,0 +1,228 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xlsxWriter потоково записывает книгу XLSX.
+// Строки первого листа пишутся сразу в zip-архив, поэтому вся таблица не хранится в памяти.
+type xlsxWriter struct {
+	zip        *zip.Writer
+	sheet      *bufio.Writer
+	sheetNames []string
+	rowIndex   int
+	sheetOpen  bool
+	location   *time.Location
+	err        error
+}
+
+// newXLSXWriter создает писатель XLSX и открывает первый лист
+func newXLSXWriter(w io.Writer, location *time.Location, sheetName string) *xlsxWriter {
+	x := &xlsxWriter{zip: zip.NewWriter(w), location: location}
+	x.err = x.openSheet(sheetName)
+	return x
+}
+
+// WriteRow записывает строку на текущий лист
+func (x *xlsxWriter) WriteRow(values ...interface{}) error {
+	if x.err != nil {
+		return x.err
+	}
+	if !x.sheetOpen {
+		return fmt.Errorf("лист уже закрыт")
+	}
+
+	x.rowIndex++
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<row r="%d">`, x.rowIndex)
+	for i, value := range values {
+		ref := columnName(i) + strconv.Itoa(x.rowIndex)
+		switch v := value.(type) {
+		case float64:
+			fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+		case int:
+			fmt.Fprintf(&sb, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case time.Time:
+			fmt.Fprintf(&sb, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(excelSerial(v.In(x.location)), 'f', -1, 64))
+		case string:
+			fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escapeXML(escapeFormula(v)))
+		}
+	}
+	sb.WriteString(`</row>`)
+
+	_, x.err = x.sheet.WriteString(sb.String())
+	return x.err
+}
+
+// WriteSummary закрывает текущий лист и записывает итоговую таблицу на отдельный лист
+func (x *xlsxWriter) WriteSummary(title string, rows [][]interface{}) error {
+	if x.err != nil {
+		return x.err
+	}
+	if err := x.closeSheet(); err != nil {
+		return err
+	}
+	if err := x.openSheet(title); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := x.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return x.closeSheet()
+}
+
+// Close завершает книгу: закрывает лист и записывает служебные части пакета
+func (x *xlsxWriter) Close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if err := x.closeSheet(); err != nil {
+		return err
+	}
+
+	var workbook, workbookRels, contentTypes strings.Builder
+
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header)
+	workbookRels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	for i, name := range x.sheetNames {
+		n := i + 1
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(name), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+	}
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(x.sheetNames)+1)
+
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+	contentTypes.WriteString(`</Types>`)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		if err := x.writePart(part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	return x.zip.Close()
+}
+
+// openSheet начинает новый лист в архиве
+func (x *xlsxWriter) openSheet(name string) error {
+	x.sheetNames = append(x.sheetNames, sheetTitle(name))
+	part, err := x.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(x.sheetNames)))
+	if err != nil {
+		return err
+	}
+
+	x.sheet = bufio.NewWriter(part)
+	x.rowIndex = 0
+	x.sheetOpen = true
+
+	_, err = x.sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return err
+}
+
+// closeSheet завершает текущий лист
+func (x *xlsxWriter) closeSheet() error {
+	if !x.sheetOpen {
+		return nil
+	}
+	x.sheetOpen = false
+	if _, err := x.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.sheet.Flush()
+}
+
+// writePart записывает служебную часть пакета целиком
+func (x *xlsxWriter) writePart(name, content string) error {
+	part, err := x.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, content)
+	return err
+}
+
+// xlsxStyles содержит минимальную таблицу стилей: стиль 0 по умолчанию и стиль 1 для дат
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// columnName возвращает буквенное обозначение столбца по его индексу (0 -> A, 26 -> AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// excelSerial переводит дату в порядковый номер дня Excel
+func excelSerial(t time.Time) float64 {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	local := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return local.Sub(epoch).Hours() / 24
+}
+
+// sheetTitle приводит название листа к ограничениям Excel
+func sheetTitle(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+// escapeXML экранирует текст и удаляет символы, недопустимые в XML
+func escapeXML(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, value)
+
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(value))
+	return sb.String()
+}
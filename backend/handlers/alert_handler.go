@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// AlertHandlerImpl представляет реализацию обработчика предупреждений о необычных тратах
+type AlertHandlerImpl struct {
+	alertService services.AlertService
+}
+
+// NewAlertHandler создает новый экземпляр обработчика предупреждений
+func NewAlertHandler(alertService services.AlertService) AlertHandler {
+	return &AlertHandlerImpl{
+		alertService: alertService,
+	}
+}
+
+// GetAlerts обрабатывает запрос на получение предупреждений.
+// Параметр unread=true оставляет только непрочитанные предупреждения
+func (h *AlertHandlerImpl) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	unreadOnly := utils.GetQueryParam(r, "unread") == "true"
+
+	// Получаем предупреждения
+	alerts, err := h.alertService.GetAlerts(r.Context(), userID, unreadOnly)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при получении предупреждений", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, alerts)
+}
+
+// CheckAlerts обрабатывает запрос на немедленную проверку новых трат пользователя
+func (h *AlertHandlerImpl) CheckAlerts(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Проверяем траты
+	alerts, err := h.alertService.CheckUser(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при проверке трат", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, alerts)
+}
+
+// MarkAlertRead обрабатывает запрос на отметку предупреждения прочитанным
+func (h *AlertHandlerImpl) MarkAlertRead(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID предупреждения из URL
+	alertID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID предупреждения", err.Error())
+		return
+	}
+
+	// Отмечаем предупреждение
+	if err := h.alertService.MarkAlertRead(r.Context(), alertID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Предупреждение не найдено", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Предупреждение отмечено прочитанным"})
+}
+
+// MarkAllAlertsRead обрабатывает запрос на отметку всех предупреждений прочитанными
+func (h *AlertHandlerImpl) MarkAllAlertsRead(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Отмечаем предупреждения
+	if err := h.alertService.MarkAllAlertsRead(r.Context(), userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось обновить предупреждения", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Все предупреждения отмечены прочитанными"})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// AnalyticsHandlerImpl представляет реализацию обработчика гибкой аналитики
+type AnalyticsHandlerImpl struct {
+	analyticsService services.AnalyticsService
+}
+
+// NewAnalyticsHandler создает новый экземпляр обработчика аналитики
+func NewAnalyticsHandler(analyticsService services.AnalyticsService) AnalyticsHandler {
+	return &AnalyticsHandlerImpl{
+		analyticsService: analyticsService,
+	}
+}
+
+// Query обрабатывает аналитический запрос. Параметры: dataset (expenses, incomes),
+// start_date и end_date в формате RFC3339, group_by и metrics списком через запятую,
+// interval (day, week, month, quarter, year) и timezone
+func (h *AnalyticsHandlerImpl) Query(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	query := models.AnalyticsQuery{
+		Dataset:  models.AnalyticsDataset(utils.GetQueryParam(r, "dataset")),
+		Interval: models.AnalyticsInterval(utils.GetQueryParam(r, "interval")),
+		Timezone: utils.GetQueryParam(r, "timezone"),
+	}
+
+	// Получаем параметры периода
+	if startDateStr := utils.GetQueryParam(r, "start_date"); startDateStr != "" {
+		query.StartDate, err = time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты начала периода", err.Error())
+			return
+		}
+	}
+
+	if endDateStr := utils.GetQueryParam(r, "end_date"); endDateStr != "" {
+		query.EndDate, err = time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты конца периода", err.Error())
+			return
+		}
+	}
+
+	for _, group := range utils.GetListQueryParam(r, "group_by") {
+		query.GroupBy = append(query.GroupBy, models.AnalyticsGroup(group))
+	}
+	for _, metric := range utils.GetListQueryParam(r, "metrics") {
+		query.Metrics = append(query.Metrics, models.AnalyticsMetric(metric))
+	}
+
+	// Выполняем запрос
+	result, err := h.analyticsService.Query(r.Context(), userID, &query)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при расчете аналитики", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
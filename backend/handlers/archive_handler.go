@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// ArchiveHandlerImpl представляет реализацию обработчика выгрузки и восстановления данных
+type ArchiveHandlerImpl struct {
+	archiveService services.ArchiveService
+}
+
+// NewArchiveHandler создает новый экземпляр обработчика архивов
+func NewArchiveHandler(archiveService services.ArchiveService) ArchiveHandler {
+	return &ArchiveHandlerImpl{
+		archiveService: archiveService,
+	}
+}
+
+// ExportArchive обрабатывает запрос на выгрузку всех данных пользователя
+func (h *ArchiveHandlerImpl) ExportArchive(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Собираем архив
+	archive, err := h.archiveService.ExportArchive(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при выгрузке данных", err.Error())
+		return
+	}
+
+	// Отправляем архив как файл
+	filename := fmt.Sprintf("czfinance-export-%s.json", time.Now().Format("20060102"))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	utils.RespondWithJSON(w, http.StatusOK, archive)
+}
+
+// ImportArchive обрабатывает запрос на восстановление данных пользователя из архива
+func (h *ArchiveHandlerImpl) ImportArchive(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Режим восстановления: merge добавляет данные к существующим, replace заменяет их
+	mode := utils.GetQueryParam(r, "mode")
+	if mode == "" {
+		mode = "merge"
+	}
+	if mode != "merge" && mode != "replace" {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный режим восстановления", "Допустимые значения: merge, replace")
+		return
+	}
+
+	// Декодируем архив. Неизвестные поля допускаются для совместимости с более новыми версиями
+	var archive models.Archive
+	r.Body = http.MaxBytesReader(w, r.Body, 50<<20) // Ограничение 50 МБ
+	if err := json.NewDecoder(r.Body).Decode(&archive); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при разборе архива", err.Error())
+		return
+	}
+
+	// Восстанавливаем данные
+	result, err := h.archiveService.ImportArchive(r.Context(), userID, &archive, mode == "replace")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при восстановлении данных", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// ComparisonHandlerImpl представляет реализацию обработчика сравнения периодов
+type ComparisonHandlerImpl struct {
+	comparisonService services.ComparisonService
+}
+
+// NewComparisonHandler создает новый экземпляр обработчика сравнения периодов
+func NewComparisonHandler(comparisonService services.ComparisonService) ComparisonHandler {
+	return &ComparisonHandlerImpl{
+		comparisonService: comparisonService,
+	}
+}
+
+// ComparePeriods обрабатывает запрос на сравнение двух периодов. Параметры start_date и end_date
+// задают основной период, compare_start_date и compare_end_date — период сравнения в формате RFC3339.
+// Без периода сравнения основной период сравнивается с предыдущим
+func (h *ComparisonHandlerImpl) ComparePeriods(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	request := models.ComparisonRequest{Mode: models.ComparePrevious}
+	if !h.parsePeriod(w, r, &request) {
+		return
+	}
+
+	// Получаем параметры периода сравнения
+	if request.CompareStartDate, err = parseComparisonDate(r, "compare_start_date"); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты начала периода сравнения", err.Error())
+		return
+	}
+	if request.CompareEndDate, err = parseComparisonDate(r, "compare_end_date"); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты конца периода сравнения", err.Error())
+		return
+	}
+	if !request.CompareStartDate.IsZero() || !request.CompareEndDate.IsZero() {
+		request.Mode = models.CompareCustom
+	}
+
+	h.respondWithComparison(w, r, userID, &request)
+}
+
+// CompareYearOverYear обрабатывает запрос на сравнение периода с тем же периодом год назад.
+// Параметры start_date и end_date задают основной период в формате RFC3339
+func (h *ComparisonHandlerImpl) CompareYearOverYear(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	request := models.ComparisonRequest{Mode: models.CompareYearAgo}
+	if !h.parsePeriod(w, r, &request) {
+		return
+	}
+
+	h.respondWithComparison(w, r, userID, &request)
+}
+
+// parsePeriod заполняет основной период из параметров запроса. При ошибке отправляет ответ и возвращает false
+func (h *ComparisonHandlerImpl) parsePeriod(w http.ResponseWriter, r *http.Request, request *models.ComparisonRequest) bool {
+	var err error
+	if request.StartDate, err = parseComparisonDate(r, "start_date"); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты начала периода", err.Error())
+		return false
+	}
+	if request.EndDate, err = parseComparisonDate(r, "end_date"); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты конца периода", err.Error())
+		return false
+	}
+	return true
+}
+
+// respondWithComparison выполняет сравнение и отправляет результат
+func (h *ComparisonHandlerImpl) respondWithComparison(w http.ResponseWriter, r *http.Request, userID int64, request *models.ComparisonRequest) {
+	comparison, err := h.comparisonService.ComparePeriods(r.Context(), userID, request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось сравнить периоды", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, comparison)
+}
+
+// parseComparisonDate разбирает дату из параметра запроса. Для отсутствующего параметра возвращается нулевая дата
+func parseComparisonDate(r *http.Request, name string) (time.Time, error) {
+	value := utils.GetQueryParam(r, name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
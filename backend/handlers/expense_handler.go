@@ -141,13 +141,9 @@ func (h *ExpenseHandlerImpl) GetExpenseSummary(w http.ResponseWriter, r *http.Re
 	startDateStr := utils.GetQueryParam(r, "start_date")
 	endDateStr := utils.GetQueryParam(r, "end_date")
 
-	// Если даты не указаны, используем текущий месяц
+	// Если даты не указаны, сервис использует текущий месяц в часовом поясе пользователя
 	var startDate, endDate time.Time
-	if startDateStr == "" || endDateStr == "" {
-		now := time.Now()
-		startDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-		endDate = startDate.AddDate(0, 1, -1).Add(time.Hour * 23).Add(time.Minute * 59).Add(time.Second * 59)
-	} else {
+	if startDateStr != "" && endDateStr != "" {
 		var err error
 		startDate, err = time.Parse(time.RFC3339, startDateStr)
 		if err != nil {
@@ -233,3 +229,36 @@ func (h *ExpenseHandlerImpl) DeleteExpense(w http.ResponseWriter, r *http.Reques
 	// Отправляем ответ
 	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Трата успешно удалена"})
 }
+
+// SuggestCategory обрабатывает запрос на подбор категории для новой траты
+func (h *ExpenseHandlerImpl) SuggestCategory(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.SuggestCategoryRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Подбираем категорию
+	suggestions, err := h.expenseService.SuggestCategory(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось подобрать категорию", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, suggestions)
+}
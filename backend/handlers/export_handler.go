@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"cz.Finance/backend/exporters"
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// ExportHandlerImpl представляет реализацию обработчика выгрузки операций в таблицы
+type ExportHandlerImpl struct {
+	expenseService services.ExpenseService
+	incomeService  services.IncomeService
+	userService    services.UserService
+}
+
+// NewExportHandler создает новый экземпляр обработчика выгрузки
+func NewExportHandler(expenseService services.ExpenseService, incomeService services.IncomeService, userService services.UserService) ExportHandler {
+	return &ExportHandlerImpl{
+		expenseService: expenseService,
+		incomeService:  incomeService,
+		userService:    userService,
+	}
+}
+
+// summaryEntry накапливает итог по одной категории или источнику
+type summaryEntry struct {
+	total float64
+	count int
+}
+
+// ExportExpenses обрабатывает запрос на выгрузку трат в CSV или XLSX
+func (h *ExportHandlerImpl) ExportExpenses(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем формат выгрузки
+	format, err := getTableFormat(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат выгрузки", err.Error())
+		return
+	}
+
+	// Получаем фильтры из запроса
+	startDate, endDate, err := getExportPeriod(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты", err.Error())
+		return
+	}
+	filter := &models.ExpenseFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Category:  models.ExpenseCategory(utils.GetQueryParam(r, "category")),
+	}
+
+	// Даты выгружаются в часовом поясе пользователя
+	location, err := h.userLocation(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Пользователь не найден", err.Error())
+		return
+	}
+
+	// Начинаем потоковую выгрузку
+	setExportHeaders(w, format, "expenses")
+	writer, err := exporters.NewTableWriter(format, w, location, "Траты", "Дата", "Название", "Категория", "Сумма", "Описание")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при выгрузке трат", err.Error())
+		return
+	}
+
+	summary := make(map[string]*summaryEntry)
+	err = h.expenseService.StreamUserExpenses(r.Context(), userID, filter, func(expense *models.Expense) error {
+		entry, ok := summary[string(expense.Category)]
+		if !ok {
+			entry = &summaryEntry{}
+			summary[string(expense.Category)] = entry
+		}
+		entry.total += expense.Amount
+		entry.count++
+
+		return writer.WriteRow(expense.Date, expense.Title, string(expense.Category), expense.Amount, expense.Description)
+	})
+	if err == nil {
+		err = writer.WriteSummary("Сводка", summaryRows("Категория", summary))
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		// Заголовки уже отправлены, поэтому ошибку можно только записать в журнал
+		fmt.Printf("Ошибка при выгрузке трат пользователя ID=%d: %v\n", userID, err)
+	}
+}
+
+// ExportIncomes обрабатывает запрос на выгрузку накоплений в CSV или XLSX
+func (h *ExportHandlerImpl) ExportIncomes(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем формат выгрузки
+	format, err := getTableFormat(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат выгрузки", err.Error())
+		return
+	}
+
+	// Получаем фильтры из запроса
+	startDate, endDate, err := getExportPeriod(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты", err.Error())
+		return
+	}
+	filter := &models.IncomeFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Source:    models.IncomeSource(utils.GetQueryParam(r, "source")),
+	}
+
+	// Даты выгружаются в часовом поясе пользователя
+	location, err := h.userLocation(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Пользователь не найден", err.Error())
+		return
+	}
+
+	// Начинаем потоковую выгрузку
+	setExportHeaders(w, format, "incomes")
+	writer, err := exporters.NewTableWriter(format, w, location, "Накопления", "Дата", "Источник", "Сумма", "Описание")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при выгрузке накоплений", err.Error())
+		return
+	}
+
+	summary := make(map[string]*summaryEntry)
+	err = h.incomeService.StreamUserIncomes(r.Context(), userID, filter, func(income *models.Income) error {
+		entry, ok := summary[string(income.Source)]
+		if !ok {
+			entry = &summaryEntry{}
+			summary[string(income.Source)] = entry
+		}
+		entry.total += income.Amount
+		entry.count++
+
+		return writer.WriteRow(income.Date, string(income.Source), income.Amount, income.Description)
+	})
+	if err == nil {
+		err = writer.WriteSummary("Сводка", summaryRows("Источник", summary))
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		// Заголовки уже отправлены, поэтому ошибку можно только записать в журнал
+		fmt.Printf("Ошибка при выгрузке накоплений пользователя ID=%d: %v\n", userID, err)
+	}
+}
+
+// userLocation возвращает часовой пояс пользователя. Если пояс не задан или неизвестен, используется UTC
+func (h *ExportHandlerImpl) userLocation(ctx context.Context, userID int64) (*time.Location, error) {
+	user, err := h.userService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := models.LoadTimezone(user.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return location, nil
+}
+
+// getTableFormat получает формат выгрузки из запроса. По умолчанию используется CSV
+func getTableFormat(r *http.Request) (exporters.TableFormat, error) {
+	format := exporters.TableFormat(utils.GetQueryParam(r, "format"))
+	switch format {
+	case "":
+		return exporters.TableFormatCSV, nil
+	case exporters.TableFormatCSV, exporters.TableFormatXLSX:
+		return format, nil
+	default:
+		return "", fmt.Errorf("допустимые значения: csv, xlsx")
+	}
+}
+
+// getExportPeriod получает необязательные границы периода из запроса
+func getExportPeriod(r *http.Request) (*time.Time, *time.Time, error) {
+	var startDate, endDate *time.Time
+
+	if value := utils.GetQueryParam(r, "start_date"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		startDate = &parsed
+	}
+
+	if value := utils.GetQueryParam(r, "end_date"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		endDate = &parsed
+	}
+
+	return startDate, endDate, nil
+}
+
+// setExportHeaders устанавливает заголовки ответа для скачивания файла
+func setExportHeaders(w http.ResponseWriter, format exporters.TableFormat, name string) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format)
+	w.Header().Set("Content-Type", exporters.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+}
+
+// summaryRows формирует строки итоговой таблицы, упорядоченные по названию группы
+func summaryRows(groupTitle string, summary map[string]*summaryEntry) [][]interface{} {
+	keys := make([]string, 0, len(summary))
+	for key := range summary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rows := [][]interface{}{{groupTitle, "Количество", "Сумма"}}
+	var total float64
+	var count int
+	for _, key := range keys {
+		entry := summary[key]
+		rows = append(rows, []interface{}{key, entry.count, entry.total})
+		total += entry.total
+		count += entry.count
+	}
+	rows = append(rows, []interface{}{"Итого", count, total})
+
+	return rows
+}
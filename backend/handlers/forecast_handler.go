@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// ForecastHandlerImpl представляет реализацию обработчика прогноза денежного потока
+type ForecastHandlerImpl struct {
+	forecastService services.ForecastService
+}
+
+// NewForecastHandler создает новый экземпляр обработчика прогноза
+func NewForecastHandler(forecastService services.ForecastService) ForecastHandler {
+	return &ForecastHandlerImpl{
+		forecastService: forecastService,
+	}
+}
+
+// GetForecast обрабатывает запрос на прогноз баланса и трат.
+// Параметр months задает количество следующих месяцев после текущего
+func (h *ForecastHandlerImpl) GetForecast(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	months := utils.GetIntQueryParam(r, "months", 0)
+
+	// Строим прогноз
+	forecast, err := h.forecastService.GetForecast(r.Context(), userID, months)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось построить прогноз", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, forecast)
+}
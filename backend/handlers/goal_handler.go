@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// GoalHandlerImpl представляет реализацию обработчика целей накоплений
+type GoalHandlerImpl struct {
+	goalService services.GoalService
+}
+
+// NewGoalHandler создает новый экземпляр обработчика целей накоплений
+func NewGoalHandler(goalService services.GoalService) GoalHandler {
+	return &GoalHandlerImpl{
+		goalService: goalService,
+	}
+}
+
+// CreateGoal обрабатывает запрос на создание цели накоплений
+func (h *GoalHandlerImpl) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateGoalRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем цель
+	goal, err := h.goalService.CreateGoal(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось создать цель", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, goal)
+}
+
+// GetGoal обрабатывает запрос на получение цели накоплений со взносами
+func (h *GoalHandlerImpl) GetGoal(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID цели из URL
+	goalID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID цели", err.Error())
+		return
+	}
+
+	// Получаем цель
+	goal, err := h.goalService.GetGoal(r.Context(), goalID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Цель не найдена", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, goal)
+}
+
+// GetUserGoals обрабатывает запрос на получение всех целей накоплений пользователя
+func (h *GoalHandlerImpl) GetUserGoals(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем цели пользователя
+	goals, err := h.goalService.GetUserGoals(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить цели", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, goals)
+}
+
+// UpdateGoal обрабатывает запрос на обновление цели накоплений
+func (h *GoalHandlerImpl) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID цели из URL
+	goalID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID цели", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateGoalRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Обновляем цель
+	goal, err := h.goalService.UpdateGoal(r.Context(), goalID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось обновить цель", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, goal)
+}
+
+// DeleteGoal обрабатывает запрос на удаление цели накоплений
+func (h *GoalHandlerImpl) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID цели из URL
+	goalID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID цели", err.Error())
+		return
+	}
+
+	// Удаляем цель
+	if err := h.goalService.DeleteGoal(r.Context(), goalID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось удалить цель", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Цель успешно удалена"})
+}
+
+// AddContribution обрабатывает запрос на добавление взноса в цель
+func (h *GoalHandlerImpl) AddContribution(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID цели из URL
+	goalID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID цели", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateGoalContributionRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Добавляем взнос
+	goal, err := h.goalService.AddContribution(r.Context(), goalID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось добавить взнос", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, goal)
+}
+
+// DeleteContribution обрабатывает запрос на удаление взноса из цели
+func (h *GoalHandlerImpl) DeleteContribution(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID цели и взноса из URL
+	goalID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID цели", err.Error())
+		return
+	}
+
+	contributionID, err := strconv.ParseInt(mux.Vars(r)["contribution_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID взноса", err.Error())
+		return
+	}
+
+	// Удаляем взнос
+	if err := h.goalService.DeleteContribution(r.Context(), goalID, contributionID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось удалить взнос", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Взнос успешно удален"})
+}
@@ -0,0 +1,587 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// HouseholdHandlerImpl представляет реализацию обработчика домохозяйств
+type HouseholdHandlerImpl struct {
+	householdService services.HouseholdService
+}
+
+// NewHouseholdHandler создает новый экземпляр обработчика домохозяйств
+func NewHouseholdHandler(householdService services.HouseholdService) HouseholdHandler {
+	return &HouseholdHandlerImpl{
+		householdService: householdService,
+	}
+}
+
+// CreateHousehold обрабатывает запрос на создание домохозяйства
+func (h *HouseholdHandlerImpl) CreateHousehold(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateHouseholdRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем домохозяйство
+	household, err := h.householdService.CreateHousehold(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось создать домохозяйство", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, household)
+}
+
+// GetUserHouseholds обрабатывает запрос на получение домохозяйств пользователя
+func (h *HouseholdHandlerImpl) GetUserHouseholds(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем домохозяйства пользователя
+	households, err := h.householdService.GetUserHouseholds(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить домохозяйства", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, households)
+}
+
+// GetHousehold обрабатывает запрос на получение домохозяйства с участниками
+func (h *HouseholdHandlerImpl) GetHousehold(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Получаем домохозяйство
+	household, err := h.householdService.GetHousehold(r.Context(), householdID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Домохозяйство не найдено", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, household)
+}
+
+// UpdateHousehold обрабатывает запрос на переименование домохозяйства
+func (h *HouseholdHandlerImpl) UpdateHousehold(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateHouseholdRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Обновляем домохозяйство
+	household, err := h.householdService.UpdateHousehold(r.Context(), householdID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusForbidden, "Не удалось обновить домохозяйство", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, household)
+}
+
+// DeleteHousehold обрабатывает запрос на удаление домохозяйства
+func (h *HouseholdHandlerImpl) DeleteHousehold(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Удаляем домохозяйство
+	if err := h.householdService.DeleteHousehold(r.Context(), householdID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusForbidden, "Не удалось удалить домохозяйство", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Домохозяйство успешно удалено"})
+}
+
+// InviteMember обрабатывает запрос на приглашение пользователя в домохозяйство
+func (h *HouseholdHandlerImpl) InviteMember(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateHouseholdInvitationRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем приглашение
+	invitation, err := h.householdService.InviteMember(r.Context(), householdID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось пригласить пользователя", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, invitation)
+}
+
+// GetHouseholdInvitations обрабатывает запрос на получение приглашений домохозяйства
+func (h *HouseholdHandlerImpl) GetHouseholdInvitations(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Получаем приглашения
+	invitations, err := h.householdService.GetHouseholdInvitations(r.Context(), householdID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusForbidden, "Не удалось получить приглашения", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, invitations)
+}
+
+// RevokeInvitation обрабатывает запрос на отзыв приглашения
+func (h *HouseholdHandlerImpl) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства и приглашения из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(mux.Vars(r)["invitation_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID приглашения", err.Error())
+		return
+	}
+
+	// Отзываем приглашение
+	if err := h.householdService.RevokeInvitation(r.Context(), householdID, invitationID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось отозвать приглашение", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Приглашение отозвано"})
+}
+
+// GetMyInvitations обрабатывает запрос на получение приглашений текущего пользователя
+func (h *HouseholdHandlerImpl) GetMyInvitations(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем приглашения
+	invitations, err := h.householdService.GetMyInvitations(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить приглашения", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, invitations)
+}
+
+// AcceptInvitation обрабатывает запрос на принятие приглашения
+func (h *HouseholdHandlerImpl) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID приглашения из URL
+	invitationID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID приглашения", err.Error())
+		return
+	}
+
+	// Принимаем приглашение
+	household, err := h.householdService.RespondToInvitation(r.Context(), invitationID, userID, true)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось принять приглашение", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, household)
+}
+
+// DeclineInvitation обрабатывает запрос на отклонение приглашения
+func (h *HouseholdHandlerImpl) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID приглашения из URL
+	invitationID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID приглашения", err.Error())
+		return
+	}
+
+	// Отклоняем приглашение
+	if _, err := h.householdService.RespondToInvitation(r.Context(), invitationID, userID, false); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось отклонить приглашение", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Приглашение отклонено"})
+}
+
+// UpdateMemberRole обрабатывает запрос на изменение роли участника
+func (h *HouseholdHandlerImpl) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства и участника из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	memberID, err := strconv.ParseInt(mux.Vars(r)["member_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID участника", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateHouseholdMemberRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Изменяем роль
+	if err := h.householdService.UpdateMemberRole(r.Context(), householdID, memberID, userID, request.Role); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось изменить роль участника", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Роль участника изменена"})
+}
+
+// RemoveMember обрабатывает запрос на исключение участника или выход из домохозяйства
+func (h *HouseholdHandlerImpl) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства и участника из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	memberID, err := strconv.ParseInt(mux.Vars(r)["member_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID участника", err.Error())
+		return
+	}
+
+	// Исключаем участника
+	if err := h.householdService.RemoveMember(r.Context(), householdID, memberID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось исключить участника", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Участник исключен из домохозяйства"})
+}
+
+// GetHouseholdExpenses обрабатывает запрос на получение общих трат домохозяйства
+func (h *HouseholdHandlerImpl) GetHouseholdExpenses(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Получаем параметры пагинации
+	limit := utils.GetIntQueryParam(r, "limit", 10)
+	offset := utils.GetIntQueryParam(r, "offset", 0)
+
+	// Получаем траты
+	expenses, err := h.householdService.GetHouseholdExpenses(r.Context(), householdID, userID, limit, offset)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось получить траты домохозяйства", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, expenses)
+}
+
+// GetHouseholdIncomes обрабатывает запрос на получение общих накоплений домохозяйства
+func (h *HouseholdHandlerImpl) GetHouseholdIncomes(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Получаем параметры пагинации
+	limit := utils.GetIntQueryParam(r, "limit", 10)
+	offset := utils.GetIntQueryParam(r, "offset", 0)
+
+	// Получаем накопления
+	incomes, err := h.householdService.GetHouseholdIncomes(r.Context(), householdID, userID, limit, offset)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось получить накопления домохозяйства", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, incomes)
+}
+
+// GetBudgets обрабатывает запрос на получение бюджетов домохозяйства
+func (h *HouseholdHandlerImpl) GetBudgets(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Получаем бюджеты
+	budgets, err := h.householdService.GetBudgets(r.Context(), householdID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось получить бюджеты", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, budgets)
+}
+
+// SetBudget обрабатывает запрос на установку бюджета домохозяйства по категории
+func (h *HouseholdHandlerImpl) SetBudget(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.SetHouseholdBudgetRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Сохраняем бюджет
+	if err := h.householdService.SetBudget(r.Context(), householdID, userID, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось установить бюджет", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Бюджет успешно установлен"})
+}
+
+// GetDashboard обрабатывает запрос на получение сводки домохозяйства за месяц.
+// По умолчанию используется текущий месяц
+func (h *HouseholdHandlerImpl) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID домохозяйства из URL
+	householdID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID домохозяйства", err.Error())
+		return
+	}
+
+	// Получаем год и месяц из параметров запроса
+	now := time.Now()
+	year := utils.GetIntQueryParam(r, "year", now.Year())
+	month := utils.GetIntQueryParam(r, "month", int(now.Month()))
+
+	// Проверяем корректность месяца
+	if month < 1 || month > 12 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Месяц должен быть в диапазоне от 1 до 12", "")
+		return
+	}
+
+	// Получаем сводку
+	dashboard, err := h.householdService.GetDashboard(r.Context(), householdID, userID, year, month)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось получить сводку домохозяйства", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, dashboard)
+}
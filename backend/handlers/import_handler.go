@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"cz.Finance/backend/importers"
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// ImportHandlerImpl представляет реализацию обработчика импорта выписок
+type ImportHandlerImpl struct {
+	importService services.ImportService
+}
+
+// NewImportHandler создает новый экземпляр обработчика импорта выписок
+func NewImportHandler(importService services.ImportService) ImportHandler {
+	return &ImportHandlerImpl{
+		importService: importService,
+	}
+}
+
+// CreateImport обрабатывает загрузку выписки и возвращает предварительный просмотр
+func (h *ImportHandlerImpl) CreateImport(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Проверяем размер файла
+	r.ParseMultipartForm(10 << 20) // Ограничение 10 МБ
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка загрузки файла", err.Error())
+		return
+	}
+	defer file.Close()
+
+	// Определяем формат выписки: явно указанный или по расширению файла
+	format := models.ImportFormat(strings.ToLower(r.FormValue("format")))
+	if format == "" {
+		format, err = importers.DetectFormat(handler.Filename)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Неизвестный формат выписки", err.Error())
+			return
+		}
+	}
+
+	// Разбираем выписку
+	preview, err := h.importService.PreviewImport(r.Context(), userID, format, handler.Filename, file)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при разборе выписки", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, preview)
+}
+
+// GetImport обрабатывает запрос на получение импорта по ID
+func (h *ImportHandlerImpl) GetImport(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID импорта из URL
+	importID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID импорта", err.Error())
+		return
+	}
+
+	// Получаем импорт
+	preview, err := h.importService.GetImport(r.Context(), importID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Импорт не найден", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, preview)
+}
+
+// GetUserImports обрабатывает запрос на получение списка импортов пользователя
+func (h *ImportHandlerImpl) GetUserImports(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем импорты
+	imports, err := h.importService.GetUserImports(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при получении импортов", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, imports)
+}
+
+// CommitImport обрабатывает запрос на подтверждение импорта
+func (h *ImportHandlerImpl) CommitImport(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID импорта из URL
+	importID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID импорта", err.Error())
+		return
+	}
+
+	// Подтверждаем импорт
+	imp, err := h.importService.CommitImport(r.Context(), importID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при подтверждении импорта", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, imp)
+}
+
+// RollbackImport обрабатывает запрос на отмену импорта
+func (h *ImportHandlerImpl) RollbackImport(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID импорта из URL
+	importID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID импорта", err.Error())
+		return
+	}
+
+	// Отменяем импорт
+	imp, err := h.importService.RollbackImport(r.Context(), importID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при отмене импорта", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, imp)
+}
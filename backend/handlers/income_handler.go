@@ -141,13 +141,9 @@ func (h *IncomeHandlerImpl) GetIncomeSummary(w http.ResponseWriter, r *http.Requ
 	startDateStr := utils.GetQueryParam(r, "start_date")
 	endDateStr := utils.GetQueryParam(r, "end_date")
 
-	// Если даты не указаны, используем текущий месяц
+	// Если даты не указаны, сервис использует текущий месяц в часовом поясе пользователя
 	var startDate, endDate time.Time
-	if startDateStr == "" || endDateStr == "" {
-		now := time.Now()
-		startDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-		endDate = startDate.AddDate(0, 1, -1).Add(time.Hour * 23).Add(time.Minute * 59).Add(time.Second * 59)
-	} else {
+	if startDateStr != "" && endDateStr != "" {
 		var err error
 		startDate, err = time.Parse(time.RFC3339, startDateStr)
 		if err != nil {
@@ -23,6 +23,7 @@ type ExpenseHandler interface {
 	GetExpenseSummary(w http.ResponseWriter, r *http.Request)
 	UpdateExpense(w http.ResponseWriter, r *http.Request)
 	DeleteExpense(w http.ResponseWriter, r *http.Request)
+	SuggestCategory(w http.ResponseWriter, r *http.Request)
 }
 
 // IncomeHandler интерфейс для обработки запросов связанных с накоплениями
@@ -57,6 +58,21 @@ type WishlistHandler interface {
 	GetUserWishlist(w http.ResponseWriter, r *http.Request)
 	UpdateWishlistItem(w http.ResponseWriter, r *http.Request)
 	DeleteWishlistItem(w http.ResponseWriter, r *http.Request)
+	PurchaseWishlistItem(w http.ResponseWriter, r *http.Request)
+	RecordPrice(w http.ResponseWriter, r *http.Request)
+	GetAffordabilityPlan(w http.ResponseWriter, r *http.Request)
+	AddAllocation(w http.ResponseWriter, r *http.Request)
+	DeleteAllocation(w http.ResponseWriter, r *http.Request)
+}
+
+// WishlistShareHandler интерфейс для обработки запросов связанных с публичными ссылками на список желаний
+type WishlistShareHandler interface {
+	GetShare(w http.ResponseWriter, r *http.Request)
+	UpdateShare(w http.ResponseWriter, r *http.Request)
+	RevokeShare(w http.ResponseWriter, r *http.Request)
+	GetPublicWishlist(w http.ResponseWriter, r *http.Request)
+	ReserveItem(w http.ResponseWriter, r *http.Request)
+	CancelReservation(w http.ResponseWriter, r *http.Request)
 }
 
 // TelegramHandler интерфейс для обработки запросов от Telegram
@@ -65,3 +81,178 @@ type TelegramHandler interface {
 	GetUserByTelegramID(w http.ResponseWriter, r *http.Request)
 	UnlinkTelegramAccount(w http.ResponseWriter, r *http.Request)
 }
+
+// ImportHandler интерфейс для обработки запросов связанных с импортом выписок
+type ImportHandler interface {
+	CreateImport(w http.ResponseWriter, r *http.Request)
+	GetImport(w http.ResponseWriter, r *http.Request)
+	GetUserImports(w http.ResponseWriter, r *http.Request)
+	CommitImport(w http.ResponseWriter, r *http.Request)
+	RollbackImport(w http.ResponseWriter, r *http.Request)
+}
+
+// ArchiveHandler интерфейс для обработки запросов выгрузки и восстановления данных пользователя
+type ArchiveHandler interface {
+	ExportArchive(w http.ResponseWriter, r *http.Request)
+	ImportArchive(w http.ResponseWriter, r *http.Request)
+}
+
+// ExportHandler интерфейс для обработки запросов выгрузки операций в таблицы
+type ExportHandler interface {
+	ExportExpenses(w http.ResponseWriter, r *http.Request)
+	ExportIncomes(w http.ResponseWriter, r *http.Request)
+}
+
+// LedgerHandler интерфейс для обработки запросов выгрузки в текстовую бухгалтерию
+type LedgerHandler interface {
+	ExportLedger(w http.ResponseWriter, r *http.Request)
+	GetLedgerAccounts(w http.ResponseWriter, r *http.Request)
+	UpdateLedgerAccounts(w http.ResponseWriter, r *http.Request)
+}
+
+// ReportHandler интерфейс для обработки запросов печатных отчетов
+type ReportHandler interface {
+	GetMonthlyReport(w http.ResponseWriter, r *http.Request)
+}
+
+// GoalHandler интерфейс для обработки запросов связанных с целями накоплений
+type GoalHandler interface {
+	CreateGoal(w http.ResponseWriter, r *http.Request)
+	GetGoal(w http.ResponseWriter, r *http.Request)
+	GetUserGoals(w http.ResponseWriter, r *http.Request)
+	UpdateGoal(w http.ResponseWriter, r *http.Request)
+	DeleteGoal(w http.ResponseWriter, r *http.Request)
+	AddContribution(w http.ResponseWriter, r *http.Request)
+	DeleteContribution(w http.ResponseWriter, r *http.Request)
+}
+
+// HouseholdHandler интерфейс для обработки запросов связанных с домохозяйствами
+type HouseholdHandler interface {
+	CreateHousehold(w http.ResponseWriter, r *http.Request)
+	GetUserHouseholds(w http.ResponseWriter, r *http.Request)
+	GetHousehold(w http.ResponseWriter, r *http.Request)
+	UpdateHousehold(w http.ResponseWriter, r *http.Request)
+	DeleteHousehold(w http.ResponseWriter, r *http.Request)
+	InviteMember(w http.ResponseWriter, r *http.Request)
+	GetHouseholdInvitations(w http.ResponseWriter, r *http.Request)
+	RevokeInvitation(w http.ResponseWriter, r *http.Request)
+	GetMyInvitations(w http.ResponseWriter, r *http.Request)
+	AcceptInvitation(w http.ResponseWriter, r *http.Request)
+	DeclineInvitation(w http.ResponseWriter, r *http.Request)
+	UpdateMemberRole(w http.ResponseWriter, r *http.Request)
+	RemoveMember(w http.ResponseWriter, r *http.Request)
+	GetHouseholdExpenses(w http.ResponseWriter, r *http.Request)
+	GetHouseholdIncomes(w http.ResponseWriter, r *http.Request)
+	GetBudgets(w http.ResponseWriter, r *http.Request)
+	SetBudget(w http.ResponseWriter, r *http.Request)
+	GetDashboard(w http.ResponseWriter, r *http.Request)
+}
+
+// SplitHandler интерфейс для обработки запросов связанных с разделением общих трат
+type SplitHandler interface {
+	CreateGroup(w http.ResponseWriter, r *http.Request)
+	GetUserGroups(w http.ResponseWriter, r *http.Request)
+	GetGroup(w http.ResponseWriter, r *http.Request)
+	DeleteGroup(w http.ResponseWriter, r *http.Request)
+	AddParticipant(w http.ResponseWriter, r *http.Request)
+	RemoveParticipant(w http.ResponseWriter, r *http.Request)
+	CreateExpense(w http.ResponseWriter, r *http.Request)
+	GetExpenses(w http.ResponseWriter, r *http.Request)
+	DeleteExpense(w http.ResponseWriter, r *http.Request)
+	CreateSettlement(w http.ResponseWriter, r *http.Request)
+	GetSettlements(w http.ResponseWriter, r *http.Request)
+	DeleteSettlement(w http.ResponseWriter, r *http.Request)
+	GetBalances(w http.ResponseWriter, r *http.Request)
+}
+
+// LoanHandler интерфейс для обработки запросов связанных с кредитами
+type LoanHandler interface {
+	CreateLoan(w http.ResponseWriter, r *http.Request)
+	GetLoan(w http.ResponseWriter, r *http.Request)
+	GetUserLoans(w http.ResponseWriter, r *http.Request)
+	UpdateLoan(w http.ResponseWriter, r *http.Request)
+	DeleteLoan(w http.ResponseWriter, r *http.Request)
+	AddPayment(w http.ResponseWriter, r *http.Request)
+	DeletePayment(w http.ResponseWriter, r *http.Request)
+}
+
+// NetWorthHandler интерфейс для обработки запросов связанных с активами и чистой стоимостью капитала
+type NetWorthHandler interface {
+	CreateAsset(w http.ResponseWriter, r *http.Request)
+	GetAsset(w http.ResponseWriter, r *http.Request)
+	GetUserAssets(w http.ResponseWriter, r *http.Request)
+	UpdateAsset(w http.ResponseWriter, r *http.Request)
+	DeleteAsset(w http.ResponseWriter, r *http.Request)
+	AddValuation(w http.ResponseWriter, r *http.Request)
+	DeleteValuation(w http.ResponseWriter, r *http.Request)
+	GetNetWorth(w http.ResponseWriter, r *http.Request)
+	CreateSnapshot(w http.ResponseWriter, r *http.Request)
+}
+
+// InvestmentHandler интерфейс для обработки запросов связанных с инвестиционным портфелем
+type InvestmentHandler interface {
+	GetPortfolio(w http.ResponseWriter, r *http.Request)
+	AddTransaction(w http.ResponseWriter, r *http.Request)
+	GetTransactions(w http.ResponseWriter, r *http.Request)
+	DeleteTransaction(w http.ResponseWriter, r *http.Request)
+	GetPrices(w http.ResponseWriter, r *http.Request)
+	UpdatePrices(w http.ResponseWriter, r *http.Request)
+	ImportPrices(w http.ResponseWriter, r *http.Request)
+	DeletePrice(w http.ResponseWriter, r *http.Request)
+}
+
+// RuleHandler интерфейс для обработки запросов связанных с правилами категоризации
+type RuleHandler interface {
+	CreateRule(w http.ResponseWriter, r *http.Request)
+	GetRule(w http.ResponseWriter, r *http.Request)
+	GetUserRules(w http.ResponseWriter, r *http.Request)
+	UpdateRule(w http.ResponseWriter, r *http.Request)
+	DeleteRule(w http.ResponseWriter, r *http.Request)
+	ReorderRules(w http.ResponseWriter, r *http.Request)
+	TestRule(w http.ResponseWriter, r *http.Request)
+}
+
+// PayeeHandler интерфейс для обработки запросов связанных с получателями платежей
+type PayeeHandler interface {
+	CreatePayee(w http.ResponseWriter, r *http.Request)
+	GetPayee(w http.ResponseWriter, r *http.Request)
+	GetUserPayees(w http.ResponseWriter, r *http.Request)
+	UpdatePayee(w http.ResponseWriter, r *http.Request)
+	DeletePayee(w http.ResponseWriter, r *http.Request)
+	LinkExpenses(w http.ResponseWriter, r *http.Request)
+	GetTopPayees(w http.ResponseWriter, r *http.Request)
+}
+
+// SubscriptionHandler интерфейс для обработки запросов связанных с подписками и регулярными списаниями
+type SubscriptionHandler interface {
+	DetectSubscriptions(w http.ResponseWriter, r *http.Request)
+	ConfirmSubscription(w http.ResponseWriter, r *http.Request)
+	GetRecurringRule(w http.ResponseWriter, r *http.Request)
+	GetRecurringRules(w http.ResponseWriter, r *http.Request)
+	UpdateRecurringRule(w http.ResponseWriter, r *http.Request)
+	DeleteRecurringRule(w http.ResponseWriter, r *http.Request)
+}
+
+// AlertHandler интерфейс для обработки запросов связанных с предупреждениями о необычных тратах
+type AlertHandler interface {
+	GetAlerts(w http.ResponseWriter, r *http.Request)
+	CheckAlerts(w http.ResponseWriter, r *http.Request)
+	MarkAlertRead(w http.ResponseWriter, r *http.Request)
+	MarkAllAlertsRead(w http.ResponseWriter, r *http.Request)
+}
+
+// ForecastHandler интерфейс для обработки запросов прогноза денежного потока
+type ForecastHandler interface {
+	GetForecast(w http.ResponseWriter, r *http.Request)
+}
+
+// ComparisonHandler интерфейс для обработки запросов сравнения периодов
+type ComparisonHandler interface {
+	ComparePeriods(w http.ResponseWriter, r *http.Request)
+	CompareYearOverYear(w http.ResponseWriter, r *http.Request)
+}
+
+// AnalyticsHandler интерфейс для обработки аналитических запросов
+type AnalyticsHandler interface {
+	Query(w http.ResponseWriter, r *http.Request)
+}
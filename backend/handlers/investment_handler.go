@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// InvestmentHandlerImpl представляет реализацию обработчика инвестиционного портфеля
+type InvestmentHandlerImpl struct {
+	investmentService services.InvestmentService
+}
+
+// NewInvestmentHandler создает новый экземпляр обработчика инвестиционного портфеля
+func NewInvestmentHandler(investmentService services.InvestmentService) InvestmentHandler {
+	return &InvestmentHandlerImpl{
+		investmentService: investmentService,
+	}
+}
+
+// GetPortfolio обрабатывает запрос на получение позиций и прибыли по портфелю
+func (h *InvestmentHandlerImpl) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем портфель
+	portfolio, err := h.investmentService.GetPortfolio(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить портфель", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, portfolio)
+}
+
+// AddTransaction обрабатывает запрос на запись покупки, продажи или дивиденда
+func (h *InvestmentHandlerImpl) AddTransaction(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateInvestmentTransactionRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Записываем операцию
+	transaction, err := h.investmentService.AddTransaction(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось записать операцию", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, transaction)
+}
+
+// GetTransactions обрабатывает запрос на получение операций с ценными бумагами
+func (h *InvestmentHandlerImpl) GetTransactions(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем операции, при необходимости по одной бумаге
+	transactions, err := h.investmentService.GetTransactions(r.Context(), userID, utils.GetQueryParam(r, "ticker"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить операции", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, transactions)
+}
+
+// DeleteTransaction обрабатывает запрос на удаление операции с ценной бумагой
+func (h *InvestmentHandlerImpl) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID операции из URL
+	transactionID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID операции", err.Error())
+		return
+	}
+
+	// Удаляем операцию
+	if err := h.investmentService.DeleteTransaction(r.Context(), transactionID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось удалить операцию", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Операция успешно удалена"})
+}
+
+// GetPrices обрабатывает запрос на получение введенных цен
+func (h *InvestmentHandlerImpl) GetPrices(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем цены, при необходимости по одной бумаге
+	prices, err := h.investmentService.GetPrices(r.Context(), userID, utils.GetQueryParam(r, "ticker"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить цены", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, prices)
+}
+
+// UpdatePrices обрабатывает запрос на пакетное обновление цен
+func (h *InvestmentHandlerImpl) UpdatePrices(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateSecurityPricesRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Сохраняем цены
+	saved, err := h.investmentService.UpdatePrices(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось сохранить цены", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]int{"saved": saved})
+}
+
+// ImportPrices обрабатывает загрузку цен из CSV-файла
+func (h *InvestmentHandlerImpl) ImportPrices(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Проверяем размер файла
+	r.ParseMultipartForm(10 << 20) // Ограничение 10 МБ
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка загрузки файла", err.Error())
+		return
+	}
+	defer file.Close()
+
+	// Загружаем цены
+	saved, err := h.investmentService.ImportPrices(r.Context(), userID, file)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при разборе файла цен", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]int{"saved": saved})
+}
+
+// DeletePrice обрабатывает запрос на удаление цены бумаги на дату
+func (h *InvestmentHandlerImpl) DeletePrice(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем тикер и дату из URL
+	vars := mux.Vars(r)
+	date, err := time.Parse("2006-01-02", vars["date"])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверная дата", err.Error())
+		return
+	}
+
+	// Удаляем цену
+	if err := h.investmentService.DeletePrice(r.Context(), userID, vars["ticker"], date); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось удалить цену", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Цена успешно удалена"})
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// LedgerHandlerImpl представляет реализацию обработчика выгрузки в текстовую бухгалтерию
+type LedgerHandlerImpl struct {
+	ledgerService services.LedgerService
+}
+
+// NewLedgerHandler создает новый экземпляр обработчика текстовой бухгалтерии
+func NewLedgerHandler(ledgerService services.LedgerService) LedgerHandler {
+	return &LedgerHandlerImpl{
+		ledgerService: ledgerService,
+	}
+}
+
+// ExportLedger обрабатывает запрос на выгрузку журнала hledger или файла Beancount
+func (h *LedgerHandlerImpl) ExportLedger(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем формат выгрузки
+	format := models.LedgerFormat(utils.GetQueryParam(r, "format"))
+	if format == "" {
+		format = models.LedgerFormatHledger
+	}
+	if format != models.LedgerFormatHledger && format != models.LedgerFormatBeancount {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат выгрузки", "Допустимые значения: hledger, beancount")
+		return
+	}
+
+	// Получаем период из запроса
+	startDate, endDate, err := getExportPeriod(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты", err.Error())
+		return
+	}
+
+	// Формируем файл
+	var buf bytes.Buffer
+	if err := h.ledgerService.ExportLedger(r.Context(), userID, format, startDate, endDate, &buf); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при выгрузке бухгалтерской книги", err.Error())
+		return
+	}
+
+	// Отправляем файл
+	extension := "journal"
+	if format == models.LedgerFormatBeancount {
+		extension = "beancount"
+	}
+	filename := fmt.Sprintf("czfinance-%s.%s", time.Now().Format("20060102"), extension)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// GetLedgerAccounts обрабатывает запрос на получение сопоставления счетов
+func (h *LedgerHandlerImpl) GetLedgerAccounts(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем сопоставление счетов
+	accounts, err := h.ledgerService.GetLedgerAccounts(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при получении счетов", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, accounts)
+}
+
+// UpdateLedgerAccounts обрабатывает запрос на изменение сопоставления счетов
+func (h *LedgerHandlerImpl) UpdateLedgerAccounts(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateLedgerAccountsRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Сохраняем сопоставление счетов
+	accounts, err := h.ledgerService.UpdateLedgerAccounts(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось сохранить счета", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, accounts)
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// LoanHandlerImpl представляет реализацию обработчика кредитов
+type LoanHandlerImpl struct {
+	loanService services.LoanService
+}
+
+// NewLoanHandler создает новый экземпляр обработчика кредитов
+func NewLoanHandler(loanService services.LoanService) LoanHandler {
+	return &LoanHandlerImpl{
+		loanService: loanService,
+	}
+}
+
+// CreateLoan обрабатывает запрос на добавление кредита
+func (h *LoanHandlerImpl) CreateLoan(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateLoanRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем кредит
+	loan, err := h.loanService.CreateLoan(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось добавить кредит", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, loan)
+}
+
+// GetLoan обрабатывает запрос на получение кредита с платежами и графиком
+func (h *LoanHandlerImpl) GetLoan(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID кредита из URL
+	loanID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID кредита", err.Error())
+		return
+	}
+
+	// Получаем кредит
+	loan, err := h.loanService.GetLoan(r.Context(), loanID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Кредит не найден", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, loan)
+}
+
+// GetUserLoans обрабатывает запрос на получение всех кредитов пользователя
+func (h *LoanHandlerImpl) GetUserLoans(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем кредиты пользователя
+	loans, err := h.loanService.GetUserLoans(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить кредиты", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, loans)
+}
+
+// UpdateLoan обрабатывает запрос на обновление кредита
+func (h *LoanHandlerImpl) UpdateLoan(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID кредита из URL
+	loanID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID кредита", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateLoanRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Обновляем кредит
+	loan, err := h.loanService.UpdateLoan(r.Context(), loanID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось обновить кредит", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, loan)
+}
+
+// DeleteLoan обрабатывает запрос на удаление кредита
+func (h *LoanHandlerImpl) DeleteLoan(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID кредита из URL
+	loanID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID кредита", err.Error())
+		return
+	}
+
+	// Удаляем кредит
+	if err := h.loanService.DeleteLoan(r.Context(), loanID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось удалить кредит", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Кредит успешно удален"})
+}
+
+// AddPayment обрабатывает запрос на запись платежа по кредиту
+func (h *LoanHandlerImpl) AddPayment(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID кредита из URL
+	loanID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID кредита", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateLoanPaymentRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Записываем платеж
+	loan, err := h.loanService.AddPayment(r.Context(), loanID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось записать платеж", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, loan)
+}
+
+// DeletePayment обрабатывает запрос на удаление последнего платежа по кредиту
+func (h *LoanHandlerImpl) DeletePayment(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID кредита и платежа из URL
+	loanID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID кредита", err.Error())
+		return
+	}
+
+	paymentID, err := strconv.ParseInt(mux.Vars(r)["payment_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID платежа", err.Error())
+		return
+	}
+
+	// Удаляем платеж
+	loan, err := h.loanService.DeletePayment(r.Context(), loanID, paymentID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось удалить платеж", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, loan)
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// NetWorthHandlerImpl представляет реализацию обработчика активов и чистой стоимости капитала
+type NetWorthHandlerImpl struct {
+	netWorthService services.NetWorthService
+}
+
+// NewNetWorthHandler создает новый экземпляр обработчика активов и чистой стоимости капитала
+func NewNetWorthHandler(netWorthService services.NetWorthService) NetWorthHandler {
+	return &NetWorthHandlerImpl{
+		netWorthService: netWorthService,
+	}
+}
+
+// CreateAsset обрабатывает запрос на добавление актива или обязательства
+func (h *NetWorthHandlerImpl) CreateAsset(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateAssetRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем актив
+	asset, err := h.netWorthService.CreateAsset(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось добавить актив", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, asset)
+}
+
+// GetAsset обрабатывает запрос на получение актива с историей оценок
+func (h *NetWorthHandlerImpl) GetAsset(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID актива из URL
+	assetID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID актива", err.Error())
+		return
+	}
+
+	// Получаем актив
+	asset, err := h.netWorthService.GetAsset(r.Context(), assetID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Актив не найден", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, asset)
+}
+
+// GetUserAssets обрабатывает запрос на получение всех активов и обязательств пользователя
+func (h *NetWorthHandlerImpl) GetUserAssets(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем активы пользователя
+	assets, err := h.netWorthService.GetUserAssets(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить активы", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, assets)
+}
+
+// UpdateAsset обрабатывает запрос на обновление актива
+func (h *NetWorthHandlerImpl) UpdateAsset(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID актива из URL
+	assetID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID актива", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateAssetRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Обновляем актив
+	asset, err := h.netWorthService.UpdateAsset(r.Context(), assetID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось обновить актив", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, asset)
+}
+
+// DeleteAsset обрабатывает запрос на удаление актива
+func (h *NetWorthHandlerImpl) DeleteAsset(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID актива из URL
+	assetID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID актива", err.Error())
+		return
+	}
+
+	// Удаляем актив
+	if err := h.netWorthService.DeleteAsset(r.Context(), assetID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось удалить актив", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Актив успешно удален"})
+}
+
+// AddValuation обрабатывает запрос на добавление оценки стоимости актива
+func (h *NetWorthHandlerImpl) AddValuation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID актива из URL
+	assetID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID актива", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateAssetValuationRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Добавляем оценку
+	asset, err := h.netWorthService.AddValuation(r.Context(), assetID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось добавить оценку", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, asset)
+}
+
+// DeleteValuation обрабатывает запрос на удаление оценки стоимости актива
+func (h *NetWorthHandlerImpl) DeleteValuation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID актива и оценки из URL
+	assetID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID актива", err.Error())
+		return
+	}
+
+	valuationID, err := strconv.ParseInt(mux.Vars(r)["valuation_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID оценки", err.Error())
+		return
+	}
+
+	// Удаляем оценку
+	if err := h.netWorthService.DeleteValuation(r.Context(), assetID, valuationID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось удалить оценку", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Оценка успешно удалена"})
+}
+
+// GetNetWorth обрабатывает запрос на получение чистой стоимости капитала и ее динамики
+func (h *NetWorthHandlerImpl) GetNetWorth(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем глубину истории в месяцах
+	months := utils.GetIntQueryParam(r, "months", 12)
+
+	// Рассчитываем чистую стоимость капитала
+	netWorth, err := h.netWorthService.GetNetWorth(r.Context(), userID, months)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось рассчитать капитал", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, netWorth)
+}
+
+// CreateSnapshot обрабатывает запрос на сохранение снимка капитала за текущий месяц
+func (h *NetWorthHandlerImpl) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Сохраняем снимок
+	snapshot, err := h.netWorthService.SnapshotUser(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось сохранить снимок капитала", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, snapshot)
+}
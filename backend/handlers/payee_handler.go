@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// PayeeHandlerImpl представляет реализацию обработчика получателей платежей
+type PayeeHandlerImpl struct {
+	payeeService services.PayeeService
+}
+
+// NewPayeeHandler создает новый экземпляр обработчика получателей платежей
+func NewPayeeHandler(payeeService services.PayeeService) PayeeHandler {
+	return &PayeeHandlerImpl{
+		payeeService: payeeService,
+	}
+}
+
+// CreatePayee обрабатывает запрос на создание получателя
+func (h *PayeeHandlerImpl) CreatePayee(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.PayeeRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем получателя
+	payee, err := h.payeeService.CreatePayee(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось создать получателя", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, payee)
+}
+
+// GetPayee обрабатывает запрос на получение получателя по ID
+func (h *PayeeHandlerImpl) GetPayee(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID получателя из URL
+	payeeID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID получателя", err.Error())
+		return
+	}
+
+	// Получаем получателя
+	payee, err := h.payeeService.GetPayee(r.Context(), payeeID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Получатель не найден", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, payee)
+}
+
+// GetUserPayees обрабатывает запрос на получение всех получателей пользователя
+func (h *PayeeHandlerImpl) GetUserPayees(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем получателей
+	payees, err := h.payeeService.GetUserPayees(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при получении получателей", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, payees)
+}
+
+// UpdatePayee обрабатывает запрос на обновление получателя
+func (h *PayeeHandlerImpl) UpdatePayee(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID получателя из URL
+	payeeID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID получателя", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.PayeeRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Обновляем получателя
+	payee, err := h.payeeService.UpdatePayee(r.Context(), payeeID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось обновить получателя", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, payee)
+}
+
+// DeletePayee обрабатывает запрос на удаление получателя
+func (h *PayeeHandlerImpl) DeletePayee(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID получателя из URL
+	payeeID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID получателя", err.Error())
+		return
+	}
+
+	// Удаляем получателя
+	if err := h.payeeService.DeletePayee(r.Context(), payeeID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось удалить получателя", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Получатель успешно удален"})
+}
+
+// LinkExpenses обрабатывает запрос на связывание трат без получателя с получателями
+func (h *PayeeHandlerImpl) LinkExpenses(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Связываем траты
+	result, err := h.payeeService.LinkExpenses(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось связать траты с получателями", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
+
+// GetTopPayees обрабатывает запрос на получение крупнейших получателей за период.
+// Без параметров периода используется текущий месяц
+func (h *PayeeHandlerImpl) GetTopPayees(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем параметры периода. Если они не указаны, сервис использует текущий месяц
+	var startDate, endDate time.Time
+
+	if startDateStr := utils.GetQueryParam(r, "start_date"); startDateStr != "" {
+		startDate, err = time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты начала периода", err.Error())
+			return
+		}
+	}
+
+	if endDateStr := utils.GetQueryParam(r, "end_date"); endDateStr != "" {
+		endDate, err = time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат даты конца периода", err.Error())
+			return
+		}
+	}
+
+	limit := utils.GetIntQueryParam(r, "limit", 0)
+
+	// Получаем статистику
+	result, err := h.payeeService.GetTopPayees(r.Context(), userID, startDate, endDate, limit)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка при получении статистики по получателям", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// ReportHandlerImpl представляет реализацию обработчика печатных отчетов
+type ReportHandlerImpl struct {
+	reportService services.ReportService
+}
+
+// NewReportHandler создает новый экземпляр обработчика отчетов
+func NewReportHandler(reportService services.ReportService) ReportHandler {
+	return &ReportHandlerImpl{
+		reportService: reportService,
+	}
+}
+
+// GetMonthlyReport обрабатывает запрос на получение PDF-отчета за месяц
+func (h *ReportHandlerImpl) GetMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем год и месяц из URL
+	vars := mux.Vars(r)
+	year, err := strconv.Atoi(vars["year"])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат года", err.Error())
+		return
+	}
+
+	month, err := strconv.Atoi(vars["month"])
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный формат месяца", err.Error())
+		return
+	}
+
+	// Проверяем корректность месяца
+	if month < 1 || month > 12 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Месяц должен быть в диапазоне от 1 до 12", "")
+		return
+	}
+
+	// Формируем отчет
+	var buf bytes.Buffer
+	if err := h.reportService.WriteMonthlyReport(r.Context(), userID, year, month, &buf); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при формировании отчета", err.Error())
+		return
+	}
+
+	// Отправляем файл
+	filename := fmt.Sprintf("report-%d-%02d.pdf", year, month)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,431 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// SplitHandlerImpl представляет реализацию обработчика разделения общих трат
+type SplitHandlerImpl struct {
+	splitService services.SplitService
+}
+
+// NewSplitHandler создает новый экземпляр обработчика разделения общих трат
+func NewSplitHandler(splitService services.SplitService) SplitHandler {
+	return &SplitHandlerImpl{
+		splitService: splitService,
+	}
+}
+
+// CreateGroup обрабатывает запрос на создание группы
+func (h *SplitHandlerImpl) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateSplitGroupRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Создаем группу
+	group, err := h.splitService.CreateGroup(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось создать группу", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, group)
+}
+
+// GetUserGroups обрабатывает запрос на получение групп пользователя
+func (h *SplitHandlerImpl) GetUserGroups(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем группы
+	groups, err := h.splitService.GetUserGroups(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить группы", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, groups)
+}
+
+// GetGroup обрабатывает запрос на получение группы с участниками
+func (h *SplitHandlerImpl) GetGroup(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Получаем группу
+	group, err := h.splitService.GetGroup(r.Context(), groupID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Группа не найдена", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, group)
+}
+
+// DeleteGroup обрабатывает запрос на удаление группы
+func (h *SplitHandlerImpl) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Удаляем группу
+	if err := h.splitService.DeleteGroup(r.Context(), groupID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusForbidden, "Не удалось удалить группу", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Группа успешно удалена"})
+}
+
+// AddParticipant обрабатывает запрос на добавление участника в группу
+func (h *SplitHandlerImpl) AddParticipant(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.AddSplitParticipantRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Добавляем участника
+	participant, err := h.splitService.AddParticipant(r.Context(), groupID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось добавить участника", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, participant)
+}
+
+// RemoveParticipant обрабатывает запрос на удаление участника из группы
+func (h *SplitHandlerImpl) RemoveParticipant(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы и участника из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	participantID, err := strconv.ParseInt(mux.Vars(r)["participant_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID участника", err.Error())
+		return
+	}
+
+	// Удаляем участника
+	if err := h.splitService.RemoveParticipant(r.Context(), groupID, participantID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось удалить участника", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Участник удален из группы"})
+}
+
+// CreateExpense обрабатывает запрос на добавление общей траты группы
+func (h *SplitHandlerImpl) CreateExpense(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateSplitExpenseRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Добавляем трату
+	expense, err := h.splitService.CreateExpense(r.Context(), groupID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось добавить трату группы", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, expense)
+}
+
+// GetExpenses обрабатывает запрос на получение общих трат группы
+func (h *SplitHandlerImpl) GetExpenses(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Получаем траты
+	expenses, err := h.splitService.GetExpenses(r.Context(), groupID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось получить траты группы", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, expenses)
+}
+
+// DeleteExpense обрабатывает запрос на удаление общей траты группы
+func (h *SplitHandlerImpl) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы и траты из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	expenseID, err := strconv.ParseInt(mux.Vars(r)["expense_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID траты", err.Error())
+		return
+	}
+
+	// Удаляем трату
+	if err := h.splitService.DeleteExpense(r.Context(), groupID, expenseID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось удалить трату группы", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Трата группы успешно удалена"})
+}
+
+// CreateSettlement обрабатывает запрос на запись погашения долга
+func (h *SplitHandlerImpl) CreateSettlement(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateSplitSettlementRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Записываем погашение
+	settlement, err := h.splitService.CreateSettlement(r.Context(), groupID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось записать погашение", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, settlement)
+}
+
+// GetSettlements обрабатывает запрос на получение погашений долгов группы
+func (h *SplitHandlerImpl) GetSettlements(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Получаем погашения
+	settlements, err := h.splitService.GetSettlements(r.Context(), groupID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось получить погашения", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, settlements)
+}
+
+// DeleteSettlement обрабатывает запрос на удаление погашения долга
+func (h *SplitHandlerImpl) DeleteSettlement(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы и погашения из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	settlementID, err := strconv.ParseInt(mux.Vars(r)["settlement_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID погашения", err.Error())
+		return
+	}
+
+	// Удаляем погашение
+	if err := h.splitService.DeleteSettlement(r.Context(), groupID, settlementID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось удалить погашение", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Погашение успешно удалено"})
+}
+
+// GetBalances обрабатывает запрос на получение сальдо участников и долгов группы.
+// По умолчанию долги упрощаются, параметр simplify=false возвращает долги по парам участников
+func (h *SplitHandlerImpl) GetBalances(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID группы из URL
+	groupID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID группы", err.Error())
+		return
+	}
+
+	// Получаем режим расчета долгов
+	simplify := utils.GetQueryParam(r, "simplify") != "false"
+
+	// Рассчитываем сальдо
+	balances, err := h.splitService.GetBalances(r.Context(), groupID, userID, simplify)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось рассчитать долги группы", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, balances)
+}
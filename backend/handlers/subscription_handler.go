@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+)
+
+// SubscriptionHandlerImpl представляет реализацию обработчика подписок и регулярных списаний
+type SubscriptionHandlerImpl struct {
+	subscriptionService services.SubscriptionService
+}
+
+// NewSubscriptionHandler создает новый экземпляр обработчика подписок
+func NewSubscriptionHandler(subscriptionService services.SubscriptionService) SubscriptionHandler {
+	return &SubscriptionHandlerImpl{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// DetectSubscriptions обрабатывает запрос на поиск подписок в истории трат
+func (h *SubscriptionHandlerImpl) DetectSubscriptions(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Ищем подписки
+	report, err := h.subscriptionService.DetectSubscriptions(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при поиске подписок", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// ConfirmSubscription обрабатывает запрос на подтверждение найденной подписки
+func (h *SubscriptionHandlerImpl) ConfirmSubscription(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.ConfirmSubscriptionRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Подтверждаем подписку
+	rule, err := h.subscriptionService.ConfirmSubscription(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось подтвердить подписку", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, rule)
+}
+
+// GetRecurringRule обрабатывает запрос на получение регулярного списания по ID
+func (h *SubscriptionHandlerImpl) GetRecurringRule(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID регулярного списания из URL
+	ruleID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID регулярного списания", err.Error())
+		return
+	}
+
+	// Получаем регулярное списание
+	rule, err := h.subscriptionService.GetRecurringRule(r.Context(), ruleID, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Регулярное списание не найдено", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, rule)
+}
+
+// GetRecurringRules обрабатывает запрос на получение регулярных списаний пользователя
+func (h *SubscriptionHandlerImpl) GetRecurringRules(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем регулярные списания
+	rules, err := h.subscriptionService.GetRecurringRules(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Ошибка при получении регулярных списаний", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, rules)
+}
+
+// UpdateRecurringRule обрабатывает запрос на обновление регулярного списания
+func (h *SubscriptionHandlerImpl) UpdateRecurringRule(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID регулярного списания из URL
+	ruleID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID регулярного списания", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateRecurringRuleRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Обновляем регулярное списание
+	rule, err := h.subscriptionService.UpdateRecurringRule(r.Context(), ruleID, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось обновить регулярное списание", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, rule)
+}
+
+// DeleteRecurringRule обрабатывает запрос на удаление регулярного списания
+func (h *SubscriptionHandlerImpl) DeleteRecurringRule(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID регулярного списания из URL
+	ruleID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID регулярного списания", err.Error())
+		return
+	}
+
+	// Удаляем регулярное списание
+	if err := h.subscriptionService.DeleteRecurringRule(r.Context(), ruleID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось удалить регулярное списание", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Регулярное списание успешно удалено"})
+}
@@ -94,8 +94,17 @@ func (h *WishlistHandlerImpl) GetUserWishlist(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Получаем отбор по признаку покупки
+	status := models.WishlistStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", models.WishlistStatusAll, models.WishlistStatusActive, models.WishlistStatusPurchased:
+	default:
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный статус", "допустимые значения: all, active, purchased")
+		return
+	}
+
 	// Получаем список желаний пользователя
-	items, err := h.wishlistService.GetUserWishlist(r.Context(), userID)
+	items, err := h.wishlistService.GetUserWishlist(r.Context(), userID, status)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось получить список желаний", err.Error())
 		return
@@ -173,3 +182,182 @@ func (h *WishlistHandlerImpl) DeleteWishlistItem(w http.ResponseWriter, r *http.
 	// Отправляем ответ
 	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Элемент успешно удален"})
 }
+
+// PurchaseWishlistItem обрабатывает запрос на покупку элемента списка желаний с созданием траты
+func (h *WishlistHandlerImpl) PurchaseWishlistItem(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID элемента из URL
+	id, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный ID", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.PurchaseWishlistItemRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Отмечаем покупку
+	purchase, err := h.wishlistService.PurchaseWishlistItem(r.Context(), id, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось отметить покупку", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, purchase)
+}
+
+// RecordPrice обрабатывает запрос на запись наблюдаемой цены элемента списка желаний
+func (h *WishlistHandlerImpl) RecordPrice(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID элемента из URL
+	id, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный ID", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.RecordWishlistPriceRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Записываем цену
+	result, err := h.wishlistService.RecordPrice(r.Context(), id, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось записать цену", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, result)
+}
+
+// GetAffordabilityPlan обрабатывает запрос на получение плана покупок из списка желаний
+func (h *WishlistHandlerImpl) GetAffordabilityPlan(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем количество анализируемых месяцев
+	months := utils.GetIntQueryParam(r, "months", 6)
+	if months < 1 || months > 36 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный период", "количество месяцев должно быть от 1 до 36")
+		return
+	}
+
+	// Строим план покупок
+	plan, err := h.wishlistService.GetAffordabilityPlan(r.Context(), userID, months)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось построить план покупок", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, plan)
+}
+
+// AddAllocation обрабатывает запрос на резервирование денег под элемент списка желаний
+func (h *WishlistHandlerImpl) AddAllocation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID элемента из URL
+	id, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный ID", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.CreateWishlistAllocationRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Резервируем средства
+	item, err := h.wishlistService.AddAllocation(r.Context(), id, userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Не удалось зарезервировать средства", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, item)
+}
+
+// DeleteAllocation обрабатывает запрос на удаление резервирования
+func (h *WishlistHandlerImpl) DeleteAllocation(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем ID элемента и резервирования из URL
+	id, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный ID", err.Error())
+		return
+	}
+
+	allocationID, err := strconv.ParseInt(mux.Vars(r)["allocation_id"], 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Неверный ID резервирования", err.Error())
+		return
+	}
+
+	// Удаляем резервирование
+	if err := h.wishlistService.DeleteAllocation(r.Context(), id, allocationID, userID); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось удалить резервирование", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Резервирование успешно удалено"})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/services"
+	"cz.Finance/backend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// WishlistShareHandlerImpl представляет реализацию обработчика публичных ссылок на список желаний
+type WishlistShareHandlerImpl struct {
+	shareService services.WishlistShareService
+}
+
+// NewWishlistShareHandler создает новый экземпляр обработчика публичных ссылок на список желаний
+func NewWishlistShareHandler(shareService services.WishlistShareService) WishlistShareHandler {
+	return &WishlistShareHandlerImpl{
+		shareService: shareService,
+	}
+}
+
+// GetShare обрабатывает запрос на получение настроек публичной ссылки
+func (h *WishlistShareHandlerImpl) GetShare(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Получаем настройки ссылки
+	share, err := h.shareService.GetShare(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Публичная ссылка не создана", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, share)
+}
+
+// UpdateShare обрабатывает запрос на включение и настройку публичной ссылки
+func (h *WishlistShareHandlerImpl) UpdateShare(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса
+	var request models.UpdateWishlistShareRequest
+	if err := utils.ParseJSON(r, &request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+		return
+	}
+
+	// Сохраняем настройки ссылки
+	share, err := h.shareService.UpdateShare(r.Context(), userID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Не удалось сохранить публичную ссылку", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, share)
+}
+
+// RevokeShare обрабатывает запрос на отзыв публичной ссылки
+func (h *WishlistShareHandlerImpl) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID пользователя из контекста
+	userID, err := utils.GetUserIDFromContext(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Требуется авторизация", err.Error())
+		return
+	}
+
+	// Отзываем ссылку
+	if err := h.shareService.RevokeShare(r.Context(), userID); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось отозвать публичную ссылку", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Публичная ссылка отозвана"})
+}
+
+// GetPublicWishlist обрабатывает запрос на просмотр списка желаний по публичной ссылке
+func (h *WishlistShareHandlerImpl) GetPublicWishlist(w http.ResponseWriter, r *http.Request) {
+	// Получаем токен ссылки из URL
+	token := mux.Vars(r)["token"]
+
+	// Получаем публичный список желаний
+	wishlist, err := h.shareService.GetPublicWishlist(r.Context(), token)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Список желаний не найден", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// ReserveItem обрабатывает запрос посетителя на бронирование подарка
+func (h *WishlistShareHandlerImpl) ReserveItem(w http.ResponseWriter, r *http.Request) {
+	// Получаем токен ссылки и ID элемента из URL
+	token := mux.Vars(r)["token"]
+	itemID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный ID", err.Error())
+		return
+	}
+
+	// Декодируем тело запроса, оно может быть пустым
+	var request models.ReserveWishlistItemRequest
+	if r.ContentLength != 0 {
+		if err := utils.ParseJSON(r, &request); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Некорректный запрос", err.Error())
+			return
+		}
+	}
+
+	// Валидируем запрос
+	if err := utils.ValidateStruct(request); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Ошибка валидации", err.Error())
+		return
+	}
+
+	// Бронируем подарок
+	reservation, err := h.shareService.ReserveItem(r.Context(), token, itemID, &request)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusConflict, "Не удалось забронировать подарок", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusCreated, reservation)
+}
+
+// CancelReservation обрабатывает запрос посетителя на отмену бронирования
+func (h *WishlistShareHandlerImpl) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	// Получаем токен ссылки и ID элемента из URL
+	token := mux.Vars(r)["token"]
+	itemID, err := utils.GetIDParam(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Некорректный ID", err.Error())
+		return
+	}
+
+	// Отменяем бронирование
+	cancelToken := utils.GetQueryParam(r, "cancel_token")
+	if err := h.shareService.CancelReservation(r.Context(), token, itemID, cancelToken); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "Не удалось отменить бронирование", err.Error())
+		return
+	}
+
+	// Отправляем ответ
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"message": "Бронирование отменено"})
+}
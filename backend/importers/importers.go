@@ -0,0 +1,104 @@
+package importers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// Transaction представляет операцию из банковской выписки.
+// Отрицательная сумма означает списание, положительная - зачисление.
+type Transaction struct {
+	ExternalID  string
+	Date        time.Time
+	Amount      float64
+	Payee       string
+	Memo        string
+	Category    string
+	CheckNumber string
+}
+
+// Statement представляет разобранную банковскую выписку
+type Statement struct {
+	Account      string
+	Transactions []Transaction
+}
+
+// Parse разбирает выписку в указанном формате
+func Parse(format models.ImportFormat, r io.Reader) (*Statement, error) {
+	switch format {
+	case models.ImportFormatOFX:
+		return ParseOFX(r)
+	case models.ImportFormatQIF:
+		return ParseQIF(r)
+	default:
+		return nil, fmt.Errorf("неподдерживаемый формат выписки: %s", format)
+	}
+}
+
+// DetectFormat определяет формат выписки по расширению файла
+func DetectFormat(fileName string) (models.ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ofx", ".qfx":
+		return models.ImportFormatOFX, nil
+	case ".qif":
+		return models.ImportFormatQIF, nil
+	default:
+		return "", errors.New("не удалось определить формат выписки, укажите его явно")
+	}
+}
+
+// parseAmount разбирает сумму с учетом разных разделителей разрядов и дробной части
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	value = strings.ReplaceAll(value, " ", "")
+	value = strings.ReplaceAll(value, "\u00a0", "")
+
+	// Если встречаются оба разделителя, дробную часть отделяет последний из них.
+	// Если за каждой запятой следуют ровно три цифры, запятая отделяет разряды, иначе - дробную часть
+	switch {
+	case strings.Contains(value, ",") && strings.Contains(value, "."):
+		if strings.LastIndex(value, ",") > strings.LastIndex(value, ".") {
+			value = strings.ReplaceAll(value, ".", "")
+			value = strings.ReplaceAll(value, ",", ".")
+		} else {
+			value = strings.ReplaceAll(value, ",", "")
+		}
+	case isThousandsGrouped(value):
+		value = strings.ReplaceAll(value, ",", "")
+	default:
+		value = strings.ReplaceAll(value, ",", ".")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("неверный формат суммы: %s", value)
+	}
+
+	return amount, nil
+}
+
+// isThousandsGrouped проверяет, что запятые делят число на группы по три цифры, как в "1,234,567"
+func isThousandsGrouped(value string) bool {
+	groups := strings.Split(value, ",")
+	if len(groups) < 2 {
+		return false
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return false
+		}
+		for _, r := range group {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package importers
+
+import (
+	"testing"
+
+	"cz.Finance/backend/models"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{value: "123.45", want: 123.45},
+		{value: "-45,5", want: -45.5},
+		{value: "1,23", want: 1.23},
+		{value: "12,3456", want: 12.3456},
+		{value: "1 234,56", want: 1234.56},
+		{value: "1\u00a0234,56", want: 1234.56},
+		{value: "1,234.56", want: 1234.56},
+		{value: "1.234,56", want: 1234.56},
+		{value: "-1.234.567,8", want: -1234567.8},
+		{value: "1,234", want: 1234},
+		{value: "-1,234,567", want: -1234567},
+		{value: "1,234,56", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			amount, err := parseAmount(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получено %g", amount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if amount != tt.want {
+				t.Errorf("получено %g, ожидалось %g", amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     models.ImportFormat
+		wantErr  bool
+	}{
+		{fileName: "statement.ofx", want: models.ImportFormatOFX},
+		{fileName: "STATEMENT.QFX", want: models.ImportFormatOFX},
+		{fileName: "export.qif", want: models.ImportFormatQIF},
+		{fileName: "export.csv", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			format, err := DetectFormat(tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+			if format != tt.want {
+				t.Errorf("получен формат %q, ожидался %q", format, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package importers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ofxToken представляет тег OFX вместе с текстом, следующим за ним
+type ofxToken struct {
+	name    string
+	closing bool
+	value   string
+}
+
+// ParseOFX разбирает выписку в формате OFX/QFX.
+// Поддерживаются как SGML-версии 1.x без закрывающих тегов у элементов, так и XML-версии 2.x.
+func ParseOFX(r io.Reader) (*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при чтении файла: %v", err)
+	}
+
+	content := string(data)
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, errors.New("файл не является выпиской OFX")
+	}
+
+	statement := &Statement{}
+	var current *Transaction
+
+	for _, token := range tokenizeOFX(content[start:]) {
+		switch {
+		case token.name == "STMTTRN" && !token.closing:
+			current = &Transaction{}
+		case token.name == "STMTTRN" && token.closing:
+			if current != nil {
+				if err := appendOFXTransaction(statement, current); err != nil {
+					return nil, err
+				}
+			}
+			current = nil
+		case token.name == "ACCTID" && !token.closing:
+			if statement.Account == "" {
+				statement.Account = token.value
+			}
+		case current != nil && !token.closing:
+			if err := setOFXField(current, token); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(statement.Transactions) == 0 {
+		return nil, errors.New("в выписке не найдено ни одной операции")
+	}
+
+	return statement, nil
+}
+
+// tokenizeOFX разбивает тело OFX на теги и их значения
+func tokenizeOFX(content string) []ofxToken {
+	var tokens []ofxToken
+
+	for {
+		open := strings.Index(content, "<")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(content[open:], ">")
+		if end < 0 {
+			break
+		}
+		end += open
+
+		name := strings.TrimSpace(content[open+1 : end])
+		content = content[end+1:]
+
+		next := strings.Index(content, "<")
+		value := content
+		if next >= 0 {
+			value = content[:next]
+		}
+
+		token := ofxToken{value: html.UnescapeString(strings.TrimSpace(value))}
+		if strings.HasPrefix(name, "/") {
+			token.closing = true
+			name = name[1:]
+		}
+		token.name = strings.ToUpper(name)
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+// setOFXField заполняет поле операции значением тега
+func setOFXField(transaction *Transaction, token ofxToken) error {
+	switch token.name {
+	case "FITID":
+		transaction.ExternalID = token.value
+	case "DTPOSTED":
+		date, err := parseOFXDate(token.value)
+		if err != nil {
+			return err
+		}
+		transaction.Date = date
+	case "TRNAMT":
+		amount, err := parseAmount(token.value)
+		if err != nil {
+			return err
+		}
+		transaction.Amount = amount
+	case "NAME":
+		transaction.Payee = token.value
+	case "MEMO":
+		transaction.Memo = token.value
+	case "CHECKNUM":
+		transaction.CheckNumber = token.value
+	}
+	return nil
+}
+
+// appendOFXTransaction проверяет операцию и добавляет ее в выписку
+func appendOFXTransaction(statement *Statement, transaction *Transaction) error {
+	if transaction.ExternalID == "" {
+		return errors.New("операция в выписке не содержит FITID")
+	}
+	if transaction.Date.IsZero() {
+		return fmt.Errorf("операция %s не содержит даты", transaction.ExternalID)
+	}
+	statement.Transactions = append(statement.Transactions, *transaction)
+	return nil
+}
+
+// parseOFXDate разбирает дату в формате OFX: YYYYMMDD[HHMMSS[.XXX]][[gmt offset[:tz name]]]
+func parseOFXDate(value string) (time.Time, error) {
+	location := time.UTC
+
+	// Разбираем часовой пояс в квадратных скобках
+	if bracket := strings.Index(value, "["); bracket >= 0 {
+		zone := strings.TrimSuffix(value[bracket+1:], "]")
+		value = value[:bracket]
+
+		offsetStr := zone
+		if colon := strings.Index(zone, ":"); colon >= 0 {
+			offsetStr = zone[:colon]
+		}
+		offset, err := strconv.ParseFloat(offsetStr, 64)
+		if err == nil {
+			location = time.FixedZone(zone, int(offset*3600))
+		}
+	}
+
+	// Отбрасываем миллисекунды
+	if dot := strings.Index(value, "."); dot >= 0 {
+		value = value[:dot]
+	}
+
+	switch {
+	case len(value) >= 14:
+		return time.ParseInLocation("20060102150405", value[:14], location)
+	case len(value) >= 8:
+		return time.ParseInLocation("20060102", value[:8], location)
+	default:
+		return time.Time{}, fmt.Errorf("неверный формат даты OFX: %s", value)
+	}
+}
@@ -0,0 +1,136 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseOFXDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "20240115", want: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "20240115123045", want: time.Date(2024, time.January, 15, 12, 30, 45, 0, time.UTC)},
+		{value: "20240115123045.123", want: time.Date(2024, time.January, 15, 12, 30, 45, 0, time.UTC)},
+		{value: "20240115123000.000[-5:EST]", want: time.Date(2024, time.January, 15, 17, 30, 0, 0, time.UTC)},
+		{value: "20240115120000[+3]", want: time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)},
+		{value: "20240231", wantErr: true},
+		{value: "2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			date, err := parseOFXDate(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получена дата %v", date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if !date.Equal(tt.want) {
+				t.Errorf("получена дата %v, ожидалась %v", date, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantAccount string
+		wantIDs     []string
+		wantAmounts []float64
+		wantPayees  []string
+		wantErr     bool
+	}{
+		{
+			name: "SGML без закрывающих тегов",
+			content: "OFXHEADER:100\nDATA:OFXSGML\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>" +
+				"<BANKACCTFROM><ACCTID>40817810<ACCTTYPE>CHECKING</BANKACCTFROM><BANKTRANLIST>\n" +
+				"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240115<TRNAMT>-1234.50<FITID>A1<NAME>Магнит &amp; Ко<MEMO>продукты</STMTTRN>\n" +
+				"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20240120120000[+3:MSK]<TRNAMT>50000,00<FITID>A2<NAME>Зарплата</STMTTRN>\n" +
+				"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>",
+			wantAccount: "40817810",
+			wantIDs:     []string{"A1", "A2"},
+			wantAmounts: []float64{-1234.5, 50000},
+			wantPayees:  []string{"Магнит & Ко", "Зарплата"},
+		},
+		{
+			name: "XML",
+			content: `<?xml version="1.0"?><?OFX OFXHEADER="200" VERSION="220"?>` +
+				"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>123</ACCTID></BANKACCTFROM>" +
+				"<BANKTRANLIST><STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240201</DTPOSTED>" +
+				"<TRNAMT>-99.90</TRNAMT><FITID>B1</FITID><NAME>Кино</NAME></STMTTRN></BANKTRANLIST>" +
+				"</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>",
+			wantAccount: "123",
+			wantIDs:     []string{"B1"},
+			wantAmounts: []float64{-99.9},
+			wantPayees:  []string{"Кино"},
+		},
+		{
+			name:    "операция без FITID",
+			content: "<OFX><STMTTRN><DTPOSTED>20240115<TRNAMT>-1</STMTTRN></OFX>",
+			wantErr: true,
+		},
+		{
+			name:    "операция без даты",
+			content: "<OFX><STMTTRN><FITID>C1<TRNAMT>-1</STMTTRN></OFX>",
+			wantErr: true,
+		},
+		{
+			name:    "неверная сумма",
+			content: "<OFX><STMTTRN><FITID>C1<DTPOSTED>20240115<TRNAMT>много</STMTTRN></OFX>",
+			wantErr: true,
+		},
+		{
+			name:    "не OFX",
+			content: "Date,Amount\n2024-01-15,-1\n",
+			wantErr: true,
+		},
+		{
+			name:    "нет операций",
+			content: "<OFX><BANKTRANLIST></BANKTRANLIST></OFX>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := ParseOFX(strings.NewReader(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получено %d операций", len(statement.Transactions))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			if statement.Account != tt.wantAccount {
+				t.Errorf("счет %q, ожидался %q", statement.Account, tt.wantAccount)
+			}
+			if len(statement.Transactions) != len(tt.wantIDs) {
+				t.Fatalf("получено %d операций, ожидалось %d", len(statement.Transactions), len(tt.wantIDs))
+			}
+			for i, transaction := range statement.Transactions {
+				if transaction.ExternalID != tt.wantIDs[i] {
+					t.Errorf("операция %d: идентификатор %q, ожидался %q", i, transaction.ExternalID, tt.wantIDs[i])
+				}
+				if transaction.Amount != tt.wantAmounts[i] {
+					t.Errorf("операция %d: сумма %g, ожидалось %g", i, transaction.Amount, tt.wantAmounts[i])
+				}
+				if transaction.Payee != tt.wantPayees[i] {
+					t.Errorf("операция %d: получатель %q, ожидался %q", i, transaction.Payee, tt.wantPayees[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package importers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// ParsePrices разбирает CSV с ценами ценных бумаг в колонках «тикер, дата, цена».
+// Разделителем может быть запятая или точка с запятой, строка заголовка пропускается.
+// Дата указывается в формате YYYY-MM-DD или DD.MM.YYYY
+func ParsePrices(r io.Reader) ([]models.SecurityPrice, error) {
+	reader := bufio.NewReader(r)
+
+	// Определяем разделитель по первой строке
+	firstLine, err := reader.Peek(reader.Size())
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if index := strings.IndexByte(string(firstLine), '\n'); index >= 0 {
+		firstLine = firstLine[:index]
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	if strings.Contains(string(firstLine), ";") {
+		csvReader.Comma = ';'
+	}
+
+	var prices []models.SecurityPrice
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %v", line, err)
+		}
+
+		// Пропускаем пустые строки
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("строка %d: ожидаются колонки тикер, дата и цена", line)
+		}
+
+		price, err := parseAmount(record[2])
+		if err != nil {
+			// Первая строка с нечисловой ценой считается заголовком
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("строка %d: %v", line, err)
+		}
+
+		date, err := parsePriceDate(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %v", line, err)
+		}
+
+		ticker := strings.ToUpper(strings.TrimSpace(record[0]))
+		if ticker == "" || price <= 0 {
+			return nil, fmt.Errorf("строка %d: тикер и положительная цена обязательны", line)
+		}
+
+		prices = append(prices, models.SecurityPrice{
+			Ticker: ticker,
+			Date:   date,
+			Price:  price,
+		})
+	}
+
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("файл не содержит цен")
+	}
+
+	return prices, nil
+}
+
+// parsePriceDate разбирает дату цены в формате YYYY-MM-DD или DD.MM.YYYY
+func parsePriceDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "02.01.2006"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("неверный формат даты: %s", value)
+}
@@ -0,0 +1,167 @@
+package importers
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseQIF разбирает выписку в формате QIF.
+// QIF не содержит идентификаторов операций, поэтому они вычисляются по содержимому операции.
+func ParseQIF(r io.Reader) (*Statement, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	statement := &Statement{}
+	current := Transaction{}
+	hasFields := false
+	inAccountBlock := false
+	occurrences := make(map[string]int)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		line = strings.TrimPrefix(line, "\ufeff")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Заголовки секций
+		if strings.HasPrefix(line, "!") {
+			header := strings.ToLower(strings.TrimSpace(line))
+			inAccountBlock = header == "!account"
+			continue
+		}
+
+		code, value := line[0], strings.TrimSpace(line[1:])
+
+		// Блок описания счета содержит имя счета в поле N
+		if inAccountBlock {
+			if code == 'N' && statement.Account == "" {
+				statement.Account = value
+			}
+			if code == '^' {
+				inAccountBlock = false
+			}
+			continue
+		}
+
+		switch code {
+		case 'D':
+			date, err := parseQIFDate(value)
+			if err != nil {
+				return nil, err
+			}
+			current.Date = date
+		case 'T', 'U':
+			amount, err := parseAmount(value)
+			if err != nil {
+				return nil, err
+			}
+			current.Amount = amount
+		case 'P':
+			current.Payee = value
+		case 'M':
+			current.Memo = value
+		case 'L':
+			current.Category = value
+		case 'N':
+			current.CheckNumber = value
+		case '^':
+			if hasFields {
+				if current.Date.IsZero() {
+					return nil, errors.New("операция в выписке QIF не содержит даты")
+				}
+				current.ExternalID = qifExternalID(current, occurrences)
+				statement.Transactions = append(statement.Transactions, current)
+			}
+			current = Transaction{}
+			hasFields = false
+			continue
+		}
+		hasFields = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при чтении файла: %v", err)
+	}
+
+	if len(statement.Transactions) == 0 {
+		return nil, errors.New("в выписке не найдено ни одной операции")
+	}
+
+	return statement, nil
+}
+
+// qifExternalID вычисляет устойчивый идентификатор операции QIF.
+// Одинаковые операции в одном файле различаются порядковым номером.
+func qifExternalID(transaction Transaction, occurrences map[string]int) string {
+	key := fmt.Sprintf("%s|%.2f|%s|%s|%s",
+		transaction.Date.Format("2006-01-02"),
+		transaction.Amount,
+		transaction.Payee,
+		transaction.Memo,
+		transaction.CheckNumber,
+	)
+	occurrences[key]++
+
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s|%d", key, occurrences[key])))
+	return hex.EncodeToString(hash[:])
+}
+
+// parseQIFDate разбирает дату QIF. Встречаются форматы MM/DD/YYYY, MM/DD'YY, DD.MM.YYYY и YYYY-MM-DD.
+func parseQIFDate(value string) (time.Time, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+
+	// Формат ISO
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+
+	// Год после апострофа в Quicken означает 2000-е годы
+	value = strings.Replace(value, "'", "/", 1)
+
+	separator := "/"
+	dayFirst := false
+	if strings.Contains(value, ".") {
+		separator = "."
+		dayFirst = true
+	} else if strings.Contains(value, "-") {
+		separator = "-"
+	}
+
+	parts := strings.Split(value, separator)
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("неверный формат даты QIF: %s", value)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("неверный формат даты QIF: %s", value)
+		}
+		numbers[i] = number
+	}
+
+	month, day, year := numbers[0], numbers[1], numbers[2]
+	if dayFirst || month > 12 {
+		month, day = day, month
+	}
+	if year < 100 {
+		year += 2000
+	}
+
+	// time.Date переносит несуществующие даты вроде 31.02 на следующий месяц, такие даты отклоняем
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("неверный формат даты QIF: %s", value)
+	}
+
+	return date, nil
+}
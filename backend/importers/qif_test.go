@@ -0,0 +1,127 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQIFDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "01/15/2024", want: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "1/5'24", want: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{value: "15.01.2024", want: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "05.01.24", want: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{value: "2024-01-15", want: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "15/01/2024", want: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "29.02.2024", want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{value: "31.02.2024", wantErr: true},
+		{value: "02/30/2024", wantErr: true},
+		{value: "29.02.2023", wantErr: true},
+		{value: "31.04.2024", wantErr: true},
+		{value: "13/13/2024", wantErr: true},
+		{value: "00.01.2024", wantErr: true},
+		{value: "01/2024", wantErr: true},
+		{value: "завтра", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			date, err := parseQIFDate(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получена дата %v", date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if !date.Equal(tt.want) {
+				t.Errorf("получена дата %v, ожидалась %v", date, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQIF(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantAccount string
+		wantAmounts []float64
+		wantPayees  []string
+		wantErr     bool
+	}{
+		{
+			name: "счет и операции",
+			content: "!Account\nNОсновная карта\nTBank\n^\n!Type:Bank\n" +
+				"D15.01.2024\nT-1 234,50\nPПятерочка\nMпродукты\nLFood\n^\n" +
+				"D01/20/2024\nT50,000.00\nPЗарплата\n^\n",
+			wantAccount: "Основная карта",
+			wantAmounts: []float64{-1234.5, 50000},
+			wantPayees:  []string{"Пятерочка", "Зарплата"},
+		},
+		{
+			name:        "одинаковые операции",
+			content:     "!Type:Bank\r\nD2024-01-15\r\nT-100\r\nPМетро\r\n^\r\nD2024-01-15\r\nT-100\r\nPМетро\r\n^\r\n",
+			wantAmounts: []float64{-100, -100},
+			wantPayees:  []string{"Метро", "Метро"},
+		},
+		{
+			name:    "операция без даты",
+			content: "!Type:Bank\nT-100\nPМетро\n^\n",
+			wantErr: true,
+		},
+		{
+			name:    "несуществующая дата",
+			content: "!Type:Bank\nD31.02.2024\nT-100\n^\n",
+			wantErr: true,
+		},
+		{
+			name:    "нет операций",
+			content: "!Type:Bank\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := ParseQIF(strings.NewReader(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получено %d операций", len(statement.Transactions))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			if statement.Account != tt.wantAccount {
+				t.Errorf("счет %q, ожидался %q", statement.Account, tt.wantAccount)
+			}
+			if len(statement.Transactions) != len(tt.wantAmounts) {
+				t.Fatalf("получено %d операций, ожидалось %d", len(statement.Transactions), len(tt.wantAmounts))
+			}
+
+			ids := make(map[string]bool)
+			for i, transaction := range statement.Transactions {
+				if transaction.Amount != tt.wantAmounts[i] {
+					t.Errorf("операция %d: сумма %g, ожидалось %g", i, transaction.Amount, tt.wantAmounts[i])
+				}
+				if transaction.Payee != tt.wantPayees[i] {
+					t.Errorf("операция %d: получатель %q, ожидался %q", i, transaction.Payee, tt.wantPayees[i])
+				}
+				if transaction.ExternalID == "" || ids[transaction.ExternalID] {
+					t.Errorf("операция %d: идентификатор %q пустой или повторяется", i, transaction.ExternalID)
+				}
+				ids[transaction.ExternalID] = true
+			}
+		})
+	}
+}
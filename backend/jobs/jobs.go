@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+
+	"cz.Finance/backend/configs"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/services"
+)
+
+// RegisterJobs регистрирует фоновые задачи приложения в планировщике
+func RegisterJobs(scheduler *Scheduler, db *sql.DB, config *configs.Config) {
+	// Инициализация репозиториев
+	userRepo := repositories.NewUserRepository(db)
+	loanRepo := repositories.NewLoanRepository(db)
+	assetRepo := repositories.NewAssetRepository(db)
+	netWorthRepo := repositories.NewNetWorthRepository(db)
+	investmentRepo := repositories.NewInvestmentRepository(db)
+	expenseRepo := repositories.NewExpenseRepository(db)
+	telegramRepo := repositories.NewTelegramUserRepository(db)
+	alertRepo := repositories.NewAlertRepository(db)
+
+	// Инициализация сервисов
+	netWorthService := services.NewNetWorthService(assetRepo, netWorthRepo, loanRepo, investmentRepo, userRepo)
+	notificationService := services.NewNotificationService(config.Telegram, telegramRepo)
+	alertService := services.NewAlertService(alertRepo, expenseRepo, userRepo, notificationService)
+
+	// Ежемесячные снимки чистой стоимости капитала. Снимок за текущий месяц
+	// перезаписывается при каждом запуске, поэтому в базе остается значение на конец месяца
+	scheduler.Add("networth-snapshot", config.Jobs.NetWorthSnapshotInterval, func(ctx context.Context) error {
+		saved, err := netWorthService.SnapshotAll(ctx)
+		if err != nil {
+			return err
+		}
+		scheduler.logger.Infof("Сохранено снимков капитала: %d", saved)
+		return nil
+	})
+
+	// Поиск необычных трат и всплесков трат по категориям. Повторные запуски не создают
+	// дублей, поэтому в Telegram отправляются только новые предупреждения
+	scheduler.Add("anomaly-check", config.Jobs.AnomalyCheckInterval, func(ctx context.Context) error {
+		created, err := alertService.CheckAll(ctx)
+		if err != nil {
+			return err
+		}
+		scheduler.logger.Infof("Создано предупреждений о необычных тратах: %d", created)
+		return nil
+	})
+}
@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Job представляет периодическую фоновую задачу
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler запускает фоновые задачи с заданным интервалом
+type Scheduler struct {
+	logger *logrus.Logger
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler создает новый планировщик фоновых задач
+func NewScheduler(logger *logrus.Logger) *Scheduler {
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+
+	return &Scheduler{logger: logger}
+}
+
+// Add регистрирует задачу. Задачи с неположительным интервалом считаются отключенными
+func (s *Scheduler) Add(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		s.logger.Infof("Фоновая задача %s отключена", name)
+		return
+	}
+
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start запускает все зарегистрированные задачи. Каждая задача выполняется сразу
+// после запуска и затем с заданным интервалом до вызова Stop
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Stop останавливает задачи и дожидается завершения выполняющихся запусков
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop выполняет задачу по таймеру до отмены контекста
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.logger.Infof("Фоновая задача %s запущена с интервалом %v", job.Name, job.Interval)
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run выполняет один запуск задачи, не давая ошибке или панике остановить планировщик
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Errorf("Паника в фоновой задаче %s: %v", job.Name, r)
+		}
+	}()
+
+	started := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Errorf("Ошибка фоновой задачи %s: %v", job.Name, err)
+		return
+	}
+
+	s.logger.Debugf("Фоновая задача %s выполнена за %v", job.Name, time.Since(started))
+}
@@ -10,6 +10,7 @@ import (
 
 	"cz.Finance/backend/configs"
 	"cz.Finance/backend/database"
+	"cz.Finance/backend/jobs"
 	"cz.Finance/backend/routes"
 
 	"github.com/gorilla/mux"
@@ -42,6 +43,11 @@ func main() {
 	// Регистрируем маршруты
 	routes.RegisterRoutes(router, db, config)
 
+	// Запускаем фоновые задачи
+	scheduler := jobs.NewScheduler(config.Logger)
+	jobs.RegisterJobs(scheduler, db, config)
+	scheduler.Start(context.Background())
+
 	// Создаем HTTP-сервер
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -91,6 +97,9 @@ func main() {
 		log.Fatalf("Ошибка при остановке сервера: %v", err)
 	}
 
+	// Останавливаем фоновые задачи
+	scheduler.Stop()
+
 	log.Info("Сервер остановлен")
 }
 
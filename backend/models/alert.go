@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// AlertKind перечисляет виды предупреждений о необычных тратах
+type AlertKind string
+
+const (
+	// AlertUnusualAmount — трата заметно больше обычных трат в своей категории
+	AlertUnusualAmount AlertKind = "unusual_amount"
+	// AlertCategorySpike — траты в категории за месяц заметно выше обычного
+	AlertCategorySpike AlertKind = "category_spike"
+)
+
+// Alert представляет предупреждение о необычной трате или всплеске трат в категории
+type Alert struct {
+	ID        int64           `json:"id" db:"id"`
+	UserID    int64           `json:"user_id" db:"user_id"`
+	Kind      AlertKind       `json:"kind" db:"kind"`
+	ExpenseID *int64          `json:"expense_id,omitempty" db:"expense_id"`
+	Category  ExpenseCategory `json:"category" db:"category"`
+	Period    time.Time       `json:"period" db:"period"`
+	Amount    float64         `json:"amount" db:"amount"`
+	Expected  float64         `json:"expected" db:"expected"`
+	Score     float64         `json:"score" db:"score"`
+	Message   string          `json:"message" db:"message"`
+	Notified  bool            `json:"notified" db:"notified"`
+	ReadAt    *time.Time      `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
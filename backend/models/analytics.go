@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// AnalyticsDataset перечисляет наборы данных, по которым строится аналитика
+type AnalyticsDataset string
+
+const (
+	AnalyticsExpenses AnalyticsDataset = "expenses"
+	AnalyticsIncomes  AnalyticsDataset = "incomes"
+)
+
+// AnalyticsGroup перечисляет поля, по которым можно группировать записи
+type AnalyticsGroup string
+
+const (
+	GroupByCategory AnalyticsGroup = "category"
+	GroupBySource   AnalyticsGroup = "source"
+	GroupByTag      AnalyticsGroup = "tag"
+	GroupByPayee    AnalyticsGroup = "payee"
+	GroupByAccount  AnalyticsGroup = "account"
+	GroupByWeekday  AnalyticsGroup = "weekday"
+)
+
+// AnalyticsDatasetGroups задает группировки, доступные для каждого набора данных
+var AnalyticsDatasetGroups = map[AnalyticsDataset][]AnalyticsGroup{
+	AnalyticsExpenses: {GroupByCategory, GroupByTag, GroupByPayee, GroupByAccount, GroupByWeekday},
+	AnalyticsIncomes:  {GroupBySource, GroupByWeekday},
+}
+
+// AnalyticsInterval перечисляет интервалы разбивки периода
+type AnalyticsInterval string
+
+const (
+	IntervalDay     AnalyticsInterval = "day"
+	IntervalWeek    AnalyticsInterval = "week"
+	IntervalMonth   AnalyticsInterval = "month"
+	IntervalQuarter AnalyticsInterval = "quarter"
+	IntervalYear    AnalyticsInterval = "year"
+)
+
+// AnalyticsMetric перечисляет рассчитываемые показатели
+type AnalyticsMetric string
+
+const (
+	MetricSum    AnalyticsMetric = "sum"
+	MetricCount  AnalyticsMetric = "count"
+	MetricAvg    AnalyticsMetric = "avg"
+	MetricMedian AnalyticsMetric = "median"
+)
+
+// AnalyticsIntervals перечисляет допустимые интервалы
+var AnalyticsIntervals = []AnalyticsInterval{IntervalDay, IntervalWeek, IntervalMonth, IntervalQuarter, IntervalYear}
+
+// AnalyticsMetrics перечисляет допустимые показатели
+var AnalyticsMetrics = []AnalyticsMetric{MetricSum, MetricCount, MetricAvg, MetricMedian}
+
+// AnalyticsQuery описывает запрос аналитики: период, группировки, интервал и показатели.
+// Интервалы и дни недели считаются в часовом поясе Timezone
+type AnalyticsQuery struct {
+	Dataset   AnalyticsDataset  `json:"dataset"`
+	StartDate time.Time         `json:"start_date"`
+	EndDate   time.Time         `json:"end_date"`
+	GroupBy   []AnalyticsGroup  `json:"group_by"`
+	Interval  AnalyticsInterval `json:"interval,omitempty"`
+	Metrics   []AnalyticsMetric `json:"metrics"`
+	Timezone  string            `json:"timezone"`
+}
+
+// AnalyticsRow представляет одну группу результата. Заполняются только запрошенные показатели.
+// День недели указывается номером от 1 (понедельник) до 7 (воскресенье)
+type AnalyticsRow struct {
+	Period *time.Time                `json:"period,omitempty"`
+	Groups map[AnalyticsGroup]string `json:"groups,omitempty"`
+	Sum    *float64                  `json:"sum,omitempty"`
+	Count  *int64                    `json:"count,omitempty"`
+	Avg    *float64                  `json:"avg,omitempty"`
+	Median *float64                  `json:"median,omitempty"`
+}
+
+// AnalyticsResult содержит результат запроса аналитики
+type AnalyticsResult struct {
+	Query AnalyticsQuery `json:"query"`
+	Rows  []AnalyticsRow `json:"rows"`
+}
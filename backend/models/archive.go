@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// ArchiveVersion текущая версия формата архива с данными пользователя.
+// Во второй версии добавлены счета бухгалтерской книги, кредиты, активы, инвестиции,
+// правила категоризации, получатели платежей и регулярные списания
+const ArchiveVersion = 2
+
+// Archive представляет выгрузку всех данных пользователя
+type Archive struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	User       ArchiveUser      `json:"user"`
+	Expenses   []Expense        `json:"expenses"`
+	Incomes    []Income         `json:"incomes"`
+	Wishlist   []WishlistItem   `json:"wishlist"`
+	Goals      []Goal           `json:"goals,omitempty"`
+	Telegram   *ArchiveTelegram `json:"telegram,omitempty"`
+	Avatar     *ArchiveFile     `json:"avatar,omitempty"`
+
+	// Разделы второй версии архива
+	LedgerAccounts []LedgerAccount         `json:"ledger_accounts,omitempty"`
+	Loans          []Loan                  `json:"loans,omitempty"`
+	Assets         []Asset                 `json:"assets,omitempty"`
+	NetWorth       []NetWorthSnapshot      `json:"net_worth,omitempty"`
+	Investments    []InvestmentTransaction `json:"investments,omitempty"`
+	SecurityPrices []SecurityPrice         `json:"security_prices,omitempty"`
+	Rules          []CategorizationRule    `json:"categorization_rules,omitempty"`
+	Payees         []Payee                 `json:"payees,omitempty"`
+	RecurringRules []RecurringRule         `json:"recurring_rules,omitempty"`
+}
+
+// ArchiveUser содержит профиль пользователя без учетных данных
+type ArchiveUser struct {
+	Email        string    `json:"email"`
+	Username     string    `json:"username"`
+	FirstName    string    `json:"first_name"`
+	LastName     string    `json:"last_name"`
+	MonthlyLimit float64   `json:"monthly_limit"`
+	SavingsGoal  float64   `json:"savings_goal"`
+	Timezone     string    `json:"timezone,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ArchiveTelegram содержит сведения о связанном аккаунте Telegram.
+// При восстановлении связь не создается: ее нужно подтвердить заново через бота.
+type ArchiveTelegram struct {
+	TelegramID int64  `json:"telegram_id"`
+	Username   string `json:"username"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+}
+
+// ArchiveFile содержит файл, вложенный в архив
+type ArchiveFile struct {
+	FileName string `json:"file_name"`
+	Data     []byte `json:"data"`
+}
+
+// ArchiveRestoreResult описывает результат восстановления архива.
+// IDMap сопоставляет идентификаторы из архива с идентификаторами созданных записей.
+type ArchiveRestoreResult struct {
+	Expenses       int                        `json:"expenses"`
+	Incomes        int                        `json:"incomes"`
+	Wishlist       int                        `json:"wishlist"`
+	Goals          int                        `json:"goals"`
+	LedgerAccounts int                        `json:"ledger_accounts"`
+	Loans          int                        `json:"loans"`
+	Assets         int                        `json:"assets"`
+	Investments    int                        `json:"investments"`
+	Rules          int                        `json:"categorization_rules"`
+	Payees         int                        `json:"payees"`
+	RecurringRules int                        `json:"recurring_rules"`
+	IDMap          map[string]map[int64]int64 `json:"id_map"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// ComparisonMode перечисляет способы выбора периода, с которым сравнивается основной
+type ComparisonMode string
+
+const (
+	// ComparePrevious — предыдущий период той же длины, для целых месяцев — предыдущие месяцы
+	ComparePrevious ComparisonMode = "previous"
+	// CompareYearAgo — тот же период год назад
+	CompareYearAgo ComparisonMode = "year"
+	// CompareCustom — период, указанный в запросе
+	CompareCustom ComparisonMode = "custom"
+)
+
+// ComparisonRequest описывает сравниваемые периоды. Периоды полуоткрытые, конец не входит в период.
+// Период сравнения указывается только в режиме CompareCustom
+type ComparisonRequest struct {
+	Mode             ComparisonMode
+	StartDate        time.Time
+	EndDate          time.Time
+	CompareStartDate time.Time
+	CompareEndDate   time.Time
+}
+
+// ComparisonPeriod представляет границы одного из сравниваемых периодов
+type ComparisonPeriod struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// ComparisonDelta представляет изменение суммы между периодами. Процент изменения
+// не указывается, если в периоде сравнения сумма была нулевой
+type ComparisonDelta struct {
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+// ComparisonItem представляет изменение суммы по категории трат или источнику накоплений
+type ComparisonItem struct {
+	Name          string   `json:"name"`
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	ChangePercent *float64 `json:"change_percent"`
+}
+
+// PeriodComparison содержит сравнение трат и накоплений за два периода.
+// Категории и источники упорядочены по абсолютной величине изменения
+type PeriodComparison struct {
+	Mode       ComparisonMode   `json:"mode"`
+	Current    ComparisonPeriod `json:"current"`
+	Previous   ComparisonPeriod `json:"previous"`
+	Expenses   ComparisonDelta  `json:"expenses"`
+	Incomes    ComparisonDelta  `json:"incomes"`
+	Balance    ComparisonDelta  `json:"balance"`
+	Categories []ComparisonItem `json:"categories"`
+	Sources    []ComparisonItem `json:"sources"`
+}
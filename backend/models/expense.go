@@ -20,6 +20,34 @@ const (
 	CategoryOther         ExpenseCategory = "other"
 )
 
+// ExpenseCategories содержит все категории трат в порядке отображения
+var ExpenseCategories = []ExpenseCategory{
+	CategoryFood,
+	CategoryTransport,
+	CategoryHousing,
+	CategoryUtilities,
+	CategoryShopping,
+	CategoryEntertainment,
+	CategoryHealthcare,
+	CategoryEducation,
+	CategoryTravel,
+	CategoryOther,
+}
+
+// ExpenseCategoryTitles содержит названия категорий трат для отображения пользователю
+var ExpenseCategoryTitles = map[ExpenseCategory]string{
+	CategoryFood:          "Продукты",
+	CategoryTransport:     "Транспорт",
+	CategoryHousing:       "Жильё",
+	CategoryUtilities:     "Коммунальные",
+	CategoryShopping:      "Покупки",
+	CategoryEntertainment: "Развлечения",
+	CategoryHealthcare:    "Здоровье",
+	CategoryEducation:     "Образование",
+	CategoryTravel:        "Путешествия",
+	CategoryOther:         "Другое",
+}
+
 // Expense представляет модель траты
 type Expense struct {
 	ID          int64           `json:"id" db:"id"`
@@ -29,17 +57,25 @@ type Expense struct {
 	Category    ExpenseCategory `json:"category" db:"category" validate:"required"`
 	Date        time.Time       `json:"date" db:"date"`
 	Description string          `json:"description" db:"description"`
+	Account     string          `json:"account,omitempty" db:"account"`
+	Tags        []string        `json:"tags" db:"tags"`
+	PayeeID     *int64          `json:"payee_id,omitempty" db:"payee_id"`
+	HouseholdID *int64          `json:"household_id,omitempty" db:"household_id"`
 	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
 }
 
-// CreateExpenseRequest модель для создания новой траты
+// CreateExpenseRequest модель для создания новой траты.
+// Если категория не указана, ее назначают правила категоризации
 type CreateExpenseRequest struct {
 	Title       string          `json:"title" validate:"required,min=2,max=100"`
 	Amount      float64         `json:"amount" validate:"required,gt=0"`
-	Category    ExpenseCategory `json:"category" validate:"required"`
+	Category    ExpenseCategory `json:"category"`
 	Date        time.Time       `json:"date"`
 	Description string          `json:"description"`
+	Account     string          `json:"account" validate:"max=100"`
+	Tags        []string        `json:"tags" validate:"max=10,dive,min=1,max=30"`
+	HouseholdID *int64          `json:"household_id"`
 }
 
 // UpdateExpenseRequest модель для обновления траты
@@ -49,6 +85,9 @@ type UpdateExpenseRequest struct {
 	Category    *ExpenseCategory `json:"category"`
 	Date        *time.Time       `json:"date"`
 	Description *string          `json:"description"`
+	Account     *string          `json:"account" validate:"omitempty,max=100"`
+	Tags        *[]string        `json:"tags" validate:"omitempty,max=10,dive,min=1,max=30"`
+	HouseholdID *int64           `json:"household_id"`
 }
 
 // ExpenseSummary предоставляет общую информацию о тратах за период
@@ -59,3 +98,10 @@ type ExpenseSummary struct {
 	CategorySummary map[string]float64 `json:"category_summary"`
 	RecentExpenses  []Expense          `json:"recent_expenses"`
 }
+
+// ExpenseFilter задает условия отбора трат. Пустые поля не ограничивают выборку
+type ExpenseFilter struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	Category  ExpenseCategory
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+)
+
+// ForecastItemKind перечисляет источники запланированных списаний в прогнозе
+type ForecastItemKind string
+
+const (
+	// ForecastItemRecurring — подтвержденная подписка или другое регулярное списание
+	ForecastItemRecurring ForecastItemKind = "recurring"
+	// ForecastItemLoan — платеж по графику кредита
+	ForecastItemLoan ForecastItemKind = "loan"
+)
+
+// ForecastItem представляет известное заранее списание, учтенное в прогнозе
+type ForecastItem struct {
+	Date            time.Time        `json:"date"`
+	Kind            ForecastItemKind `json:"kind"`
+	Title           string           `json:"title"`
+	Category        ExpenseCategory  `json:"category"`
+	Amount          float64          `json:"amount"`
+	RecurringRuleID *int64           `json:"recurring_rule_id,omitempty"`
+	LoanID          *int64           `json:"loan_id,omitempty"`
+}
+
+// ForecastDay представляет прогноз на один день
+type ForecastDay struct {
+	Date      time.Time `json:"date"`
+	Incomes   float64   `json:"incomes"`
+	Expenses  float64   `json:"expenses"`
+	Scheduled float64   `json:"scheduled"`
+	Balance   float64   `json:"balance"`
+}
+
+// ForecastCategory представляет прогноз трат в категории за месяц
+type ForecastCategory struct {
+	Category  ExpenseCategory `json:"category"`
+	Actual    float64         `json:"actual"`
+	RunRate   float64         `json:"run_rate"`
+	Scheduled float64         `json:"scheduled"`
+	Projected float64         `json:"projected"`
+}
+
+// ForecastMonth представляет прогноз на месяц. Для текущего месяца в суммы входят уже совершенные траты
+type ForecastMonth struct {
+	Month           time.Time          `json:"month"`
+	Expenses        float64            `json:"expenses"`
+	Incomes         float64            `json:"incomes"`
+	ClosingBalance  float64            `json:"closing_balance"`
+	MonthlyLimit    float64            `json:"monthly_limit"`
+	LimitPercent    float64            `json:"limit_percent"`
+	LimitExceeded   bool               `json:"limit_exceeded"`
+	LimitExceededOn *time.Time         `json:"limit_exceeded_on,omitempty"`
+	Categories      []ForecastCategory `json:"categories"`
+}
+
+// CashFlowForecast содержит прогноз денежного потока до конца текущего и следующих месяцев
+type CashFlowForecast struct {
+	StartDate        time.Time       `json:"start_date"`
+	EndDate          time.Time       `json:"end_date"`
+	OpeningBalance   float64         `json:"opening_balance"`
+	ClosingBalance   float64         `json:"closing_balance"`
+	DailyExpenseRate float64         `json:"daily_expense_rate"`
+	DailyIncomeRate  float64         `json:"daily_income_rate"`
+	RunRateDays      int             `json:"run_rate_days"`
+	Months           []ForecastMonth `json:"months"`
+	Days             []ForecastDay   `json:"days"`
+	Scheduled        []ForecastItem  `json:"scheduled"`
+	LimitExceeded    bool            `json:"limit_exceeded"`
+	Warnings         []string        `json:"warnings"`
+}
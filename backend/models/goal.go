@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+)
+
+// Goal представляет модель цели накоплений
+type Goal struct {
+	ID            int64      `json:"id" db:"id"`
+	UserID        int64      `json:"user_id" db:"user_id"`
+	Name          string     `json:"name" db:"name" validate:"required,min=2,max=100"`
+	TargetAmount  float64    `json:"target_amount" db:"target_amount" validate:"required,gt=0"`
+	Deadline      *time.Time `json:"deadline,omitempty" db:"deadline"`
+	Account       string     `json:"account,omitempty" db:"account" validate:"max=100"`
+	Description   string     `json:"description,omitempty" db:"description"`
+	CurrentAmount float64    `json:"current_amount" db:"current_amount"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Рассчитываемые поля
+	Progress        float64            `json:"progress"`
+	RemainingAmount float64            `json:"remaining_amount"`
+	MonthsLeft      int                `json:"months_left,omitempty"`
+	RequiredMonthly float64            `json:"required_monthly,omitempty"`
+	Completed       bool               `json:"completed"`
+	Overdue         bool               `json:"overdue"`
+	Contributions   []GoalContribution `json:"contributions,omitempty"`
+}
+
+// GoalContribution представляет взнос в цель накоплений. Отрицательная сумма означает изъятие
+type GoalContribution struct {
+	ID          int64     `json:"id" db:"id"`
+	GoalID      int64     `json:"goal_id" db:"goal_id"`
+	Amount      float64   `json:"amount" db:"amount" validate:"required,ne=0"`
+	Date        time.Time `json:"date" db:"date"`
+	Description string    `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateGoalRequest модель для создания новой цели накоплений
+type CreateGoalRequest struct {
+	Name         string     `json:"name" validate:"required,min=2,max=100"`
+	TargetAmount float64    `json:"target_amount" validate:"required,gt=0"`
+	Deadline     *time.Time `json:"deadline"`
+	Account      string     `json:"account" validate:"max=100"`
+	Description  string     `json:"description"`
+}
+
+// UpdateGoalRequest модель для обновления цели накоплений
+type UpdateGoalRequest struct {
+	Name         *string    `json:"name" validate:"omitempty,min=2,max=100"`
+	TargetAmount *float64   `json:"target_amount" validate:"omitempty,gt=0"`
+	Deadline     *time.Time `json:"deadline"`
+	Account      *string    `json:"account" validate:"omitempty,max=100"`
+	Description  *string    `json:"description"`
+}
+
+// CreateGoalContributionRequest модель для добавления взноса в цель
+type CreateGoalContributionRequest struct {
+	Amount      float64   `json:"amount" validate:"required,ne=0"`
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+}
+
+// GoalsSummary содержит общую информацию о целях накоплений для панели мониторинга
+type GoalsSummary struct {
+	TotalTarget     float64 `json:"total_target"`
+	TotalSaved      float64 `json:"total_saved"`
+	Progress        float64 `json:"progress"`
+	RequiredMonthly float64 `json:"required_monthly"`
+	Active          int     `json:"active"`
+	Completed       int     `json:"completed"`
+	Goals           []Goal  `json:"goals"`
+}
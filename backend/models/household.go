@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+)
+
+// HouseholdRole перечисляет роли участников домохозяйства
+type HouseholdRole string
+
+const (
+	// HouseholdRoleOwner управляет участниками, приглашениями и бюджетами
+	HouseholdRoleOwner HouseholdRole = "owner"
+	// HouseholdRoleEditor добавляет общие траты и накопления и задает бюджеты
+	HouseholdRoleEditor HouseholdRole = "editor"
+	// HouseholdRoleViewer только просматривает общую панель
+	HouseholdRoleViewer HouseholdRole = "viewer"
+)
+
+// HouseholdInvitationStatus перечисляет состояния приглашения в домохозяйство
+type HouseholdInvitationStatus string
+
+const (
+	InvitationPending  HouseholdInvitationStatus = "pending"
+	InvitationAccepted HouseholdInvitationStatus = "accepted"
+	InvitationDeclined HouseholdInvitationStatus = "declined"
+	InvitationRevoked  HouseholdInvitationStatus = "revoked"
+)
+
+// Household представляет домохозяйство — общий бюджет нескольких пользователей
+type Household struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name" validate:"required,min=2,max=100"`
+	CreatedBy int64     `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Роль текущего пользователя в домохозяйстве
+	Role    HouseholdRole     `json:"role,omitempty"`
+	Members []HouseholdMember `json:"members,omitempty"`
+}
+
+// HouseholdMember представляет участника домохозяйства
+type HouseholdMember struct {
+	HouseholdID int64         `json:"household_id" db:"household_id"`
+	UserID      int64         `json:"user_id" db:"user_id"`
+	Username    string        `json:"username" db:"username"`
+	FirstName   string        `json:"first_name,omitempty" db:"first_name"`
+	LastName    string        `json:"last_name,omitempty" db:"last_name"`
+	Role        HouseholdRole `json:"role" db:"role"`
+	JoinedAt    time.Time     `json:"joined_at" db:"joined_at"`
+}
+
+// HouseholdInvitation представляет приглашение пользователя в домохозяйство
+type HouseholdInvitation struct {
+	ID               int64                     `json:"id" db:"id"`
+	HouseholdID      int64                     `json:"household_id" db:"household_id"`
+	HouseholdName    string                    `json:"household_name,omitempty" db:"household_name"`
+	InvitedBy        int64                     `json:"invited_by" db:"invited_by"`
+	InviteeID        int64                     `json:"invitee_id" db:"invitee_id"`
+	Email            string                    `json:"email,omitempty" db:"email"`
+	TelegramUsername string                    `json:"telegram_username,omitempty" db:"telegram_username"`
+	Role             HouseholdRole             `json:"role" db:"role"`
+	Status           HouseholdInvitationStatus `json:"status" db:"status"`
+	CreatedAt        time.Time                 `json:"created_at" db:"created_at"`
+	RespondedAt      *time.Time                `json:"responded_at,omitempty" db:"responded_at"`
+}
+
+// HouseholdBudget представляет бюджет домохозяйства по категории с фактическими тратами за месяц
+type HouseholdBudget struct {
+	Category ExpenseCategory `json:"category" db:"category"`
+	Title    string          `json:"title"`
+	Amount   float64         `json:"amount" db:"amount"`
+	Spent    float64         `json:"spent"`
+	Percent  float64         `json:"percent"`
+}
+
+// HouseholdMemberTotals содержит вклад участника в общие траты и накопления за период
+type HouseholdMemberTotals struct {
+	UserID   int64   `json:"user_id"`
+	Username string  `json:"username"`
+	Expenses float64 `json:"expenses"`
+	Incomes  float64 `json:"incomes"`
+}
+
+// HouseholdDashboard содержит сводку домохозяйства за месяц
+type HouseholdDashboard struct {
+	Household          *Household              `json:"household"`
+	Year               int                     `json:"year"`
+	Month              int                     `json:"month"`
+	Expenses           float64                 `json:"expenses"`
+	Incomes            float64                 `json:"incomes"`
+	Balance            float64                 `json:"balance"`
+	ExpensesByCategory map[string]float64      `json:"expenses_by_category"`
+	IncomesBySource    map[string]float64      `json:"incomes_by_source"`
+	Members            []HouseholdMemberTotals `json:"members"`
+	Budgets            []HouseholdBudget       `json:"budgets"`
+	RecentExpenses     []Expense               `json:"recent_expenses"`
+	RecentIncomes      []Income                `json:"recent_incomes"`
+}
+
+// CreateHouseholdRequest модель для создания домохозяйства
+type CreateHouseholdRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// UpdateHouseholdRequest модель для переименования домохозяйства
+type UpdateHouseholdRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+// CreateHouseholdInvitationRequest модель для приглашения пользователя по email или имени пользователя в Telegram
+type CreateHouseholdInvitationRequest struct {
+	Email            string        `json:"email" validate:"omitempty,email"`
+	TelegramUsername string        `json:"telegram_username" validate:"omitempty,max=100"`
+	Role             HouseholdRole `json:"role" validate:"required,oneof=editor viewer"`
+}
+
+// UpdateHouseholdMemberRequest модель для изменения роли участника
+type UpdateHouseholdMemberRequest struct {
+	Role HouseholdRole `json:"role" validate:"required,oneof=owner editor viewer"`
+}
+
+// SetHouseholdBudgetRequest модель для установки бюджета домохозяйства по категории.
+// Нулевая сумма удаляет бюджет
+type SetHouseholdBudgetRequest struct {
+	Category ExpenseCategory `json:"category" validate:"required"`
+	Amount   float64         `json:"amount" validate:"gte=0"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// ImportFormat перечисляет поддерживаемые форматы банковских выписок
+type ImportFormat string
+
+const (
+	ImportFormatOFX ImportFormat = "ofx"
+	ImportFormatQIF ImportFormat = "qif"
+)
+
+// ImportStatus перечисляет возможные состояния импорта
+type ImportStatus string
+
+const (
+	ImportStatusPreview    ImportStatus = "preview"
+	ImportStatusCommitted  ImportStatus = "committed"
+	ImportStatusRolledBack ImportStatus = "rolled_back"
+)
+
+// ImportRowKind определяет, во что превратится строка выписки: в трату или в накопление
+type ImportRowKind string
+
+const (
+	ImportRowExpense ImportRowKind = "expense"
+	ImportRowIncome  ImportRowKind = "income"
+)
+
+// Import представляет модель импорта банковской выписки
+type Import struct {
+	ID            int64        `json:"id" db:"id"`
+	UserID        int64        `json:"user_id" db:"user_id"`
+	Format        ImportFormat `json:"format" db:"format"`
+	FileName      string       `json:"file_name" db:"file_name"`
+	Account       string       `json:"account,omitempty" db:"account"`
+	Status        ImportStatus `json:"status" db:"status"`
+	TotalRows     int          `json:"total_rows" db:"total_rows"`
+	DuplicateRows int          `json:"duplicate_rows" db:"duplicate_rows"`
+	CommittedRows int          `json:"committed_rows" db:"committed_rows"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// ImportRow представляет строку выписки, подготовленную к импорту
+type ImportRow struct {
+	ID          int64           `json:"id" db:"id"`
+	ImportID    int64           `json:"import_id" db:"import_id"`
+	ExternalID  string          `json:"external_id" db:"external_id"`
+	Kind        ImportRowKind   `json:"kind" db:"kind"`
+	Title       string          `json:"title" db:"title"`
+	Amount      float64         `json:"amount" db:"amount"`
+	Category    ExpenseCategory `json:"category,omitempty" db:"category"`
+	Source      IncomeSource    `json:"source,omitempty" db:"source"`
+	Date        time.Time       `json:"date" db:"date"`
+	Description string          `json:"description" db:"description"`
+	Tags        []string        `json:"tags,omitempty" db:"tags"`
+	RuleID      *int64          `json:"rule_id,omitempty" db:"rule_id"`
+	PayeeID     *int64          `json:"payee_id,omitempty" db:"payee_id"`
+	Duplicate   bool            `json:"duplicate" db:"duplicate"`
+}
+
+// ImportPreview содержит импорт и строки, которые будут созданы при его подтверждении
+type ImportPreview struct {
+	Import Import      `json:"import"`
+	Rows   []ImportRow `json:"rows"`
+}
@@ -16,6 +16,26 @@ const (
 	SourceOther      IncomeSource = "other"
 )
 
+// IncomeSources содержит все источники дохода в порядке отображения
+var IncomeSources = []IncomeSource{
+	SourceSalary,
+	SourceFreelance,
+	SourceInvestment,
+	SourceGift,
+	SourceRental,
+	SourceOther,
+}
+
+// IncomeSourceTitles содержит названия источников дохода для отображения пользователю
+var IncomeSourceTitles = map[IncomeSource]string{
+	SourceSalary:     "Зарплата",
+	SourceFreelance:  "Фриланс",
+	SourceInvestment: "Инвестиции",
+	SourceGift:       "Подарок",
+	SourceRental:     "Аренда",
+	SourceOther:      "Другое",
+}
+
 // Income представляет модель накопления (дохода)
 type Income struct {
 	ID          int64        `json:"id" db:"id"`
@@ -24,6 +44,7 @@ type Income struct {
 	Source      IncomeSource `json:"source" db:"source" validate:"required"`
 	Date        time.Time    `json:"date" db:"date"`
 	Description string       `json:"description" db:"description"`
+	HouseholdID *int64       `json:"household_id,omitempty" db:"household_id"`
 	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
 }
@@ -34,6 +55,7 @@ type CreateIncomeRequest struct {
 	Source      IncomeSource `json:"source" validate:"required"`
 	Date        time.Time    `json:"date"`
 	Description string       `json:"description"`
+	HouseholdID *int64       `json:"household_id"`
 }
 
 // UpdateIncomeRequest модель для обновления накопления
@@ -42,6 +64,7 @@ type UpdateIncomeRequest struct {
 	Source      *IncomeSource `json:"source"`
 	Date        *time.Time    `json:"date"`
 	Description *string       `json:"description"`
+	HouseholdID *int64        `json:"household_id"`
 }
 
 // IncomeSummary предоставляет общую информацию о накоплениях за период
@@ -52,3 +75,10 @@ type IncomeSummary struct {
 	SourceSummary  map[string]float64 `json:"source_summary"`
 	RecentIncomes  []Income           `json:"recent_incomes"`
 }
+
+// IncomeFilter задает условия отбора накоплений. Пустые поля не ограничивают выборку
+type IncomeFilter struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	Source    IncomeSource
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+)
+
+// InvestmentTransactionType перечисляет виды операций с ценными бумагами
+type InvestmentTransactionType string
+
+const (
+	InvestmentBuy      InvestmentTransactionType = "buy"
+	InvestmentSell     InvestmentTransactionType = "sell"
+	InvestmentDividend InvestmentTransactionType = "dividend"
+)
+
+// InvestmentTransaction представляет покупку, продажу или дивиденд по ценной бумаге.
+// Для дивиденда количество и цена не заполняются, используется только сумма
+type InvestmentTransaction struct {
+	ID            int64                     `json:"id" db:"id"`
+	UserID        int64                     `json:"user_id" db:"user_id"`
+	Ticker        string                    `json:"ticker" db:"ticker"`
+	Type          InvestmentTransactionType `json:"type" db:"type"`
+	Date          time.Time                 `json:"date" db:"date"`
+	Quantity      float64                   `json:"quantity" db:"quantity"`
+	Price         float64                   `json:"price" db:"price"`
+	Fee           float64                   `json:"fee" db:"fee"`
+	Amount        float64                   `json:"amount" db:"amount"`
+	Note          string                    `json:"note,omitempty" db:"note"`
+	IncomeID      *int64                    `json:"income_id,omitempty" db:"income_id"`
+	IncomeCreated bool                      `json:"income_created,omitempty" db:"income_created"`
+	CreatedAt     time.Time                 `json:"created_at" db:"created_at"`
+
+	// Результат продажи по методу FIFO
+	RealizedPnL float64 `json:"realized_pnl,omitempty"`
+}
+
+// SecurityPrice представляет цену ценной бумаги на дату, которую ведет пользователь
+type SecurityPrice struct {
+	Ticker    string    `json:"ticker" db:"ticker"`
+	Date      time.Time `json:"date" db:"date"`
+	Price     float64   `json:"price" db:"price"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// InvestmentLot представляет непроданную часть покупки
+type InvestmentLot struct {
+	Date      time.Time `json:"date"`
+	Quantity  float64   `json:"quantity"`
+	Price     float64   `json:"price"`
+	CostBasis float64   `json:"cost_basis"`
+}
+
+// Holding представляет позицию по ценной бумаге с оценкой по последней известной цене
+type Holding struct {
+	Ticker        string          `json:"ticker"`
+	Quantity      float64         `json:"quantity"`
+	CostBasis     float64         `json:"cost_basis"`
+	AveragePrice  float64         `json:"average_price"`
+	Price         float64         `json:"price"`
+	PriceDate     *time.Time      `json:"price_date,omitempty"`
+	MarketValue   float64         `json:"market_value"`
+	UnrealizedPnL float64         `json:"unrealized_pnl"`
+	UnrealizedPct float64         `json:"unrealized_pct"`
+	RealizedPnL   float64         `json:"realized_pnl"`
+	Dividends     float64         `json:"dividends"`
+	Lots          []InvestmentLot `json:"lots"`
+}
+
+// Portfolio содержит позиции пользователя и итоги по портфелю
+type Portfolio struct {
+	Date          time.Time `json:"date"`
+	CostBasis     float64   `json:"cost_basis"`
+	MarketValue   float64   `json:"market_value"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	UnrealizedPct float64   `json:"unrealized_pct"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	Dividends     float64   `json:"dividends"`
+	Holdings      []Holding `json:"holdings"`
+}
+
+// CreateInvestmentTransactionRequest модель для записи операции с ценной бумагой.
+// Для дивиденда с create_income создается накопление с источником «Инвестиции»
+type CreateInvestmentTransactionRequest struct {
+	Ticker       string                    `json:"ticker" validate:"required,max=20"`
+	Type         InvestmentTransactionType `json:"type" validate:"required,oneof=buy sell dividend"`
+	Date         time.Time                 `json:"date"`
+	Quantity     float64                   `json:"quantity" validate:"gte=0"`
+	Price        float64                   `json:"price" validate:"gte=0"`
+	Fee          float64                   `json:"fee" validate:"gte=0"`
+	Amount       float64                   `json:"amount" validate:"gte=0"`
+	Note         string                    `json:"note" validate:"max=255"`
+	CreateIncome bool                      `json:"create_income"`
+}
+
+// SecurityPriceRequest модель для ввода цены ценной бумаги
+type SecurityPriceRequest struct {
+	Ticker string    `json:"ticker" validate:"required,max=20"`
+	Date   time.Time `json:"date"`
+	Price  float64   `json:"price" validate:"required,gt=0"`
+}
+
+// UpdateSecurityPricesRequest модель для пакетного обновления цен
+type UpdateSecurityPricesRequest struct {
+	Prices []SecurityPriceRequest `json:"prices" validate:"required,min=1,max=1000,dive"`
+}
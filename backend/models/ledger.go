@@ -0,0 +1,41 @@
+package models
+
+// LedgerFormat перечисляет поддерживаемые форматы текстовой бухгалтерии
+type LedgerFormat string
+
+const (
+	LedgerFormatHledger   LedgerFormat = "hledger"
+	LedgerFormatBeancount LedgerFormat = "beancount"
+)
+
+// LedgerAccountKind определяет, к чему относится счет: к категории трат, источнику накоплений или активу
+type LedgerAccountKind string
+
+const (
+	LedgerAccountCategory LedgerAccountKind = "category"
+	LedgerAccountSource   LedgerAccountKind = "source"
+	LedgerAccountAsset    LedgerAccountKind = "asset"
+)
+
+// LedgerDefaultAsset ключ счета актива, с которого списываются траты и на который зачисляются накопления
+const LedgerDefaultAsset = "default"
+
+// LedgerAccount представляет сопоставление категории или источника со счетом бухгалтерской книги
+type LedgerAccount struct {
+	Kind    LedgerAccountKind `json:"kind" db:"kind" validate:"required,oneof=category source asset"`
+	Key     string            `json:"key" db:"key" validate:"required,max=50"`
+	Account string            `json:"account" db:"account" validate:"required,max=255"`
+}
+
+// LedgerAccountMap содержит итоговые названия счетов с учетом значений по умолчанию
+type LedgerAccountMap struct {
+	Categories map[string]string `json:"categories"`
+	Sources    map[string]string `json:"sources"`
+	Asset      string            `json:"asset"`
+	Currency   string            `json:"currency"`
+}
+
+// UpdateLedgerAccountsRequest модель для изменения сопоставления счетов
+type UpdateLedgerAccountsRequest struct {
+	Accounts []LedgerAccount `json:"accounts" validate:"dive"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+)
+
+// LoanType перечисляет способы погашения кредита
+type LoanType string
+
+const (
+	// LoanAnnuity — равные ежемесячные платежи
+	LoanAnnuity LoanType = "annuity"
+	// LoanDifferentiated — равные доли основного долга и убывающие проценты
+	LoanDifferentiated LoanType = "differentiated"
+)
+
+// EarlyRepaymentStrategy перечисляет способы пересчета графика после досрочного погашения
+type EarlyRepaymentStrategy string
+
+const (
+	// EarlyReduceTerm сохраняет размер платежа и сокращает срок
+	EarlyReduceTerm EarlyRepaymentStrategy = "reduce_term"
+	// EarlyReducePayment сохраняет срок и уменьшает платеж
+	EarlyReducePayment EarlyRepaymentStrategy = "reduce_payment"
+)
+
+// Loan представляет кредит или долг пользователя
+type Loan struct {
+	ID          int64           `json:"id" db:"id"`
+	UserID      int64           `json:"user_id" db:"user_id"`
+	Name        string          `json:"name" db:"name"`
+	Lender      string          `json:"lender,omitempty" db:"lender"`
+	Principal   float64         `json:"principal" db:"principal"`
+	Rate        float64         `json:"rate" db:"rate"`
+	TermMonths  int             `json:"term_months" db:"term_months"`
+	StartDate   time.Time       `json:"start_date" db:"start_date"`
+	Type        LoanType        `json:"type" db:"type"`
+	Category    ExpenseCategory `json:"category" db:"category"`
+	Description string          `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+
+	// Вычисляемые поля, рассчитываются по графику и фактическим платежам
+	PaidPrincipal    float64             `json:"paid_principal"`
+	PaidInterest     float64             `json:"paid_interest"`
+	RemainingBalance float64             `json:"remaining_balance"`
+	RemainingMonths  int                 `json:"remaining_months"`
+	MonthlyPayment   float64             `json:"monthly_payment"`
+	NextPaymentDate  *time.Time          `json:"next_payment_date,omitempty"`
+	Closed           bool                `json:"closed"`
+	Payments         []LoanPayment       `json:"payments,omitempty"`
+	Schedule         []LoanScheduleEntry `json:"schedule,omitempty"`
+}
+
+// LoanScheduleEntry представляет строку графика платежей
+type LoanScheduleEntry struct {
+	Number    int       `json:"number"`
+	Date      time.Time `json:"date"`
+	Payment   float64   `json:"payment"`
+	Principal float64   `json:"principal"`
+	Interest  float64   `json:"interest"`
+	Remaining float64   `json:"remaining"`
+}
+
+// LoanPayment представляет фактический платеж по кредиту, связанный с тратой
+type LoanPayment struct {
+	ID             int64                  `json:"id" db:"id"`
+	LoanID         int64                  `json:"loan_id" db:"loan_id"`
+	Date           time.Time              `json:"date" db:"date"`
+	Amount         float64                `json:"amount" db:"amount"`
+	Principal      float64                `json:"principal" db:"principal"`
+	Interest       float64                `json:"interest" db:"interest"`
+	Early          bool                   `json:"early" db:"early"`
+	Strategy       EarlyRepaymentStrategy `json:"strategy,omitempty" db:"strategy"`
+	ExpenseID      *int64                 `json:"expense_id,omitempty" db:"expense_id"`
+	ExpenseCreated bool                   `json:"expense_created,omitempty" db:"expense_created"`
+	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
+}
+
+// DebtsSummary содержит сводку по кредитам для панели мониторинга
+type DebtsSummary struct {
+	TotalPrincipal  float64    `json:"total_principal"`
+	TotalRemaining  float64    `json:"total_remaining"`
+	MonthlyPayments float64    `json:"monthly_payments"`
+	Active          int        `json:"active"`
+	Closed          int        `json:"closed"`
+	NextPaymentDate *time.Time `json:"next_payment_date,omitempty"`
+	Loans           []Loan     `json:"loans"`
+}
+
+// CreateLoanRequest модель для добавления кредита
+type CreateLoanRequest struct {
+	Name        string          `json:"name" validate:"required,min=2,max=100"`
+	Lender      string          `json:"lender" validate:"max=100"`
+	Principal   float64         `json:"principal" validate:"required,gt=0"`
+	Rate        float64         `json:"rate" validate:"gte=0,lte=100"`
+	TermMonths  int             `json:"term_months" validate:"required,min=1,max=600"`
+	StartDate   time.Time       `json:"start_date" validate:"required"`
+	Type        LoanType        `json:"type" validate:"required,oneof=annuity differentiated"`
+	Category    ExpenseCategory `json:"category"`
+	Description string          `json:"description" validate:"max=500"`
+}
+
+// UpdateLoanRequest модель для обновления описательных полей кредита.
+// Условия кредита не меняются, чтобы не искажать историю платежей
+type UpdateLoanRequest struct {
+	Name        *string          `json:"name" validate:"omitempty,min=2,max=100"`
+	Lender      *string          `json:"lender" validate:"omitempty,max=100"`
+	Category    *ExpenseCategory `json:"category"`
+	Description *string          `json:"description" validate:"omitempty,max=500"`
+}
+
+// CreateLoanPaymentRequest модель для записи платежа по кредиту.
+// Если expense_id не указан, для платежа создается новая трата
+type CreateLoanPaymentRequest struct {
+	Amount    float64                `json:"amount" validate:"required,gt=0"`
+	Date      time.Time              `json:"date"`
+	Early     bool                   `json:"early"`
+	Strategy  EarlyRepaymentStrategy `json:"strategy" validate:"omitempty,oneof=reduce_term reduce_payment"`
+	ExpenseID *int64                 `json:"expense_id"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+)
+
+// AssetKind перечисляет виды активов и обязательств
+type AssetKind string
+
+const (
+	AssetAccount    AssetKind = "account"
+	AssetProperty   AssetKind = "property"
+	AssetVehicle    AssetKind = "vehicle"
+	AssetInvestment AssetKind = "investment"
+	AssetOther      AssetKind = "other"
+)
+
+// Asset представляет актив или обязательство с ручной оценкой стоимости,
+// например счет, квартиру, автомобиль или долг знакомому
+type Asset struct {
+	ID          int64     `json:"id" db:"id"`
+	UserID      int64     `json:"user_id" db:"user_id"`
+	Name        string    `json:"name" db:"name"`
+	Kind        AssetKind `json:"kind" db:"kind"`
+	Liability   bool      `json:"liability" db:"liability"`
+	Description string    `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Последняя оценка стоимости
+	CurrentValue float64          `json:"current_value"`
+	ValuedAt     *time.Time       `json:"valued_at,omitempty"`
+	Valuations   []AssetValuation `json:"valuations,omitempty"`
+}
+
+// AssetValuation представляет оценку стоимости актива или обязательства на дату
+type AssetValuation struct {
+	ID        int64     `json:"id" db:"id"`
+	AssetID   int64     `json:"asset_id" db:"asset_id"`
+	Value     float64   `json:"value" db:"value"`
+	Date      time.Time `json:"date" db:"date"`
+	Note      string    `json:"note,omitempty" db:"note"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NetWorthSnapshot представляет чистую стоимость капитала пользователя на конец месяца
+type NetWorthSnapshot struct {
+	UserID      int64     `json:"-" db:"user_id"`
+	Date        time.Time `json:"date" db:"date"`
+	Assets      float64   `json:"assets" db:"assets"`
+	Liabilities float64   `json:"liabilities" db:"liabilities"`
+	NetWorth    float64   `json:"net_worth" db:"net_worth"`
+	Stored      bool      `json:"stored"`
+}
+
+// NetWorthItem представляет составляющую чистой стоимости капитала
+type NetWorthItem struct {
+	Source    string  `json:"source"`
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Kind      string  `json:"kind"`
+	Liability bool    `json:"liability"`
+	Value     float64 `json:"value"`
+}
+
+// NetWorth содержит текущую чистую стоимость капитала с разбивкой и помесячную динамику
+type NetWorth struct {
+	Date        time.Time          `json:"date"`
+	Assets      float64            `json:"assets"`
+	Liabilities float64            `json:"liabilities"`
+	NetWorth    float64            `json:"net_worth"`
+	Items       []NetWorthItem     `json:"items"`
+	Series      []NetWorthSnapshot `json:"series"`
+}
+
+// CreateAssetRequest модель для добавления актива или обязательства с начальной оценкой
+type CreateAssetRequest struct {
+	Name        string     `json:"name" validate:"required,min=2,max=100"`
+	Kind        AssetKind  `json:"kind" validate:"required,oneof=account property vehicle investment other"`
+	Liability   bool       `json:"liability"`
+	Description string     `json:"description" validate:"max=500"`
+	Value       *float64   `json:"value" validate:"omitempty,gte=0"`
+	Date        *time.Time `json:"date"`
+}
+
+// UpdateAssetRequest модель для обновления актива или обязательства
+type UpdateAssetRequest struct {
+	Name        *string    `json:"name" validate:"omitempty,min=2,max=100"`
+	Kind        *AssetKind `json:"kind" validate:"omitempty,oneof=account property vehicle investment other"`
+	Liability   *bool      `json:"liability"`
+	Description *string    `json:"description" validate:"omitempty,max=500"`
+}
+
+// CreateAssetValuationRequest модель для добавления оценки стоимости
+type CreateAssetValuationRequest struct {
+	Value float64   `json:"value" validate:"gte=0"`
+	Date  time.Time `json:"date"`
+	Note  string    `json:"note" validate:"max=255"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Payee представляет получателя платежа (магазин, сервис, организацию).
+// Траты связываются с получателем по нормализованному названию и псевдонимам
+type Payee struct {
+	ID              int64           `json:"id" db:"id"`
+	UserID          int64           `json:"user_id" db:"user_id"`
+	Name            string          `json:"name" db:"name"`
+	DefaultCategory ExpenseCategory `json:"default_category,omitempty" db:"default_category"`
+	Aliases         []string        `json:"aliases" db:"aliases"`
+	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// PayeeRequest модель для создания и обновления получателя.
+// Псевдонимы сравниваются с названиями трат без учета регистра, знаков препинания и номеров
+type PayeeRequest struct {
+	Name            string          `json:"name" validate:"required,min=2,max=100"`
+	DefaultCategory ExpenseCategory `json:"default_category"`
+	Aliases         []string        `json:"aliases" validate:"max=50,dive,min=2,max=100"`
+}
+
+// PayeeLinkResult содержит количество трат, связанных с получателями
+type PayeeLinkResult struct {
+	Linked int `json:"linked"`
+}
+
+// PayeeStat содержит сумму и количество трат у получателя за период
+type PayeeStat struct {
+	PayeeID         int64           `json:"payee_id"`
+	Name            string          `json:"name"`
+	DefaultCategory ExpenseCategory `json:"default_category,omitempty"`
+	Count           int             `json:"count"`
+	Total           float64         `json:"total"`
+	Average         float64         `json:"average"`
+	Percentage      float64         `json:"percentage"`
+}
+
+// TopPayees содержит крупнейших получателей за период и траты, не связанные с получателем
+type TopPayees struct {
+	StartDate       time.Time   `json:"start_date"`
+	EndDate         time.Time   `json:"end_date"`
+	Total           float64     `json:"total"`
+	Payees          []PayeeStat `json:"payees"`
+	UnassignedCount int         `json:"unassigned_count"`
+	UnassignedTotal float64     `json:"unassigned_total"`
+}
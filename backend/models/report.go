@@ -0,0 +1,24 @@
+package models
+
+// MonthlyReport содержит данные ежемесячного финансового отчета
+type MonthlyReport struct {
+	Year         int
+	Month        int
+	UserName     string
+	Expenses     float64
+	Incomes      float64
+	Balance      float64
+	MonthlyLimit float64
+	SavingsGoal  float64
+	Categories   []ReportCategory
+	// DailyExpenses содержит сумму трат за каждый день месяца, индекс 0 соответствует первому числу
+	DailyExpenses []float64
+	TopExpenses   []Expense
+}
+
+// ReportCategory представляет строку таблицы трат по категориям
+type ReportCategory struct {
+	Category ExpenseCategory
+	Amount   float64
+	Share    float64
+}
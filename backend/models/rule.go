@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// CategorizationRule представляет пользовательское правило автоматической категоризации трат.
+// Правила проверяются по возрастанию приоритета, применяется первое подходящее
+type CategorizationRule struct {
+	ID                 int64           `json:"id" db:"id"`
+	UserID             int64           `json:"user_id" db:"user_id"`
+	Name               string          `json:"name" db:"name"`
+	Priority           int             `json:"priority" db:"priority"`
+	Enabled            bool            `json:"enabled" db:"enabled"`
+	TitlePattern       string          `json:"title_pattern,omitempty" db:"title_pattern"`
+	DescriptionPattern string          `json:"description_pattern,omitempty" db:"description_pattern"`
+	MinAmount          *float64        `json:"min_amount,omitempty" db:"min_amount"`
+	MaxAmount          *float64        `json:"max_amount,omitempty" db:"max_amount"`
+	Account            string          `json:"account,omitempty" db:"account"`
+	Weekdays           []int           `json:"weekdays,omitempty" db:"weekdays"`
+	Category           ExpenseCategory `json:"category,omitempty" db:"category"`
+	Tags               []string        `json:"tags" db:"tags"`
+	CleanTitle         string          `json:"clean_title,omitempty" db:"clean_title"`
+	CreatedAt          time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CategorizationRuleRequest модель для создания правила или проверки его на истории.
+// Регулярные выражения не чувствительны к регистру, дни недели задаются числами
+// от 1 (понедельник) до 7 (воскресенье)
+type CategorizationRuleRequest struct {
+	Name               string          `json:"name" validate:"required,min=2,max=100"`
+	Priority           *int            `json:"priority"`
+	Enabled            *bool           `json:"enabled"`
+	TitlePattern       string          `json:"title_pattern" validate:"max=255"`
+	DescriptionPattern string          `json:"description_pattern" validate:"max=255"`
+	MinAmount          *float64        `json:"min_amount" validate:"omitempty,gte=0"`
+	MaxAmount          *float64        `json:"max_amount" validate:"omitempty,gte=0"`
+	Account            string          `json:"account" validate:"max=100"`
+	Weekdays           []int           `json:"weekdays" validate:"max=7,dive,min=1,max=7"`
+	Category           ExpenseCategory `json:"category"`
+	Tags               []string        `json:"tags" validate:"max=10,dive,min=1,max=30"`
+	CleanTitle         string          `json:"clean_title" validate:"omitempty,min=2,max=100"`
+}
+
+// ReorderRulesRequest модель для изменения порядка правил.
+// Приоритеты назначаются в порядке перечисления ID
+type ReorderRulesRequest struct {
+	RuleIDs []int64 `json:"rule_ids" validate:"required,min=1"`
+}
+
+// RuleMatch описывает изменения, которые правило внесет в трату
+type RuleMatch struct {
+	ExpenseID   int64           `json:"expense_id"`
+	Date        time.Time       `json:"date"`
+	Title       string          `json:"title"`
+	Amount      float64         `json:"amount"`
+	Category    ExpenseCategory `json:"category"`
+	NewTitle    string          `json:"new_title"`
+	NewCategory ExpenseCategory `json:"new_category"`
+	NewTags     []string        `json:"new_tags"`
+}
+
+// RuleTestResult содержит результат проверки правила на истории трат
+type RuleTestResult struct {
+	Total   int         `json:"total"`
+	Matched int         `json:"matched"`
+	Changed int         `json:"changed"`
+	Matches []RuleMatch `json:"matches"`
+}
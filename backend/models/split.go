@@ -0,0 +1,143 @@
+package models
+
+import (
+	"time"
+)
+
+// SplitMethod перечисляет способы разделения общей траты между участниками
+type SplitMethod string
+
+const (
+	// SplitEqual делит сумму поровну
+	SplitEqual SplitMethod = "equal"
+	// SplitShares делит сумму пропорционально долям участников
+	SplitShares SplitMethod = "shares"
+	// SplitExact задает точную сумму для каждого участника
+	SplitExact SplitMethod = "exact"
+)
+
+// SplitGroup представляет группу для разделения общих трат, например поездку
+type SplitGroup struct {
+	ID           int64              `json:"id" db:"id"`
+	Name         string             `json:"name" db:"name"`
+	CreatedBy    int64              `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" db:"updated_at"`
+	Participants []SplitParticipant `json:"participants,omitempty"`
+}
+
+// SplitParticipant представляет участника группы: зарегистрированного пользователя или именованный контакт
+type SplitParticipant struct {
+	ID        int64     `json:"id" db:"id"`
+	GroupID   int64     `json:"group_id" db:"group_id"`
+	UserID    *int64    `json:"user_id,omitempty" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// SplitExpense представляет общую трату группы, оплаченную одним из участников
+type SplitExpense struct {
+	ID          int64        `json:"id" db:"id"`
+	GroupID     int64        `json:"group_id" db:"group_id"`
+	PaidBy      int64        `json:"paid_by" db:"paid_by"`
+	Title       string       `json:"title" db:"title"`
+	Amount      float64      `json:"amount" db:"amount"`
+	Method      SplitMethod  `json:"method" db:"method"`
+	Date        time.Time    `json:"date" db:"date"`
+	Description string       `json:"description" db:"description"`
+	CreatedBy   int64        `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	Shares      []SplitShare `json:"shares"`
+}
+
+// SplitShare представляет долю участника в общей трате.
+// Value хранит вес для способа shares и точную сумму для способа exact
+type SplitShare struct {
+	ParticipantID int64   `json:"participant_id" db:"participant_id"`
+	Value         float64 `json:"value" db:"value"`
+	Amount        float64 `json:"amount" db:"amount"`
+}
+
+// SplitSettlement представляет погашение долга одним участником группы другому
+type SplitSettlement struct {
+	ID              int64     `json:"id" db:"id"`
+	GroupID         int64     `json:"group_id" db:"group_id"`
+	FromParticipant int64     `json:"from_participant" db:"from_participant"`
+	ToParticipant   int64     `json:"to_participant" db:"to_participant"`
+	Amount          float64   `json:"amount" db:"amount"`
+	Date            time.Time `json:"date" db:"date"`
+	Note            string    `json:"note,omitempty" db:"note"`
+	ExpenseID       *int64    `json:"expense_id,omitempty" db:"expense_id"`
+	IncomeID        *int64    `json:"income_id,omitempty" db:"income_id"`
+	CreatedBy       int64     `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// SplitBalance содержит итог участника: сколько он заплатил, сколько должен и сальдо.
+// Положительное сальдо означает, что участнику должны остальные
+type SplitBalance struct {
+	ParticipantID int64   `json:"participant_id"`
+	Name          string  `json:"name"`
+	UserID        *int64  `json:"user_id,omitempty"`
+	Paid          float64 `json:"paid"`
+	Owed          float64 `json:"owed"`
+	Balance       float64 `json:"balance"`
+}
+
+// SplitDebt представляет долг одного участника другому
+type SplitDebt struct {
+	FromParticipant int64   `json:"from_participant"`
+	FromName        string  `json:"from_name"`
+	ToParticipant   int64   `json:"to_participant"`
+	ToName          string  `json:"to_name"`
+	Amount          float64 `json:"amount"`
+}
+
+// SplitBalances содержит сальдо участников группы и список долгов «кто кому должен»
+type SplitBalances struct {
+	GroupID    int64          `json:"group_id"`
+	Simplified bool           `json:"simplified"`
+	Balances   []SplitBalance `json:"balances"`
+	Debts      []SplitDebt    `json:"debts"`
+}
+
+// CreateSplitGroupRequest модель для создания группы. Создатель добавляется участником автоматически
+type CreateSplitGroupRequest struct {
+	Name         string                       `json:"name" validate:"required,min=2,max=100"`
+	Participants []AddSplitParticipantRequest `json:"participants" validate:"dive"`
+}
+
+// AddSplitParticipantRequest модель для добавления участника: зарегистрированного пользователя
+// по email или имени пользователя либо именованного контакта
+type AddSplitParticipantRequest struct {
+	Email    string `json:"email" validate:"omitempty,email"`
+	Username string `json:"username" validate:"omitempty,max=50"`
+	Name     string `json:"name" validate:"omitempty,max=100"`
+}
+
+// CreateSplitExpenseRequest модель для добавления общей траты.
+// Для способа equal список долей можно не указывать — трата делится на всех участников
+type CreateSplitExpenseRequest struct {
+	Title       string              `json:"title" validate:"required,min=2,max=100"`
+	Amount      float64             `json:"amount" validate:"required,gt=0"`
+	Method      SplitMethod         `json:"method" validate:"required,oneof=equal shares exact"`
+	PaidBy      int64               `json:"paid_by" validate:"required"`
+	Date        time.Time           `json:"date"`
+	Description string              `json:"description" validate:"max=500"`
+	Shares      []SplitShareRequest `json:"shares" validate:"dive"`
+}
+
+// SplitShareRequest модель доли участника в общей трате
+type SplitShareRequest struct {
+	ParticipantID int64   `json:"participant_id" validate:"required"`
+	Value         float64 `json:"value" validate:"gte=0"`
+}
+
+// CreateSplitSettlementRequest модель для записи погашения долга
+type CreateSplitSettlementRequest struct {
+	FromParticipant int64     `json:"from_participant" validate:"required"`
+	ToParticipant   int64     `json:"to_participant" validate:"required"`
+	Amount          float64   `json:"amount" validate:"required,gt=0"`
+	Date            time.Time `json:"date"`
+	Note            string    `json:"note" validate:"max=255"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+)
+
+// RecurringCadence перечисляет периодичность регулярных списаний
+type RecurringCadence string
+
+const (
+	CadenceWeekly    RecurringCadence = "weekly"
+	CadenceMonthly   RecurringCadence = "monthly"
+	CadenceQuarterly RecurringCadence = "quarterly"
+	CadenceYearly    RecurringCadence = "yearly"
+)
+
+// RecurringRule представляет подтвержденную пользователем подписку или другое регулярное списание.
+// MatchKey связывает правило с группой трат: "payee:<ID получателя>" или "title:<нормализованное название>"
+type RecurringRule struct {
+	ID        int64            `json:"id" db:"id"`
+	UserID    int64            `json:"user_id" db:"user_id"`
+	MatchKey  string           `json:"match_key" db:"match_key"`
+	Title     string           `json:"title" db:"title"`
+	PayeeID   *int64           `json:"payee_id,omitempty" db:"payee_id"`
+	Category  ExpenseCategory  `json:"category" db:"category"`
+	Amount    float64          `json:"amount" db:"amount"`
+	Cadence   RecurringCadence `json:"cadence" db:"cadence"`
+	NextDate  time.Time        `json:"next_date" db:"next_date"`
+	Active    bool             `json:"active" db:"active"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// ConfirmSubscriptionRequest модель для подтверждения найденной подписки.
+// Название, сумму и категорию можно уточнить, иначе они берутся из последнего списания
+type ConfirmSubscriptionRequest struct {
+	Key      string          `json:"key" validate:"required,max=150"`
+	Title    string          `json:"title" validate:"omitempty,min=2,max=100"`
+	Amount   *float64        `json:"amount" validate:"omitempty,gt=0"`
+	Category ExpenseCategory `json:"category"`
+}
+
+// UpdateRecurringRuleRequest модель для обновления регулярного списания
+type UpdateRecurringRuleRequest struct {
+	Title    *string           `json:"title" validate:"omitempty,min=2,max=100"`
+	Amount   *float64          `json:"amount" validate:"omitempty,gt=0"`
+	Category *ExpenseCategory  `json:"category"`
+	Cadence  *RecurringCadence `json:"cadence" validate:"omitempty,oneof=weekly monthly quarterly yearly"`
+	NextDate *time.Time        `json:"next_date"`
+	Active   *bool             `json:"active"`
+}
+
+// SubscriptionPriceChange описывает подорожание подписки
+type SubscriptionPriceChange struct {
+	PreviousAmount float64   `json:"previous_amount"`
+	CurrentAmount  float64   `json:"current_amount"`
+	Change         float64   `json:"change"`
+	ChangePercent  float64   `json:"change_percent"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+// DetectedSubscription представляет найденное в истории трат регулярное списание
+type DetectedSubscription struct {
+	Key             string                   `json:"key"`
+	Title           string                   `json:"title"`
+	PayeeID         *int64                   `json:"payee_id,omitempty"`
+	Category        ExpenseCategory          `json:"category"`
+	Cadence         RecurringCadence         `json:"cadence"`
+	IntervalDays    float64                  `json:"interval_days"`
+	Amount          float64                  `json:"amount"`
+	AverageAmount   float64                  `json:"average_amount"`
+	AnnualCost      float64                  `json:"annual_cost"`
+	Occurrences     int                      `json:"occurrences"`
+	FirstDate       time.Time                `json:"first_date"`
+	LastDate        time.Time                `json:"last_date"`
+	NextDate        time.Time                `json:"next_date"`
+	Active          bool                     `json:"active"`
+	PriceIncrease   *SubscriptionPriceChange `json:"price_increase,omitempty"`
+	RecurringRuleID *int64                   `json:"recurring_rule_id,omitempty"`
+	ExpenseIDs      []int64                  `json:"expense_ids"`
+}
+
+// SubscriptionReport содержит найденные подписки и их суммарную стоимость.
+// Итоги учитывают только активные подписки
+type SubscriptionReport struct {
+	Subscriptions  []DetectedSubscription `json:"subscriptions"`
+	MonthlyCost    float64                `json:"monthly_cost"`
+	AnnualCost     float64                `json:"annual_cost"`
+	PriceIncreases int                    `json:"price_increases"`
+}
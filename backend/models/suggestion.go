@@ -0,0 +1,27 @@
+package models
+
+// SuggestCategoryRequest модель для подбора категории траты по названию, описанию и сумме
+type SuggestCategoryRequest struct {
+	Title       string  `json:"title" validate:"required,min=1,max=100"`
+	Description string  `json:"description" validate:"max=500"`
+	Amount      float64 `json:"amount" validate:"gte=0"`
+}
+
+// CategorySuggestion представляет предлагаемую категорию с уверенностью от 0 до 1
+type CategorySuggestion struct {
+	Category   ExpenseCategory `json:"category"`
+	Title      string          `json:"title"`
+	Confidence float64         `json:"confidence"`
+}
+
+// CategorySuggestions содержит предложенные категории в порядке убывания уверенности.
+// Source показывает, откуда получено предложение: из правила, категории получателя,
+// обученной модели или ниоткуда
+type CategorySuggestions struct {
+	Suggestions []CategorySuggestion `json:"suggestions"`
+	Confident   bool                 `json:"confident"`
+	Source      string               `json:"source"`
+	RuleID      *int64               `json:"rule_id,omitempty"`
+	PayeeID     *int64               `json:"payee_id,omitempty"`
+	TrainedOn   int                  `json:"trained_on"`
+}
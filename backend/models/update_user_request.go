@@ -8,4 +8,5 @@ type UpdateUserRequest struct {
 	Email        *string  `json:"email" validate:"omitempty,email"`
 	MonthlyLimit *float64 `json:"monthly_limit" validate:"omitempty,gte=0"`
 	SavingsGoal  *float64 `json:"savings_goal" validate:"omitempty,gte=0"`
+	Timezone     *string  `json:"timezone" validate:"omitempty,timezone"`
 }
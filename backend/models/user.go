@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -16,10 +17,46 @@ type User struct {
 	AvatarPath   string    `json:"avatar_url" db:"avatar_path"`
 	MonthlyLimit float64   `json:"monthly_limit" db:"monthly_limit"`
 	SavingsGoal  float64   `json:"savings_goal" db:"savings_goal"`
+	Timezone     string    `json:"timezone" db:"timezone"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// DefaultTimezone используется для пользователей, которые не указали часовой пояс
+const DefaultTimezone = "UTC"
+
+// LoadTimezone загружает часовой пояс по имени из базы IANA. Пустое имя и "Local"
+// отклоняются: time.LoadLocation принимает их как UTC и пояс сервера, а PostgreSQL
+// такие имена в AT TIME ZONE не понимает
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("неизвестный часовой пояс: %q", name)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("неизвестный часовой пояс: %s", name)
+	}
+	return location, nil
+}
+
+// TimezoneName возвращает имя часового пояса пользователя для запросов к базе данных.
+// Если пояс не задан или неизвестен, используется UTC
+func (u *User) TimezoneName() string {
+	if _, err := LoadTimezone(u.Timezone); err != nil {
+		return DefaultTimezone
+	}
+	return u.Timezone
+}
+
+// Location возвращает часовой пояс пользователя, в котором считаются дни и месяцы.
+// Если пояс не задан или неизвестен, используется UTC
+func (u *User) Location() *time.Location {
+	if location, err := LoadTimezone(u.Timezone); err == nil {
+		return location
+	}
+	return time.UTC
+}
+
 // UserSignup модель для регистрации пользователя
 type UserSignup struct {
 	Email     string `json:"email" validate:"required,email"`
@@ -27,6 +64,7 @@ type UserSignup struct {
 	Password  string `json:"password" validate:"required,min=6"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
+	Timezone  string `json:"timezone" validate:"omitempty,timezone"`
 }
 
 // UserLogin модель для входа пользователя
@@ -45,6 +83,7 @@ type UserResponse struct {
 	AvatarURL    string    `json:"avatar_url"`
 	MonthlyLimit float64   `json:"monthly_limit"`
 	SavingsGoal  float64   `json:"savings_goal"`
+	Timezone     string    `json:"timezone"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -64,6 +103,7 @@ func (u *User) ToUserResponse() UserResponse {
 		AvatarURL:    u.AvatarPath,
 		MonthlyLimit: u.MonthlyLimit,
 		SavingsGoal:  u.SavingsGoal,
+		Timezone:     u.Timezone,
 		CreatedAt:    u.CreatedAt,
 	}
 
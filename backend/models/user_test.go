@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestUserTimezone(t *testing.T) {
+	tests := []struct {
+		timezone string
+		wantName string
+		wantErr  bool
+	}{
+		{timezone: "Europe/Moscow", wantName: "Europe/Moscow"},
+		{timezone: "UTC", wantName: "UTC"},
+		{timezone: "", wantName: DefaultTimezone, wantErr: true},
+		{timezone: "Local", wantName: DefaultTimezone, wantErr: true},
+		{timezone: "local", wantName: DefaultTimezone, wantErr: true},
+		{timezone: "Mars/Olympus", wantName: DefaultTimezone, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			location, err := LoadTimezone(tt.timezone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+			if err == nil && location.String() != tt.timezone {
+				t.Errorf("загружен пояс %q, ожидался %q", location, tt.timezone)
+			}
+
+			user := &User{Timezone: tt.timezone}
+			if name := user.TimezoneName(); name != tt.wantName {
+				t.Errorf("имя пояса %q, ожидалось %q", name, tt.wantName)
+			}
+			if location := user.Location(); location.String() != tt.wantName {
+				t.Errorf("пояс пользователя %q, ожидался %q", location, tt.wantName)
+			}
+		})
+	}
+}
@@ -21,14 +21,53 @@ type WishlistItem struct {
 	Price       float64          `json:"price" db:"price" validate:"required,gt=0"`
 	Priority    WishlistPriority `json:"priority" db:"priority" validate:"required"`
 	Description string           `json:"description,omitempty" db:"description"`
+	TargetPrice *float64         `json:"target_price,omitempty" db:"target_price" validate:"omitempty,gt=0"`
+	Shared      bool             `json:"shared" db:"shared"`
+	Allocated   float64          `json:"allocated" db:"allocated"`
+	PurchasedAt *time.Time       `json:"purchased_at,omitempty" db:"purchased_at"`
+	ExpenseID   *int64           `json:"expense_id,omitempty" db:"expense_id"`
 	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+
+	Allocations  []WishlistAllocation `json:"allocations,omitempty"`
+	PriceHistory []WishlistPrice      `json:"price_history,omitempty"`
+}
+
+// WishlistPriceSource перечисляет источники наблюдаемых цен
+type WishlistPriceSource string
+
+const (
+	PriceSourceManual   WishlistPriceSource = "manual"
+	PriceSourceTelegram WishlistPriceSource = "telegram"
+)
+
+// WishlistPrice представляет наблюдаемую цену элемента списка желаний
+type WishlistPrice struct {
+	ID         int64               `json:"id" db:"id"`
+	WishlistID int64               `json:"wishlist_id" db:"wishlist_id"`
+	Price      float64             `json:"price" db:"price" validate:"required,gt=0"`
+	Source     WishlistPriceSource `json:"source" db:"source"`
+	ObservedAt time.Time           `json:"observed_at" db:"observed_at"`
+	CreatedAt  time.Time           `json:"created_at" db:"created_at"`
+}
+
+// WishlistAllocation представляет резервирование денег под элемент списка желаний.
+// Отрицательная сумма означает снятие резерва
+type WishlistAllocation struct {
+	ID          int64     `json:"id" db:"id"`
+	WishlistID  int64     `json:"wishlist_id" db:"wishlist_id"`
+	Amount      float64   `json:"amount" db:"amount" validate:"required,ne=0"`
+	Date        time.Time `json:"date" db:"date"`
+	Description string    `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
 // CreateWishlistItemRequest модель для создания нового элемента списка желаний
 type CreateWishlistItemRequest struct {
 	Title       string           `json:"title" validate:"required,min=2,max=100"`
 	Price       float64          `json:"price" validate:"required,gt=0"`
+	TargetPrice *float64         `json:"target_price" validate:"omitempty,gt=0"`
+	Shared      bool             `json:"shared"`
 	Priority    WishlistPriority `json:"priority" validate:"required"`
 	Description string           `json:"description,omitempty"`
 }
@@ -37,6 +76,81 @@ type CreateWishlistItemRequest struct {
 type UpdateWishlistItemRequest struct {
 	Title       *string           `json:"title" validate:"omitempty,min=2,max=100"`
 	Price       *float64          `json:"price" validate:"omitempty,gt=0"`
+	TargetPrice *float64          `json:"target_price" validate:"omitempty,gte=0"`
+	Shared      *bool             `json:"shared"`
 	Priority    *WishlistPriority `json:"priority"`
 	Description *string           `json:"description,omitempty"`
 }
+
+// RecordWishlistPriceRequest модель для записи новой наблюдаемой цены
+type RecordWishlistPriceRequest struct {
+	Price      float64             `json:"price" validate:"required,gt=0"`
+	Source     WishlistPriceSource `json:"source" validate:"omitempty,oneof=manual telegram"`
+	ObservedAt time.Time           `json:"observed_at"`
+}
+
+// WishlistPriceResult представляет результат записи цены и срабатывания целевой цены
+type WishlistPriceResult struct {
+	Item          *WishlistItem  `json:"item"`
+	Price         *WishlistPrice `json:"price"`
+	TargetReached bool           `json:"target_reached"`
+	Notified      bool           `json:"notified"`
+}
+
+// WishlistStatus задает отбор элементов списка желаний по признаку покупки
+type WishlistStatus string
+
+const (
+	WishlistStatusAll       WishlistStatus = "all"
+	WishlistStatusActive    WishlistStatus = "active"
+	WishlistStatusPurchased WishlistStatus = "purchased"
+)
+
+// PurchaseWishlistItemRequest модель для отметки покупки элемента списка желаний.
+// Если сумма или название не указаны, берутся цена и название элемента. Без категории
+// она назначается правилами категоризации и получателем, как при создании траты
+type PurchaseWishlistItemRequest struct {
+	Category    ExpenseCategory `json:"category"`
+	Amount      *float64        `json:"amount" validate:"omitempty,gt=0"`
+	Date        time.Time       `json:"date"`
+	Title       *string         `json:"title" validate:"omitempty,min=2,max=100"`
+	Description string          `json:"description"`
+	Account     string          `json:"account" validate:"max=100"`
+	Tags        []string        `json:"tags" validate:"max=10,dive,min=1,max=30"`
+}
+
+// WishlistPurchase представляет результат покупки элемента списка желаний
+type WishlistPurchase struct {
+	Item    *WishlistItem `json:"item"`
+	Expense *Expense      `json:"expense"`
+}
+
+// CreateWishlistAllocationRequest модель для резервирования денег под элемент списка желаний
+type CreateWishlistAllocationRequest struct {
+	Amount      float64   `json:"amount" validate:"required,ne=0"`
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+}
+
+// AffordabilityPlan представляет план покупок из списка желаний на основе среднего свободного остатка
+type AffordabilityPlan struct {
+	MonthsAnalyzed       int                 `json:"months_analyzed"`
+	AverageIncome        float64             `json:"average_income"`
+	AverageExpenses      float64             `json:"average_expenses"`
+	AverageSurplus       float64             `json:"average_surplus"`
+	GoalsRequiredMonthly float64             `json:"goals_required_monthly"`
+	AvailableMonthly     float64             `json:"available_monthly"`
+	TotalRemaining       float64             `json:"total_remaining"`
+	Items                []AffordabilityItem `json:"items"`
+}
+
+// AffordabilityItem представляет элемент списка желаний в плане покупок
+type AffordabilityItem struct {
+	Item           WishlistItem `json:"item"`
+	Remaining      float64      `json:"remaining"`
+	CumulativeCost float64      `json:"cumulative_cost"`
+	MonthsNeeded   int          `json:"months_needed"`
+	EstimatedDate  *time.Time   `json:"estimated_date,omitempty"`
+	FullyFunded    bool         `json:"fully_funded"`
+	Affordable     bool         `json:"affordable"`
+}
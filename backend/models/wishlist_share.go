@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// WishlistShare представляет публичную ссылку на список желаний пользователя
+type WishlistShare struct {
+	ID         int64     `json:"id" db:"id"`
+	UserID     int64     `json:"user_id" db:"user_id"`
+	Token      string    `json:"token" db:"token"`
+	HidePrices bool      `json:"hide_prices" db:"hide_prices"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateWishlistShareRequest модель для включения и настройки публичной ссылки.
+// Regenerate заменяет токен, и старая ссылка перестает работать
+type UpdateWishlistShareRequest struct {
+	HidePrices bool `json:"hide_prices"`
+	Regenerate bool `json:"regenerate"`
+}
+
+// PublicWishlist представляет публичный вид списка желаний, доступный по ссылке
+type PublicWishlist struct {
+	OwnerName  string               `json:"owner_name"`
+	HidePrices bool                 `json:"hide_prices"`
+	Items      []PublicWishlistItem `json:"items"`
+}
+
+// PublicWishlistItem представляет элемент списка желаний в публичном виде.
+// Цена не передается, если владелец скрыл цены
+type PublicWishlistItem struct {
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Priority    WishlistPriority `json:"priority"`
+	Price       *float64         `json:"price,omitempty"`
+	Reserved    bool             `json:"reserved"`
+}
+
+// WishlistReservation представляет анонимное бронирование подарка посетителем публичной ссылки
+type WishlistReservation struct {
+	ID           int64     `json:"id" db:"id"`
+	WishlistID   int64     `json:"wishlist_id" db:"wishlist_id"`
+	ReserverName string    `json:"reserver_name,omitempty" db:"reserver_name"`
+	CancelToken  string    `json:"cancel_token" db:"cancel_token"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReserveWishlistItemRequest модель для бронирования подарка. Имя указывать не обязательно
+type ReserveWishlistItemRequest struct {
+	Name string `json:"name" validate:"max=100"`
+}
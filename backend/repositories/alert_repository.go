@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresAlertRepository представляет реализацию репозитория предупреждений на PostgreSQL
+type PostgresAlertRepository struct {
+	db *sql.DB
+}
+
+// NewAlertRepository создает новый экземпляр репозитория предупреждений
+func NewAlertRepository(db *sql.DB) AlertRepository {
+	return &PostgresAlertRepository{db: db}
+}
+
+// Create сохраняет предупреждение. Если такое предупреждение уже есть, возвращает false
+func (r *PostgresAlertRepository) Create(ctx context.Context, alert *models.Alert) (bool, error) {
+	query := `
+		INSERT INTO alerts (user_id, kind, expense_id, category, period, amount, expected, score, message, notified, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE, $10)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		alert.UserID,
+		alert.Kind,
+		alert.ExpenseID,
+		alert.Category,
+		alert.Period,
+		alert.Amount,
+		alert.Expected,
+		alert.Score,
+		alert.Message,
+		alert.CreatedAt,
+	).Scan(&alert.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetByUserID получает последние предупреждения пользователя, начиная с новых
+func (r *PostgresAlertRepository) GetByUserID(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]models.Alert, error) {
+	query := `
+		SELECT id, user_id, kind, expense_id, category, period, amount, expected, score, message, notified, read_at, created_at
+		FROM alerts
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []models.Alert
+	for rows.Next() {
+		var alert models.Alert
+		var expenseID sql.NullInt64
+		var readAt sql.NullTime
+		err := rows.Scan(
+			&alert.ID,
+			&alert.UserID,
+			&alert.Kind,
+			&expenseID,
+			&alert.Category,
+			&alert.Period,
+			&alert.Amount,
+			&alert.Expected,
+			&alert.Score,
+			&alert.Message,
+			&alert.Notified,
+			&readAt,
+			&alert.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if expenseID.Valid {
+			alert.ExpenseID = &expenseID.Int64
+		}
+		if readAt.Valid {
+			alert.ReadAt = &readAt.Time
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// MarkRead отмечает предупреждение прочитанным
+func (r *PostgresAlertRepository) MarkRead(ctx context.Context, id int64, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE alerts SET read_at = COALESCE(read_at, $1) WHERE id = $2 AND user_id = $3
+	`, time.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("предупреждение не найдено")
+	}
+
+	return nil
+}
+
+// MarkAllRead отмечает прочитанными все предупреждения пользователя
+func (r *PostgresAlertRepository) MarkAllRead(ctx context.Context, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE alerts SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL
+	`, time.Now(), userID)
+	return err
+}
+
+// MarkNotified отмечает, что предупреждение отправлено в Telegram
+func (r *PostgresAlertRepository) MarkNotified(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE alerts SET notified = TRUE WHERE id = $1`, id)
+	return err
+}
+
+// GetUserIDsWithRecentExpenses получает пользователей, добавивших траты после указанного момента
+func (r *PostgresAlertRepository) GetUserIDsWithRecentExpenses(ctx context.Context, since time.Time) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT user_id FROM expenses WHERE created_at >= $1`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// analyticsMaxRows ограничивает количество строк в результате аналитики
+const analyticsMaxRows = 10000
+
+// analyticsSource описывает таблицу набора данных, выражения для допустимых группировок
+// и соединения, которые нужны этим группировкам. В запрос попадают только эти выражения
+type analyticsSource struct {
+	table  string
+	groups map[models.AnalyticsGroup]string
+	joins  map[models.AnalyticsGroup]string
+}
+
+// analyticsSources задает допустимые группировки для каждого набора данных.
+// Выражение {tz} заменяется параметром часового пояса
+var analyticsSources = map[models.AnalyticsDataset]analyticsSource{
+	models.AnalyticsExpenses: {
+		table: "expenses r",
+		groups: map[models.AnalyticsGroup]string{
+			models.GroupByCategory: "r.category",
+			models.GroupByTag:      "COALESCE(t.tag, '')",
+			models.GroupByPayee:    "COALESCE(p.name, '')",
+			models.GroupByAccount:  "COALESCE(r.account, '')",
+			models.GroupByWeekday:  "EXTRACT(ISODOW FROM r.date AT TIME ZONE {tz})::int::text",
+		},
+		joins: map[models.AnalyticsGroup]string{
+			models.GroupByTag:   "LEFT JOIN LATERAL unnest(r.tags) AS t(tag) ON TRUE",
+			models.GroupByPayee: "LEFT JOIN payees p ON p.id = r.payee_id",
+		},
+	},
+	models.AnalyticsIncomes: {
+		table: "incomes r",
+		groups: map[models.AnalyticsGroup]string{
+			models.GroupBySource:  "r.source",
+			models.GroupByWeekday: "EXTRACT(ISODOW FROM r.date AT TIME ZONE {tz})::int::text",
+		},
+	},
+}
+
+// analyticsMetrics задает агрегатные выражения для показателей
+var analyticsMetrics = map[models.AnalyticsMetric]string{
+	models.MetricSum:    "COALESCE(SUM(r.amount), 0)",
+	models.MetricCount:  "COUNT(*)",
+	models.MetricAvg:    "COALESCE(AVG(r.amount), 0)",
+	models.MetricMedian: "COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY r.amount), 0)",
+}
+
+// analyticsIntervals задает единицы date_trunc для интервалов
+var analyticsIntervals = map[models.AnalyticsInterval]string{
+	models.IntervalDay:     "day",
+	models.IntervalWeek:    "week",
+	models.IntervalMonth:   "month",
+	models.IntervalQuarter: "quarter",
+	models.IntervalYear:    "year",
+}
+
+// PostgresAnalyticsRepository представляет реализацию репозитория аналитики на PostgreSQL
+type PostgresAnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewAnalyticsRepository создает новый экземпляр репозитория аналитики
+func NewAnalyticsRepository(db *sql.DB) AnalyticsRepository {
+	return &PostgresAnalyticsRepository{db: db}
+}
+
+// Query выполняет агрегирующий запрос аналитики. SQL собирается только из выражений
+// белого списка, а значения пользователя передаются параметрами
+func (r *PostgresAnalyticsRepository) Query(ctx context.Context, userID int64, query *models.AnalyticsQuery) ([]models.AnalyticsRow, error) {
+	location, err := models.LoadTimezone(query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlQuery, args, err := buildAnalyticsSQL(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.AnalyticsRow
+	for rows.Next() {
+		var row models.AnalyticsRow
+		var period time.Time
+		groups := make([]string, len(query.GroupBy))
+
+		dest := make([]interface{}, 0, 1+len(groups)+len(query.Metrics))
+		if query.Interval != "" {
+			dest = append(dest, &period)
+		}
+		for i := range groups {
+			dest = append(dest, &groups[i])
+		}
+		for _, metric := range query.Metrics {
+			switch metric {
+			case models.MetricSum:
+				row.Sum = new(float64)
+				dest = append(dest, row.Sum)
+			case models.MetricCount:
+				row.Count = new(int64)
+				dest = append(dest, row.Count)
+			case models.MetricAvg:
+				row.Avg = new(float64)
+				dest = append(dest, row.Avg)
+			case models.MetricMedian:
+				row.Median = new(float64)
+				dest = append(dest, row.Median)
+			}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		// date_trunc по местному времени возвращает время без пояса, восстанавливаем пояс запроса
+		if query.Interval != "" {
+			local := time.Date(period.Year(), period.Month(), period.Day(), 0, 0, 0, 0, location)
+			row.Period = &local
+		}
+		if len(groups) > 0 {
+			row.Groups = make(map[models.AnalyticsGroup]string, len(groups))
+			for i, group := range query.GroupBy {
+				row.Groups[group] = groups[i]
+			}
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// buildAnalyticsSQL собирает SQL-запрос аналитики и его параметры.
+// Столбцы идут в порядке: период, группировки, показатели
+func buildAnalyticsSQL(userID int64, query *models.AnalyticsQuery) (string, []interface{}, error) {
+	source, ok := analyticsSources[query.Dataset]
+	if !ok {
+		return "", nil, fmt.Errorf("неизвестный набор данных: %s", query.Dataset)
+	}
+
+	args := []interface{}{userID, query.StartDate, query.EndDate}
+	tz := ""
+	withTimezone := func(expression string) string {
+		if !strings.Contains(expression, "{tz}") {
+			return expression
+		}
+		if tz == "" {
+			args = append(args, query.Timezone)
+			tz = fmt.Sprintf("$%d::text", len(args))
+		}
+		return strings.ReplaceAll(expression, "{tz}", tz)
+	}
+
+	var columns, joins []string
+	if query.Interval != "" {
+		unit, ok := analyticsIntervals[query.Interval]
+		if !ok {
+			return "", nil, fmt.Errorf("неизвестный интервал: %s", query.Interval)
+		}
+		columns = append(columns, withTimezone(fmt.Sprintf("date_trunc('%s', r.date AT TIME ZONE {tz})", unit)))
+	}
+	for _, group := range query.GroupBy {
+		expression, ok := source.groups[group]
+		if !ok {
+			return "", nil, fmt.Errorf("группировка %s недоступна для набора %s", group, query.Dataset)
+		}
+		columns = append(columns, withTimezone(expression))
+		if join, ok := source.joins[group]; ok {
+			joins = append(joins, join)
+		}
+	}
+
+	keys := len(columns)
+	for _, metric := range query.Metrics {
+		expression, ok := analyticsMetrics[metric]
+		if !ok {
+			return "", nil, fmt.Errorf("неизвестный показатель: %s", metric)
+		}
+		columns = append(columns, expression)
+	}
+
+	var sqlQuery strings.Builder
+	sqlQuery.WriteString("SELECT " + strings.Join(columns, ", "))
+	sqlQuery.WriteString("\nFROM " + source.table)
+	for _, join := range joins {
+		sqlQuery.WriteString("\n" + join)
+	}
+	sqlQuery.WriteString("\nWHERE r.user_id = $1 AND r.date >= $2 AND r.date < $3")
+	if keys > 0 {
+		ordinals := make([]string, keys)
+		for i := range ordinals {
+			ordinals[i] = fmt.Sprint(i + 1)
+		}
+		sqlQuery.WriteString("\nGROUP BY " + strings.Join(ordinals, ", "))
+		sqlQuery.WriteString("\nORDER BY " + strings.Join(ordinals, ", "))
+	}
+	sqlQuery.WriteString(fmt.Sprintf("\nLIMIT %d", analyticsMaxRows))
+
+	return sqlQuery.String(), args, nil
+}
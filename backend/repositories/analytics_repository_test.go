@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestBuildAnalyticsSQL(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    models.AnalyticsQuery
+		wantSQL  string
+		wantArgs int
+		wantErr  bool
+	}{
+		{
+			name: "интервал, метки и дни недели с одним параметром пояса",
+			query: models.AnalyticsQuery{
+				Dataset:  models.AnalyticsExpenses,
+				Interval: models.IntervalMonth,
+				GroupBy:  []models.AnalyticsGroup{models.GroupByTag, models.GroupByWeekday},
+				Metrics:  []models.AnalyticsMetric{models.MetricSum, models.MetricCount},
+			},
+			wantSQL: "SELECT date_trunc('month', r.date AT TIME ZONE $4::text), COALESCE(t.tag, ''), " +
+				"EXTRACT(ISODOW FROM r.date AT TIME ZONE $4::text)::int::text, COALESCE(SUM(r.amount), 0), COUNT(*)\n" +
+				"FROM expenses r\n" +
+				"LEFT JOIN LATERAL unnest(r.tags) AS t(tag) ON TRUE\n" +
+				"WHERE r.user_id = $1 AND r.date >= $2 AND r.date < $3\n" +
+				"GROUP BY 1, 2, 3\n" +
+				"ORDER BY 1, 2, 3\n" +
+				"LIMIT 10000",
+			wantArgs: 4,
+		},
+		{
+			name: "получатели без пояса",
+			query: models.AnalyticsQuery{
+				Dataset: models.AnalyticsExpenses,
+				GroupBy: []models.AnalyticsGroup{models.GroupByPayee},
+				Metrics: []models.AnalyticsMetric{models.MetricAvg},
+			},
+			wantSQL: "SELECT COALESCE(p.name, ''), COALESCE(AVG(r.amount), 0)\n" +
+				"FROM expenses r\n" +
+				"LEFT JOIN payees p ON p.id = r.payee_id\n" +
+				"WHERE r.user_id = $1 AND r.date >= $2 AND r.date < $3\n" +
+				"GROUP BY 1\n" +
+				"ORDER BY 1\n" +
+				"LIMIT 10000",
+			wantArgs: 3,
+		},
+		{
+			name: "итог за период без группировок",
+			query: models.AnalyticsQuery{
+				Dataset: models.AnalyticsIncomes,
+				Metrics: []models.AnalyticsMetric{models.MetricMedian},
+			},
+			wantSQL: "SELECT COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY r.amount), 0)\n" +
+				"FROM incomes r\n" +
+				"WHERE r.user_id = $1 AND r.date >= $2 AND r.date < $3\n" +
+				"LIMIT 10000",
+			wantArgs: 3,
+		},
+		{
+			name:    "неизвестный набор данных",
+			query:   models.AnalyticsQuery{Dataset: "users", Metrics: []models.AnalyticsMetric{models.MetricSum}},
+			wantErr: true,
+		},
+		{
+			name: "группировка недоступна для набора",
+			query: models.AnalyticsQuery{
+				Dataset: models.AnalyticsIncomes,
+				GroupBy: []models.AnalyticsGroup{models.GroupByPayee},
+				Metrics: []models.AnalyticsMetric{models.MetricSum},
+			},
+			wantErr: true,
+		},
+		{
+			name: "выражение вместо группировки",
+			query: models.AnalyticsQuery{
+				Dataset: models.AnalyticsExpenses,
+				GroupBy: []models.AnalyticsGroup{"r.title; DROP TABLE expenses"},
+				Metrics: []models.AnalyticsMetric{models.MetricSum},
+			},
+			wantErr: true,
+		},
+		{
+			name: "неизвестный интервал",
+			query: models.AnalyticsQuery{
+				Dataset:  models.AnalyticsExpenses,
+				Interval: "hour",
+				Metrics:  []models.AnalyticsMetric{models.MetricSum},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный показатель",
+			query:   models.AnalyticsQuery{Dataset: models.AnalyticsExpenses, Metrics: []models.AnalyticsMetric{"max"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.StartDate, tt.query.EndDate, tt.query.Timezone = start, end, "Europe/Moscow"
+
+			sqlQuery, args, err := buildAnalyticsSQL(42, &tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получен запрос:\n%s", sqlQuery)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if sqlQuery != tt.wantSQL {
+				t.Errorf("получен запрос:\n%s\nожидался:\n%s", sqlQuery, tt.wantSQL)
+			}
+			if len(args) != tt.wantArgs {
+				t.Fatalf("получено %d параметров, ожидалось %d", len(args), tt.wantArgs)
+			}
+			if args[0] != int64(42) || args[1] != start || args[2] != end {
+				t.Errorf("параметры периода %v", args[:3])
+			}
+			if tt.wantArgs > 3 && args[3] != "Europe/Moscow" {
+				t.Errorf("параметр пояса %v, ожидался Europe/Moscow", args[3])
+			}
+		})
+	}
+}
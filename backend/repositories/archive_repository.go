@@ -0,0 +1,463 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"cz.Finance/backend/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresArchiveRepository представляет реализацию репозитория архивов на PostgreSQL
+type PostgresArchiveRepository struct {
+	db *sql.DB
+}
+
+// NewArchiveRepository создает новый экземпляр репозитория архивов
+func NewArchiveRepository(db *sql.DB) ArchiveRepository {
+	return &PostgresArchiveRepository{db: db}
+}
+
+// archiveReplaceQueries удаляют данные пользователя, которые содержит архив первой версии
+var archiveReplaceQueries = []string{
+	`DELETE FROM expenses WHERE user_id = $1`,
+	`DELETE FROM incomes WHERE user_id = $1`,
+	`DELETE FROM wishlist WHERE user_id = $1`,
+	`DELETE FROM goals WHERE user_id = $1`,
+}
+
+// archiveReplaceQueriesV2 удаляют данные из разделов, добавленных во второй версии архива.
+// Платежи по кредитам, оценки активов и псевдонимы получателей удаляются каскадно
+var archiveReplaceQueriesV2 = []string{
+	`DELETE FROM ledger_accounts WHERE user_id = $1`,
+	`DELETE FROM loans WHERE user_id = $1`,
+	`DELETE FROM assets WHERE user_id = $1`,
+	`DELETE FROM net_worth_snapshots WHERE user_id = $1`,
+	`DELETE FROM investment_transactions WHERE user_id = $1`,
+	`DELETE FROM security_prices WHERE user_id = $1`,
+	`DELETE FROM categorization_rules WHERE user_id = $1`,
+	`DELETE FROM recurring_rules WHERE user_id = $1`,
+	`DELETE FROM payees WHERE user_id = $1`,
+}
+
+// Restore восстанавливает данные из архива в одной транзакции.
+// Все записи создаются заново, поэтому идентификаторы из архива сопоставляются с новыми.
+// При replace = true существующие данные пользователя предварительно удаляются. Архив первой
+// версии не содержит разделов второй, поэтому для него эти данные сохраняются
+func (r *PostgresArchiveRepository) Restore(ctx context.Context, userID int64, archive *models.Archive, replace bool) (*models.ArchiveRestoreResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if replace {
+		queries := archiveReplaceQueries
+		if archive.Version >= 2 {
+			queries = append(append([]string{}, archiveReplaceQueriesV2...), queries...)
+		}
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Восстанавливаем настройки профиля
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users
+		SET first_name = $1, last_name = $2, monthly_limit = $3, savings_goal = $4,
+		    timezone = COALESCE(NULLIF($5, ''), timezone), updated_at = $6
+		WHERE id = $7
+	`, archive.User.FirstName, archive.User.LastName, archive.User.MonthlyLimit, archive.User.SavingsGoal, archive.User.Timezone, time.Now(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ArchiveRestoreResult{
+		IDMap: map[string]map[int64]int64{
+			"expenses":             {},
+			"incomes":              {},
+			"wishlist":             {},
+			"goals":                {},
+			"loans":                {},
+			"assets":               {},
+			"investments":          {},
+			"categorization_rules": {},
+			"payees":               {},
+			"recurring_rules":      {},
+		},
+	}
+
+	// Получатели восстанавливаются первыми, чтобы сохранить их связь с тратами
+	if err := restorePayees(ctx, tx, userID, archive.Payees, result); err != nil {
+		return nil, err
+	}
+
+	for _, expense := range archive.Expenses {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO expenses (user_id, title, amount, category, date, description, account, tags, payee_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id
+		`, userID, expense.Title, expense.Amount, expense.Category, expense.Date, expense.Description,
+			expense.Account, pq.Array(expenseTags(expense.Tags)), restoredID(result.IDMap["payees"], expense.PayeeID),
+			restoredTime(expense.CreatedAt), restoredTime(expense.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		result.IDMap["expenses"][expense.ID] = id
+		result.Expenses++
+	}
+
+	for _, income := range archive.Incomes {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO incomes (user_id, amount, source, date, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, userID, income.Amount, income.Source, income.Date, income.Description,
+			restoredTime(income.CreatedAt), restoredTime(income.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		result.IDMap["incomes"][income.ID] = id
+		result.Incomes++
+	}
+
+	for _, item := range archive.Wishlist {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO wishlist (user_id, title, price, priority, description, target_price, shared, purchased_at, expense_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id
+		`, userID, item.Title, item.Price, item.Priority, item.Description, item.TargetPrice, item.Shared, item.PurchasedAt,
+			restoredID(result.IDMap["expenses"], item.ExpenseID),
+			restoredTime(item.CreatedAt), restoredTime(item.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, allocation := range item.Allocations {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO wishlist_allocations (wishlist_id, amount, date, description, created_at)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, allocation.Amount, allocation.Date, allocation.Description, restoredTime(allocation.CreatedAt))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, price := range item.PriceHistory {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO wishlist_prices (wishlist_id, price, source, observed_at, created_at)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, price.Price, price.Source, price.ObservedAt, restoredTime(price.CreatedAt))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result.IDMap["wishlist"][item.ID] = id
+		result.Wishlist++
+	}
+
+	for _, goal := range archive.Goals {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO goals (user_id, name, target_amount, deadline, account, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`, userID, goal.Name, goal.TargetAmount, goal.Deadline, goal.Account, goal.Description,
+			restoredTime(goal.CreatedAt), restoredTime(goal.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, contribution := range goal.Contributions {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO goal_contributions (goal_id, amount, date, description, created_at)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, contribution.Amount, contribution.Date, contribution.Description, restoredTime(contribution.CreatedAt))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result.IDMap["goals"][goal.ID] = id
+		result.Goals++
+	}
+
+	if err := restoreLedgerAccounts(ctx, tx, userID, archive.LedgerAccounts, result); err != nil {
+		return nil, err
+	}
+	if err := restoreLoans(ctx, tx, userID, archive.Loans, result); err != nil {
+		return nil, err
+	}
+	if err := restoreAssets(ctx, tx, userID, archive.Assets, archive.NetWorth, result); err != nil {
+		return nil, err
+	}
+	if err := restoreInvestments(ctx, tx, userID, archive.Investments, archive.SecurityPrices, result); err != nil {
+		return nil, err
+	}
+	if err := restoreRules(ctx, tx, userID, archive.Rules, result); err != nil {
+		return nil, err
+	}
+	if err := restoreRecurringRules(ctx, tx, userID, archive.RecurringRules, result); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// restoredTime возвращает время из архива или текущее время, если оно не указано
+func restoredTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
+
+// restoredID переводит ID записи из архива в ID восстановленной записи.
+// Если запись не была восстановлена, связь сбрасывается
+func restoredID(idMap map[int64]int64, archivedID *int64) interface{} {
+	if archivedID == nil {
+		return nil
+	}
+	if id, ok := idMap[*archivedID]; ok {
+		return id
+	}
+	return nil
+}
+
+// restoreLedgerAccounts восстанавливает сопоставление счетов бухгалтерской книги.
+// Уже настроенный счет для той же категории или источника не перезаписывается
+func restoreLedgerAccounts(ctx context.Context, tx *sql.Tx, userID int64, accounts []models.LedgerAccount, result *models.ArchiveRestoreResult) error {
+	for _, account := range accounts {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO ledger_accounts (user_id, kind, key, account)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, kind, key) DO NOTHING
+		`, userID, account.Kind, account.Key, account.Account)
+		if err != nil {
+			return err
+		}
+		if restored, err := res.RowsAffected(); err == nil && restored > 0 {
+			result.LedgerAccounts++
+		}
+	}
+
+	return nil
+}
+
+// restoreLoans восстанавливает кредиты и платежи по ним со связями с восстановленными тратами
+func restoreLoans(ctx context.Context, tx *sql.Tx, userID int64, loans []models.Loan, result *models.ArchiveRestoreResult) error {
+	for _, loan := range loans {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO loans (user_id, name, lender, principal, rate, term_months, start_date, type, category, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`, userID, loan.Name, loan.Lender, loan.Principal, loan.Rate, loan.TermMonths, loan.StartDate, loan.Type,
+			loan.Category, loan.Description, restoredTime(loan.CreatedAt), restoredTime(loan.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for _, payment := range loan.Payments {
+			expenseID := restoredID(result.IDMap["expenses"], payment.ExpenseID)
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO loan_payments (loan_id, date, amount, principal, interest, early, strategy, expense_id, expense_created, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10)
+			`, id, payment.Date, payment.Amount, payment.Principal, payment.Interest, payment.Early, payment.Strategy,
+				expenseID, payment.ExpenseCreated && expenseID != nil, restoredTime(payment.CreatedAt))
+			if err != nil {
+				return err
+			}
+		}
+
+		result.IDMap["loans"][loan.ID] = id
+		result.Loans++
+	}
+
+	return nil
+}
+
+// restoreAssets восстанавливает активы с историей оценок и снимки чистой стоимости капитала
+func restoreAssets(ctx context.Context, tx *sql.Tx, userID int64, assets []models.Asset, snapshots []models.NetWorthSnapshot, result *models.ArchiveRestoreResult) error {
+	for _, asset := range assets {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO assets (user_id, name, kind, liability, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, userID, asset.Name, asset.Kind, asset.Liability, asset.Description,
+			restoredTime(asset.CreatedAt), restoredTime(asset.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for _, valuation := range asset.Valuations {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO asset_valuations (asset_id, value, date, note, created_at)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, valuation.Value, valuation.Date, valuation.Note, restoredTime(valuation.CreatedAt))
+			if err != nil {
+				return err
+			}
+		}
+
+		result.IDMap["assets"][asset.ID] = id
+		result.Assets++
+	}
+
+	for _, snapshot := range snapshots {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO net_worth_snapshots (user_id, date, assets, liabilities, net_worth)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, date) DO NOTHING
+		`, userID, snapshot.Date, snapshot.Assets, snapshot.Liabilities, snapshot.NetWorth)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreInvestments восстанавливает операции с ценными бумагами со связями
+// с восстановленными накоплениями и сохраненные цены
+func restoreInvestments(ctx context.Context, tx *sql.Tx, userID int64, transactions []models.InvestmentTransaction, prices []models.SecurityPrice, result *models.ArchiveRestoreResult) error {
+	for _, transaction := range transactions {
+		incomeID := restoredID(result.IDMap["incomes"], transaction.IncomeID)
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO investment_transactions (user_id, ticker, type, date, quantity, price, fee, amount, note, income_id, income_created, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`, userID, transaction.Ticker, transaction.Type, transaction.Date, transaction.Quantity, transaction.Price,
+			transaction.Fee, transaction.Amount, transaction.Note, incomeID, transaction.IncomeCreated && incomeID != nil,
+			restoredTime(transaction.CreatedAt)).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		result.IDMap["investments"][transaction.ID] = id
+		result.Investments++
+	}
+
+	for _, price := range prices {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO security_prices (user_id, ticker, date, price, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, ticker, date) DO NOTHING
+		`, userID, price.Ticker, price.Date, price.Price, restoredTime(price.UpdatedAt))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreRules восстанавливает правила категоризации. Правило с тем же названием,
+// что у существующего, пропускается
+func restoreRules(ctx context.Context, tx *sql.Tx, userID int64, rules []models.CategorizationRule, result *models.ArchiveRestoreResult) error {
+	for _, rule := range rules {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM categorization_rules WHERE user_id = $1 AND LOWER(name) = LOWER($2))
+		`, userID, rule.Name).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		var id int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO categorization_rules (user_id, name, priority, enabled, title_pattern, description_pattern,
+			                                  min_amount, max_amount, account, weekdays, category, tags, clean_title,
+			                                  created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			RETURNING id
+		`, userID, rule.Name, rule.Priority, rule.Enabled, rule.TitlePattern, rule.DescriptionPattern,
+			rule.MinAmount, rule.MaxAmount, rule.Account, pq.Array(ruleWeekdays(rule.Weekdays)), rule.Category,
+			pq.Array(expenseTags(rule.Tags)), rule.CleanTitle,
+			restoredTime(rule.CreatedAt), restoredTime(rule.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		result.IDMap["categorization_rules"][rule.ID] = id
+		result.Rules++
+	}
+
+	return nil
+}
+
+// restorePayees восстанавливает получателей с псевдонимами. Получатель с тем же названием
+// и уже занятые псевдонимы не дублируются: связи переводятся на существующего получателя
+func restorePayees(ctx context.Context, tx *sql.Tx, userID int64, payees []models.Payee, result *models.ArchiveRestoreResult) error {
+	for _, payee := range payees {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO payees (user_id, name, default_category, created_at, updated_at)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+			ON CONFLICT (user_id, LOWER(name)) DO UPDATE SET updated_at = payees.updated_at
+			RETURNING id
+		`, userID, payee.Name, payee.DefaultCategory, restoredTime(payee.CreatedAt), restoredTime(payee.UpdatedAt)).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for _, alias := range payee.Aliases {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO payee_aliases (user_id, alias, payee_id) VALUES ($1, $2, $3)
+				ON CONFLICT (user_id, alias) DO NOTHING
+			`, userID, alias, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.IDMap["payees"][payee.ID] = id
+		result.Payees++
+	}
+
+	return nil
+}
+
+// restoreRecurringRules восстанавливает регулярные списания. Правило с уже существующим
+// ключом сопоставления пропускается
+func restoreRecurringRules(ctx context.Context, tx *sql.Tx, userID int64, rules []models.RecurringRule, result *models.ArchiveRestoreResult) error {
+	for _, rule := range rules {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO recurring_rules (user_id, match_key, title, payee_id, category, amount, cadence, next_date, active, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (user_id, match_key) DO NOTHING
+			RETURNING id
+		`, userID, rule.MatchKey, rule.Title, restoredID(result.IDMap["payees"], rule.PayeeID), rule.Category,
+			rule.Amount, rule.Cadence, rule.NextDate, rule.Active,
+			restoredTime(rule.CreatedAt), restoredTime(rule.UpdatedAt)).Scan(&id)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		result.IDMap["recurring_rules"][rule.ID] = id
+		result.RecurringRules++
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresAssetRepository представляет реализацию репозитория активов и обязательств на PostgreSQL
+type PostgresAssetRepository struct {
+	db *sql.DB
+}
+
+// NewAssetRepository создает новый экземпляр репозитория активов и обязательств
+func NewAssetRepository(db *sql.DB) AssetRepository {
+	return &PostgresAssetRepository{db: db}
+}
+
+// assetSelectQuery выбирает активы вместе с последней оценкой стоимости
+const assetSelectQuery = `
+	SELECT a.id, a.user_id, a.name, a.kind, a.liability, COALESCE(a.description, ''), a.created_at, a.updated_at,
+	       COALESCE(v.value, 0), v.date
+	FROM assets a
+	LEFT JOIN LATERAL (
+		SELECT value, date FROM asset_valuations
+		WHERE asset_id = a.id
+		ORDER BY date DESC, id DESC
+		LIMIT 1
+	) v ON true
+`
+
+// Create создает актив и, если указана, его начальную оценку в одной транзакции
+func (r *PostgresAssetRepository) Create(ctx context.Context, asset *models.Asset, valuation *models.AssetValuation) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO assets (user_id, name, kind, liability, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING id
+	`, asset.UserID, asset.Name, asset.Kind, asset.Liability, asset.Description, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if valuation != nil {
+		valuation.AssetID = id
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO asset_valuations (asset_id, value, date, note, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, id, valuation.Value, valuation.Date, valuation.Note, time.Now()).Scan(&valuation.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает актив по его ID
+func (r *PostgresAssetRepository) GetByID(ctx context.Context, id int64) (*models.Asset, error) {
+	asset, err := scanAsset(r.db.QueryRowContext(ctx, assetSelectQuery+`WHERE a.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("актив не найден")
+		}
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+// GetByUserID получает все активы и обязательства пользователя
+func (r *PostgresAssetRepository) GetByUserID(ctx context.Context, userID int64) ([]models.Asset, error) {
+	rows, err := r.db.QueryContext(ctx, assetSelectQuery+`
+		WHERE a.user_id = $1
+		ORDER BY a.liability, a.kind, a.name, a.id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assets []models.Asset
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, *asset)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+// Update обновляет актив в базе данных
+func (r *PostgresAssetRepository) Update(ctx context.Context, asset *models.Asset) error {
+	query := `
+		UPDATE assets
+		SET name = $1, kind = $2, liability = $3, description = $4, updated_at = $5
+		WHERE id = $6 AND user_id = $7
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		asset.Name,
+		asset.Kind,
+		asset.Liability,
+		asset.Description,
+		time.Now(),
+		asset.ID,
+		asset.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("актив не найден или у вас нет прав на его изменение")
+	}
+
+	return nil
+}
+
+// Delete удаляет актив вместе с оценками
+func (r *PostgresAssetRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM assets WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("актив не найден или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// AddValuation добавляет оценку стоимости актива
+func (r *PostgresAssetRepository) AddValuation(ctx context.Context, valuation *models.AssetValuation) (int64, error) {
+	query := `
+		INSERT INTO asset_valuations (asset_id, value, date, note, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		valuation.AssetID,
+		valuation.Value,
+		valuation.Date,
+		valuation.Note,
+		time.Now(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetValuations получает оценки стоимости актива в порядке от новых к старым
+func (r *PostgresAssetRepository) GetValuations(ctx context.Context, assetID int64) ([]models.AssetValuation, error) {
+	return r.queryValuations(ctx, `
+		SELECT v.id, v.asset_id, v.value, v.date, COALESCE(v.note, ''), v.created_at
+		FROM asset_valuations v
+		WHERE v.asset_id = $1
+		ORDER BY v.date DESC, v.id DESC
+	`, assetID)
+}
+
+// GetValuationsByUserID получает оценки всех активов пользователя в хронологическом порядке
+func (r *PostgresAssetRepository) GetValuationsByUserID(ctx context.Context, userID int64) ([]models.AssetValuation, error) {
+	return r.queryValuations(ctx, `
+		SELECT v.id, v.asset_id, v.value, v.date, COALESCE(v.note, ''), v.created_at
+		FROM asset_valuations v
+		JOIN assets a ON a.id = v.asset_id
+		WHERE a.user_id = $1
+		ORDER BY v.date, v.id
+	`, userID)
+}
+
+// DeleteValuation удаляет оценку стоимости актива пользователя
+func (r *PostgresAssetRepository) DeleteValuation(ctx context.Context, id int64, assetID int64, userID int64) error {
+	query := `
+		DELETE FROM asset_valuations v
+		USING assets a
+		WHERE v.id = $1 AND v.asset_id = $2 AND a.id = v.asset_id AND a.user_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, assetID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("оценка не найдена или у вас нет прав на ее удаление")
+	}
+
+	return nil
+}
+
+// queryValuations выполняет запрос оценок стоимости и читает результат
+func (r *PostgresAssetRepository) queryValuations(ctx context.Context, query string, args ...interface{}) ([]models.AssetValuation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var valuations []models.AssetValuation
+	for rows.Next() {
+		var valuation models.AssetValuation
+		err := rows.Scan(
+			&valuation.ID,
+			&valuation.AssetID,
+			&valuation.Value,
+			&valuation.Date,
+			&valuation.Note,
+			&valuation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		valuations = append(valuations, valuation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return valuations, nil
+}
+
+// scanAsset читает актив из строки результата
+func scanAsset(row rowScanner) (*models.Asset, error) {
+	var asset models.Asset
+	var valuedAt sql.NullTime
+	err := row.Scan(
+		&asset.ID,
+		&asset.UserID,
+		&asset.Name,
+		&asset.Kind,
+		&asset.Liability,
+		&asset.Description,
+		&asset.CreatedAt,
+		&asset.UpdatedAt,
+		&asset.CurrentValue,
+		&valuedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if valuedAt.Valid {
+		asset.ValuedAt = &valuedAt.Time
+	}
+
+	return &asset, nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"cz.Finance/backend/models"
+
+	"github.com/lib/pq"
 )
 
 // PostgresExpenseRepository представляет реализацию репозитория трат на PostgreSQL
@@ -19,11 +22,18 @@ func NewExpenseRepository(db *sql.DB) ExpenseRepository {
 	return &PostgresExpenseRepository{db: db}
 }
 
+// expenseSelectQuery выбирает поля траты в порядке, ожидаемом scanExpense
+const expenseSelectQuery = `
+		SELECT id, user_id, title, amount, category, date, description, COALESCE(account, ''), tags,
+		       payee_id, household_id, created_at, updated_at
+		FROM expenses
+`
+
 // Create создает новую трату в базе данных
 func (r *PostgresExpenseRepository) Create(ctx context.Context, expense *models.Expense) (int64, error) {
 	query := `
-		INSERT INTO expenses (user_id, title, amount, category, date, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO expenses (user_id, title, amount, category, date, description, account, tags, payee_id, household_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id
 	`
 
@@ -37,6 +47,10 @@ func (r *PostgresExpenseRepository) Create(ctx context.Context, expense *models.
 		expense.Category,
 		expense.Date,
 		expense.Description,
+		expense.Account,
+		pq.Array(expenseTags(expense.Tags)),
+		expense.PayeeID,
+		expense.HouseholdID,
 		time.Now(),
 		time.Now(),
 	).Scan(&id)
@@ -50,25 +64,11 @@ func (r *PostgresExpenseRepository) Create(ctx context.Context, expense *models.
 
 // GetByID получает трату по её ID
 func (r *PostgresExpenseRepository) GetByID(ctx context.Context, id int64) (*models.Expense, error) {
-	query := `
-		SELECT id, user_id, title, amount, category, date, description, created_at, updated_at
-		FROM expenses
+	query := expenseSelectQuery + `
 		WHERE id = $1
 	`
 
-	var expense models.Expense
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&expense.ID,
-		&expense.UserID,
-		&expense.Title,
-		&expense.Amount,
-		&expense.Category,
-		&expense.Date,
-		&expense.Description,
-		&expense.CreatedAt,
-		&expense.UpdatedAt,
-	)
-
+	expense, err := scanExpense(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("трата не найдена")
@@ -76,14 +76,12 @@ func (r *PostgresExpenseRepository) GetByID(ctx context.Context, id int64) (*mod
 		return nil, err
 	}
 
-	return &expense, nil
+	return expense, nil
 }
 
 // GetByUserID получает траты пользователя с пагинацией
 func (r *PostgresExpenseRepository) GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]models.Expense, error) {
-	query := `
-		SELECT id, user_id, title, amount, category, date, description, created_at, updated_at
-		FROM expenses
+	query := expenseSelectQuery + `
 		WHERE user_id = $1
 		ORDER BY date DESC
 		LIMIT $2 OFFSET $3
@@ -97,22 +95,40 @@ func (r *PostgresExpenseRepository) GetByUserID(ctx context.Context, userID int6
 
 	var expenses []models.Expense
 	for rows.Next() {
-		var expense models.Expense
-		err := rows.Scan(
-			&expense.ID,
-			&expense.UserID,
-			&expense.Title,
-			&expense.Amount,
-			&expense.Category,
-			&expense.Date,
-			&expense.Description,
-			&expense.CreatedAt,
-			&expense.UpdatedAt,
-		)
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+// GetAllByUserID получает все траты пользователя
+func (r *PostgresExpenseRepository) GetAllByUserID(ctx context.Context, userID int64) ([]models.Expense, error) {
+	query := expenseSelectQuery + `
+		WHERE user_id = $1
+		ORDER BY date, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []models.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
 		if err != nil {
 			return nil, err
 		}
-		expenses = append(expenses, expense)
+		expenses = append(expenses, *expense)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -124,10 +140,8 @@ func (r *PostgresExpenseRepository) GetByUserID(ctx context.Context, userID int6
 
 // GetByUserIDAndPeriod получает траты пользователя за определенный период
 func (r *PostgresExpenseRepository) GetByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) ([]models.Expense, error) {
-	query := `
-		SELECT id, user_id, title, amount, category, date, description, created_at, updated_at
-		FROM expenses
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+	query := expenseSelectQuery + `
+		WHERE user_id = $1 AND date >= $2 AND date < $3
 		ORDER BY date DESC
 	`
 
@@ -139,22 +153,11 @@ func (r *PostgresExpenseRepository) GetByUserIDAndPeriod(ctx context.Context, us
 
 	var expenses []models.Expense
 	for rows.Next() {
-		var expense models.Expense
-		err := rows.Scan(
-			&expense.ID,
-			&expense.UserID,
-			&expense.Title,
-			&expense.Amount,
-			&expense.Category,
-			&expense.Date,
-			&expense.Description,
-			&expense.CreatedAt,
-			&expense.UpdatedAt,
-		)
+		expense, err := scanExpense(rows)
 		if err != nil {
 			return nil, err
 		}
-		expenses = append(expenses, expense)
+		expenses = append(expenses, *expense)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -164,12 +167,52 @@ func (r *PostgresExpenseRepository) GetByUserIDAndPeriod(ctx context.Context, us
 	return expenses, nil
 }
 
+// StreamByFilter последовательно передает траты пользователя, подходящие под фильтр, в функцию fn.
+// Записи читаются из базы по одной и не накапливаются в памяти.
+func (r *PostgresExpenseRepository) StreamByFilter(ctx context.Context, userID int64, filter *models.ExpenseFilter, fn func(expense *models.Expense) error) error {
+	query := expenseSelectQuery + `
+		WHERE user_id = $1`
+	args := []interface{}{userID}
+
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		query += fmt.Sprintf(" AND date >= $%d", len(args))
+	}
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		query += fmt.Sprintf(" AND date < $%d", len(args))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		query += fmt.Sprintf(" AND category = $%d", len(args))
+	}
+	query += " ORDER BY date, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(expense); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 // GetTotalAmountByUserIDAndPeriod получает общую сумму трат пользователя за период
 func (r *PostgresExpenseRepository) GetTotalAmountByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) (float64, error) {
 	query := `
 		SELECT COALESCE(SUM(amount), 0)
 		FROM expenses
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+		WHERE user_id = $1 AND date >= $2 AND date < $3
 	`
 
 	var totalAmount float64
@@ -186,7 +229,7 @@ func (r *PostgresExpenseRepository) GetCategorySummaryByUserIDAndPeriod(ctx cont
 	query := `
 		SELECT category, SUM(amount) as total
 		FROM expenses
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+		WHERE user_id = $1 AND date >= $2 AND date < $3
 		GROUP BY category
 		ORDER BY total DESC
 	`
@@ -215,12 +258,46 @@ func (r *PostgresExpenseRepository) GetCategorySummaryByUserIDAndPeriod(ctx cont
 	return categorySummary, nil
 }
 
+// GetMonthlyTotalsByUserIDAndPeriod получает суммы трат пользователя по месяцам за период.
+// Месяцы определяются в часовом поясе timezone, ключ результата имеет вид "2006-01"
+func (r *PostgresExpenseRepository) GetMonthlyTotalsByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time, timezone string) (map[string]float64, error) {
+	query := `
+		SELECT to_char(date_trunc('month', date AT TIME ZONE $4), 'YYYY-MM') AS month, SUM(amount)
+		FROM expenses
+		WHERE user_id = $1 AND date >= $2 AND date < $3
+		GROUP BY month
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, startDate, endDate, timezone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	monthlyTotals := make(map[string]float64)
+	for rows.Next() {
+		var month string
+		var amount float64
+		if err := rows.Scan(&month, &amount); err != nil {
+			return nil, err
+		}
+		monthlyTotals[month] = amount
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return monthlyTotals, nil
+}
+
 // Update обновляет информацию о трате
 func (r *PostgresExpenseRepository) Update(ctx context.Context, expense *models.Expense) error {
 	query := `
 		UPDATE expenses
-		SET title = $1, amount = $2, category = $3, date = $4, description = $5, updated_at = $6
-		WHERE id = $7 AND user_id = $8
+		SET title = $1, amount = $2, category = $3, date = $4, description = $5, account = $6, tags = $7,
+		    payee_id = $8, household_id = $9, updated_at = $10
+		WHERE id = $11 AND user_id = $12
 	`
 
 	result, err := r.db.ExecContext(
@@ -231,6 +308,10 @@ func (r *PostgresExpenseRepository) Update(ctx context.Context, expense *models.
 		expense.Category,
 		expense.Date,
 		expense.Description,
+		expense.Account,
+		pq.Array(expenseTags(expense.Tags)),
+		expense.PayeeID,
+		expense.HouseholdID,
 		time.Now(),
 		expense.ID,
 		expense.UserID,
@@ -272,3 +353,47 @@ func (r *PostgresExpenseRepository) Delete(ctx context.Context, id int64, userID
 
 	return nil
 }
+
+// scanExpense читает трату из строки результата
+func scanExpense(row rowScanner) (*models.Expense, error) {
+	var expense models.Expense
+	var payeeID, householdID sql.NullInt64
+	err := row.Scan(
+		&expense.ID,
+		&expense.UserID,
+		&expense.Title,
+		&expense.Amount,
+		&expense.Category,
+		&expense.Date,
+		&expense.Description,
+		&expense.Account,
+		pq.Array(&expense.Tags),
+		&payeeID,
+		&householdID,
+		&expense.CreatedAt,
+		&expense.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if payeeID.Valid {
+		expense.PayeeID = &payeeID.Int64
+	}
+	if householdID.Valid {
+		expense.HouseholdID = &householdID.Int64
+	}
+	if expense.Tags == nil {
+		expense.Tags = []string{}
+	}
+
+	return &expense, nil
+}
+
+// expenseTags заменяет отсутствующие метки пустым списком, чтобы не записывать NULL
+func expenseTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
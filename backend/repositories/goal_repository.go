@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresGoalRepository представляет реализацию репозитория целей накоплений на PostgreSQL
+type PostgresGoalRepository struct {
+	db *sql.DB
+}
+
+// NewGoalRepository создает новый экземпляр репозитория целей накоплений
+func NewGoalRepository(db *sql.DB) GoalRepository {
+	return &PostgresGoalRepository{db: db}
+}
+
+// goalSelectQuery выбирает цели вместе с накопленной суммой взносов
+const goalSelectQuery = `
+	SELECT g.id, g.user_id, g.name, g.target_amount, g.deadline, COALESCE(g.account, ''), COALESCE(g.description, ''),
+	       COALESCE((SELECT SUM(c.amount) FROM goal_contributions c WHERE c.goal_id = g.id), 0),
+	       g.created_at, g.updated_at
+	FROM goals g
+`
+
+// Create создает новую цель накоплений в базе данных
+func (r *PostgresGoalRepository) Create(ctx context.Context, goal *models.Goal) (int64, error) {
+	query := `
+		INSERT INTO goals (user_id, name, target_amount, deadline, account, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		goal.UserID,
+		goal.Name,
+		goal.TargetAmount,
+		goal.Deadline,
+		goal.Account,
+		goal.Description,
+		time.Now(),
+		time.Now(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает цель накоплений по ее ID
+func (r *PostgresGoalRepository) GetByID(ctx context.Context, id int64) (*models.Goal, error) {
+	query := goalSelectQuery + `WHERE g.id = $1`
+
+	goal, err := scanGoal(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("цель не найдена")
+		}
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// GetByUserID получает все цели накоплений пользователя
+func (r *PostgresGoalRepository) GetByUserID(ctx context.Context, userID int64) ([]models.Goal, error) {
+	query := goalSelectQuery + `
+		WHERE g.user_id = $1
+		ORDER BY g.deadline NULLS LAST, g.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []models.Goal
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, *goal)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+// Update обновляет цель накоплений в базе данных
+func (r *PostgresGoalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	query := `
+		UPDATE goals
+		SET name = $1, target_amount = $2, deadline = $3, account = $4, description = $5, updated_at = $6
+		WHERE id = $7 AND user_id = $8
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		goal.Name,
+		goal.TargetAmount,
+		goal.Deadline,
+		goal.Account,
+		goal.Description,
+		time.Now(),
+		goal.ID,
+		goal.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("цель не найдена или у вас нет прав на ее изменение")
+	}
+
+	return nil
+}
+
+// Delete удаляет цель накоплений вместе со взносами
+func (r *PostgresGoalRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	query := `DELETE FROM goals WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("цель не найдена или у вас нет прав на ее удаление")
+	}
+
+	return nil
+}
+
+// AddContribution добавляет взнос в цель накоплений
+func (r *PostgresGoalRepository) AddContribution(ctx context.Context, contribution *models.GoalContribution) (int64, error) {
+	query := `
+		INSERT INTO goal_contributions (goal_id, amount, date, description, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		contribution.GoalID,
+		contribution.Amount,
+		contribution.Date,
+		contribution.Description,
+		time.Now(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetContributions получает взносы в цель в порядке от новых к старым
+func (r *PostgresGoalRepository) GetContributions(ctx context.Context, goalID int64) ([]models.GoalContribution, error) {
+	query := `
+		SELECT id, goal_id, amount, date, COALESCE(description, ''), created_at
+		FROM goal_contributions
+		WHERE goal_id = $1
+		ORDER BY date DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contributions []models.GoalContribution
+	for rows.Next() {
+		var contribution models.GoalContribution
+		err := rows.Scan(
+			&contribution.ID,
+			&contribution.GoalID,
+			&contribution.Amount,
+			&contribution.Date,
+			&contribution.Description,
+			&contribution.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		contributions = append(contributions, contribution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contributions, nil
+}
+
+// DeleteContribution удаляет взнос, если цель принадлежит пользователю
+func (r *PostgresGoalRepository) DeleteContribution(ctx context.Context, id int64, goalID int64, userID int64) error {
+	query := `
+		DELETE FROM goal_contributions c
+		USING goals g
+		WHERE c.id = $1 AND c.goal_id = $2 AND g.id = c.goal_id AND g.user_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, goalID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("взнос не найден или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// rowScanner объединяет *sql.Row и *sql.Rows для общего кода чтения строк
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanGoal читает цель накоплений из строки результата
+func scanGoal(row rowScanner) (*models.Goal, error) {
+	var goal models.Goal
+	var deadline sql.NullTime
+	err := row.Scan(
+		&goal.ID,
+		&goal.UserID,
+		&goal.Name,
+		&goal.TargetAmount,
+		&deadline,
+		&goal.Account,
+		&goal.Description,
+		&goal.CurrentAmount,
+		&goal.CreatedAt,
+		&goal.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline.Valid {
+		goal.Deadline = &deadline.Time
+	}
+
+	return &goal, nil
+}
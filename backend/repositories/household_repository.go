@@ -0,0 +1,645 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresHouseholdRepository представляет реализацию репозитория домохозяйств на PostgreSQL
+type PostgresHouseholdRepository struct {
+	db *sql.DB
+}
+
+// NewHouseholdRepository создает новый экземпляр репозитория домохозяйств
+func NewHouseholdRepository(db *sql.DB) HouseholdRepository {
+	return &PostgresHouseholdRepository{db: db}
+}
+
+// Create создает домохозяйство и добавляет создателя владельцем в одной транзакции
+func (r *PostgresHouseholdRepository) Create(ctx context.Context, household *models.Household) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO households (name, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		RETURNING id
+	`, household.Name, household.CreatedBy, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO household_members (household_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+	`, id, household.CreatedBy, models.HouseholdRoleOwner, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает домохозяйство по его ID
+func (r *PostgresHouseholdRepository) GetByID(ctx context.Context, id int64) (*models.Household, error) {
+	query := `
+		SELECT id, name, COALESCE(created_by, 0), created_at, updated_at
+		FROM households
+		WHERE id = $1
+	`
+
+	var household models.Household
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&household.ID,
+		&household.Name,
+		&household.CreatedBy,
+		&household.CreatedAt,
+		&household.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("домохозяйство не найдено")
+		}
+		return nil, err
+	}
+
+	return &household, nil
+}
+
+// GetByUserID получает домохозяйства, в которых состоит пользователь, вместе с его ролью
+func (r *PostgresHouseholdRepository) GetByUserID(ctx context.Context, userID int64) ([]models.Household, error) {
+	query := `
+		SELECT h.id, h.name, COALESCE(h.created_by, 0), h.created_at, h.updated_at, m.role
+		FROM households h
+		JOIN household_members m ON m.household_id = h.id
+		WHERE m.user_id = $1
+		ORDER BY h.name, h.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var households []models.Household
+	for rows.Next() {
+		var household models.Household
+		err := rows.Scan(
+			&household.ID,
+			&household.Name,
+			&household.CreatedBy,
+			&household.CreatedAt,
+			&household.UpdatedAt,
+			&household.Role,
+		)
+		if err != nil {
+			return nil, err
+		}
+		households = append(households, household)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return households, nil
+}
+
+// Update обновляет название домохозяйства
+func (r *PostgresHouseholdRepository) Update(ctx context.Context, household *models.Household) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE households SET name = $1, updated_at = $2 WHERE id = $3
+	`, household.Name, time.Now(), household.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("домохозяйство не найдено")
+	}
+
+	return nil
+}
+
+// Delete удаляет домохозяйство. Общие траты и накопления остаются у авторов как личные
+func (r *PostgresHouseholdRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM households WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("домохозяйство не найдено")
+	}
+
+	return nil
+}
+
+// memberSelectQuery выбирает участников домохозяйства вместе с профилем пользователя
+const memberSelectQuery = `
+	SELECT m.household_id, m.user_id, u.username, COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), m.role, m.joined_at
+	FROM household_members m
+	JOIN users u ON u.id = m.user_id
+`
+
+// GetMember получает участника домохозяйства
+func (r *PostgresHouseholdRepository) GetMember(ctx context.Context, householdID int64, userID int64) (*models.HouseholdMember, error) {
+	query := memberSelectQuery + `WHERE m.household_id = $1 AND m.user_id = $2`
+
+	member, err := scanHouseholdMember(r.db.QueryRowContext(ctx, query, householdID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("пользователь не состоит в домохозяйстве")
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// GetMembers получает всех участников домохозяйства
+func (r *PostgresHouseholdRepository) GetMembers(ctx context.Context, householdID int64) ([]models.HouseholdMember, error) {
+	query := memberSelectQuery + `
+		WHERE m.household_id = $1
+		ORDER BY m.joined_at, m.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.HouseholdMember
+	for rows.Next() {
+		member, err := scanHouseholdMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// UpdateMemberRole изменяет роль участника домохозяйства
+func (r *PostgresHouseholdRepository) UpdateMemberRole(ctx context.Context, householdID int64, userID int64, role models.HouseholdRole) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE household_members SET role = $1 WHERE household_id = $2 AND user_id = $3
+	`, role, householdID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("пользователь не состоит в домохозяйстве")
+	}
+
+	return nil
+}
+
+// RemoveMember исключает участника из домохозяйства. Его общие записи становятся личными
+func (r *PostgresHouseholdRepository) RemoveMember(ctx context.Context, householdID int64, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM household_members WHERE household_id = $1 AND user_id = $2
+	`, householdID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("пользователь не состоит в домохозяйстве")
+	}
+
+	for _, query := range []string{
+		`UPDATE expenses SET household_id = NULL WHERE household_id = $1 AND user_id = $2`,
+		`UPDATE incomes SET household_id = NULL WHERE household_id = $1 AND user_id = $2`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, householdID, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// invitationSelectQuery выбирает приглашения вместе с названием домохозяйства
+const invitationSelectQuery = `
+	SELECT i.id, i.household_id, h.name, i.invited_by, i.invitee_id, COALESCE(i.email, ''),
+	       COALESCE(i.telegram_username, ''), i.role, i.status, i.created_at, i.responded_at
+	FROM household_invitations i
+	JOIN households h ON h.id = i.household_id
+`
+
+// CreateInvitation создает приглашение в домохозяйство
+func (r *PostgresHouseholdRepository) CreateInvitation(ctx context.Context, invitation *models.HouseholdInvitation) (int64, error) {
+	query := `
+		INSERT INTO household_invitations (household_id, invited_by, invitee_id, email, telegram_username, role, status, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		invitation.HouseholdID,
+		invitation.InvitedBy,
+		invitation.InviteeID,
+		invitation.Email,
+		invitation.TelegramUsername,
+		invitation.Role,
+		models.InvitationPending,
+		time.Now(),
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetInvitation получает приглашение по ID
+func (r *PostgresHouseholdRepository) GetInvitation(ctx context.Context, id int64) (*models.HouseholdInvitation, error) {
+	query := invitationSelectQuery + `WHERE i.id = $1`
+
+	invitation, err := scanHouseholdInvitation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("приглашение не найдено")
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetInvitationsByHousehold получает все приглашения домохозяйства
+func (r *PostgresHouseholdRepository) GetInvitationsByHousehold(ctx context.Context, householdID int64) ([]models.HouseholdInvitation, error) {
+	return r.queryInvitations(ctx, invitationSelectQuery+`
+		WHERE i.household_id = $1
+		ORDER BY i.created_at DESC, i.id DESC
+	`, householdID)
+}
+
+// GetPendingInvitationsForUser получает ожидающие ответа приглашения пользователя
+func (r *PostgresHouseholdRepository) GetPendingInvitationsForUser(ctx context.Context, userID int64) ([]models.HouseholdInvitation, error) {
+	return r.queryInvitations(ctx, invitationSelectQuery+`
+		WHERE i.invitee_id = $1 AND i.status = $2
+		ORDER BY i.created_at DESC, i.id DESC
+	`, userID, models.InvitationPending)
+}
+
+// HasPendingInvitation проверяет, есть ли у пользователя ожидающее приглашение в домохозяйство
+func (r *PostgresHouseholdRepository) HasPendingInvitation(ctx context.Context, householdID int64, userID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+		    SELECT 1 FROM household_invitations
+		    WHERE household_id = $1 AND invitee_id = $2 AND status = $3
+		)
+	`, householdID, userID, models.InvitationPending).Scan(&exists)
+	return exists, err
+}
+
+// RespondToInvitation меняет статус ожидающего приглашения. При принятии приглашенный
+// добавляется в участники в той же транзакции
+func (r *PostgresHouseholdRepository) RespondToInvitation(ctx context.Context, invitation *models.HouseholdInvitation, status models.HouseholdInvitationStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE household_invitations
+		SET status = $1, responded_at = $2
+		WHERE id = $3 AND status = $4
+	`, status, time.Now(), invitation.ID, models.InvitationPending)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("приглашение уже обработано")
+	}
+
+	if status == models.InvitationAccepted {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO household_members (household_id, user_id, role, joined_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (household_id, user_id) DO NOTHING
+		`, invitation.HouseholdID, invitation.InviteeID, invitation.Role, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetBudgets получает бюджеты домохозяйства по категориям
+func (r *PostgresHouseholdRepository) GetBudgets(ctx context.Context, householdID int64) (map[models.ExpenseCategory]float64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT category, amount FROM household_budgets WHERE household_id = $1
+	`, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := make(map[models.ExpenseCategory]float64)
+	for rows.Next() {
+		var category models.ExpenseCategory
+		var amount float64
+		if err := rows.Scan(&category, &amount); err != nil {
+			return nil, err
+		}
+		budgets[category] = amount
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+// SetBudget устанавливает бюджет домохозяйства по категории. Нулевая сумма удаляет бюджет
+func (r *PostgresHouseholdRepository) SetBudget(ctx context.Context, householdID int64, category models.ExpenseCategory, amount float64) error {
+	if amount == 0 {
+		_, err := r.db.ExecContext(ctx, `
+			DELETE FROM household_budgets WHERE household_id = $1 AND category = $2
+		`, householdID, category)
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO household_budgets (household_id, category, amount)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (household_id, category) DO UPDATE SET amount = EXCLUDED.amount
+	`, householdID, category, amount)
+	return err
+}
+
+// GetCategorySummary получает сводку общих трат домохозяйства по категориям за период
+func (r *PostgresHouseholdRepository) GetCategorySummary(ctx context.Context, householdID int64, startDate, endDate time.Time) (map[string]float64, error) {
+	return r.querySummary(ctx, `
+		SELECT category, SUM(amount)
+		FROM expenses
+		WHERE household_id = $1 AND date >= $2 AND date < $3
+		GROUP BY category
+	`, householdID, startDate, endDate)
+}
+
+// GetSourceSummary получает сводку общих накоплений домохозяйства по источникам за период
+func (r *PostgresHouseholdRepository) GetSourceSummary(ctx context.Context, householdID int64, startDate, endDate time.Time) (map[string]float64, error) {
+	return r.querySummary(ctx, `
+		SELECT source, SUM(amount)
+		FROM incomes
+		WHERE household_id = $1 AND date >= $2 AND date < $3
+		GROUP BY source
+	`, householdID, startDate, endDate)
+}
+
+// GetMemberTotals получает вклад каждого участника в общие траты и накопления за период
+func (r *PostgresHouseholdRepository) GetMemberTotals(ctx context.Context, householdID int64, startDate, endDate time.Time) ([]models.HouseholdMemberTotals, error) {
+	query := `
+		SELECT m.user_id, u.username,
+		       COALESCE((SELECT SUM(e.amount) FROM expenses e
+		                 WHERE e.household_id = m.household_id AND e.user_id = m.user_id
+		                   AND e.date >= $2 AND e.date < $3), 0),
+		       COALESCE((SELECT SUM(i.amount) FROM incomes i
+		                 WHERE i.household_id = m.household_id AND i.user_id = m.user_id
+		                   AND i.date >= $2 AND i.date < $3), 0)
+		FROM household_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.household_id = $1
+		ORDER BY m.joined_at, m.user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, householdID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []models.HouseholdMemberTotals
+	for rows.Next() {
+		var total models.HouseholdMemberTotals
+		if err := rows.Scan(&total.UserID, &total.Username, &total.Expenses, &total.Incomes); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// GetExpenses получает общие траты домохозяйства с пагинацией
+func (r *PostgresHouseholdRepository) GetExpenses(ctx context.Context, householdID int64, limit, offset int) ([]models.Expense, error) {
+	query := expenseSelectQuery + `
+		WHERE household_id = $1
+		ORDER BY date DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, householdID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []models.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+// GetIncomes получает общие накопления домохозяйства с пагинацией
+func (r *PostgresHouseholdRepository) GetIncomes(ctx context.Context, householdID int64, limit, offset int) ([]models.Income, error) {
+	query := incomeSelectQuery + `
+		WHERE household_id = $1
+		ORDER BY date DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, householdID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var incomes []models.Income
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, *income)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return incomes, nil
+}
+
+// queryInvitations выполняет запрос приглашений и читает результат
+func (r *PostgresHouseholdRepository) queryInvitations(ctx context.Context, query string, args ...interface{}) ([]models.HouseholdInvitation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []models.HouseholdInvitation
+	for rows.Next() {
+		invitation, err := scanHouseholdInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// querySummary выполняет запрос сводки вида «ключ — сумма»
+func (r *PostgresHouseholdRepository) querySummary(ctx context.Context, query string, args ...interface{}) (map[string]float64, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := make(map[string]float64)
+	for rows.Next() {
+		var key string
+		var amount float64
+		if err := rows.Scan(&key, &amount); err != nil {
+			return nil, err
+		}
+		summary[key] = amount
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// scanHouseholdMember читает участника домохозяйства из строки результата
+func scanHouseholdMember(row rowScanner) (*models.HouseholdMember, error) {
+	var member models.HouseholdMember
+	err := row.Scan(
+		&member.HouseholdID,
+		&member.UserID,
+		&member.Username,
+		&member.FirstName,
+		&member.LastName,
+		&member.Role,
+		&member.JoinedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// scanHouseholdInvitation читает приглашение из строки результата
+func scanHouseholdInvitation(row rowScanner) (*models.HouseholdInvitation, error) {
+	var invitation models.HouseholdInvitation
+	var respondedAt sql.NullTime
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.HouseholdID,
+		&invitation.HouseholdName,
+		&invitation.InvitedBy,
+		&invitation.InviteeID,
+		&invitation.Email,
+		&invitation.TelegramUsername,
+		&invitation.Role,
+		&invitation.Status,
+		&invitation.CreatedAt,
+		&respondedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if respondedAt.Valid {
+		invitation.RespondedAt = &respondedAt.Time
+	}
+
+	return &invitation, nil
+}
@@ -0,0 +1,378 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresImportRepository представляет реализацию репозитория импортов на PostgreSQL
+type PostgresImportRepository struct {
+	db *sql.DB
+}
+
+// NewImportRepository создает новый экземпляр репозитория импортов
+func NewImportRepository(db *sql.DB) ImportRepository {
+	return &PostgresImportRepository{db: db}
+}
+
+// Create сохраняет импорт вместе с подготовленными строками в одной транзакции
+func (r *PostgresImportRepository) Create(ctx context.Context, imp *models.Import, rows []models.ImportRow) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO imports (user_id, format, file_name, account, status, total_rows, duplicate_rows, committed_rows, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	var id int64
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		imp.UserID,
+		imp.Format,
+		imp.FileName,
+		imp.Account,
+		imp.Status,
+		imp.TotalRows,
+		imp.DuplicateRows,
+		imp.CommittedRows,
+		time.Now(),
+		time.Now(),
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowQuery := `
+		INSERT INTO import_rows (import_id, external_id, kind, title, amount, category, source, date, description, tags, rule_id, payee_id, duplicate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	for _, row := range rows {
+		_, err = tx.ExecContext(
+			ctx,
+			rowQuery,
+			id,
+			row.ExternalID,
+			row.Kind,
+			row.Title,
+			row.Amount,
+			row.Category,
+			row.Source,
+			row.Date,
+			row.Description,
+			pq.Array(expenseTags(row.Tags)),
+			row.RuleID,
+			row.PayeeID,
+			row.Duplicate,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает импорт по его ID
+func (r *PostgresImportRepository) GetByID(ctx context.Context, id int64) (*models.Import, error) {
+	query := `
+		SELECT id, user_id, format, COALESCE(file_name, ''), COALESCE(account, ''), status,
+		       total_rows, duplicate_rows, committed_rows, created_at, updated_at
+		FROM imports
+		WHERE id = $1
+	`
+
+	var imp models.Import
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&imp.ID,
+		&imp.UserID,
+		&imp.Format,
+		&imp.FileName,
+		&imp.Account,
+		&imp.Status,
+		&imp.TotalRows,
+		&imp.DuplicateRows,
+		&imp.CommittedRows,
+		&imp.CreatedAt,
+		&imp.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("импорт не найден")
+		}
+		return nil, err
+	}
+
+	return &imp, nil
+}
+
+// GetByUserID получает все импорты пользователя
+func (r *PostgresImportRepository) GetByUserID(ctx context.Context, userID int64) ([]models.Import, error) {
+	query := `
+		SELECT id, user_id, format, COALESCE(file_name, ''), COALESCE(account, ''), status,
+		       total_rows, duplicate_rows, committed_rows, created_at, updated_at
+		FROM imports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var imports []models.Import
+	for rows.Next() {
+		var imp models.Import
+		err := rows.Scan(
+			&imp.ID,
+			&imp.UserID,
+			&imp.Format,
+			&imp.FileName,
+			&imp.Account,
+			&imp.Status,
+			&imp.TotalRows,
+			&imp.DuplicateRows,
+			&imp.CommittedRows,
+			&imp.CreatedAt,
+			&imp.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// GetRows получает строки импорта
+func (r *PostgresImportRepository) GetRows(ctx context.Context, importID int64) ([]models.ImportRow, error) {
+	query := `
+		SELECT id, import_id, external_id, kind, title, amount, COALESCE(category, ''), COALESCE(source, ''),
+		       date, COALESCE(description, ''), tags, rule_id, payee_id, duplicate
+		FROM import_rows
+		WHERE import_id = $1
+		ORDER BY date, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, importID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var importRows []models.ImportRow
+	for rows.Next() {
+		var row models.ImportRow
+		var ruleID, payeeID sql.NullInt64
+		err := rows.Scan(
+			&row.ID,
+			&row.ImportID,
+			&row.ExternalID,
+			&row.Kind,
+			&row.Title,
+			&row.Amount,
+			&row.Category,
+			&row.Source,
+			&row.Date,
+			&row.Description,
+			pq.Array(&row.Tags),
+			&ruleID,
+			&payeeID,
+			&row.Duplicate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ruleID.Valid {
+			row.RuleID = &ruleID.Int64
+		}
+		if payeeID.Valid {
+			row.PayeeID = &payeeID.Int64
+		}
+		importRows = append(importRows, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return importRows, nil
+}
+
+// GetExistingExternalIDs возвращает внешние идентификаторы, которые уже есть среди трат и накоплений пользователя
+func (r *PostgresImportRepository) GetExistingExternalIDs(ctx context.Context, userID int64, externalIDs []string) (map[string]bool, error) {
+	query := `
+		SELECT external_id FROM expenses WHERE user_id = $1 AND external_id = ANY($2)
+		UNION
+		SELECT external_id FROM incomes WHERE user_id = $1 AND external_id = ANY($2)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(externalIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return nil, err
+		}
+		existing[externalID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Commit создает траты и накопления из строк импорта в одной транзакции.
+// Строки с уже существующим внешним идентификатором пропускаются, поэтому повторный импорт идемпотентен.
+func (r *PostgresImportRepository) Commit(ctx context.Context, id int64, userID int64) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Блокируем импорт, чтобы исключить параллельное подтверждение
+	var status models.ImportStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM imports WHERE id = $1 AND user_id = $2 FOR UPDATE`, id, userID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("импорт не найден")
+		}
+		return 0, err
+	}
+	if status != models.ImportStatusPreview {
+		return 0, errors.New("импорт уже подтвержден или отменен")
+	}
+
+	now := time.Now()
+
+	expenseResult, err := tx.ExecContext(ctx, `
+		INSERT INTO expenses (user_id, title, amount, category, date, description, account, tags, payee_id, import_id, external_id, created_at, updated_at)
+		SELECT $1, r.title, r.amount, r.category, r.date, r.description, i.account, r.tags, r.payee_id, r.import_id, r.external_id, $3, $3
+		FROM import_rows r
+		JOIN imports i ON i.id = r.import_id
+		WHERE r.import_id = $2 AND r.kind = 'expense' AND NOT r.duplicate
+		ON CONFLICT (user_id, external_id) WHERE external_id IS NOT NULL DO NOTHING
+	`, userID, id, now)
+	if err != nil {
+		return 0, err
+	}
+
+	incomeResult, err := tx.ExecContext(ctx, `
+		INSERT INTO incomes (user_id, amount, source, date, description, import_id, external_id, created_at, updated_at)
+		SELECT $1, amount, source, date, CASE WHEN description = '' THEN title ELSE title || ': ' || description END,
+		       import_id, external_id, $3, $3
+		FROM import_rows
+		WHERE import_id = $2 AND kind = 'income' AND NOT duplicate
+		ON CONFLICT (user_id, external_id) WHERE external_id IS NOT NULL DO NOTHING
+	`, userID, id, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expensesCreated, err := expenseResult.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	incomesCreated, err := incomeResult.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	committed := int(expensesCreated + incomesCreated)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE imports SET status = $1, committed_rows = $2, updated_at = $3 WHERE id = $4
+	`, models.ImportStatusCommitted, committed, now, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return committed, nil
+}
+
+// Rollback удаляет все траты и накопления, созданные импортом
+func (r *PostgresImportRepository) Rollback(ctx context.Context, id int64, userID int64) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var status models.ImportStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM imports WHERE id = $1 AND user_id = $2 FOR UPDATE`, id, userID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("импорт не найден")
+		}
+		return 0, err
+	}
+	if status == models.ImportStatusRolledBack {
+		return 0, errors.New("импорт уже отменен")
+	}
+
+	expenseResult, err := tx.ExecContext(ctx, `DELETE FROM expenses WHERE import_id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return 0, err
+	}
+	incomeResult, err := tx.ExecContext(ctx, `DELETE FROM incomes WHERE import_id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	expensesDeleted, err := expenseResult.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	incomesDeleted, err := incomeResult.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE imports SET status = $1, updated_at = $2 WHERE id = $3
+	`, models.ImportStatusRolledBack, time.Now(), id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(expensesDeleted + incomesDeleted), nil
+}
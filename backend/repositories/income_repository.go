@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"cz.Finance/backend/models"
@@ -19,11 +20,17 @@ func NewIncomeRepository(db *sql.DB) IncomeRepository {
 	return &PostgresIncomeRepository{db: db}
 }
 
+// incomeSelectQuery выбирает поля накопления в порядке, ожидаемом scanIncome
+const incomeSelectQuery = `
+		SELECT id, user_id, amount, source, date, description, household_id, created_at, updated_at
+		FROM incomes
+`
+
 // Create создает новое накопление в базе данных
 func (r *PostgresIncomeRepository) Create(ctx context.Context, income *models.Income) (int64, error) {
 	query := `
-		INSERT INTO incomes (user_id, amount, source, date, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO incomes (user_id, amount, source, date, description, household_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
@@ -36,6 +43,7 @@ func (r *PostgresIncomeRepository) Create(ctx context.Context, income *models.In
 		income.Source,
 		income.Date,
 		income.Description,
+		income.HouseholdID,
 		time.Now(),
 		time.Now(),
 	).Scan(&id)
@@ -49,24 +57,11 @@ func (r *PostgresIncomeRepository) Create(ctx context.Context, income *models.In
 
 // GetByID получает накопление по его ID
 func (r *PostgresIncomeRepository) GetByID(ctx context.Context, id int64) (*models.Income, error) {
-	query := `
-		SELECT id, user_id, amount, source, date, description, created_at, updated_at
-		FROM incomes
+	query := incomeSelectQuery + `
 		WHERE id = $1
 	`
 
-	var income models.Income
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&income.ID,
-		&income.UserID,
-		&income.Amount,
-		&income.Source,
-		&income.Date,
-		&income.Description,
-		&income.CreatedAt,
-		&income.UpdatedAt,
-	)
-
+	income, err := scanIncome(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("накопление не найдено")
@@ -74,14 +69,12 @@ func (r *PostgresIncomeRepository) GetByID(ctx context.Context, id int64) (*mode
 		return nil, err
 	}
 
-	return &income, nil
+	return income, nil
 }
 
 // GetByUserID получает накопления пользователя с пагинацией
 func (r *PostgresIncomeRepository) GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]models.Income, error) {
-	query := `
-		SELECT id, user_id, amount, source, date, description, created_at, updated_at
-		FROM incomes
+	query := incomeSelectQuery + `
 		WHERE user_id = $1
 		ORDER BY date DESC
 		LIMIT $2 OFFSET $3
@@ -95,21 +88,40 @@ func (r *PostgresIncomeRepository) GetByUserID(ctx context.Context, userID int64
 
 	var incomes []models.Income
 	for rows.Next() {
-		var income models.Income
-		err := rows.Scan(
-			&income.ID,
-			&income.UserID,
-			&income.Amount,
-			&income.Source,
-			&income.Date,
-			&income.Description,
-			&income.CreatedAt,
-			&income.UpdatedAt,
-		)
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, *income)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return incomes, nil
+}
+
+// GetAllByUserID получает все накопления пользователя
+func (r *PostgresIncomeRepository) GetAllByUserID(ctx context.Context, userID int64) ([]models.Income, error) {
+	query := incomeSelectQuery + `
+		WHERE user_id = $1
+		ORDER BY date, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var incomes []models.Income
+	for rows.Next() {
+		income, err := scanIncome(rows)
 		if err != nil {
 			return nil, err
 		}
-		incomes = append(incomes, income)
+		incomes = append(incomes, *income)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -121,10 +133,8 @@ func (r *PostgresIncomeRepository) GetByUserID(ctx context.Context, userID int64
 
 // GetByUserIDAndPeriod получает накопления пользователя за определенный период
 func (r *PostgresIncomeRepository) GetByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) ([]models.Income, error) {
-	query := `
-		SELECT id, user_id, amount, source, date, description, created_at, updated_at
-		FROM incomes
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+	query := incomeSelectQuery + `
+		WHERE user_id = $1 AND date >= $2 AND date < $3
 		ORDER BY date DESC
 	`
 
@@ -136,21 +146,11 @@ func (r *PostgresIncomeRepository) GetByUserIDAndPeriod(ctx context.Context, use
 
 	var incomes []models.Income
 	for rows.Next() {
-		var income models.Income
-		err := rows.Scan(
-			&income.ID,
-			&income.UserID,
-			&income.Amount,
-			&income.Source,
-			&income.Date,
-			&income.Description,
-			&income.CreatedAt,
-			&income.UpdatedAt,
-		)
+		income, err := scanIncome(rows)
 		if err != nil {
 			return nil, err
 		}
-		incomes = append(incomes, income)
+		incomes = append(incomes, *income)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -160,12 +160,52 @@ func (r *PostgresIncomeRepository) GetByUserIDAndPeriod(ctx context.Context, use
 	return incomes, nil
 }
 
+// StreamByFilter последовательно передает накопления пользователя, подходящие под фильтр, в функцию fn.
+// Записи читаются из базы по одной и не накапливаются в памяти.
+func (r *PostgresIncomeRepository) StreamByFilter(ctx context.Context, userID int64, filter *models.IncomeFilter, fn func(income *models.Income) error) error {
+	query := incomeSelectQuery + `
+		WHERE user_id = $1`
+	args := []interface{}{userID}
+
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		query += fmt.Sprintf(" AND date >= $%d", len(args))
+	}
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		query += fmt.Sprintf(" AND date < $%d", len(args))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		query += fmt.Sprintf(" AND source = $%d", len(args))
+	}
+	query += " ORDER BY date, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(income); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 // GetTotalAmountByUserIDAndPeriod получает общую сумму накоплений пользователя за период
 func (r *PostgresIncomeRepository) GetTotalAmountByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) (float64, error) {
 	query := `
 		SELECT COALESCE(SUM(amount), 0)
 		FROM incomes
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+		WHERE user_id = $1 AND date >= $2 AND date < $3
 	`
 
 	var totalAmount float64
@@ -182,7 +222,7 @@ func (r *PostgresIncomeRepository) GetSourceSummaryByUserIDAndPeriod(ctx context
 	query := `
 		SELECT source, SUM(amount) as total
 		FROM incomes
-		WHERE user_id = $1 AND date >= $2 AND date <= $3
+		WHERE user_id = $1 AND date >= $2 AND date < $3
 		GROUP BY source
 		ORDER BY total DESC
 	`
@@ -211,12 +251,45 @@ func (r *PostgresIncomeRepository) GetSourceSummaryByUserIDAndPeriod(ctx context
 	return sourceSummary, nil
 }
 
+// GetMonthlyTotalsByUserIDAndPeriod получает суммы накоплений пользователя по месяцам за период.
+// Месяцы определяются в часовом поясе timezone, ключ результата имеет вид "2006-01"
+func (r *PostgresIncomeRepository) GetMonthlyTotalsByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time, timezone string) (map[string]float64, error) {
+	query := `
+		SELECT to_char(date_trunc('month', date AT TIME ZONE $4), 'YYYY-MM') AS month, SUM(amount)
+		FROM incomes
+		WHERE user_id = $1 AND date >= $2 AND date < $3
+		GROUP BY month
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, startDate, endDate, timezone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	monthlyTotals := make(map[string]float64)
+	for rows.Next() {
+		var month string
+		var amount float64
+		if err := rows.Scan(&month, &amount); err != nil {
+			return nil, err
+		}
+		monthlyTotals[month] = amount
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return monthlyTotals, nil
+}
+
 // Update обновляет информацию о накоплении
 func (r *PostgresIncomeRepository) Update(ctx context.Context, income *models.Income) error {
 	query := `
 		UPDATE incomes
-		SET amount = $1, source = $2, date = $3, description = $4, updated_at = $5
-		WHERE id = $6 AND user_id = $7
+		SET amount = $1, source = $2, date = $3, description = $4, household_id = $5, updated_at = $6
+		WHERE id = $7 AND user_id = $8
 	`
 
 	result, err := r.db.ExecContext(
@@ -226,6 +299,7 @@ func (r *PostgresIncomeRepository) Update(ctx context.Context, income *models.In
 		income.Source,
 		income.Date,
 		income.Description,
+		income.HouseholdID,
 		time.Now(),
 		income.ID,
 		income.UserID,
@@ -267,3 +341,29 @@ func (r *PostgresIncomeRepository) Delete(ctx context.Context, id int64, userID
 
 	return nil
 }
+
+// scanIncome читает накопление из строки результата
+func scanIncome(row rowScanner) (*models.Income, error) {
+	var income models.Income
+	var householdID sql.NullInt64
+	err := row.Scan(
+		&income.ID,
+		&income.UserID,
+		&income.Amount,
+		&income.Source,
+		&income.Date,
+		&income.Description,
+		&householdID,
+		&income.CreatedAt,
+		&income.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if householdID.Valid {
+		income.HouseholdID = &householdID.Int64
+	}
+
+	return &income, nil
+}
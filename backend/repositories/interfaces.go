@@ -23,9 +23,12 @@ type ExpenseRepository interface {
 	Create(ctx context.Context, expense *models.Expense) (int64, error)
 	GetByID(ctx context.Context, id int64) (*models.Expense, error)
 	GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]models.Expense, error)
+	GetAllByUserID(ctx context.Context, userID int64) ([]models.Expense, error)
 	GetByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) ([]models.Expense, error)
+	StreamByFilter(ctx context.Context, userID int64, filter *models.ExpenseFilter, fn func(expense *models.Expense) error) error
 	GetTotalAmountByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) (float64, error)
 	GetCategorySummaryByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) (map[string]float64, error)
+	GetMonthlyTotalsByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time, timezone string) (map[string]float64, error)
 	Update(ctx context.Context, expense *models.Expense) error
 	Delete(ctx context.Context, id int64, userID int64) error
 }
@@ -35,9 +38,12 @@ type IncomeRepository interface {
 	Create(ctx context.Context, income *models.Income) (int64, error)
 	GetByID(ctx context.Context, id int64) (*models.Income, error)
 	GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]models.Income, error)
+	GetAllByUserID(ctx context.Context, userID int64) ([]models.Income, error)
 	GetByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) ([]models.Income, error)
+	StreamByFilter(ctx context.Context, userID int64, filter *models.IncomeFilter, fn func(income *models.Income) error) error
 	GetTotalAmountByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) (float64, error)
 	GetSourceSummaryByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) (map[string]float64, error)
+	GetMonthlyTotalsByUserIDAndPeriod(ctx context.Context, userID int64, startDate, endDate time.Time, timezone string) (map[string]float64, error)
 	Update(ctx context.Context, income *models.Income) error
 	Delete(ctx context.Context, id int64, userID int64) error
 }
@@ -49,6 +55,23 @@ type WishlistRepository interface {
 	GetByUserID(ctx context.Context, userID int64) ([]models.WishlistItem, error)
 	Update(ctx context.Context, item *models.WishlistItem) error
 	Delete(ctx context.Context, id int64, userID int64) error
+	AddAllocation(ctx context.Context, allocation *models.WishlistAllocation) (int64, error)
+	GetAllocations(ctx context.Context, wishlistID int64) ([]models.WishlistAllocation, error)
+	DeleteAllocation(ctx context.Context, id int64, wishlistID int64, userID int64) error
+	Purchase(ctx context.Context, item *models.WishlistItem, expense *models.Expense) (int64, error)
+	AddPrice(ctx context.Context, price *models.WishlistPrice) (int64, error)
+	GetPrices(ctx context.Context, wishlistID int64) ([]models.WishlistPrice, error)
+}
+
+// WishlistShareRepository интерфейс для работы с публичными ссылками на список желаний в базе данных
+type WishlistShareRepository interface {
+	GetByUserID(ctx context.Context, userID int64) (*models.WishlistShare, error)
+	GetByToken(ctx context.Context, token string) (*models.WishlistShare, error)
+	Save(ctx context.Context, share *models.WishlistShare) error
+	Delete(ctx context.Context, userID int64) error
+	GetSharedItems(ctx context.Context, userID int64) ([]models.PublicWishlistItem, error)
+	Reserve(ctx context.Context, ownerID int64, reservation *models.WishlistReservation) error
+	CancelReservation(ctx context.Context, ownerID int64, wishlistID int64, cancelToken string) error
 }
 
 // TelegramUserRepository интерфейс для работы с Telegram пользователями
@@ -56,5 +79,174 @@ type TelegramUserRepository interface {
 	Create(ctx context.Context, telegramUser *models.TelegramUser) (int64, error)
 	GetByTelegramID(ctx context.Context, telegramID int64) (*models.TelegramUser, error)
 	GetByUserID(ctx context.Context, userID int64) (*models.TelegramUser, error)
+	GetByUsername(ctx context.Context, username string) (*models.TelegramUser, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// ImportRepository интерфейс для работы с импортами банковских выписок
+type ImportRepository interface {
+	Create(ctx context.Context, imp *models.Import, rows []models.ImportRow) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.Import, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.Import, error)
+	GetRows(ctx context.Context, importID int64) ([]models.ImportRow, error)
+	GetExistingExternalIDs(ctx context.Context, userID int64, externalIDs []string) (map[string]bool, error)
+	Commit(ctx context.Context, id int64, userID int64) (int, error)
+	Rollback(ctx context.Context, id int64, userID int64) (int, error)
+}
+
+// ArchiveRepository интерфейс для восстановления данных пользователя из архива
+type ArchiveRepository interface {
+	Restore(ctx context.Context, userID int64, archive *models.Archive, replace bool) (*models.ArchiveRestoreResult, error)
+}
+
+// LedgerRepository интерфейс для работы с сопоставлением счетов бухгалтерской книги
+type LedgerRepository interface {
+	GetAccounts(ctx context.Context, userID int64) ([]models.LedgerAccount, error)
+	SaveAccounts(ctx context.Context, userID int64, accounts []models.LedgerAccount) error
+}
+
+// GoalRepository интерфейс для работы с целями накоплений в базе данных
+type GoalRepository interface {
+	Create(ctx context.Context, goal *models.Goal) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.Goal, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.Goal, error)
+	Update(ctx context.Context, goal *models.Goal) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	AddContribution(ctx context.Context, contribution *models.GoalContribution) (int64, error)
+	GetContributions(ctx context.Context, goalID int64) ([]models.GoalContribution, error)
+	DeleteContribution(ctx context.Context, id int64, goalID int64, userID int64) error
+}
+
+// HouseholdRepository интерфейс для работы с домохозяйствами, их участниками, приглашениями и бюджетами
+type HouseholdRepository interface {
+	Create(ctx context.Context, household *models.Household) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.Household, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.Household, error)
+	Update(ctx context.Context, household *models.Household) error
 	Delete(ctx context.Context, id int64) error
+	GetMember(ctx context.Context, householdID int64, userID int64) (*models.HouseholdMember, error)
+	GetMembers(ctx context.Context, householdID int64) ([]models.HouseholdMember, error)
+	UpdateMemberRole(ctx context.Context, householdID int64, userID int64, role models.HouseholdRole) error
+	RemoveMember(ctx context.Context, householdID int64, userID int64) error
+	CreateInvitation(ctx context.Context, invitation *models.HouseholdInvitation) (int64, error)
+	GetInvitation(ctx context.Context, id int64) (*models.HouseholdInvitation, error)
+	GetInvitationsByHousehold(ctx context.Context, householdID int64) ([]models.HouseholdInvitation, error)
+	GetPendingInvitationsForUser(ctx context.Context, userID int64) ([]models.HouseholdInvitation, error)
+	HasPendingInvitation(ctx context.Context, householdID int64, userID int64) (bool, error)
+	RespondToInvitation(ctx context.Context, invitation *models.HouseholdInvitation, status models.HouseholdInvitationStatus) error
+	GetBudgets(ctx context.Context, householdID int64) (map[models.ExpenseCategory]float64, error)
+	SetBudget(ctx context.Context, householdID int64, category models.ExpenseCategory, amount float64) error
+	GetCategorySummary(ctx context.Context, householdID int64, startDate, endDate time.Time) (map[string]float64, error)
+	GetSourceSummary(ctx context.Context, householdID int64, startDate, endDate time.Time) (map[string]float64, error)
+	GetMemberTotals(ctx context.Context, householdID int64, startDate, endDate time.Time) ([]models.HouseholdMemberTotals, error)
+	GetExpenses(ctx context.Context, householdID int64, limit, offset int) ([]models.Expense, error)
+	GetIncomes(ctx context.Context, householdID int64, limit, offset int) ([]models.Income, error)
+}
+
+// SplitRepository интерфейс для работы с группами разделения общих трат, их тратами и погашениями долгов
+type SplitRepository interface {
+	CreateGroup(ctx context.Context, group *models.SplitGroup) (int64, error)
+	GetGroup(ctx context.Context, id int64) (*models.SplitGroup, error)
+	GetGroupsByUserID(ctx context.Context, userID int64) ([]models.SplitGroup, error)
+	DeleteGroup(ctx context.Context, id int64) error
+	AddParticipant(ctx context.Context, participant *models.SplitParticipant) (int64, error)
+	GetParticipants(ctx context.Context, groupID int64) ([]models.SplitParticipant, error)
+	RemoveParticipant(ctx context.Context, groupID int64, participantID int64) error
+	CreateExpense(ctx context.Context, expense *models.SplitExpense) (int64, error)
+	GetExpense(ctx context.Context, id int64) (*models.SplitExpense, error)
+	GetExpenses(ctx context.Context, groupID int64) ([]models.SplitExpense, error)
+	DeleteExpense(ctx context.Context, id int64, groupID int64) error
+	CreateSettlement(ctx context.Context, settlement *models.SplitSettlement, expense *models.Expense, income *models.Income) (int64, error)
+	GetSettlement(ctx context.Context, id int64) (*models.SplitSettlement, error)
+	GetSettlements(ctx context.Context, groupID int64) ([]models.SplitSettlement, error)
+	DeleteSettlement(ctx context.Context, settlement *models.SplitSettlement) error
+}
+
+// LoanRepository интерфейс для работы с кредитами и платежами по ним в базе данных
+type LoanRepository interface {
+	Create(ctx context.Context, loan *models.Loan) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.Loan, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.Loan, error)
+	Update(ctx context.Context, loan *models.Loan) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	AddPayment(ctx context.Context, payment *models.LoanPayment, expense *models.Expense) (int64, error)
+	GetPayments(ctx context.Context, loanID int64) ([]models.LoanPayment, error)
+	DeletePayment(ctx context.Context, payment *models.LoanPayment) error
+}
+
+// AssetRepository интерфейс для работы с активами, обязательствами и их оценками в базе данных
+type AssetRepository interface {
+	Create(ctx context.Context, asset *models.Asset, valuation *models.AssetValuation) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.Asset, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.Asset, error)
+	Update(ctx context.Context, asset *models.Asset) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	AddValuation(ctx context.Context, valuation *models.AssetValuation) (int64, error)
+	GetValuations(ctx context.Context, assetID int64) ([]models.AssetValuation, error)
+	GetValuationsByUserID(ctx context.Context, userID int64) ([]models.AssetValuation, error)
+	DeleteValuation(ctx context.Context, id int64, assetID int64, userID int64) error
+}
+
+// NetWorthRepository интерфейс для работы со снимками чистой стоимости капитала в базе данных
+type NetWorthRepository interface {
+	SaveSnapshot(ctx context.Context, snapshot *models.NetWorthSnapshot) error
+	GetSnapshots(ctx context.Context, userID int64, from time.Time) ([]models.NetWorthSnapshot, error)
+	GetTrackedUserIDs(ctx context.Context) ([]int64, error)
+}
+
+// InvestmentRepository интерфейс для работы с операциями с ценными бумагами и их ценами в базе данных
+type InvestmentRepository interface {
+	AddTransaction(ctx context.Context, transaction *models.InvestmentTransaction, income *models.Income) (int64, error)
+	GetTransaction(ctx context.Context, id int64) (*models.InvestmentTransaction, error)
+	GetTransactions(ctx context.Context, userID int64, ticker string) ([]models.InvestmentTransaction, error)
+	DeleteTransaction(ctx context.Context, transaction *models.InvestmentTransaction) error
+	SavePrices(ctx context.Context, userID int64, prices []models.SecurityPrice) error
+	GetPrices(ctx context.Context, userID int64, ticker string) ([]models.SecurityPrice, error)
+	DeletePrice(ctx context.Context, userID int64, ticker string, date time.Time) error
+}
+
+// RuleRepository интерфейс для работы с правилами автоматической категоризации в базе данных
+type RuleRepository interface {
+	Create(ctx context.Context, rule *models.CategorizationRule) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.CategorizationRule, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.CategorizationRule, error)
+	Update(ctx context.Context, rule *models.CategorizationRule) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	Reorder(ctx context.Context, userID int64, ruleIDs []int64) error
+}
+
+// PayeeRepository интерфейс для работы с получателями платежей в базе данных
+type PayeeRepository interface {
+	Create(ctx context.Context, payee *models.Payee) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.Payee, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.Payee, error)
+	Update(ctx context.Context, payee *models.Payee) error
+	Delete(ctx context.Context, id int64, userID int64) error
+	LinkExpenses(ctx context.Context, userID int64, links map[int64]int64) error
+	GetTopPayees(ctx context.Context, userID int64, startDate, endDate time.Time, limit int) ([]models.PayeeStat, error)
+	GetUnassignedSummary(ctx context.Context, userID int64, startDate, endDate time.Time) (int, float64, error)
+}
+
+// RecurringRuleRepository интерфейс для работы с регулярными списаниями в базе данных
+type RecurringRuleRepository interface {
+	Create(ctx context.Context, rule *models.RecurringRule) (int64, error)
+	GetByID(ctx context.Context, id int64) (*models.RecurringRule, error)
+	GetByUserID(ctx context.Context, userID int64) ([]models.RecurringRule, error)
+	Update(ctx context.Context, rule *models.RecurringRule) error
+	Delete(ctx context.Context, id int64, userID int64) error
+}
+
+// AlertRepository интерфейс для работы с предупреждениями о необычных тратах в базе данных
+type AlertRepository interface {
+	Create(ctx context.Context, alert *models.Alert) (bool, error)
+	GetByUserID(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]models.Alert, error)
+	MarkRead(ctx context.Context, id int64, userID int64) error
+	MarkAllRead(ctx context.Context, userID int64) error
+	MarkNotified(ctx context.Context, id int64) error
+	GetUserIDsWithRecentExpenses(ctx context.Context, since time.Time) ([]int64, error)
+}
+
+// AnalyticsRepository интерфейс для агрегирующих запросов аналитики
+type AnalyticsRepository interface {
+	Query(ctx context.Context, userID int64, query *models.AnalyticsQuery) ([]models.AnalyticsRow, error)
 }
@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresInvestmentRepository представляет реализацию репозитория инвестиционного портфеля на PostgreSQL
+type PostgresInvestmentRepository struct {
+	db *sql.DB
+}
+
+// NewInvestmentRepository создает новый экземпляр репозитория инвестиционного портфеля
+func NewInvestmentRepository(db *sql.DB) InvestmentRepository {
+	return &PostgresInvestmentRepository{db: db}
+}
+
+// investmentTransactionSelectQuery выбирает операции с ценными бумагами
+const investmentTransactionSelectQuery = `
+	SELECT id, user_id, ticker, type, date, quantity, price, fee, amount, COALESCE(note, ''),
+	       income_id, income_created, created_at
+	FROM investment_transactions
+`
+
+// AddTransaction записывает операцию с ценной бумагой. Если передано накопление, оно создается
+// в той же транзакции и связывается с операцией
+func (r *PostgresInvestmentRepository) AddTransaction(ctx context.Context, transaction *models.InvestmentTransaction, income *models.Income) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if income != nil {
+		var incomeID int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO incomes (user_id, amount, source, date, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`, income.UserID, income.Amount, income.Source, income.Date, income.Description, time.Now()).Scan(&incomeID)
+		if err != nil {
+			return 0, err
+		}
+		income.ID = incomeID
+		transaction.IncomeID = &income.ID
+		transaction.IncomeCreated = true
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO investment_transactions (user_id, ticker, type, date, quantity, price, fee, amount, note,
+		                                     income_id, income_created, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`, transaction.UserID, transaction.Ticker, transaction.Type, transaction.Date, transaction.Quantity,
+		transaction.Price, transaction.Fee, transaction.Amount, transaction.Note, transaction.IncomeID,
+		transaction.IncomeCreated, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetTransaction получает операцию с ценной бумагой по ее ID
+func (r *PostgresInvestmentRepository) GetTransaction(ctx context.Context, id int64) (*models.InvestmentTransaction, error) {
+	transaction, err := scanInvestmentTransaction(r.db.QueryRowContext(ctx, investmentTransactionSelectQuery+`WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("операция не найдена")
+		}
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// GetTransactions получает операции пользователя в хронологическом порядке.
+// Если тикер не указан, возвращаются операции по всем бумагам
+func (r *PostgresInvestmentRepository) GetTransactions(ctx context.Context, userID int64, ticker string) ([]models.InvestmentTransaction, error) {
+	rows, err := r.db.QueryContext(ctx, investmentTransactionSelectQuery+`
+		WHERE user_id = $1 AND ($2 = '' OR ticker = $2)
+		ORDER BY date, id
+	`, userID, ticker)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []models.InvestmentTransaction
+	for rows.Next() {
+		transaction, err := scanInvestmentTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// DeleteTransaction удаляет операцию вместе с накоплением, если оно было создано этой операцией
+func (r *PostgresInvestmentRepository) DeleteTransaction(ctx context.Context, transaction *models.InvestmentTransaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM investment_transactions WHERE id = $1 AND user_id = $2`,
+		transaction.ID, transaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("операция не найдена или у вас нет прав на ее удаление")
+	}
+
+	if transaction.IncomeCreated && transaction.IncomeID != nil {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM incomes WHERE id = $1`, *transaction.IncomeID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SavePrices сохраняет цены пользователя, заменяя ранее введенные цены на те же даты
+func (r *PostgresInvestmentRepository) SavePrices(ctx context.Context, userID int64, prices []models.SecurityPrice) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO security_prices (user_id, ticker, date, price, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, ticker, date) DO UPDATE
+		SET price = EXCLUDED.price, updated_at = EXCLUDED.updated_at
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, price := range prices {
+		if _, err = stmt.ExecContext(ctx, userID, price.Ticker, price.Date, price.Price, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPrices получает цены пользователя в хронологическом порядке.
+// Если тикер не указан, возвращаются цены по всем бумагам
+func (r *PostgresInvestmentRepository) GetPrices(ctx context.Context, userID int64, ticker string) ([]models.SecurityPrice, error) {
+	query := `
+		SELECT ticker, date, price, updated_at
+		FROM security_prices
+		WHERE user_id = $1 AND ($2 = '' OR ticker = $2)
+		ORDER BY date, ticker
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, ticker)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []models.SecurityPrice
+	for rows.Next() {
+		var price models.SecurityPrice
+		if err := rows.Scan(&price.Ticker, &price.Date, &price.Price, &price.UpdatedAt); err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// DeletePrice удаляет цену бумаги на дату
+func (r *PostgresInvestmentRepository) DeletePrice(ctx context.Context, userID int64, ticker string, date time.Time) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM security_prices WHERE user_id = $1 AND ticker = $2 AND date = $3`,
+		userID, ticker, date)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("цена не найдена")
+	}
+
+	return nil
+}
+
+// scanInvestmentTransaction читает операцию с ценной бумагой из строки результата
+func scanInvestmentTransaction(row rowScanner) (*models.InvestmentTransaction, error) {
+	var transaction models.InvestmentTransaction
+	var incomeID sql.NullInt64
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.UserID,
+		&transaction.Ticker,
+		&transaction.Type,
+		&transaction.Date,
+		&transaction.Quantity,
+		&transaction.Price,
+		&transaction.Fee,
+		&transaction.Amount,
+		&transaction.Note,
+		&incomeID,
+		&transaction.IncomeCreated,
+		&transaction.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if incomeID.Valid {
+		transaction.IncomeID = &incomeID.Int64
+	}
+
+	return &transaction, nil
+}
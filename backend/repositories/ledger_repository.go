@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresLedgerRepository представляет реализацию репозитория счетов бухгалтерской книги на PostgreSQL
+type PostgresLedgerRepository struct {
+	db *sql.DB
+}
+
+// NewLedgerRepository создает новый экземпляр репозитория счетов бухгалтерской книги
+func NewLedgerRepository(db *sql.DB) LedgerRepository {
+	return &PostgresLedgerRepository{db: db}
+}
+
+// GetAccounts получает настроенные пользователем счета
+func (r *PostgresLedgerRepository) GetAccounts(ctx context.Context, userID int64) ([]models.LedgerAccount, error) {
+	query := `
+		SELECT kind, key, account
+		FROM ledger_accounts
+		WHERE user_id = $1
+		ORDER BY kind, key
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []models.LedgerAccount
+	for rows.Next() {
+		var account models.LedgerAccount
+		if err := rows.Scan(&account.Kind, &account.Key, &account.Account); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// SaveAccounts создает или обновляет счета пользователя в одной транзакции
+func (r *PostgresLedgerRepository) SaveAccounts(ctx context.Context, userID int64, accounts []models.LedgerAccount) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO ledger_accounts (user_id, kind, key, account, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (user_id, kind, key) DO UPDATE
+		SET account = EXCLUDED.account, updated_at = EXCLUDED.updated_at
+	`
+
+	now := time.Now()
+	for _, account := range accounts {
+		if _, err := tx.ExecContext(ctx, query, userID, account.Kind, account.Key, account.Account, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
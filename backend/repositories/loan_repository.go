@@ -0,0 +1,306 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresLoanRepository представляет реализацию репозитория кредитов на PostgreSQL
+type PostgresLoanRepository struct {
+	db *sql.DB
+}
+
+// NewLoanRepository создает новый экземпляр репозитория кредитов
+func NewLoanRepository(db *sql.DB) LoanRepository {
+	return &PostgresLoanRepository{db: db}
+}
+
+// loanSelectQuery выбирает кредиты пользователей
+const loanSelectQuery = `
+	SELECT id, user_id, name, COALESCE(lender, ''), principal, rate, term_months, start_date, type, category,
+	       COALESCE(description, ''), created_at, updated_at
+	FROM loans
+`
+
+// Create создает новый кредит в базе данных
+func (r *PostgresLoanRepository) Create(ctx context.Context, loan *models.Loan) (int64, error) {
+	query := `
+		INSERT INTO loans (user_id, name, lender, principal, rate, term_months, start_date, type, category, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		loan.UserID,
+		loan.Name,
+		loan.Lender,
+		loan.Principal,
+		loan.Rate,
+		loan.TermMonths,
+		loan.StartDate,
+		loan.Type,
+		loan.Category,
+		loan.Description,
+		time.Now(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает кредит по его ID
+func (r *PostgresLoanRepository) GetByID(ctx context.Context, id int64) (*models.Loan, error) {
+	loan, err := scanLoan(r.db.QueryRowContext(ctx, loanSelectQuery+`WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("кредит не найден")
+		}
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// GetByUserID получает все кредиты пользователя
+func (r *PostgresLoanRepository) GetByUserID(ctx context.Context, userID int64) ([]models.Loan, error) {
+	rows, err := r.db.QueryContext(ctx, loanSelectQuery+`
+		WHERE user_id = $1
+		ORDER BY start_date, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var loans []models.Loan
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, *loan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// Update обновляет описательные поля кредита
+func (r *PostgresLoanRepository) Update(ctx context.Context, loan *models.Loan) error {
+	query := `
+		UPDATE loans
+		SET name = $1, lender = $2, category = $3, description = $4, updated_at = $5
+		WHERE id = $6 AND user_id = $7
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		loan.Name,
+		loan.Lender,
+		loan.Category,
+		loan.Description,
+		time.Now(),
+		loan.ID,
+		loan.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("кредит не найден или у вас нет прав на его изменение")
+	}
+
+	return nil
+}
+
+// Delete удаляет кредит вместе с платежами. Траты, созданные по платежам, сохраняются
+func (r *PostgresLoanRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM loans WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("кредит не найден или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// AddPayment записывает платеж по кредиту. Если передана трата, она создается в той же транзакции
+// и связывается с платежом
+func (r *PostgresLoanRepository) AddPayment(ctx context.Context, payment *models.LoanPayment, expense *models.Expense) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if expense != nil {
+		var expenseID int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO expenses (user_id, title, amount, category, date, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+			RETURNING id
+		`, expense.UserID, expense.Title, expense.Amount, expense.Category, expense.Date, expense.Description,
+			time.Now()).Scan(&expenseID)
+		if err != nil {
+			return 0, err
+		}
+		expense.ID = expenseID
+		payment.ExpenseID = &expense.ID
+		payment.ExpenseCreated = true
+	}
+
+	var strategy sql.NullString
+	if payment.Strategy != "" {
+		strategy = sql.NullString{String: string(payment.Strategy), Valid: true}
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO loan_payments (loan_id, date, amount, principal, interest, early, strategy, expense_id, expense_created, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`, payment.LoanID, payment.Date, payment.Amount, payment.Principal, payment.Interest, payment.Early, strategy,
+		payment.ExpenseID, payment.ExpenseCreated, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetPayments получает платежи по кредиту в порядке их внесения
+func (r *PostgresLoanRepository) GetPayments(ctx context.Context, loanID int64) ([]models.LoanPayment, error) {
+	query := `
+		SELECT id, loan_id, date, amount, principal, interest, early, COALESCE(strategy, ''),
+		       expense_id, expense_created, created_at
+		FROM loan_payments
+		WHERE loan_id = $1
+		ORDER BY date, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []models.LoanPayment
+	for rows.Next() {
+		var payment models.LoanPayment
+		var expenseID sql.NullInt64
+		err := rows.Scan(
+			&payment.ID,
+			&payment.LoanID,
+			&payment.Date,
+			&payment.Amount,
+			&payment.Principal,
+			&payment.Interest,
+			&payment.Early,
+			&payment.Strategy,
+			&expenseID,
+			&payment.ExpenseCreated,
+			&payment.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if expenseID.Valid {
+			payment.ExpenseID = &expenseID.Int64
+		}
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// DeletePayment удаляет платеж по кредиту вместе с тратой, если она была создана этим платежом
+func (r *PostgresLoanRepository) DeletePayment(ctx context.Context, payment *models.LoanPayment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM loan_payments WHERE id = $1 AND loan_id = $2`, payment.ID, payment.LoanID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("платеж не найден")
+	}
+
+	if payment.ExpenseCreated && payment.ExpenseID != nil {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM expenses WHERE id = $1`, *payment.ExpenseID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// scanLoan читает кредит из строки результата
+func scanLoan(row rowScanner) (*models.Loan, error) {
+	var loan models.Loan
+	err := row.Scan(
+		&loan.ID,
+		&loan.UserID,
+		&loan.Name,
+		&loan.Lender,
+		&loan.Principal,
+		&loan.Rate,
+		&loan.TermMonths,
+		&loan.StartDate,
+		&loan.Type,
+		&loan.Category,
+		&loan.Description,
+		&loan.CreatedAt,
+		&loan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loan, nil
+}
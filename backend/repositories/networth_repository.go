@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresNetWorthRepository представляет реализацию репозитория снимков чистой стоимости капитала на PostgreSQL
+type PostgresNetWorthRepository struct {
+	db *sql.DB
+}
+
+// NewNetWorthRepository создает новый экземпляр репозитория снимков чистой стоимости капитала
+func NewNetWorthRepository(db *sql.DB) NetWorthRepository {
+	return &PostgresNetWorthRepository{db: db}
+}
+
+// SaveSnapshot сохраняет снимок за месяц, заменяя ранее сохраненный снимок за ту же дату
+func (r *PostgresNetWorthRepository) SaveSnapshot(ctx context.Context, snapshot *models.NetWorthSnapshot) error {
+	query := `
+		INSERT INTO net_worth_snapshots (user_id, date, assets, liabilities, net_worth, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, date) DO UPDATE
+		SET assets = EXCLUDED.assets, liabilities = EXCLUDED.liabilities,
+		    net_worth = EXCLUDED.net_worth, created_at = EXCLUDED.created_at
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		snapshot.UserID,
+		snapshot.Date,
+		snapshot.Assets,
+		snapshot.Liabilities,
+		snapshot.NetWorth,
+		time.Now(),
+	)
+
+	return err
+}
+
+// GetSnapshots получает снимки пользователя начиная с указанной даты в хронологическом порядке
+func (r *PostgresNetWorthRepository) GetSnapshots(ctx context.Context, userID int64, from time.Time) ([]models.NetWorthSnapshot, error) {
+	query := `
+		SELECT user_id, date, assets, liabilities, net_worth
+		FROM net_worth_snapshots
+		WHERE user_id = $1 AND date >= $2
+		ORDER BY date
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []models.NetWorthSnapshot
+	for rows.Next() {
+		var snapshot models.NetWorthSnapshot
+		err := rows.Scan(
+			&snapshot.UserID,
+			&snapshot.Date,
+			&snapshot.Assets,
+			&snapshot.Liabilities,
+			&snapshot.NetWorth,
+		)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Stored = true
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// GetTrackedUserIDs получает пользователей, у которых есть активы, кредиты или инвестиции
+func (r *PostgresNetWorthRepository) GetTrackedUserIDs(ctx context.Context) ([]int64, error) {
+	query := `
+		SELECT user_id FROM assets
+		UNION
+		SELECT user_id FROM loans
+		UNION
+		SELECT user_id FROM investment_transactions
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
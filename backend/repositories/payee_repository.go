@@ -0,0 +1,263 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresPayeeRepository представляет реализацию репозитория получателей платежей на PostgreSQL
+type PostgresPayeeRepository struct {
+	db *sql.DB
+}
+
+// NewPayeeRepository создает новый экземпляр репозитория получателей платежей
+func NewPayeeRepository(db *sql.DB) PayeeRepository {
+	return &PostgresPayeeRepository{db: db}
+}
+
+// payeeSelectQuery выбирает получателей вместе с псевдонимами
+const payeeSelectQuery = `
+	SELECT p.id, p.user_id, p.name, COALESCE(p.default_category, ''),
+	       COALESCE(ARRAY(SELECT a.alias FROM payee_aliases a WHERE a.payee_id = p.id ORDER BY a.alias), '{}'),
+	       p.created_at, p.updated_at
+	FROM payees p
+`
+
+// Create создает получателя вместе с псевдонимами в одной транзакции
+func (r *PostgresPayeeRepository) Create(ctx context.Context, payee *models.Payee) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO payees (user_id, name, default_category, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $4)
+		RETURNING id
+	`, payee.UserID, payee.Name, payee.DefaultCategory, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertPayeeAliases(ctx, tx, payee.UserID, id, payee.Aliases); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает получателя по его ID
+func (r *PostgresPayeeRepository) GetByID(ctx context.Context, id int64) (*models.Payee, error) {
+	payee, err := scanPayee(r.db.QueryRowContext(ctx, payeeSelectQuery+`WHERE p.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("получатель не найден")
+		}
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+// GetByUserID получает получателей пользователя, упорядоченных по названию
+func (r *PostgresPayeeRepository) GetByUserID(ctx context.Context, userID int64) ([]models.Payee, error) {
+	rows, err := r.db.QueryContext(ctx, payeeSelectQuery+`
+		WHERE p.user_id = $1
+		ORDER BY LOWER(p.name), p.id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payees []models.Payee
+	for rows.Next() {
+		payee, err := scanPayee(rows)
+		if err != nil {
+			return nil, err
+		}
+		payees = append(payees, *payee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payees, nil
+}
+
+// Update обновляет получателя и полностью заменяет его псевдонимы
+func (r *PostgresPayeeRepository) Update(ctx context.Context, payee *models.Payee) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE payees
+		SET name = $1, default_category = NULLIF($2, ''), updated_at = $3
+		WHERE id = $4 AND user_id = $5
+	`, payee.Name, payee.DefaultCategory, time.Now(), payee.ID, payee.UserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("получатель не найден или у вас нет прав на его изменение")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM payee_aliases WHERE payee_id = $1`, payee.ID); err != nil {
+		return err
+	}
+
+	if err := insertPayeeAliases(ctx, tx, payee.UserID, payee.ID, payee.Aliases); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete удаляет получателя. Связанные траты остаются без получателя
+func (r *PostgresPayeeRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM payees WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("получатель не найден или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// LinkExpenses связывает траты пользователя с получателями. Ключ карты — ID траты, значение — ID получателя
+func (r *PostgresPayeeRepository) LinkExpenses(ctx context.Context, userID int64, links map[int64]int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for expenseID, payeeID := range links {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE expenses SET payee_id = $1, updated_at = $2 WHERE id = $3 AND user_id = $4
+		`, payeeID, time.Now(), expenseID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetTopPayees получает получателей с наибольшей суммой трат за период
+func (r *PostgresPayeeRepository) GetTopPayees(ctx context.Context, userID int64, startDate, endDate time.Time, limit int) ([]models.PayeeStat, error) {
+	query := `
+		SELECT p.id, p.name, COALESCE(p.default_category, ''), COUNT(*), SUM(e.amount)
+		FROM expenses e
+		JOIN payees p ON p.id = e.payee_id
+		WHERE e.user_id = $1 AND e.date >= $2 AND e.date < $3
+		GROUP BY p.id, p.name, p.default_category
+		ORDER BY SUM(e.amount) DESC, p.id
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, startDate, endDate, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []models.PayeeStat
+	for rows.Next() {
+		var stat models.PayeeStat
+		if err := rows.Scan(&stat.PayeeID, &stat.Name, &stat.DefaultCategory, &stat.Count, &stat.Total); err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetUnassignedSummary получает количество и сумму трат без получателя за период
+func (r *PostgresPayeeRepository) GetUnassignedSummary(ctx context.Context, userID int64, startDate, endDate time.Time) (int, float64, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(amount), 0)
+		FROM expenses
+		WHERE user_id = $1 AND date >= $2 AND date < $3 AND payee_id IS NULL
+	`
+
+	var count int
+	var total float64
+	if err := r.db.QueryRowContext(ctx, query, userID, startDate, endDate).Scan(&count, &total); err != nil {
+		return 0, 0, err
+	}
+
+	return count, total, nil
+}
+
+// insertPayeeAliases сохраняет псевдонимы получателя
+func insertPayeeAliases(ctx context.Context, tx *sql.Tx, userID, payeeID int64, aliases []string) error {
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO payee_aliases (user_id, alias, payee_id) VALUES ($1, $2, $3)
+		`, userID, alias, payeeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanPayee читает получателя из строки результата
+func scanPayee(row rowScanner) (*models.Payee, error) {
+	var payee models.Payee
+	err := row.Scan(
+		&payee.ID,
+		&payee.UserID,
+		&payee.Name,
+		&payee.DefaultCategory,
+		pq.Array(&payee.Aliases),
+		&payee.CreatedAt,
+		&payee.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if payee.Aliases == nil {
+		payee.Aliases = []string{}
+	}
+
+	return &payee, nil
+}
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresRecurringRuleRepository представляет реализацию репозитория регулярных списаний на PostgreSQL
+type PostgresRecurringRuleRepository struct {
+	db *sql.DB
+}
+
+// NewRecurringRuleRepository создает новый экземпляр репозитория регулярных списаний
+func NewRecurringRuleRepository(db *sql.DB) RecurringRuleRepository {
+	return &PostgresRecurringRuleRepository{db: db}
+}
+
+// recurringRuleSelectQuery выбирает регулярные списания
+const recurringRuleSelectQuery = `
+	SELECT id, user_id, match_key, title, payee_id, category, amount, cadence, next_date, active, created_at, updated_at
+	FROM recurring_rules
+`
+
+// Create создает регулярное списание
+func (r *PostgresRecurringRuleRepository) Create(ctx context.Context, rule *models.RecurringRule) (int64, error) {
+	query := `
+		INSERT INTO recurring_rules (user_id, match_key, title, payee_id, category, amount, cadence, next_date, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		ON CONFLICT (user_id, match_key) DO NOTHING
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		rule.UserID,
+		rule.MatchKey,
+		rule.Title,
+		rule.PayeeID,
+		rule.Category,
+		rule.Amount,
+		rule.Cadence,
+		rule.NextDate,
+		rule.Active,
+		time.Now(),
+	).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("подписка уже подтверждена")
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает регулярное списание по его ID
+func (r *PostgresRecurringRuleRepository) GetByID(ctx context.Context, id int64) (*models.RecurringRule, error) {
+	rule, err := scanRecurringRule(r.db.QueryRowContext(ctx, recurringRuleSelectQuery+`WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("регулярное списание не найдено")
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetByUserID получает регулярные списания пользователя в порядке ближайшего списания
+func (r *PostgresRecurringRuleRepository) GetByUserID(ctx context.Context, userID int64) ([]models.RecurringRule, error) {
+	rows, err := r.db.QueryContext(ctx, recurringRuleSelectQuery+`
+		WHERE user_id = $1
+		ORDER BY next_date, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []models.RecurringRule
+	for rows.Next() {
+		rule, err := scanRecurringRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Update обновляет регулярное списание
+func (r *PostgresRecurringRuleRepository) Update(ctx context.Context, rule *models.RecurringRule) error {
+	query := `
+		UPDATE recurring_rules
+		SET title = $1, category = $2, amount = $3, cadence = $4, next_date = $5, active = $6, updated_at = $7
+		WHERE id = $8 AND user_id = $9
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		rule.Title,
+		rule.Category,
+		rule.Amount,
+		rule.Cadence,
+		rule.NextDate,
+		rule.Active,
+		time.Now(),
+		rule.ID,
+		rule.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("регулярное списание не найдено или у вас нет прав на его изменение")
+	}
+
+	return nil
+}
+
+// Delete удаляет регулярное списание
+func (r *PostgresRecurringRuleRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recurring_rules WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("регулярное списание не найдено или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// scanRecurringRule читает регулярное списание из строки результата
+func scanRecurringRule(row rowScanner) (*models.RecurringRule, error) {
+	var rule models.RecurringRule
+	var payeeID sql.NullInt64
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.MatchKey,
+		&rule.Title,
+		&payeeID,
+		&rule.Category,
+		&rule.Amount,
+		&rule.Cadence,
+		&rule.NextDate,
+		&rule.Active,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if payeeID.Valid {
+		rule.PayeeID = &payeeID.Int64
+	}
+
+	return &rule, nil
+}
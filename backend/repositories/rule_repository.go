@@ -0,0 +1,257 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+
+	"github.com/lib/pq"
+)
+
+// PostgresRuleRepository представляет реализацию репозитория правил категоризации на PostgreSQL
+type PostgresRuleRepository struct {
+	db *sql.DB
+}
+
+// NewRuleRepository создает новый экземпляр репозитория правил категоризации
+func NewRuleRepository(db *sql.DB) RuleRepository {
+	return &PostgresRuleRepository{db: db}
+}
+
+// ruleSelectQuery выбирает правила категоризации
+const ruleSelectQuery = `
+	SELECT id, user_id, name, priority, enabled, COALESCE(title_pattern, ''), COALESCE(description_pattern, ''),
+	       min_amount, max_amount, COALESCE(account, ''), weekdays, COALESCE(category, ''), tags,
+	       COALESCE(clean_title, ''), created_at, updated_at
+	FROM categorization_rules
+`
+
+// Create создает правило. Если приоритет не задан, правило добавляется в конец списка
+func (r *PostgresRuleRepository) Create(ctx context.Context, rule *models.CategorizationRule) (int64, error) {
+	query := `
+		INSERT INTO categorization_rules (user_id, name, priority, enabled, title_pattern, description_pattern,
+		                                  min_amount, max_amount, account, weekdays, category, tags, clean_title,
+		                                  created_at, updated_at)
+		VALUES ($1, $2, COALESCE($3, (SELECT COALESCE(MAX(priority), 0) + 1 FROM categorization_rules WHERE user_id = $1)),
+		        $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14)
+		RETURNING id, priority
+	`
+
+	var priority sql.NullInt64
+	if rule.Priority > 0 {
+		priority = sql.NullInt64{Int64: int64(rule.Priority), Valid: true}
+	}
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		rule.UserID,
+		rule.Name,
+		priority,
+		rule.Enabled,
+		rule.TitlePattern,
+		rule.DescriptionPattern,
+		rule.MinAmount,
+		rule.MaxAmount,
+		rule.Account,
+		pq.Array(ruleWeekdays(rule.Weekdays)),
+		rule.Category,
+		pq.Array(expenseTags(rule.Tags)),
+		rule.CleanTitle,
+		time.Now(),
+	).Scan(&id, &rule.Priority)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetByID получает правило по его ID
+func (r *PostgresRuleRepository) GetByID(ctx context.Context, id int64) (*models.CategorizationRule, error) {
+	rule, err := scanRule(r.db.QueryRowContext(ctx, ruleSelectQuery+`WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("правило не найдено")
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetByUserID получает правила пользователя в порядке приоритета
+func (r *PostgresRuleRepository) GetByUserID(ctx context.Context, userID int64) ([]models.CategorizationRule, error) {
+	rows, err := r.db.QueryContext(ctx, ruleSelectQuery+`
+		WHERE user_id = $1
+		ORDER BY priority, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []models.CategorizationRule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Update обновляет правило в базе данных
+func (r *PostgresRuleRepository) Update(ctx context.Context, rule *models.CategorizationRule) error {
+	query := `
+		UPDATE categorization_rules
+		SET name = $1, priority = $2, enabled = $3, title_pattern = $4, description_pattern = $5,
+		    min_amount = $6, max_amount = $7, account = $8, weekdays = $9, category = $10, tags = $11,
+		    clean_title = $12, updated_at = $13
+		WHERE id = $14 AND user_id = $15
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		rule.Name,
+		rule.Priority,
+		rule.Enabled,
+		rule.TitlePattern,
+		rule.DescriptionPattern,
+		rule.MinAmount,
+		rule.MaxAmount,
+		rule.Account,
+		pq.Array(ruleWeekdays(rule.Weekdays)),
+		rule.Category,
+		pq.Array(expenseTags(rule.Tags)),
+		rule.CleanTitle,
+		time.Now(),
+		rule.ID,
+		rule.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("правило не найдено или у вас нет прав на его изменение")
+	}
+
+	return nil
+}
+
+// Delete удаляет правило
+func (r *PostgresRuleRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM categorization_rules WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("правило не найдено или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// Reorder назначает правилам приоритеты в порядке перечисления ID в одной транзакции
+func (r *PostgresRuleRepository) Reorder(ctx context.Context, userID int64, ruleIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, id := range ruleIDs {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE categorization_rules SET priority = $1, updated_at = $2 WHERE id = $3 AND user_id = $4
+		`, i+1, time.Now(), id, userID)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return errors.New("правило не найдено или у вас нет прав на его изменение")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ruleWeekdays преобразует дни недели в массив PostgreSQL, заменяя отсутствующие дни пустым списком
+func ruleWeekdays(weekdays []int) []int64 {
+	result := make([]int64, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		result = append(result, int64(weekday))
+	}
+	return result
+}
+
+// scanRule читает правило категоризации из строки результата
+func scanRule(row rowScanner) (*models.CategorizationRule, error) {
+	var rule models.CategorizationRule
+	var minAmount, maxAmount sql.NullFloat64
+	var weekdays pq.Int64Array
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Priority,
+		&rule.Enabled,
+		&rule.TitlePattern,
+		&rule.DescriptionPattern,
+		&minAmount,
+		&maxAmount,
+		&rule.Account,
+		&weekdays,
+		&rule.Category,
+		pq.Array(&rule.Tags),
+		&rule.CleanTitle,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if minAmount.Valid {
+		rule.MinAmount = &minAmount.Float64
+	}
+	if maxAmount.Valid {
+		rule.MaxAmount = &maxAmount.Float64
+	}
+	for _, weekday := range weekdays {
+		rule.Weekdays = append(rule.Weekdays, int(weekday))
+	}
+	if rule.Tags == nil {
+		rule.Tags = []string{}
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,580 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresSplitRepository представляет реализацию репозитория разделения общих трат на PostgreSQL
+type PostgresSplitRepository struct {
+	db *sql.DB
+}
+
+// NewSplitRepository создает новый экземпляр репозитория разделения общих трат
+func NewSplitRepository(db *sql.DB) SplitRepository {
+	return &PostgresSplitRepository{db: db}
+}
+
+// CreateGroup создает группу вместе с начальными участниками в одной транзакции
+func (r *PostgresSplitRepository) CreateGroup(ctx context.Context, group *models.SplitGroup) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO split_groups (name, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		RETURNING id
+	`, group.Name, group.CreatedBy, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range group.Participants {
+		participant := &group.Participants[i]
+		participant.GroupID = id
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO split_participants (group_id, user_id, name, created_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id
+		`, id, participant.UserID, participant.Name, time.Now()).Scan(&participant.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetGroup получает группу по ее ID
+func (r *PostgresSplitRepository) GetGroup(ctx context.Context, id int64) (*models.SplitGroup, error) {
+	query := `
+		SELECT id, name, COALESCE(created_by, 0), created_at, updated_at
+		FROM split_groups
+		WHERE id = $1
+	`
+
+	var group models.SplitGroup
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&group.ID,
+		&group.Name,
+		&group.CreatedBy,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("группа не найдена")
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// GetGroupsByUserID получает группы, в которых пользователь является участником
+func (r *PostgresSplitRepository) GetGroupsByUserID(ctx context.Context, userID int64) ([]models.SplitGroup, error) {
+	query := `
+		SELECT g.id, g.name, COALESCE(g.created_by, 0), g.created_at, g.updated_at
+		FROM split_groups g
+		JOIN split_participants p ON p.group_id = g.id
+		WHERE p.user_id = $1
+		ORDER BY g.updated_at DESC, g.id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []models.SplitGroup
+	for rows.Next() {
+		var group models.SplitGroup
+		err := rows.Scan(
+			&group.ID,
+			&group.Name,
+			&group.CreatedBy,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// DeleteGroup удаляет группу вместе с участниками, тратами и погашениями
+func (r *PostgresSplitRepository) DeleteGroup(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM split_groups WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("группа не найдена")
+	}
+
+	return nil
+}
+
+// AddParticipant добавляет участника в группу
+func (r *PostgresSplitRepository) AddParticipant(ctx context.Context, participant *models.SplitParticipant) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO split_participants (group_id, user_id, name, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, participant.GroupID, participant.UserID, participant.Name, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetParticipants получает участников группы
+func (r *PostgresSplitRepository) GetParticipants(ctx context.Context, groupID int64) ([]models.SplitParticipant, error) {
+	query := `
+		SELECT id, group_id, user_id, name, created_at
+		FROM split_participants
+		WHERE group_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []models.SplitParticipant
+	for rows.Next() {
+		var participant models.SplitParticipant
+		var userID sql.NullInt64
+		err := rows.Scan(
+			&participant.ID,
+			&participant.GroupID,
+			&userID,
+			&participant.Name,
+			&participant.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if userID.Valid {
+			participant.UserID = &userID.Int64
+		}
+		participants = append(participants, participant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return participants, nil
+}
+
+// RemoveParticipant удаляет участника, который еще не упоминается в тратах и погашениях группы
+func (r *PostgresSplitRepository) RemoveParticipant(ctx context.Context, groupID int64, participantID int64) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM split_participants p
+		WHERE p.id = $1 AND p.group_id = $2
+		  AND NOT EXISTS (SELECT 1 FROM split_expenses e WHERE e.paid_by = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM split_expense_shares s WHERE s.participant_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM split_settlements s WHERE s.from_participant = p.id OR s.to_participant = p.id)
+	`, participantID, groupID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("участник не найден или уже участвует в тратах группы")
+	}
+
+	return nil
+}
+
+// CreateExpense сохраняет общую трату вместе с долями участников в одной транзакции
+func (r *PostgresSplitRepository) CreateExpense(ctx context.Context, expense *models.SplitExpense) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO split_expenses (group_id, paid_by, title, amount, method, date, description, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, expense.GroupID, expense.PaidBy, expense.Title, expense.Amount, expense.Method, expense.Date,
+		expense.Description, expense.CreatedBy, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, share := range expense.Shares {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO split_expense_shares (split_expense_id, participant_id, value, amount)
+			VALUES ($1, $2, $3, $4)
+		`, id, share.ParticipantID, share.Value, share.Amount)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE split_groups SET updated_at = $1 WHERE id = $2`, time.Now(), expense.GroupID); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// splitExpenseSelectQuery выбирает общие траты групп
+const splitExpenseSelectQuery = `
+	SELECT id, group_id, paid_by, title, amount, method, date, COALESCE(description, ''),
+	       COALESCE(created_by, 0), created_at
+	FROM split_expenses
+`
+
+// GetExpense получает общую трату по ее ID вместе с долями
+func (r *PostgresSplitRepository) GetExpense(ctx context.Context, id int64) (*models.SplitExpense, error) {
+	expense, err := scanSplitExpense(r.db.QueryRowContext(ctx, splitExpenseSelectQuery+`WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("трата группы не найдена")
+		}
+		return nil, err
+	}
+
+	shares, err := r.getShares(ctx, `WHERE s.split_expense_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	expense.Shares = shares[expense.ID]
+
+	return expense, nil
+}
+
+// GetExpenses получает общие траты группы вместе с долями, начиная с последних
+func (r *PostgresSplitRepository) GetExpenses(ctx context.Context, groupID int64) ([]models.SplitExpense, error) {
+	rows, err := r.db.QueryContext(ctx, splitExpenseSelectQuery+`
+		WHERE group_id = $1
+		ORDER BY date DESC, id DESC
+	`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []models.SplitExpense
+	for rows.Next() {
+		expense, err := scanSplitExpense(rows)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, *expense)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	shares, err := r.getShares(ctx, `
+		JOIN split_expenses e ON e.id = s.split_expense_id
+		WHERE e.group_id = $1
+	`, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range expenses {
+		expenses[i].Shares = shares[expenses[i].ID]
+	}
+
+	return expenses, nil
+}
+
+// DeleteExpense удаляет общую трату группы
+func (r *PostgresSplitRepository) DeleteExpense(ctx context.Context, id int64, groupID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM split_expenses WHERE id = $1 AND group_id = $2`, id, groupID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("трата группы не найдена")
+	}
+
+	return nil
+}
+
+// CreateSettlement записывает погашение долга и создает соответствующие трату плательщика
+// и накопление получателя в одной транзакции. Трата или накопление могут отсутствовать,
+// если участник не является зарегистрированным пользователем
+func (r *PostgresSplitRepository) CreateSettlement(ctx context.Context, settlement *models.SplitSettlement, expense *models.Expense, income *models.Income) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if expense != nil {
+		var expenseID int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO expenses (user_id, title, amount, category, date, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+			RETURNING id
+		`, expense.UserID, expense.Title, expense.Amount, expense.Category, expense.Date, expense.Description,
+			time.Now()).Scan(&expenseID)
+		if err != nil {
+			return 0, err
+		}
+		expense.ID = expenseID
+		settlement.ExpenseID = &expense.ID
+	}
+
+	if income != nil {
+		var incomeID int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO incomes (user_id, amount, source, date, description, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $6)
+			RETURNING id
+		`, income.UserID, income.Amount, income.Source, income.Date, income.Description,
+			time.Now()).Scan(&incomeID)
+		if err != nil {
+			return 0, err
+		}
+		income.ID = incomeID
+		settlement.IncomeID = &income.ID
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO split_settlements (group_id, from_participant, to_participant, amount, date, note,
+		                               expense_id, income_id, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`, settlement.GroupID, settlement.FromParticipant, settlement.ToParticipant, settlement.Amount, settlement.Date,
+		settlement.Note, settlement.ExpenseID, settlement.IncomeID, settlement.CreatedBy, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE split_groups SET updated_at = $1 WHERE id = $2`, time.Now(), settlement.GroupID); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// splitSettlementSelectQuery выбирает погашения долгов групп
+const splitSettlementSelectQuery = `
+	SELECT id, group_id, from_participant, to_participant, amount, date, COALESCE(note, ''),
+	       expense_id, income_id, COALESCE(created_by, 0), created_at
+	FROM split_settlements
+`
+
+// GetSettlement получает погашение долга по его ID
+func (r *PostgresSplitRepository) GetSettlement(ctx context.Context, id int64) (*models.SplitSettlement, error) {
+	settlement, err := scanSplitSettlement(r.db.QueryRowContext(ctx, splitSettlementSelectQuery+`WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("погашение не найдено")
+		}
+		return nil, err
+	}
+
+	return settlement, nil
+}
+
+// GetSettlements получает погашения долгов группы, начиная с последних
+func (r *PostgresSplitRepository) GetSettlements(ctx context.Context, groupID int64) ([]models.SplitSettlement, error) {
+	rows, err := r.db.QueryContext(ctx, splitSettlementSelectQuery+`
+		WHERE group_id = $1
+		ORDER BY date DESC, id DESC
+	`, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var settlements []models.SplitSettlement
+	for rows.Next() {
+		settlement, err := scanSplitSettlement(rows)
+		if err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, *settlement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return settlements, nil
+}
+
+// DeleteSettlement удаляет погашение вместе с созданными по нему тратой и накоплением
+func (r *PostgresSplitRepository) DeleteSettlement(ctx context.Context, settlement *models.SplitSettlement) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM split_settlements WHERE id = $1`, settlement.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("погашение не найдено")
+	}
+
+	if settlement.ExpenseID != nil {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM expenses WHERE id = $1`, *settlement.ExpenseID); err != nil {
+			return err
+		}
+	}
+
+	if settlement.IncomeID != nil {
+		if _, err = tx.ExecContext(ctx, `DELETE FROM incomes WHERE id = $1`, *settlement.IncomeID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getShares получает доли участников, сгруппированные по ID траты
+func (r *PostgresSplitRepository) getShares(ctx context.Context, condition string, args ...interface{}) (map[int64][]models.SplitShare, error) {
+	query := `
+		SELECT s.split_expense_id, s.participant_id, s.value, s.amount
+		FROM split_expense_shares s
+	` + condition + `
+		ORDER BY s.split_expense_id, s.participant_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := make(map[int64][]models.SplitShare)
+	for rows.Next() {
+		var expenseID int64
+		var share models.SplitShare
+		if err := rows.Scan(&expenseID, &share.ParticipantID, &share.Value, &share.Amount); err != nil {
+			return nil, err
+		}
+		shares[expenseID] = append(shares[expenseID], share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+// scanSplitExpense читает общую трату из строки результата
+func scanSplitExpense(row rowScanner) (*models.SplitExpense, error) {
+	var expense models.SplitExpense
+	err := row.Scan(
+		&expense.ID,
+		&expense.GroupID,
+		&expense.PaidBy,
+		&expense.Title,
+		&expense.Amount,
+		&expense.Method,
+		&expense.Date,
+		&expense.Description,
+		&expense.CreatedBy,
+		&expense.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &expense, nil
+}
+
+// scanSplitSettlement читает погашение долга из строки результата
+func scanSplitSettlement(row rowScanner) (*models.SplitSettlement, error) {
+	var settlement models.SplitSettlement
+	var expenseID, incomeID sql.NullInt64
+	err := row.Scan(
+		&settlement.ID,
+		&settlement.GroupID,
+		&settlement.FromParticipant,
+		&settlement.ToParticipant,
+		&settlement.Amount,
+		&settlement.Date,
+		&settlement.Note,
+		&expenseID,
+		&incomeID,
+		&settlement.CreatedBy,
+		&settlement.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expenseID.Valid {
+		settlement.ExpenseID = &expenseID.Int64
+	}
+	if incomeID.Valid {
+		settlement.IncomeID = &incomeID.Int64
+	}
+
+	return &settlement, nil
+}
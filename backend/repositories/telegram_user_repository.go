@@ -103,6 +103,34 @@ func (r *PostgresTelegramUserRepository) GetByUserID(ctx context.Context, userID
 	return &telegramUser, nil
 }
 
+// GetByUsername получает связь по имени пользователя в Telegram без учета регистра
+func (r *PostgresTelegramUserRepository) GetByUsername(ctx context.Context, username string) (*models.TelegramUser, error) {
+	query := `
+		SELECT id, user_id, telegram_id, username, first_name, last_name
+		FROM telegram_users
+		WHERE LOWER(username) = LOWER($1)
+	`
+
+	var telegramUser models.TelegramUser
+	err := r.db.QueryRowContext(ctx, query, username).Scan(
+		&telegramUser.ID,
+		&telegramUser.UserID,
+		&telegramUser.TelegramID,
+		&telegramUser.Username,
+		&telegramUser.FirstName,
+		&telegramUser.LastName,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("связь с Telegram пользователем не найдена")
+		}
+		return nil, err
+	}
+
+	return &telegramUser, nil
+}
+
 // Delete удаляет связь с Telegram пользователем
 func (r *PostgresTelegramUserRepository) Delete(ctx context.Context, id int64) error {
 	query := "DELETE FROM telegram_users WHERE id = $1"
@@ -23,8 +23,8 @@ func NewUserRepository(db *sql.DB) UserRepository {
 // Create создает нового пользователя в базе данных
 func (r *PostgresUserRepository) Create(ctx context.Context, user *models.User) (int64, error) {
 	query := `
-		INSERT INTO users (email, username, password_hash, first_name, last_name, monthly_limit, savings_goal, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO users (email, username, password_hash, first_name, last_name, monthly_limit, savings_goal, timezone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id
 	`
 
@@ -39,6 +39,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *models.User)
 		user.LastName,
 		user.MonthlyLimit,
 		user.SavingsGoal,
+		user.Timezone,
 		time.Now(),
 		time.Now(),
 	).Scan(&id)
@@ -53,7 +54,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *models.User)
 // GetByID получает пользователя по его ID
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, avatar_path, monthly_limit, savings_goal, created_at, updated_at
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_path, monthly_limit, savings_goal, timezone, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -74,6 +75,7 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id int64) (*models
 		&avatarPath,
 		&user.MonthlyLimit,
 		&user.SavingsGoal,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -112,7 +114,7 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 	fmt.Printf("REPO: Попытка получить пользователя по email: %s\n", email)
 
 	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, avatar_path, monthly_limit, savings_goal, created_at, updated_at
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_path, monthly_limit, savings_goal, timezone, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -133,6 +135,7 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		&avatarPath,
 		&user.MonthlyLimit,
 		&user.SavingsGoal,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -173,7 +176,7 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 // GetByUsername получает пользователя по его имени пользователя
 func (r *PostgresUserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, first_name, last_name, avatar_path, monthly_limit, savings_goal, created_at, updated_at
+		SELECT id, email, username, password_hash, first_name, last_name, avatar_path, monthly_limit, savings_goal, timezone, created_at, updated_at
 		FROM users
 		WHERE username = $1
 	`
@@ -194,6 +197,7 @@ func (r *PostgresUserRepository) GetByUsername(ctx context.Context, username str
 		&avatarPath,
 		&user.MonthlyLimit,
 		&user.SavingsGoal,
+		&user.Timezone,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -231,8 +235,8 @@ func (r *PostgresUserRepository) GetByUsername(ctx context.Context, username str
 func (r *PostgresUserRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
-		SET email = $1, username = $2, first_name = $3, last_name = $4, monthly_limit = $5, savings_goal = $6, updated_at = $7
-		WHERE id = $8
+		SET email = $1, username = $2, first_name = $3, last_name = $4, monthly_limit = $5, savings_goal = $6, timezone = $7, updated_at = $8
+		WHERE id = $9
 	`
 
 	_, err := r.db.ExecContext(
@@ -244,6 +248,7 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *models.User)
 		user.LastName,
 		user.MonthlyLimit,
 		user.SavingsGoal,
+		user.Timezone,
 		time.Now(),
 		user.ID,
 	)
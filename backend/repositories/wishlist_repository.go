@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"cz.Finance/backend/models"
+
+	"github.com/lib/pq"
 )
 
 // PostgresWishlistRepository представляет реализацию репозитория списка желаний на PostgreSQL
@@ -22,8 +24,8 @@ func NewWishlistRepository(db *sql.DB) WishlistRepository {
 // Create создает новый элемент списка желаний в базе данных
 func (r *PostgresWishlistRepository) Create(ctx context.Context, item *models.WishlistItem) (int64, error) {
 	query := `
-		INSERT INTO wishlist (user_id, title, price, priority, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO wishlist (user_id, title, price, priority, description, target_price, shared, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
 
@@ -36,6 +38,8 @@ func (r *PostgresWishlistRepository) Create(ctx context.Context, item *models.Wi
 		item.Price,
 		item.Priority,
 		item.Description,
+		item.TargetPrice,
+		item.Shared,
 		time.Now(),
 		time.Now(),
 	).Scan(&id)
@@ -50,12 +54,17 @@ func (r *PostgresWishlistRepository) Create(ctx context.Context, item *models.Wi
 // GetByID получает элемент списка желаний по его ID
 func (r *PostgresWishlistRepository) GetByID(ctx context.Context, id int64) (*models.WishlistItem, error) {
 	query := `
-		SELECT id, user_id, title, price, priority, description, created_at, updated_at
-		FROM wishlist
-		WHERE id = $1
+		SELECT w.id, w.user_id, w.title, w.price, w.priority, w.description, w.target_price, w.shared,
+		       COALESCE((SELECT SUM(a.amount) FROM wishlist_allocations a WHERE a.wishlist_id = w.id), 0),
+		       w.purchased_at, w.expense_id, w.created_at, w.updated_at
+		FROM wishlist w
+		WHERE w.id = $1
 	`
 
 	var item models.WishlistItem
+	var targetPrice sql.NullFloat64
+	var purchasedAt sql.NullTime
+	var expenseID sql.NullInt64
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&item.ID,
 		&item.UserID,
@@ -63,6 +72,11 @@ func (r *PostgresWishlistRepository) GetByID(ctx context.Context, id int64) (*mo
 		&item.Price,
 		&item.Priority,
 		&item.Description,
+		&targetPrice,
+		&item.Shared,
+		&item.Allocated,
+		&purchasedAt,
+		&expenseID,
 		&item.CreatedAt,
 		&item.UpdatedAt,
 	)
@@ -73,6 +87,7 @@ func (r *PostgresWishlistRepository) GetByID(ctx context.Context, id int64) (*mo
 		}
 		return nil, err
 	}
+	setWishlistOptional(&item, targetPrice, purchasedAt, expenseID)
 
 	return &item, nil
 }
@@ -80,10 +95,12 @@ func (r *PostgresWishlistRepository) GetByID(ctx context.Context, id int64) (*mo
 // GetByUserID получает все элементы списка желаний пользователя
 func (r *PostgresWishlistRepository) GetByUserID(ctx context.Context, userID int64) ([]models.WishlistItem, error) {
 	query := `
-		SELECT id, user_id, title, price, priority, description, created_at, updated_at
-		FROM wishlist
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		SELECT w.id, w.user_id, w.title, w.price, w.priority, w.description, w.target_price, w.shared,
+		       COALESCE((SELECT SUM(a.amount) FROM wishlist_allocations a WHERE a.wishlist_id = w.id), 0),
+		       w.purchased_at, w.expense_id, w.created_at, w.updated_at
+		FROM wishlist w
+		WHERE w.user_id = $1
+		ORDER BY w.created_at DESC
 	`
 
 	rows, err := r.db.QueryContext(ctx, query, userID)
@@ -95,6 +112,9 @@ func (r *PostgresWishlistRepository) GetByUserID(ctx context.Context, userID int
 	var items []models.WishlistItem
 	for rows.Next() {
 		var item models.WishlistItem
+		var targetPrice sql.NullFloat64
+		var purchasedAt sql.NullTime
+		var expenseID sql.NullInt64
 		err := rows.Scan(
 			&item.ID,
 			&item.UserID,
@@ -102,12 +122,18 @@ func (r *PostgresWishlistRepository) GetByUserID(ctx context.Context, userID int
 			&item.Price,
 			&item.Priority,
 			&item.Description,
+			&targetPrice,
+			&item.Shared,
+			&item.Allocated,
+			&purchasedAt,
+			&expenseID,
 			&item.CreatedAt,
 			&item.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		setWishlistOptional(&item, targetPrice, purchasedAt, expenseID)
 		items = append(items, item)
 	}
 
@@ -122,8 +148,8 @@ func (r *PostgresWishlistRepository) GetByUserID(ctx context.Context, userID int
 func (r *PostgresWishlistRepository) Update(ctx context.Context, item *models.WishlistItem) error {
 	query := `
 		UPDATE wishlist
-		SET title = $1, price = $2, priority = $3, description = $4, updated_at = $5
-		WHERE id = $6 AND user_id = $7
+		SET title = $1, price = $2, priority = $3, description = $4, target_price = $5, shared = $6, updated_at = $7
+		WHERE id = $8 AND user_id = $9
 	`
 
 	result, err := r.db.ExecContext(
@@ -133,6 +159,8 @@ func (r *PostgresWishlistRepository) Update(ctx context.Context, item *models.Wi
 		item.Price,
 		item.Priority,
 		item.Description,
+		item.TargetPrice,
+		item.Shared,
 		time.Now(),
 		item.ID,
 		item.UserID,
@@ -177,3 +205,230 @@ func (r *PostgresWishlistRepository) Delete(ctx context.Context, id int64, userI
 
 	return nil
 }
+
+// AddAllocation добавляет резервирование денег под элемент списка желаний
+func (r *PostgresWishlistRepository) AddAllocation(ctx context.Context, allocation *models.WishlistAllocation) (int64, error) {
+	query := `
+		INSERT INTO wishlist_allocations (wishlist_id, amount, date, description, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		allocation.WishlistID,
+		allocation.Amount,
+		allocation.Date,
+		allocation.Description,
+		time.Now(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetAllocations получает резервирования элемента списка желаний в порядке от новых к старым
+func (r *PostgresWishlistRepository) GetAllocations(ctx context.Context, wishlistID int64) ([]models.WishlistAllocation, error) {
+	query := `
+		SELECT id, wishlist_id, amount, date, COALESCE(description, ''), created_at
+		FROM wishlist_allocations
+		WHERE wishlist_id = $1
+		ORDER BY date DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var allocations []models.WishlistAllocation
+	for rows.Next() {
+		var allocation models.WishlistAllocation
+		err := rows.Scan(
+			&allocation.ID,
+			&allocation.WishlistID,
+			&allocation.Amount,
+			&allocation.Date,
+			&allocation.Description,
+			&allocation.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return allocations, nil
+}
+
+// DeleteAllocation удаляет резервирование, если элемент списка желаний принадлежит пользователю
+func (r *PostgresWishlistRepository) DeleteAllocation(ctx context.Context, id int64, wishlistID int64, userID int64) error {
+	query := `
+		DELETE FROM wishlist_allocations a
+		USING wishlist w
+		WHERE a.id = $1 AND a.wishlist_id = $2 AND w.id = a.wishlist_id AND w.user_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, wishlistID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("резервирование не найдено или у вас нет прав на его удаление")
+	}
+
+	return nil
+}
+
+// Purchase создает трату и отмечает элемент списка желаний купленным в одной транзакции
+func (r *PostgresWishlistRepository) Purchase(ctx context.Context, item *models.WishlistItem, expense *models.Expense) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var expenseID int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO expenses (user_id, title, amount, category, date, description, account, tags, payee_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`, expense.UserID, expense.Title, expense.Amount, expense.Category, expense.Date, expense.Description,
+		expense.Account, pq.Array(expenseTags(expense.Tags)), expense.PayeeID, time.Now(), time.Now()).Scan(&expenseID)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE wishlist
+		SET purchased_at = $1, expense_id = $2, updated_at = $3
+		WHERE id = $4 AND user_id = $5 AND purchased_at IS NULL
+	`, expense.Date, expenseID, time.Now(), item.ID, item.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		return 0, errors.New("элемент списка желаний не найден или уже куплен")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return expenseID, nil
+}
+
+// setWishlistOptional заполняет необязательные поля элемента списка желаний: целевую цену и сведения о покупке
+func setWishlistOptional(item *models.WishlistItem, targetPrice sql.NullFloat64, purchasedAt sql.NullTime, expenseID sql.NullInt64) {
+	if targetPrice.Valid {
+		item.TargetPrice = &targetPrice.Float64
+	}
+	if purchasedAt.Valid {
+		item.PurchasedAt = &purchasedAt.Time
+	}
+	if expenseID.Valid {
+		item.ExpenseID = &expenseID.Int64
+	}
+}
+
+// AddPrice записывает наблюдаемую цену и делает ее текущей ценой элемента списка желаний
+func (r *PostgresWishlistRepository) AddPrice(ctx context.Context, price *models.WishlistPrice) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO wishlist_prices (wishlist_id, price, source, observed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, price.WishlistID, price.Price, price.Source, price.ObservedAt, time.Now()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Текущей ценой считается последняя по времени наблюдения
+	_, err = tx.ExecContext(ctx, `
+		UPDATE wishlist
+		SET price = (
+		        SELECT p.price FROM wishlist_prices p
+		        WHERE p.wishlist_id = $1
+		        ORDER BY p.observed_at DESC, p.id DESC
+		        LIMIT 1
+		    ),
+		    updated_at = $2
+		WHERE id = $1
+	`, price.WishlistID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// GetPrices получает историю цен элемента списка желаний в порядке от новых к старым
+func (r *PostgresWishlistRepository) GetPrices(ctx context.Context, wishlistID int64) ([]models.WishlistPrice, error) {
+	query := `
+		SELECT id, wishlist_id, price, source, observed_at, created_at
+		FROM wishlist_prices
+		WHERE wishlist_id = $1
+		ORDER BY observed_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []models.WishlistPrice
+	for rows.Next() {
+		var price models.WishlistPrice
+		err := rows.Scan(
+			&price.ID,
+			&price.WishlistID,
+			&price.Price,
+			&price.Source,
+			&price.ObservedAt,
+			&price.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+// PostgresWishlistShareRepository представляет реализацию репозитория публичных ссылок на список желаний на PostgreSQL
+type PostgresWishlistShareRepository struct {
+	db *sql.DB
+}
+
+// NewWishlistShareRepository создает новый экземпляр репозитория публичных ссылок на список желаний
+func NewWishlistShareRepository(db *sql.DB) WishlistShareRepository {
+	return &PostgresWishlistShareRepository{db: db}
+}
+
+// GetByUserID получает публичную ссылку пользователя
+func (r *PostgresWishlistShareRepository) GetByUserID(ctx context.Context, userID int64) (*models.WishlistShare, error) {
+	query := `
+		SELECT id, user_id, token, hide_prices, created_at, updated_at
+		FROM wishlist_shares
+		WHERE user_id = $1
+	`
+
+	return r.scanShare(r.db.QueryRowContext(ctx, query, userID))
+}
+
+// GetByToken получает публичную ссылку по токену
+func (r *PostgresWishlistShareRepository) GetByToken(ctx context.Context, token string) (*models.WishlistShare, error) {
+	query := `
+		SELECT id, user_id, token, hide_prices, created_at, updated_at
+		FROM wishlist_shares
+		WHERE token = $1
+	`
+
+	return r.scanShare(r.db.QueryRowContext(ctx, query, token))
+}
+
+// Save создает или обновляет публичную ссылку пользователя
+func (r *PostgresWishlistShareRepository) Save(ctx context.Context, share *models.WishlistShare) error {
+	query := `
+		INSERT INTO wishlist_shares (user_id, token, hide_prices, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token = EXCLUDED.token, hide_prices = EXCLUDED.hide_prices, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query, share.UserID, share.Token, share.HidePrices, time.Now()).
+		Scan(&share.ID, &share.CreatedAt, &share.UpdatedAt)
+}
+
+// Delete отзывает публичную ссылку пользователя
+func (r *PostgresWishlistShareRepository) Delete(ctx context.Context, userID int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM wishlist_shares WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("публичная ссылка не найдена")
+	}
+
+	return nil
+}
+
+// GetSharedItems получает открытые для просмотра и еще не купленные элементы списка желаний с признаком бронирования
+func (r *PostgresWishlistShareRepository) GetSharedItems(ctx context.Context, userID int64) ([]models.PublicWishlistItem, error) {
+	query := `
+		SELECT w.id, w.title, COALESCE(w.description, ''), w.priority, w.price, res.id IS NOT NULL
+		FROM wishlist w
+		LEFT JOIN wishlist_reservations res ON res.wishlist_id = w.id
+		WHERE w.user_id = $1 AND w.shared AND w.purchased_at IS NULL
+		ORDER BY w.created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.PublicWishlistItem
+	for rows.Next() {
+		var item models.PublicWishlistItem
+		var price float64
+		err := rows.Scan(
+			&item.ID,
+			&item.Title,
+			&item.Description,
+			&item.Priority,
+			&price,
+			&item.Reserved,
+		)
+		if err != nil {
+			return nil, err
+		}
+		item.Price = &price
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Reserve бронирует открытый элемент списка желаний владельца. Элемент можно забронировать только один раз
+func (r *PostgresWishlistShareRepository) Reserve(ctx context.Context, ownerID int64, reservation *models.WishlistReservation) error {
+	query := `
+		INSERT INTO wishlist_reservations (wishlist_id, reserver_name, cancel_token, created_at)
+		SELECT w.id, $3, $4, $5
+		FROM wishlist w
+		WHERE w.id = $1 AND w.user_id = $2 AND w.shared AND w.purchased_at IS NULL
+		ON CONFLICT (wishlist_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		reservation.WishlistID,
+		ownerID,
+		reservation.ReserverName,
+		reservation.CancelToken,
+		time.Now(),
+	).Scan(&reservation.ID, &reservation.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("подарок уже забронирован или недоступен")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CancelReservation снимает бронирование по токену отмены, выданному при бронировании
+func (r *PostgresWishlistShareRepository) CancelReservation(ctx context.Context, ownerID int64, wishlistID int64, cancelToken string) error {
+	query := `
+		DELETE FROM wishlist_reservations res
+		USING wishlist w
+		WHERE res.wishlist_id = $1 AND res.cancel_token = $2 AND w.id = res.wishlist_id AND w.user_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, wishlistID, cancelToken, ownerID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("бронирование не найдено")
+	}
+
+	return nil
+}
+
+// scanShare читает публичную ссылку из строки результата
+func (r *PostgresWishlistShareRepository) scanShare(row rowScanner) (*models.WishlistShare, error) {
+	var share models.WishlistShare
+	err := row.Scan(
+		&share.ID,
+		&share.UserID,
+		&share.Token,
+		&share.HidePrices,
+		&share.CreatedAt,
+		&share.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("публичная ссылка не найдена")
+		}
+		return nil, err
+	}
+
+	return &share, nil
+}
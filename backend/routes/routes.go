@@ -20,16 +20,53 @@ func RegisterRoutes(router *mux.Router, db *sql.DB, config *configs.Config) {
 	expenseRepo := repositories.NewExpenseRepository(db)
 	incomeRepo := repositories.NewIncomeRepository(db)
 	wishlistRepo := repositories.NewWishlistRepository(db)
+	wishlistShareRepo := repositories.NewWishlistShareRepository(db)
 	telegramRepo := repositories.NewTelegramUserRepository(db)
+	importRepo := repositories.NewImportRepository(db)
+	archiveRepo := repositories.NewArchiveRepository(db)
+	ledgerRepo := repositories.NewLedgerRepository(db)
+	goalRepo := repositories.NewGoalRepository(db)
+	householdRepo := repositories.NewHouseholdRepository(db)
+	splitRepo := repositories.NewSplitRepository(db)
+	loanRepo := repositories.NewLoanRepository(db)
+	ruleRepo := repositories.NewRuleRepository(db)
+	payeeRepo := repositories.NewPayeeRepository(db)
+	recurringRepo := repositories.NewRecurringRuleRepository(db)
+	alertRepo := repositories.NewAlertRepository(db)
+	assetRepo := repositories.NewAssetRepository(db)
+	netWorthRepo := repositories.NewNetWorthRepository(db)
+	investmentRepo := repositories.NewInvestmentRepository(db)
+	analyticsRepo := repositories.NewAnalyticsRepository(db)
 
 	// Инициализация сервисов
 	authService := services.NewAuthService(config.JWT)
+	calculatorService := services.NewCalculatorService()
 	userService := services.NewUserService(userRepo, authService)
-	expenseService := services.NewExpenseService(expenseRepo, userRepo)
-	incomeService := services.NewIncomeService(incomeRepo, userRepo)
-	dashboardService := services.NewDashboardService(expenseRepo, incomeRepo, userRepo)
-	wishlistService := services.NewWishlistService(wishlistRepo, userRepo)
+	expenseService := services.NewExpenseService(expenseRepo, userRepo, householdRepo, ruleRepo, payeeRepo)
+	incomeService := services.NewIncomeService(incomeRepo, userRepo, householdRepo)
+	dashboardService := services.NewDashboardService(expenseRepo, incomeRepo, userRepo, goalRepo, loanRepo, calculatorService)
+	notificationService := services.NewNotificationService(config.Telegram, telegramRepo)
+	wishlistService := services.NewWishlistService(wishlistRepo, userRepo, expenseRepo, incomeRepo, goalRepo, ruleRepo, payeeRepo, notificationService)
+	wishlistShareService := services.NewWishlistShareService(wishlistShareRepo, userRepo)
 	telegramService := services.NewTelegramService(telegramRepo, userRepo)
+	importService := services.NewImportService(importRepo, userRepo, ruleRepo, payeeRepo)
+	archiveService := services.NewArchiveService(archiveRepo, userRepo, expenseRepo, incomeRepo, wishlistRepo, telegramRepo, goalRepo,
+		ledgerRepo, loanRepo, assetRepo, netWorthRepo, investmentRepo, ruleRepo, payeeRepo, recurringRepo)
+	ledgerService := services.NewLedgerService(ledgerRepo, expenseRepo, incomeRepo, userRepo)
+	reportService := services.NewReportService(dashboardService, userRepo, config.Reports)
+	goalService := services.NewGoalService(goalRepo, userRepo)
+	householdService := services.NewHouseholdService(householdRepo, userRepo, telegramRepo, notificationService)
+	splitService := services.NewSplitService(splitRepo, userRepo)
+	loanService := services.NewLoanService(loanRepo, expenseRepo, userRepo, calculatorService)
+	investmentService := services.NewInvestmentService(investmentRepo, userRepo)
+	ruleService := services.NewRuleService(ruleRepo, expenseRepo, userRepo)
+	payeeService := services.NewPayeeService(payeeRepo, expenseRepo, userRepo)
+	subscriptionService := services.NewSubscriptionService(recurringRepo, expenseRepo, payeeRepo, userRepo)
+	alertService := services.NewAlertService(alertRepo, expenseRepo, userRepo, notificationService)
+	analyticsService := services.NewAnalyticsService(analyticsRepo, userRepo)
+	forecastService := services.NewForecastService(expenseRepo, incomeRepo, userRepo, loanRepo, recurringRepo, calculatorService)
+	comparisonService := services.NewComparisonService(expenseRepo, incomeRepo, userRepo)
+	netWorthService := services.NewNetWorthService(assetRepo, netWorthRepo, loanRepo, investmentRepo, userRepo)
 	calculatorHandler := handlers.NewCalculatorHandler()
 
 	// Инициализация обработчиков
@@ -38,6 +75,25 @@ func RegisterRoutes(router *mux.Router, db *sql.DB, config *configs.Config) {
 	incomeHandler := handlers.NewIncomeHandler(incomeService)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
 	wishlistHandler := handlers.NewWishlistHandler(wishlistService)
+	wishlistShareHandler := handlers.NewWishlistShareHandler(wishlistShareService)
+	importHandler := handlers.NewImportHandler(importService)
+	archiveHandler := handlers.NewArchiveHandler(archiveService)
+	exportHandler := handlers.NewExportHandler(expenseService, incomeService, userService)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	goalHandler := handlers.NewGoalHandler(goalService)
+	householdHandler := handlers.NewHouseholdHandler(householdService)
+	splitHandler := handlers.NewSplitHandler(splitService)
+	loanHandler := handlers.NewLoanHandler(loanService)
+	netWorthHandler := handlers.NewNetWorthHandler(netWorthService)
+	investmentHandler := handlers.NewInvestmentHandler(investmentService)
+	ruleHandler := handlers.NewRuleHandler(ruleService)
+	payeeHandler := handlers.NewPayeeHandler(payeeService)
+	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService)
+	alertHandler := handlers.NewAlertHandler(alertService)
+	forecastHandler := handlers.NewForecastHandler(forecastService)
+	comparisonHandler := handlers.NewComparisonHandler(comparisonService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
 
 	// Настройка маршрутов для публичных API
 	public := router.PathPrefix("/api").Subrouter()
@@ -50,6 +106,11 @@ func RegisterRoutes(router *mux.Router, db *sql.DB, config *configs.Config) {
 	public.HandleFunc("/calculators/compound-interest", calculatorHandler.CompoundInterestCalculator).Methods("POST")
 	public.HandleFunc("/calculators/mortgage", calculatorHandler.MortgageCalculator).Methods("POST")
 
+	// Публичный просмотр списка желаний по ссылке и бронирование подарков
+	public.HandleFunc("/public/wishlist/{token}", wishlistShareHandler.GetPublicWishlist).Methods("GET")
+	public.HandleFunc("/public/wishlist/{token}/items/{id:[0-9]+}/reserve", wishlistShareHandler.ReserveItem).Methods("POST")
+	public.HandleFunc("/public/wishlist/{token}/items/{id:[0-9]+}/reserve", wishlistShareHandler.CancelReservation).Methods("DELETE")
+
 	// Настройка маршрутов для статических файлов
 	public.PathPrefix("/uploads/").Handler(http.StripPrefix("/api/uploads/", http.FileServer(http.Dir("./uploads"))))
 
@@ -63,18 +124,55 @@ func RegisterRoutes(router *mux.Router, db *sql.DB, config *configs.Config) {
 	private.HandleFunc("/users/me", userHandler.DeleteUser).Methods("DELETE")
 	private.HandleFunc("/users/me/avatar", userHandler.UploadAvatar).Methods("POST")
 	private.HandleFunc("/users/me/avatar", userHandler.RemoveAvatar).Methods("DELETE")
+	private.HandleFunc("/users/me/export", archiveHandler.ExportArchive).Methods("GET")
+	private.HandleFunc("/users/me/import", archiveHandler.ImportArchive).Methods("POST")
 
 	// Маршруты для трат
 	private.HandleFunc("/expenses", expenseHandler.CreateExpense).Methods("POST")
 	private.HandleFunc("/expenses", expenseHandler.GetUserExpenses).Methods("GET")
+	private.HandleFunc("/expenses/export", exportHandler.ExportExpenses).Methods("GET")
 	private.HandleFunc("/expenses/{id:[0-9]+}", expenseHandler.GetExpense).Methods("GET")
 	private.HandleFunc("/expenses/{id:[0-9]+}", expenseHandler.UpdateExpense).Methods("PUT")
 	private.HandleFunc("/expenses/{id:[0-9]+}", expenseHandler.DeleteExpense).Methods("DELETE")
 	private.HandleFunc("/expenses/summary", expenseHandler.GetExpenseSummary).Methods("GET")
+	private.HandleFunc("/expenses/suggest-category", expenseHandler.SuggestCategory).Methods("POST")
+
+	// Маршруты для правил автоматической категоризации трат
+	private.HandleFunc("/rules", ruleHandler.CreateRule).Methods("POST")
+	private.HandleFunc("/rules", ruleHandler.GetUserRules).Methods("GET")
+	private.HandleFunc("/rules/order", ruleHandler.ReorderRules).Methods("PUT")
+	private.HandleFunc("/rules/test", ruleHandler.TestRule).Methods("POST")
+	private.HandleFunc("/rules/{id:[0-9]+}", ruleHandler.GetRule).Methods("GET")
+	private.HandleFunc("/rules/{id:[0-9]+}", ruleHandler.UpdateRule).Methods("PUT")
+	private.HandleFunc("/rules/{id:[0-9]+}", ruleHandler.DeleteRule).Methods("DELETE")
+
+	// Маршруты для получателей платежей
+	private.HandleFunc("/payees", payeeHandler.CreatePayee).Methods("POST")
+	private.HandleFunc("/payees", payeeHandler.GetUserPayees).Methods("GET")
+	private.HandleFunc("/payees/link", payeeHandler.LinkExpenses).Methods("POST")
+	private.HandleFunc("/payees/top", payeeHandler.GetTopPayees).Methods("GET")
+	private.HandleFunc("/payees/{id:[0-9]+}", payeeHandler.GetPayee).Methods("GET")
+	private.HandleFunc("/payees/{id:[0-9]+}", payeeHandler.UpdatePayee).Methods("PUT")
+	private.HandleFunc("/payees/{id:[0-9]+}", payeeHandler.DeletePayee).Methods("DELETE")
+
+	// Маршруты для подписок и регулярных списаний
+	private.HandleFunc("/subscriptions", subscriptionHandler.DetectSubscriptions).Methods("GET")
+	private.HandleFunc("/subscriptions/confirm", subscriptionHandler.ConfirmSubscription).Methods("POST")
+	private.HandleFunc("/recurring-rules", subscriptionHandler.GetRecurringRules).Methods("GET")
+	private.HandleFunc("/recurring-rules/{id:[0-9]+}", subscriptionHandler.GetRecurringRule).Methods("GET")
+	private.HandleFunc("/recurring-rules/{id:[0-9]+}", subscriptionHandler.UpdateRecurringRule).Methods("PUT")
+	private.HandleFunc("/recurring-rules/{id:[0-9]+}", subscriptionHandler.DeleteRecurringRule).Methods("DELETE")
+
+	// Маршруты для предупреждений о необычных тратах
+	private.HandleFunc("/alerts", alertHandler.GetAlerts).Methods("GET")
+	private.HandleFunc("/alerts/check", alertHandler.CheckAlerts).Methods("POST")
+	private.HandleFunc("/alerts/read", alertHandler.MarkAllAlertsRead).Methods("PUT")
+	private.HandleFunc("/alerts/{id:[0-9]+}/read", alertHandler.MarkAlertRead).Methods("PUT")
 
 	// Маршруты для накоплений/доходов
 	private.HandleFunc("/incomes", incomeHandler.CreateIncome).Methods("POST")
 	private.HandleFunc("/incomes", incomeHandler.GetUserIncomes).Methods("GET")
+	private.HandleFunc("/incomes/export", exportHandler.ExportIncomes).Methods("GET")
 	private.HandleFunc("/incomes/{id:[0-9]+}", incomeHandler.GetIncome).Methods("GET")
 	private.HandleFunc("/incomes/{id:[0-9]+}", incomeHandler.UpdateIncome).Methods("PUT")
 	private.HandleFunc("/incomes/{id:[0-9]+}", incomeHandler.DeleteIncome).Methods("DELETE")
@@ -86,11 +184,114 @@ func RegisterRoutes(router *mux.Router, db *sql.DB, config *configs.Config) {
 	private.HandleFunc("/wishlist/{id:[0-9]+}", wishlistHandler.GetWishlistItem).Methods("GET")
 	private.HandleFunc("/wishlist/{id:[0-9]+}", wishlistHandler.UpdateWishlistItem).Methods("PUT")
 	private.HandleFunc("/wishlist/{id:[0-9]+}", wishlistHandler.DeleteWishlistItem).Methods("DELETE")
+	private.HandleFunc("/wishlist/{id:[0-9]+}/purchase", wishlistHandler.PurchaseWishlistItem).Methods("POST")
+	private.HandleFunc("/wishlist/{id:[0-9]+}/prices", wishlistHandler.RecordPrice).Methods("POST")
+	private.HandleFunc("/wishlist/share", wishlistShareHandler.GetShare).Methods("GET")
+	private.HandleFunc("/wishlist/share", wishlistShareHandler.UpdateShare).Methods("PUT")
+	private.HandleFunc("/wishlist/share", wishlistShareHandler.RevokeShare).Methods("DELETE")
+	private.HandleFunc("/wishlist/plan", wishlistHandler.GetAffordabilityPlan).Methods("GET")
+	private.HandleFunc("/wishlist/{id:[0-9]+}/allocations", wishlistHandler.AddAllocation).Methods("POST")
+	private.HandleFunc("/wishlist/{id:[0-9]+}/allocations/{allocation_id:[0-9]+}", wishlistHandler.DeleteAllocation).Methods("DELETE")
+
+	// Маршруты для целей накоплений
+	private.HandleFunc("/goals", goalHandler.CreateGoal).Methods("POST")
+	private.HandleFunc("/goals", goalHandler.GetUserGoals).Methods("GET")
+	private.HandleFunc("/goals/{id:[0-9]+}", goalHandler.GetGoal).Methods("GET")
+	private.HandleFunc("/goals/{id:[0-9]+}", goalHandler.UpdateGoal).Methods("PUT")
+	private.HandleFunc("/goals/{id:[0-9]+}", goalHandler.DeleteGoal).Methods("DELETE")
+	private.HandleFunc("/goals/{id:[0-9]+}/contributions", goalHandler.AddContribution).Methods("POST")
+	private.HandleFunc("/goals/{id:[0-9]+}/contributions/{contribution_id:[0-9]+}", goalHandler.DeleteContribution).Methods("DELETE")
+
+	// Маршруты для домохозяйств и общего бюджета
+	private.HandleFunc("/households", householdHandler.CreateHousehold).Methods("POST")
+	private.HandleFunc("/households", householdHandler.GetUserHouseholds).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}", householdHandler.GetHousehold).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}", householdHandler.UpdateHousehold).Methods("PUT")
+	private.HandleFunc("/households/{id:[0-9]+}", householdHandler.DeleteHousehold).Methods("DELETE")
+	private.HandleFunc("/households/{id:[0-9]+}/dashboard", householdHandler.GetDashboard).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}/expenses", householdHandler.GetHouseholdExpenses).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}/incomes", householdHandler.GetHouseholdIncomes).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}/budgets", householdHandler.GetBudgets).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}/budgets", householdHandler.SetBudget).Methods("PUT")
+	private.HandleFunc("/households/{id:[0-9]+}/invitations", householdHandler.InviteMember).Methods("POST")
+	private.HandleFunc("/households/{id:[0-9]+}/invitations", householdHandler.GetHouseholdInvitations).Methods("GET")
+	private.HandleFunc("/households/{id:[0-9]+}/invitations/{invitation_id:[0-9]+}", householdHandler.RevokeInvitation).Methods("DELETE")
+	private.HandleFunc("/households/{id:[0-9]+}/members/{member_id:[0-9]+}", householdHandler.UpdateMemberRole).Methods("PUT")
+	private.HandleFunc("/households/{id:[0-9]+}/members/{member_id:[0-9]+}", householdHandler.RemoveMember).Methods("DELETE")
+	private.HandleFunc("/households/invitations", householdHandler.GetMyInvitations).Methods("GET")
+	private.HandleFunc("/households/invitations/{id:[0-9]+}/accept", householdHandler.AcceptInvitation).Methods("POST")
+	private.HandleFunc("/households/invitations/{id:[0-9]+}/decline", householdHandler.DeclineInvitation).Methods("POST")
+
+	// Маршруты для кредитов и платежей по ним
+	private.HandleFunc("/loans", loanHandler.CreateLoan).Methods("POST")
+	private.HandleFunc("/loans", loanHandler.GetUserLoans).Methods("GET")
+	private.HandleFunc("/loans/{id:[0-9]+}", loanHandler.GetLoan).Methods("GET")
+	private.HandleFunc("/loans/{id:[0-9]+}", loanHandler.UpdateLoan).Methods("PUT")
+	private.HandleFunc("/loans/{id:[0-9]+}", loanHandler.DeleteLoan).Methods("DELETE")
+	private.HandleFunc("/loans/{id:[0-9]+}/payments", loanHandler.AddPayment).Methods("POST")
+	private.HandleFunc("/loans/{id:[0-9]+}/payments/{payment_id:[0-9]+}", loanHandler.DeletePayment).Methods("DELETE")
+
+	// Маршруты для активов, обязательств и чистой стоимости капитала
+	private.HandleFunc("/assets", netWorthHandler.CreateAsset).Methods("POST")
+	private.HandleFunc("/assets", netWorthHandler.GetUserAssets).Methods("GET")
+	private.HandleFunc("/assets/{id:[0-9]+}", netWorthHandler.GetAsset).Methods("GET")
+	private.HandleFunc("/assets/{id:[0-9]+}", netWorthHandler.UpdateAsset).Methods("PUT")
+	private.HandleFunc("/assets/{id:[0-9]+}", netWorthHandler.DeleteAsset).Methods("DELETE")
+	private.HandleFunc("/assets/{id:[0-9]+}/valuations", netWorthHandler.AddValuation).Methods("POST")
+	private.HandleFunc("/assets/{id:[0-9]+}/valuations/{valuation_id:[0-9]+}", netWorthHandler.DeleteValuation).Methods("DELETE")
+	private.HandleFunc("/networth", netWorthHandler.GetNetWorth).Methods("GET")
+	private.HandleFunc("/networth/snapshot", netWorthHandler.CreateSnapshot).Methods("POST")
+
+	// Маршруты для инвестиционного портфеля и цен ценных бумаг
+	private.HandleFunc("/investments/portfolio", investmentHandler.GetPortfolio).Methods("GET")
+	private.HandleFunc("/investments/transactions", investmentHandler.AddTransaction).Methods("POST")
+	private.HandleFunc("/investments/transactions", investmentHandler.GetTransactions).Methods("GET")
+	private.HandleFunc("/investments/transactions/{id:[0-9]+}", investmentHandler.DeleteTransaction).Methods("DELETE")
+	private.HandleFunc("/investments/prices", investmentHandler.GetPrices).Methods("GET")
+	private.HandleFunc("/investments/prices", investmentHandler.UpdatePrices).Methods("PUT")
+	private.HandleFunc("/investments/prices/import", investmentHandler.ImportPrices).Methods("POST")
+	private.HandleFunc("/investments/prices/{ticker}/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}", investmentHandler.DeletePrice).Methods("DELETE")
+
+	// Маршруты для разделения общих трат и долгов между участниками
+	private.HandleFunc("/splits/groups", splitHandler.CreateGroup).Methods("POST")
+	private.HandleFunc("/splits/groups", splitHandler.GetUserGroups).Methods("GET")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}", splitHandler.GetGroup).Methods("GET")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}", splitHandler.DeleteGroup).Methods("DELETE")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/participants", splitHandler.AddParticipant).Methods("POST")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/participants/{participant_id:[0-9]+}", splitHandler.RemoveParticipant).Methods("DELETE")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/expenses", splitHandler.CreateExpense).Methods("POST")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/expenses", splitHandler.GetExpenses).Methods("GET")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/expenses/{expense_id:[0-9]+}", splitHandler.DeleteExpense).Methods("DELETE")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/settlements", splitHandler.CreateSettlement).Methods("POST")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/settlements", splitHandler.GetSettlements).Methods("GET")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/settlements/{settlement_id:[0-9]+}", splitHandler.DeleteSettlement).Methods("DELETE")
+	private.HandleFunc("/splits/groups/{id:[0-9]+}/balances", splitHandler.GetBalances).Methods("GET")
+
+	// Маршруты для импорта банковских выписок
+	private.HandleFunc("/imports", importHandler.CreateImport).Methods("POST")
+	private.HandleFunc("/imports", importHandler.GetUserImports).Methods("GET")
+	private.HandleFunc("/imports/{id:[0-9]+}", importHandler.GetImport).Methods("GET")
+	private.HandleFunc("/imports/{id:[0-9]+}/commit", importHandler.CommitImport).Methods("POST")
+	private.HandleFunc("/imports/{id:[0-9]+}/rollback", importHandler.RollbackImport).Methods("POST")
+
+	// Маршруты для выгрузки в текстовую бухгалтерию
+	private.HandleFunc("/exports/ledger", ledgerHandler.ExportLedger).Methods("GET")
+	private.HandleFunc("/exports/ledger/accounts", ledgerHandler.GetLedgerAccounts).Methods("GET")
+	private.HandleFunc("/exports/ledger/accounts", ledgerHandler.UpdateLedgerAccounts).Methods("PUT")
 
 	// Маршруты для информационной панели
 	private.HandleFunc("/dashboard", dashboardHandler.GetDashboardSummary).Methods("GET")
 	private.HandleFunc("/dashboard/monthly/{year:[0-9]+}/{month:[0-9]+}", dashboardHandler.GetMonthlyStats).Methods("GET")
 	private.HandleFunc("/dashboard/yearly/{year:[0-9]+}", dashboardHandler.GetYearlyStats).Methods("GET")
+	private.HandleFunc("/dashboard/forecast", forecastHandler.GetForecast).Methods("GET")
+	private.HandleFunc("/dashboard/compare", comparisonHandler.ComparePeriods).Methods("GET")
+	private.HandleFunc("/dashboard/compare/yearly", comparisonHandler.CompareYearOverYear).Methods("GET")
+
+	// Маршруты для гибкой аналитики
+	private.HandleFunc("/analytics", analyticsHandler.Query).Methods("GET")
+
+	// Маршруты для печатных отчетов
+	private.HandleFunc("/reports/monthly/{year:[0-9]+}/{month:[0-9]+}.pdf", reportHandler.GetMonthlyReport).Methods("GET")
 
 	// Маршруты для бюджетных целей
 	private.HandleFunc("/budget/goals", dashboardHandler.GetBudgetGoals).Methods("GET")
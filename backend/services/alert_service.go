@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+const (
+	// anomalyScoreThreshold задает робастную z-оценку, начиная с которой трата считается необычной
+	anomalyScoreThreshold = 3.5
+	// anomalyMinRatio задает минимальное превышение обычного значения, чтобы не реагировать на мелкие колебания
+	anomalyMinRatio = 1.5
+	// anomalyMinDeviation задает минимальное отклонение как долю медианы
+	anomalyMinDeviation = 0.1
+	// anomalyMinSamples задает минимальное количество трат в категории для оценки отдельной траты
+	anomalyMinSamples = 8
+	// anomalyHistoryMonths задает глубину истории, по которой оцениваются траты
+	anomalyHistoryMonths = 12
+	// anomalySpikeMonths задает количество предыдущих месяцев для оценки трат в категории за месяц
+	anomalySpikeMonths = 6
+	// anomalySpikeMinMonths задает минимальное количество месяцев с тратами в категории
+	anomalySpikeMinMonths = 3
+	// anomalyLookback задает, за какой срок проверяются новые траты
+	anomalyLookback = 7 * 24 * time.Hour
+	// alertListLimit ограничивает количество предупреждений в выдаче
+	alertListLimit = 100
+)
+
+// AlertServiceImpl представляет реализацию сервиса предупреждений о необычных тратах
+type AlertServiceImpl struct {
+	alertRepo   repositories.AlertRepository
+	expenseRepo repositories.ExpenseRepository
+	userRepo    repositories.UserRepository
+	notifier    NotificationService
+}
+
+// NewAlertService создает новый экземпляр сервиса предупреждений
+func NewAlertService(alertRepo repositories.AlertRepository, expenseRepo repositories.ExpenseRepository, userRepo repositories.UserRepository, notifier NotificationService) AlertService {
+	return &AlertServiceImpl{
+		alertRepo:   alertRepo,
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+		notifier:    notifier,
+	}
+}
+
+// CheckUser проверяет новые траты пользователя, сохраняет найденные предупреждения
+// и отправляет их в Telegram. Возвращает только впервые найденные предупреждения
+func (s *AlertServiceImpl) CheckUser(ctx context.Context, userID int64) ([]models.Alert, error) {
+	// Получаем пользователя: месяцы сравниваются в его часовом поясе
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	now := time.Now().In(user.Location())
+	monthStart, _ := utils.CurrentMonthPeriod(now)
+	expenses, err := s.expenseRepo.GetByUserIDAndPeriod(ctx, userID, monthStart.AddDate(0, -anomalyHistoryMonths, 0), now)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+
+	created := make([]models.Alert, 0)
+	for _, alert := range detectAnomalies(userID, expenses, now) {
+		ok, err := s.alertRepo.Create(ctx, &alert)
+		if err != nil {
+			return nil, errors.New("ошибка при сохранении предупреждения")
+		}
+		if !ok {
+			continue
+		}
+
+		if s.notifier.Enabled() {
+			if err := s.notifier.NotifyUser(ctx, userID, alert.Message); err != nil {
+				fmt.Printf("Не удалось отправить предупреждение пользователю ID=%d: %v\n", userID, err)
+			} else if err := s.alertRepo.MarkNotified(ctx, alert.ID); err == nil {
+				alert.Notified = true
+			}
+		}
+		created = append(created, alert)
+	}
+
+	return created, nil
+}
+
+// CheckAll проверяет траты пользователей, добавленные за последнюю неделю.
+// Используется фоновой задачей и возвращает количество новых предупреждений
+func (s *AlertServiceImpl) CheckAll(ctx context.Context) (int, error) {
+	userIDs, err := s.alertRepo.GetUserIDsWithRecentExpenses(ctx, time.Now().Add(-anomalyLookback))
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, userID := range userIDs {
+		alerts, err := s.CheckUser(ctx, userID)
+		if err != nil {
+			fmt.Printf("Не удалось проверить траты пользователя ID=%d: %v\n", userID, err)
+			continue
+		}
+		total += len(alerts)
+	}
+
+	return total, nil
+}
+
+// GetAlerts получает последние предупреждения пользователя
+func (s *AlertServiceImpl) GetAlerts(ctx context.Context, userID int64, unreadOnly bool) ([]models.Alert, error) {
+	alerts, err := s.alertRepo.GetByUserID(ctx, userID, unreadOnly, alertListLimit)
+	if err != nil {
+		return nil, errors.New("ошибка при получении предупреждений")
+	}
+	if alerts == nil {
+		alerts = []models.Alert{}
+	}
+
+	return alerts, nil
+}
+
+// MarkAlertRead отмечает предупреждение прочитанным
+func (s *AlertServiceImpl) MarkAlertRead(ctx context.Context, id int64, userID int64) error {
+	return s.alertRepo.MarkRead(ctx, id, userID)
+}
+
+// MarkAllAlertsRead отмечает прочитанными все предупреждения пользователя
+func (s *AlertServiceImpl) MarkAllAlertsRead(ctx context.Context, userID int64) error {
+	if err := s.alertRepo.MarkAllRead(ctx, userID); err != nil {
+		return errors.New("ошибка при обновлении предупреждений")
+	}
+	return nil
+}
+
+// detectAnomalies сравнивает траты за последнюю неделю с историей трат в их категориях,
+// а суммы по категориям за текущий месяц — с суммами за предыдущие месяцы
+func detectAnomalies(userID int64, expenses []models.Expense, now time.Time) []models.Alert {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	byCategory := make(map[models.ExpenseCategory][]models.Expense)
+	for _, expense := range expenses {
+		byCategory[expense.Category] = append(byCategory[expense.Category], expense)
+	}
+
+	var alerts []models.Alert
+
+	// Необычно крупные траты относительно предыдущих трат в той же категории
+	for _, expense := range expenses {
+		if expense.Date.After(now) || now.Sub(expense.Date) > anomalyLookback {
+			continue
+		}
+
+		var samples []float64
+		for _, other := range byCategory[expense.Category] {
+			if other.ID != expense.ID && !other.Date.After(expense.Date) {
+				samples = append(samples, other.Amount)
+			}
+		}
+		if len(samples) < anomalyMinSamples {
+			continue
+		}
+
+		score, expected, ok := robustScore(expense.Amount, samples)
+		if !ok || score < anomalyScoreThreshold || expense.Amount < expected*anomalyMinRatio {
+			continue
+		}
+
+		// Месяц траты определяется в часовом поясе пользователя, как и текущий месяц
+		date := expense.Date.In(now.Location())
+		expenseID := expense.ID
+		alerts = append(alerts, models.Alert{
+			UserID:    userID,
+			Kind:      models.AlertUnusualAmount,
+			ExpenseID: &expenseID,
+			Category:  expense.Category,
+			Period:    time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, now.Location()),
+			Amount:    expense.Amount,
+			Expected:  roundMoney(expected),
+			Score:     roundMoney(score),
+			Message: fmt.Sprintf("Необычная трата «%s»: %.2f руб. в категории «%s», обычно около %.2f руб.",
+				expense.Title, expense.Amount, models.ExpenseCategoryTitles[expense.Category], expected),
+			CreatedAt: now,
+		})
+	}
+
+	// Всплески трат в категории за текущий месяц относительно предыдущих месяцев
+	for _, category := range models.ExpenseCategories {
+		totals := make([]float64, anomalySpikeMonths+1)
+		for _, expense := range byCategory[category] {
+			for i := 0; i <= anomalySpikeMonths; i++ {
+				start := monthStart.AddDate(0, -i, 0)
+				if !expense.Date.Before(start) && expense.Date.Before(start.AddDate(0, 1, 0)) {
+					totals[i] += expense.Amount
+					break
+				}
+			}
+		}
+
+		current := totals[0]
+		if current == 0 {
+			continue
+		}
+
+		samples := totals[1:]
+		active := 0
+		for _, total := range samples {
+			if total > 0 {
+				active++
+			}
+		}
+		if active < anomalySpikeMinMonths {
+			continue
+		}
+
+		score, expected, ok := robustScore(current, samples)
+		if !ok || score < anomalyScoreThreshold || current < expected*anomalyMinRatio {
+			continue
+		}
+
+		alerts = append(alerts, models.Alert{
+			UserID:   userID,
+			Kind:     models.AlertCategorySpike,
+			Category: category,
+			Period:   monthStart,
+			Amount:   roundMoney(current),
+			Expected: roundMoney(expected),
+			Score:    roundMoney(score),
+			Message: fmt.Sprintf("Траты в категории «%s» за %s уже %.2f руб., обычно около %.2f руб. в месяц",
+				models.ExpenseCategoryTitles[category], monthStart.Format("01.2006"), current, expected),
+			CreatedAt: now,
+		})
+	}
+
+	return alerts
+}
+
+// robustScore рассчитывает робастную z-оценку значения по медиане и медианному абсолютному
+// отклонению выборки. Отклонение не опускается ниже доли медианы, чтобы постоянные траты
+// не давали бесконечной оценки. Возвращает оценку, медиану выборки и признак того,
+// что оценку удалось рассчитать
+func robustScore(value float64, samples []float64) (float64, float64, bool) {
+	median := medianValue(samples)
+
+	deviations := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		deviations = append(deviations, math.Abs(sample-median))
+	}
+	mad := math.Max(medianValue(deviations), median*anomalyMinDeviation)
+	if mad <= 0 {
+		return 0, median, false
+	}
+
+	return 0.6745 * (value - median) / mad, median, true
+}
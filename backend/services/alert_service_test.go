@@ -0,0 +1,118 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestRobustScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      float64
+		samples    []float64
+		wantScore  float64
+		wantMedian float64
+		wantOK     bool
+	}{
+		{
+			name:       "обычный разброс",
+			value:      200,
+			samples:    []float64{100, 110, 90, 105, 95},
+			wantScore:  6.745,
+			wantMedian: 100,
+			wantOK:     true,
+		},
+		{
+			name:       "выброс в выборке не влияет на оценку",
+			value:      100,
+			samples:    []float64{10, 20, 30, 40, 1000},
+			wantScore:  4.7215,
+			wantMedian: 30,
+			wantOK:     true,
+		},
+		{
+			name:       "постоянные траты",
+			value:      1000,
+			samples:    []float64{500, 500, 500, 500},
+			wantScore:  6.745,
+			wantMedian: 500,
+			wantOK:     true,
+		},
+		{
+			name:       "значение на уровне медианы",
+			value:      100,
+			samples:    []float64{80, 100, 120},
+			wantScore:  0,
+			wantMedian: 100,
+			wantOK:     true,
+		},
+		{
+			name:       "трата ниже обычной",
+			value:      50,
+			samples:    []float64{100, 110, 90, 105, 95},
+			wantScore:  -3.3725,
+			wantMedian: 100,
+			wantOK:     true,
+		},
+		{
+			name:    "нулевые траты",
+			value:   100,
+			samples: []float64{0, 0, 0},
+		},
+		{
+			name:  "пустая выборка",
+			value: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, median, ok := robustScore(tt.value, tt.samples)
+			if ok != tt.wantOK {
+				t.Fatalf("признак расчета %v, ожидалось %v", ok, tt.wantOK)
+			}
+			if median != tt.wantMedian {
+				t.Errorf("медиана %g, ожидалось %g", median, tt.wantMedian)
+			}
+			if math.Abs(score-tt.wantScore) > 1e-9 {
+				t.Errorf("оценка %g, ожидалось %g", score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestDetectAnomaliesPeriod(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("не удалось загрузить часовой пояс: %v", err)
+	}
+	now := time.Date(2024, time.April, 2, 12, 0, 0, 0, moscow)
+
+	var expenses []models.Expense
+	for i, amount := range []float64{90, 95, 100, 105, 110, 100, 95, 105} {
+		expenses = append(expenses, models.Expense{
+			ID: int64(i + 1), Title: "Такси", Amount: amount, Category: models.CategoryTransport,
+			Date: time.Date(2024, time.January, i+1, 12, 0, 0, 0, time.UTC),
+		})
+	}
+	// По UTC трата сделана 31 марта, а в часовом поясе пользователя - уже 1 апреля
+	expenses = append(expenses, models.Expense{
+		ID: 9, Title: "Такси в аэропорт", Amount: 1000, Category: models.CategoryTransport,
+		Date: time.Date(2024, time.March, 31, 22, 0, 0, 0, time.UTC),
+	})
+
+	alerts := detectAnomalies(1, expenses, now)
+	if len(alerts) != 1 {
+		t.Fatalf("получено %d предупреждений, ожидалось 1: %+v", len(alerts), alerts)
+	}
+	if alerts[0].Kind != models.AlertUnusualAmount {
+		t.Errorf("вид предупреждения %q, ожидался %q", alerts[0].Kind, models.AlertUnusualAmount)
+	}
+	want := time.Date(2024, time.April, 1, 0, 0, 0, 0, moscow)
+	if !alerts[0].Period.Equal(want) {
+		t.Errorf("период %v, ожидался %v", alerts[0].Period, want)
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+// analyticsMaxGroups ограничивает количество одновременных группировок
+const analyticsMaxGroups = 3
+
+// AnalyticsServiceImpl представляет реализацию сервиса гибкой аналитики
+type AnalyticsServiceImpl struct {
+	analyticsRepo repositories.AnalyticsRepository
+	userRepo      repositories.UserRepository
+}
+
+// NewAnalyticsService создает новый экземпляр сервиса аналитики
+func NewAnalyticsService(analyticsRepo repositories.AnalyticsRepository, userRepo repositories.UserRepository) AnalyticsService {
+	return &AnalyticsServiceImpl{
+		analyticsRepo: analyticsRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// Query проверяет запрос аналитики и выполняет его. Без группировок и интервала возвращается
+// одна строка с показателями за весь период. При группировке по меткам трата с несколькими
+// метками учитывается в каждой из них
+func (s *AnalyticsServiceImpl) Query(ctx context.Context, userID int64, query *models.AnalyticsQuery) (*models.AnalyticsResult, error) {
+	// Получаем пользователя: его часовой пояс используется, если пояс не указан в запросе
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	if query.Timezone == "" {
+		query.Timezone = user.TimezoneName()
+	}
+	if err := normalizeAnalyticsQuery(query); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.analyticsRepo.Query(ctx, userID, query)
+	if err != nil {
+		fmt.Printf("Ошибка запроса аналитики пользователя ID=%d: %v\n", userID, err)
+		return nil, errors.New("ошибка при расчете аналитики")
+	}
+	if rows == nil {
+		rows = []models.AnalyticsRow{}
+	}
+
+	for i := range rows {
+		if rows[i].Sum != nil {
+			*rows[i].Sum = roundMoney(*rows[i].Sum)
+		}
+		if rows[i].Avg != nil {
+			*rows[i].Avg = roundMoney(*rows[i].Avg)
+		}
+		if rows[i].Median != nil {
+			*rows[i].Median = roundMoney(*rows[i].Median)
+		}
+	}
+
+	return &models.AnalyticsResult{Query: *query, Rows: rows}, nil
+}
+
+// normalizeAnalyticsQuery проверяет параметры запроса по белым спискам, убирает повторы
+// и подставляет значения по умолчанию
+func normalizeAnalyticsQuery(query *models.AnalyticsQuery) error {
+	if query.Dataset == "" {
+		query.Dataset = models.AnalyticsExpenses
+	}
+	allowedGroups, ok := models.AnalyticsDatasetGroups[query.Dataset]
+	if !ok {
+		return fmt.Errorf("неизвестный набор данных: %s", query.Dataset)
+	}
+
+	if query.Timezone == "" {
+		query.Timezone = models.DefaultTimezone
+	}
+	location, err := models.LoadTimezone(query.Timezone)
+	if err != nil {
+		return err
+	}
+
+	// По умолчанию берется текущий месяц в часовом поясе запроса
+	monthStart, monthEnd := utils.CurrentMonthPeriod(time.Now().In(location))
+	if query.StartDate.IsZero() {
+		query.StartDate = monthStart
+	}
+	if query.EndDate.IsZero() {
+		query.EndDate = monthEnd
+	}
+	if query.EndDate.Before(query.StartDate) {
+		return errors.New("дата окончания периода раньше даты начала")
+	}
+
+	if query.Interval != "" && !isAnalyticsInterval(query.Interval) {
+		return fmt.Errorf("неизвестный интервал: %s", query.Interval)
+	}
+
+	allowed := make(map[models.AnalyticsGroup]bool, len(allowedGroups))
+	for _, group := range allowedGroups {
+		allowed[group] = true
+	}
+
+	seenGroups := make(map[models.AnalyticsGroup]bool)
+	groups := make([]models.AnalyticsGroup, 0, len(query.GroupBy))
+	for _, group := range query.GroupBy {
+		if !allowed[group] {
+			return fmt.Errorf("группировка %s недоступна для набора %s", group, query.Dataset)
+		}
+		if !seenGroups[group] {
+			seenGroups[group] = true
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) > analyticsMaxGroups {
+		return fmt.Errorf("можно указать не более %d группировок", analyticsMaxGroups)
+	}
+	query.GroupBy = groups
+
+	seenMetrics := make(map[models.AnalyticsMetric]bool)
+	metrics := make([]models.AnalyticsMetric, 0, len(query.Metrics))
+	for _, metric := range query.Metrics {
+		if !isAnalyticsMetric(metric) {
+			return fmt.Errorf("неизвестный показатель: %s", metric)
+		}
+		if !seenMetrics[metric] {
+			seenMetrics[metric] = true
+			metrics = append(metrics, metric)
+		}
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, models.MetricSum)
+	}
+	query.Metrics = metrics
+
+	return nil
+}
+
+// isAnalyticsInterval проверяет, что интервал аналитики существует
+func isAnalyticsInterval(interval models.AnalyticsInterval) bool {
+	for _, known := range models.AnalyticsIntervals {
+		if known == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// isAnalyticsMetric проверяет, что показатель аналитики существует
+func isAnalyticsMetric(metric models.AnalyticsMetric) bool {
+	for _, known := range models.AnalyticsMetrics {
+		if known == metric {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestNormalizeAnalyticsQuery(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		query       models.AnalyticsQuery
+		wantGroups  []models.AnalyticsGroup
+		wantMetrics []models.AnalyticsMetric
+		wantErr     bool
+	}{
+		{
+			name:        "повторы убираются с сохранением порядка",
+			query:       models.AnalyticsQuery{StartDate: start, EndDate: end, GroupBy: []models.AnalyticsGroup{models.GroupByTag, models.GroupByCategory, models.GroupByTag}, Metrics: []models.AnalyticsMetric{models.MetricCount, models.MetricAvg, models.MetricCount}},
+			wantGroups:  []models.AnalyticsGroup{models.GroupByTag, models.GroupByCategory},
+			wantMetrics: []models.AnalyticsMetric{models.MetricCount, models.MetricAvg},
+		},
+		{
+			name:        "сумма по умолчанию",
+			query:       models.AnalyticsQuery{Dataset: models.AnalyticsIncomes, StartDate: start, EndDate: end, GroupBy: []models.AnalyticsGroup{models.GroupBySource}, Interval: models.IntervalWeek},
+			wantGroups:  []models.AnalyticsGroup{models.GroupBySource},
+			wantMetrics: []models.AnalyticsMetric{models.MetricSum},
+		},
+		{
+			name:    "неизвестный набор данных",
+			query:   models.AnalyticsQuery{Dataset: "users"},
+			wantErr: true,
+		},
+		{
+			name:    "группировка недоступна для набора",
+			query:   models.AnalyticsQuery{Dataset: models.AnalyticsIncomes, GroupBy: []models.AnalyticsGroup{models.GroupByCategory}},
+			wantErr: true,
+		},
+		{
+			name:    "слишком много группировок",
+			query:   models.AnalyticsQuery{GroupBy: []models.AnalyticsGroup{models.GroupByCategory, models.GroupByTag, models.GroupByPayee, models.GroupByAccount}},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный интервал",
+			query:   models.AnalyticsQuery{Interval: "hour"},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный показатель",
+			query:   models.AnalyticsQuery{Metrics: []models.AnalyticsMetric{"max"}},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестный часовой пояс",
+			query:   models.AnalyticsQuery{Timezone: "Mars/Olympus"},
+			wantErr: true,
+		},
+		{
+			name:    "окончание раньше начала",
+			query:   models.AnalyticsQuery{StartDate: end, EndDate: start},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := normalizeAnalyticsQuery(&tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получен запрос %+v", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if !reflect.DeepEqual(tt.query.GroupBy, tt.wantGroups) {
+				t.Errorf("группировки %v, ожидались %v", tt.query.GroupBy, tt.wantGroups)
+			}
+			if !reflect.DeepEqual(tt.query.Metrics, tt.wantMetrics) {
+				t.Errorf("показатели %v, ожидались %v", tt.query.Metrics, tt.wantMetrics)
+			}
+			if !tt.query.StartDate.Equal(start) || !tt.query.EndDate.Equal(end) {
+				t.Errorf("период %v - %v изменился", tt.query.StartDate, tt.query.EndDate)
+			}
+		})
+	}
+}
+
+func TestNormalizeAnalyticsQueryDefaults(t *testing.T) {
+	query := models.AnalyticsQuery{Timezone: "Asia/Vladivostok"}
+	if err := normalizeAnalyticsQuery(&query); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if query.Dataset != models.AnalyticsExpenses {
+		t.Errorf("набор данных %q, ожидался %q", query.Dataset, models.AnalyticsExpenses)
+	}
+	if !reflect.DeepEqual(query.Metrics, []models.AnalyticsMetric{models.MetricSum}) {
+		t.Errorf("показатели %v, ожидалась сумма", query.Metrics)
+	}
+
+	// По умолчанию берется текущий месяц в поясе запроса
+	now := time.Now().In(query.StartDate.Location())
+	if query.StartDate.Location().String() != "Asia/Vladivostok" {
+		t.Errorf("начало периода в поясе %s, ожидался Asia/Vladivostok", query.StartDate.Location())
+	}
+	if query.StartDate.Day() != 1 || query.StartDate.Hour() != 0 || query.StartDate.Month() != now.Month() {
+		t.Errorf("начало периода %v, ожидалось начало текущего месяца", query.StartDate)
+	}
+	if !query.EndDate.After(now) || query.EndDate.After(query.StartDate.AddDate(0, 1, 0)) {
+		t.Errorf("окончание периода %v не совпадает с концом текущего месяца", query.EndDate)
+	}
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+// ArchiveServiceImpl представляет реализацию сервиса выгрузки и восстановления данных пользователя
+type ArchiveServiceImpl struct {
+	archiveRepo    repositories.ArchiveRepository
+	userRepo       repositories.UserRepository
+	expenseRepo    repositories.ExpenseRepository
+	incomeRepo     repositories.IncomeRepository
+	wishlistRepo   repositories.WishlistRepository
+	telegramRepo   repositories.TelegramUserRepository
+	goalRepo       repositories.GoalRepository
+	ledgerRepo     repositories.LedgerRepository
+	loanRepo       repositories.LoanRepository
+	assetRepo      repositories.AssetRepository
+	netWorthRepo   repositories.NetWorthRepository
+	investmentRepo repositories.InvestmentRepository
+	ruleRepo       repositories.RuleRepository
+	payeeRepo      repositories.PayeeRepository
+	recurringRepo  repositories.RecurringRuleRepository
+}
+
+// NewArchiveService создает новый экземпляр сервиса архивов
+func NewArchiveService(
+	archiveRepo repositories.ArchiveRepository,
+	userRepo repositories.UserRepository,
+	expenseRepo repositories.ExpenseRepository,
+	incomeRepo repositories.IncomeRepository,
+	wishlistRepo repositories.WishlistRepository,
+	telegramRepo repositories.TelegramUserRepository,
+	goalRepo repositories.GoalRepository,
+	ledgerRepo repositories.LedgerRepository,
+	loanRepo repositories.LoanRepository,
+	assetRepo repositories.AssetRepository,
+	netWorthRepo repositories.NetWorthRepository,
+	investmentRepo repositories.InvestmentRepository,
+	ruleRepo repositories.RuleRepository,
+	payeeRepo repositories.PayeeRepository,
+	recurringRepo repositories.RecurringRuleRepository,
+) ArchiveService {
+	return &ArchiveServiceImpl{
+		archiveRepo:    archiveRepo,
+		userRepo:       userRepo,
+		expenseRepo:    expenseRepo,
+		incomeRepo:     incomeRepo,
+		wishlistRepo:   wishlistRepo,
+		telegramRepo:   telegramRepo,
+		goalRepo:       goalRepo,
+		ledgerRepo:     ledgerRepo,
+		loanRepo:       loanRepo,
+		assetRepo:      assetRepo,
+		netWorthRepo:   netWorthRepo,
+		investmentRepo: investmentRepo,
+		ruleRepo:       ruleRepo,
+		payeeRepo:      payeeRepo,
+		recurringRepo:  recurringRepo,
+	}
+}
+
+// ExportArchive собирает все данные пользователя в архив
+func (s *ArchiveServiceImpl) ExportArchive(ctx context.Context, userID int64) (*models.Archive, error) {
+	// Получаем пользователя
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Получаем траты
+	expenses, err := s.expenseRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+
+	// Получаем накопления
+	incomes, err := s.incomeRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении накоплений")
+	}
+
+	// Получаем список желаний
+	wishlist, err := s.wishlistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении списка желаний")
+	}
+	for i := range wishlist {
+		wishlist[i].Allocations, err = s.wishlistRepo.GetAllocations(ctx, wishlist[i].ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении резервирований")
+		}
+		wishlist[i].PriceHistory, err = s.wishlistRepo.GetPrices(ctx, wishlist[i].ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении истории цен")
+		}
+	}
+
+	// Получаем цели накоплений вместе со взносами
+	goals, err := s.goalRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении целей накоплений")
+	}
+	for i := range goals {
+		goals[i].Contributions, err = s.goalRepo.GetContributions(ctx, goals[i].ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении взносов")
+		}
+	}
+
+	// Получаем сопоставление счетов бухгалтерской книги
+	ledgerAccounts, err := s.ledgerRepo.GetAccounts(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении счетов бухгалтерской книги")
+	}
+
+	// Получаем кредиты вместе с платежами
+	loans, err := s.loanRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении кредитов")
+	}
+	for i := range loans {
+		loans[i].Payments, err = s.loanRepo.GetPayments(ctx, loans[i].ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении платежей по кредиту")
+		}
+	}
+
+	// Получаем активы вместе с историей оценок и снимки капитала
+	assets, err := s.assetRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении активов")
+	}
+	for i := range assets {
+		assets[i].Valuations, err = s.assetRepo.GetValuations(ctx, assets[i].ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении оценок актива")
+		}
+	}
+	snapshots, err := s.netWorthRepo.GetSnapshots(ctx, userID, time.Time{})
+	if err != nil {
+		return nil, errors.New("ошибка при получении снимков капитала")
+	}
+
+	// Получаем инвестиционные операции и сохраненные цены
+	investments, err := s.investmentRepo.GetTransactions(ctx, userID, "")
+	if err != nil {
+		return nil, errors.New("ошибка при получении инвестиционных операций")
+	}
+	prices, err := s.investmentRepo.GetPrices(ctx, userID, "")
+	if err != nil {
+		return nil, errors.New("ошибка при получении цен ценных бумаг")
+	}
+
+	// Получаем правила категоризации
+	rules, err := s.ruleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении правил категоризации")
+	}
+
+	// Получаем получателей платежей с псевдонимами
+	payees, err := s.payeeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении получателей")
+	}
+
+	// Получаем регулярные списания
+	recurringRules, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении регулярных списаний")
+	}
+
+	archive := &models.Archive{
+		Version:    models.ArchiveVersion,
+		ExportedAt: time.Now(),
+		User: models.ArchiveUser{
+			Email:        user.Email,
+			Username:     user.Username,
+			FirstName:    user.FirstName,
+			LastName:     user.LastName,
+			MonthlyLimit: user.MonthlyLimit,
+			SavingsGoal:  user.SavingsGoal,
+			Timezone:     user.Timezone,
+			CreatedAt:    user.CreatedAt,
+		},
+		Expenses: expenses,
+		Incomes:  incomes,
+		Wishlist: wishlist,
+		Goals:    goals,
+
+		LedgerAccounts: ledgerAccounts,
+		Loans:          loans,
+		Assets:         assets,
+		NetWorth:       snapshots,
+		Investments:    investments,
+		SecurityPrices: prices,
+		Rules:          rules,
+		Payees:         payees,
+		RecurringRules: recurringRules,
+	}
+
+	// Добавляем сведения о связанном аккаунте Telegram, если он есть
+	if telegramUser, err := s.telegramRepo.GetByUserID(ctx, userID); err == nil {
+		archive.Telegram = &models.ArchiveTelegram{
+			TelegramID: telegramUser.TelegramID,
+			Username:   telegramUser.Username,
+			FirstName:  telegramUser.FirstName,
+			LastName:   telegramUser.LastName,
+		}
+	}
+
+	// Вкладываем файл аватара, если он загружен пользователем
+	if user.AvatarPath != "" && user.AvatarPath != "/default-avatar.png" {
+		relPath := strings.TrimPrefix(user.AvatarPath, "/")
+		if data, err := os.ReadFile(relPath); err == nil {
+			archive.Avatar = &models.ArchiveFile{
+				FileName: filepath.Base(relPath),
+				Data:     data,
+			}
+		}
+	}
+
+	return archive, nil
+}
+
+// ImportArchive восстанавливает данные пользователя из архива
+func (s *ArchiveServiceImpl) ImportArchive(ctx context.Context, userID int64, archive *models.Archive, replace bool) (*models.ArchiveRestoreResult, error) {
+	// Проверяем существование пользователя
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Проверяем архив
+	if err := validateArchive(archive); err != nil {
+		return nil, err
+	}
+
+	// Восстанавливаем данные
+	result, err := s.archiveRepo.Restore(ctx, userID, archive, replace)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при восстановлении данных: %w", err)
+	}
+	expenseClassifiers.invalidate(userID)
+
+	// Восстанавливаем аватар
+	if archive.Avatar != nil && len(archive.Avatar.Data) > 0 {
+		if err := s.restoreAvatar(ctx, user, archive.Avatar); err != nil {
+			// Данные уже восстановлены, поэтому ошибку аватара только логируем
+			fmt.Printf("Не удалось восстановить аватар пользователя ID=%d: %v\n", userID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// restoreAvatar сохраняет файл аватара из архива и обновляет путь к нему
+func (s *ArchiveServiceImpl) restoreAvatar(ctx context.Context, user *models.User, avatar *models.ArchiveFile) error {
+	avatarsDir := "uploads/avatars"
+	if err := os.MkdirAll(avatarsDir, 0755); err != nil {
+		return fmt.Errorf("не удалось создать директорию для аватаров: %v", err)
+	}
+
+	filename := fmt.Sprintf("%d_%d_%s", user.ID, time.Now().UnixNano(), filepath.Base(avatar.FileName))
+	if err := os.WriteFile(filepath.Join(avatarsDir, filename), avatar.Data, 0644); err != nil {
+		return fmt.Errorf("не удалось сохранить файл: %v", err)
+	}
+
+	// Удаляем предыдущий аватар пользователя
+	if user.AvatarPath != "" && user.AvatarPath != "/default-avatar.png" {
+		relPath := strings.TrimPrefix(user.AvatarPath, "/")
+		if _, err := os.Stat(relPath); err == nil {
+			os.Remove(relPath)
+		}
+	}
+
+	return s.userRepo.UpdateAvatar(ctx, user.ID, fmt.Sprintf("/uploads/avatars/%s", filename))
+}
+
+// validateArchive проверяет версию архива и корректность записей в нем
+func validateArchive(archive *models.Archive) error {
+	if archive.Version < 1 || archive.Version > models.ArchiveVersion {
+		return fmt.Errorf("неподдерживаемая версия архива: %d", archive.Version)
+	}
+
+	if archive.User.Timezone != "" {
+		if _, err := models.LoadTimezone(archive.User.Timezone); err != nil {
+			return err
+		}
+	}
+
+	for i := range archive.Expenses {
+		if err := utils.ValidateStruct(archive.Expenses[i]); err != nil {
+			return fmt.Errorf("некорректная трата %d: %w", archive.Expenses[i].ID, err)
+		}
+	}
+	for i := range archive.Incomes {
+		if err := utils.ValidateStruct(archive.Incomes[i]); err != nil {
+			return fmt.Errorf("некорректное накопление %d: %w", archive.Incomes[i].ID, err)
+		}
+	}
+	for i := range archive.Wishlist {
+		if err := utils.ValidateStruct(archive.Wishlist[i]); err != nil {
+			return fmt.Errorf("некорректный элемент списка желаний %d: %w", archive.Wishlist[i].ID, err)
+		}
+		for j := range archive.Wishlist[i].Allocations {
+			if err := utils.ValidateStruct(archive.Wishlist[i].Allocations[j]); err != nil {
+				return fmt.Errorf("некорректное резервирование %d: %w", archive.Wishlist[i].Allocations[j].ID, err)
+			}
+		}
+		for j := range archive.Wishlist[i].PriceHistory {
+			if err := utils.ValidateStruct(archive.Wishlist[i].PriceHistory[j]); err != nil {
+				return fmt.Errorf("некорректная цена %d: %w", archive.Wishlist[i].PriceHistory[j].ID, err)
+			}
+		}
+	}
+
+	for i := range archive.Goals {
+		if err := utils.ValidateStruct(archive.Goals[i]); err != nil {
+			return fmt.Errorf("некорректная цель накоплений %d: %w", archive.Goals[i].ID, err)
+		}
+		for j := range archive.Goals[i].Contributions {
+			if err := utils.ValidateStruct(archive.Goals[i].Contributions[j]); err != nil {
+				return fmt.Errorf("некорректный взнос %d: %w", archive.Goals[i].Contributions[j].ID, err)
+			}
+		}
+	}
+
+	for i := range archive.LedgerAccounts {
+		if err := utils.ValidateStruct(archive.LedgerAccounts[i]); err != nil {
+			return fmt.Errorf("некорректный счет бухгалтерской книги %q: %w", archive.LedgerAccounts[i].Key, err)
+		}
+	}
+
+	for _, loan := range archive.Loans {
+		if loan.Principal <= 0 || loan.TermMonths <= 0 {
+			return fmt.Errorf("некорректный кредит %d: сумма и срок должны быть положительными", loan.ID)
+		}
+	}
+
+	for _, asset := range archive.Assets {
+		if strings.TrimSpace(asset.Name) == "" {
+			return fmt.Errorf("некорректный актив %d: не указано название", asset.ID)
+		}
+		for _, valuation := range asset.Valuations {
+			if valuation.Value < 0 {
+				return fmt.Errorf("некорректная оценка актива %d: стоимость не может быть отрицательной", asset.ID)
+			}
+		}
+	}
+
+	if err := validateInvestmentSequence(archive.Investments); err != nil {
+		return fmt.Errorf("некорректные инвестиционные операции: %w", err)
+	}
+
+	for i := range archive.Rules {
+		if _, err := compileRule(&archive.Rules[i]); err != nil {
+			return fmt.Errorf("некорректное правило категоризации %d: %w", archive.Rules[i].ID, err)
+		}
+	}
+
+	for _, payee := range archive.Payees {
+		if strings.TrimSpace(payee.Name) == "" {
+			return fmt.Errorf("некорректный получатель %d: не указано название", payee.ID)
+		}
+	}
+
+	for _, rule := range archive.RecurringRules {
+		if rule.MatchKey == "" || !isKnownCategory(rule.Category) {
+			return fmt.Errorf("некорректное регулярное списание %d", rule.ID)
+		}
+	}
+
+	return nil
+}
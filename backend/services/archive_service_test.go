@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestValidateArchive(t *testing.T) {
+	expense := models.Expense{ID: 1, Title: "Кофе", Amount: 250, Category: models.CategoryFood}
+	income := models.Income{ID: 2, Amount: 50000, Source: models.SourceSalary}
+
+	tests := []struct {
+		name    string
+		archive models.Archive
+		wantErr bool
+	}{
+		{
+			name:    "корректный архив",
+			archive: models.Archive{Version: models.ArchiveVersion, Expenses: []models.Expense{expense}, Incomes: []models.Income{income}},
+		},
+		{
+			name:    "пустой архив",
+			archive: models.Archive{Version: 1},
+		},
+		{
+			name:    "без версии",
+			archive: models.Archive{Expenses: []models.Expense{expense}},
+			wantErr: true,
+		},
+		{
+			name:    "версия новее поддерживаемой",
+			archive: models.Archive{Version: models.ArchiveVersion + 1},
+			wantErr: true,
+		},
+		{
+			name:    "трата без названия",
+			archive: models.Archive{Version: 1, Expenses: []models.Expense{{ID: 3, Amount: 100, Category: models.CategoryFood}}},
+			wantErr: true,
+		},
+		{
+			name:    "накопление с отрицательной суммой",
+			archive: models.Archive{Version: 1, Incomes: []models.Income{{ID: 4, Amount: -1, Source: models.SourceGift}}},
+			wantErr: true,
+		},
+		{
+			name:    "желание без приоритета",
+			archive: models.Archive{Version: 1, Wishlist: []models.WishlistItem{{ID: 5, Title: "Велосипед", Price: 30000}}},
+			wantErr: true,
+		},
+		{
+			name: "счет бухгалтерской книги",
+			archive: models.Archive{Version: 2, LedgerAccounts: []models.LedgerAccount{
+				{Kind: models.LedgerAccountCategory, Key: string(models.CategoryFood), Account: "Expenses:Food"},
+			}},
+		},
+		{
+			name: "счет бухгалтерской книги неизвестного вида",
+			archive: models.Archive{Version: 2, LedgerAccounts: []models.LedgerAccount{
+				{Kind: models.LedgerAccountKind("loan"), Key: "1", Account: "Liabilities:Loan"},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "кредит без срока",
+			archive: models.Archive{Version: 2, Loans: []models.Loan{{ID: 6, Name: "Ипотека", Principal: 3000000}}},
+			wantErr: true,
+		},
+		{
+			name: "актив с отрицательной оценкой",
+			archive: models.Archive{Version: 2, Assets: []models.Asset{
+				{ID: 7, Name: "Квартира", Kind: models.AssetProperty, Valuations: []models.AssetValuation{{Value: -1}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "продажа больше купленного",
+			archive: models.Archive{Version: 2, Investments: []models.InvestmentTransaction{
+				{ID: 8, Ticker: "SBER", Type: models.InvestmentBuy, Date: time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC), Quantity: 10, Price: 250},
+				{ID: 9, Ticker: "SBER", Type: models.InvestmentSell, Date: time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC), Quantity: 15, Price: 270},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "правило категоризации без условий",
+			archive: models.Archive{Version: 2, Rules: []models.CategorizationRule{
+				{ID: 10, Name: "Кофе", Category: models.CategoryFood},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "получатель без названия",
+			archive: models.Archive{Version: 2, Payees: []models.Payee{{ID: 11, Name: "  "}}},
+			wantErr: true,
+		},
+		{
+			name:    "регулярное списание без ключа",
+			archive: models.Archive{Version: 2, RecurringRules: []models.RecurringRule{{ID: 12, Title: "Netflix", Category: models.CategoryEntertainment}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArchive(&tt.archive)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,12 +2,16 @@ package services
 
 import (
 	"math"
+	"time"
+
+	"cz.Finance/backend/models"
 )
 
 // CalculatorService интерфейс для калькуляторов финансовых расчетов
 type CalculatorService interface {
 	CalculateCompoundInterest(principal float64, rate float64, time float64, frequency int) map[string]interface{}
 	CalculateMortgage(principal float64, rate float64, years int) map[string]interface{}
+	CalculateLoanSchedule(principal float64, rate float64, months int, loanType models.LoanType, firstPaymentDate time.Time) []models.LoanScheduleEntry
 }
 
 // CalculatorServiceImpl представляет реализацию сервиса калькуляторов
@@ -162,3 +166,64 @@ func (s *CalculatorServiceImpl) CalculateMortgage(principal float64, rate float6
 		"amortization_schedule": amortizationSchedule,
 	}
 }
+
+// CalculateLoanSchedule строит помесячный график платежей по кредиту аннуитетным
+// или дифференцированным способом. Последний платеж гасит остаток долга полностью
+func (s *CalculatorServiceImpl) CalculateLoanSchedule(principal float64, rate float64, months int, loanType models.LoanType, firstPaymentDate time.Time) []models.LoanScheduleEntry {
+	if months <= 0 || principal <= 0 {
+		return []models.LoanScheduleEntry{}
+	}
+
+	// Месячная процентная ставка
+	monthlyRate := rate / 100 / 12
+
+	// Ежемесячный платеж для аннуитета и доля основного долга для дифференцированного платежа
+	annuityPayment := calculateAnnuityPayment(principal, monthlyRate, months)
+	principalPart := principal / float64(months)
+
+	schedule := make([]models.LoanScheduleEntry, months)
+	remaining := principal
+
+	for month := 0; month < months; month++ {
+		interest := math.Round(remaining*monthlyRate*100) / 100
+
+		var principalPayment float64
+		if loanType == models.LoanDifferentiated {
+			principalPayment = math.Round(principalPart*100) / 100
+		} else {
+			principalPayment = math.Round((annuityPayment-interest)*100) / 100
+		}
+
+		// В последнем месяце гасим остаток, накопившийся из-за округлений
+		if month == months-1 || principalPayment > remaining {
+			principalPayment = math.Round(remaining*100) / 100
+		}
+
+		remaining = math.Round((remaining-principalPayment)*100) / 100
+
+		schedule[month] = models.LoanScheduleEntry{
+			Number:    month + 1,
+			Date:      firstPaymentDate.AddDate(0, month, 0),
+			Payment:   math.Round((principalPayment+interest)*100) / 100,
+			Principal: principalPayment,
+			Interest:  interest,
+			Remaining: remaining,
+		}
+	}
+
+	return schedule
+}
+
+// calculateAnnuityPayment вычисляет аннуитетный платеж. При нулевой ставке долг делится поровну
+func calculateAnnuityPayment(principal float64, monthlyRate float64, months int) float64 {
+	if months <= 0 {
+		return 0
+	}
+
+	if monthlyRate == 0 {
+		return principal / float64(months)
+	}
+
+	factor := math.Pow(1+monthlyRate, float64(months))
+	return principal * monthlyRate * factor / (factor - 1)
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"cz.Finance/backend/models"
+)
+
+const (
+	// classifierCacheTTL задает время жизни обученной модели пользователя
+	classifierCacheTTL = 30 * time.Minute
+	// classifierMinSamples задает минимальный объем истории, при котором модели можно доверять
+	classifierMinSamples = 10
+	// classifierConfidenceThreshold задает уверенность, начиная с которой категорию можно назначать без подтверждения
+	classifierConfidenceThreshold = 0.6
+	// classifierMaxSuggestions ограничивает количество предлагаемых категорий
+	classifierMaxSuggestions = 3
+)
+
+// categoryClassifier представляет наивный байесовский классификатор категорий трат.
+// Признаки — слова названия и описания и порядок суммы
+type categoryClassifier struct {
+	samples     int
+	docCounts   map[models.ExpenseCategory]int
+	tokenCounts map[models.ExpenseCategory]map[string]int
+	tokenTotals map[models.ExpenseCategory]int
+	vocabulary  map[string]bool
+}
+
+// trainCategoryClassifier обучает классификатор на тратах пользователя
+func trainCategoryClassifier(expenses []models.Expense) *categoryClassifier {
+	classifier := &categoryClassifier{
+		docCounts:   make(map[models.ExpenseCategory]int),
+		tokenCounts: make(map[models.ExpenseCategory]map[string]int),
+		tokenTotals: make(map[models.ExpenseCategory]int),
+		vocabulary:  make(map[string]bool),
+	}
+
+	for _, expense := range expenses {
+		tokens := classifierTokens(expense.Title, expense.Description, expense.Amount)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		classifier.samples++
+		classifier.docCounts[expense.Category]++
+		if classifier.tokenCounts[expense.Category] == nil {
+			classifier.tokenCounts[expense.Category] = make(map[string]int)
+		}
+		for _, token := range tokens {
+			classifier.tokenCounts[expense.Category][token]++
+			classifier.tokenTotals[expense.Category]++
+			classifier.vocabulary[token] = true
+		}
+	}
+
+	return classifier
+}
+
+// predict возвращает вероятности категорий в порядке убывания.
+// Используется сглаживание Лапласа, логарифмы вероятностей нормируются через softmax
+func (c *categoryClassifier) predict(title, description string, amount float64) []models.CategorySuggestion {
+	if c.samples == 0 {
+		return []models.CategorySuggestion{}
+	}
+
+	tokens := classifierTokens(title, description, amount)
+	vocabularySize := float64(len(c.vocabulary))
+
+	scores := make(map[models.ExpenseCategory]float64, len(c.docCounts))
+	maxScore := math.Inf(-1)
+	for category, docs := range c.docCounts {
+		score := math.Log(float64(docs) / float64(c.samples))
+		denominator := float64(c.tokenTotals[category]) + vocabularySize
+		for _, token := range tokens {
+			score += math.Log((float64(c.tokenCounts[category][token]) + 1) / denominator)
+		}
+		scores[category] = score
+		if score > maxScore {
+			maxScore = score
+		}
+	}
+
+	total := 0.0
+	for category, score := range scores {
+		scores[category] = math.Exp(score - maxScore)
+		total += scores[category]
+	}
+
+	suggestions := make([]models.CategorySuggestion, 0, len(scores))
+	for category, score := range scores {
+		suggestions = append(suggestions, models.CategorySuggestion{
+			Category:   category,
+			Title:      models.ExpenseCategoryTitles[category],
+			Confidence: math.Round(score/total*1000) / 1000,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].Category < suggestions[j].Category
+	})
+
+	return suggestions
+}
+
+// recognizes проверяет, что в названии или описании есть хотя бы одно слово из истории трат.
+// Без таких слов вероятности определяются только частотой категорий и порядком суммы,
+// поэтому подсказке нельзя доверять
+func (c *categoryClassifier) recognizes(title, description string) bool {
+	for _, token := range classifierTokens(title, description, 0) {
+		if c.vocabulary[token] {
+			return true
+		}
+	}
+	return false
+}
+
+// classifierTokens разбивает текст на слова в нижнем регистре и добавляет признак порядка суммы.
+// Числа и однобуквенные слова отбрасываются
+func classifierTokens(title, description string, amount float64) []string {
+	words := strings.FieldsFunc(strings.ToLower(title+" "+description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words)+1)
+	for _, word := range words {
+		word = strings.ReplaceAll(word, "ё", "е")
+		if len([]rune(word)) < 2 || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+
+	if len(tokens) == 0 {
+		return tokens
+	}
+
+	// Сумма учитывается по порядку величины, чтобы отличать кофе от крупной покупки
+	if amount > 0 {
+		tokens = append(tokens, "#amount:"+strconv.Itoa(int(math.Log2(amount+1))))
+	}
+
+	return tokens
+}
+
+// classifierCache хранит обученные модели пользователей
+type classifierCache struct {
+	mu      sync.Mutex
+	entries map[int64]classifierCacheEntry
+}
+
+// classifierCacheEntry содержит модель и время ее обучения
+type classifierCacheEntry struct {
+	classifier *categoryClassifier
+	trainedAt  time.Time
+}
+
+// expenseClassifiers — общий кэш моделей. Его сбрасывают все сервисы, которые создают
+// или удаляют траты пользователя, чтобы подсказки учитывали новые записи
+var expenseClassifiers = newClassifierCache()
+
+// newClassifierCache создает пустой кэш моделей
+func newClassifierCache() *classifierCache {
+	return &classifierCache{entries: make(map[int64]classifierCacheEntry)}
+}
+
+// get возвращает модель пользователя, если она еще не устарела
+func (c *classifierCache) get(userID int64) *categoryClassifier {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[userID]
+	if !ok || time.Since(entry.trainedAt) > classifierCacheTTL {
+		return nil
+	}
+
+	return entry.classifier
+}
+
+// put сохраняет модель пользователя
+func (c *classifierCache) put(userID int64, classifier *categoryClassifier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[userID] = classifierCacheEntry{classifier: classifier, trainedAt: time.Now()}
+}
+
+// invalidate сбрасывает модель пользователя после изменения его трат
+func (c *classifierCache) invalidate(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, userID)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"cz.Finance/backend/models"
+)
+
+// classifierHistory возвращает историю трат, на которой обучаются тесты классификатора
+func classifierHistory() []models.Expense {
+	var expenses []models.Expense
+	for i := 0; i < 6; i++ {
+		expenses = append(expenses,
+			models.Expense{Title: "Кофе с собой", Amount: 250, Category: models.CategoryFood},
+			models.Expense{Title: "Такси до работы", Amount: 600, Category: models.CategoryTransport},
+		)
+	}
+	return expenses
+}
+
+func TestClassifierTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		amount      float64
+		want        []string
+	}{
+		{
+			name:   "нижний регистр и ё",
+			title:  "Ёлка, ИГРУШКИ",
+			amount: 1023,
+			want:   []string{"елка", "игрушки", "#amount:10"},
+		},
+		{
+			name:        "числа и однобуквенные слова отбрасываются",
+			title:       "Кофе и 2 круассана",
+			description: "в 8:30",
+			want:        []string{"кофе", "круассана"},
+		},
+		{
+			name:   "без слов нет и признака суммы",
+			title:  "123 45",
+			amount: 500,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifierTokens(tt.title, tt.description, tt.amount); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("получено %q, ожидалось %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryClassifierPredict(t *testing.T) {
+	classifier := trainCategoryClassifier(classifierHistory())
+
+	tests := []struct {
+		name           string
+		title          string
+		amount         float64
+		wantTop        models.ExpenseCategory
+		wantConfident  bool
+		wantRecognized bool
+	}{
+		{name: "известное слово", title: "Кофе латте", amount: 300, wantTop: models.CategoryFood, wantConfident: true, wantRecognized: true},
+		{name: "известное слово в другом регистре", title: "ТАКСИ", amount: 700, wantTop: models.CategoryTransport, wantConfident: true, wantRecognized: true},
+		// Сумма совпадает с кофе, поэтому вероятность высокая, но доверять ей нельзя
+		{name: "только незнакомые слова", title: "Абонемент бассейн", amount: 250, wantTop: models.CategoryFood, wantRecognized: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := classifier.predict(tt.title, "", tt.amount)
+			if len(suggestions) != 2 {
+				t.Fatalf("получено %d подсказок, ожидалось 2", len(suggestions))
+			}
+			if suggestions[0].Category != tt.wantTop {
+				t.Errorf("первая категория %q, ожидалась %q", suggestions[0].Category, tt.wantTop)
+			}
+			if confident := suggestions[0].Confidence >= classifierConfidenceThreshold; tt.wantConfident && !confident {
+				t.Errorf("уверенность %.3f ниже порога", suggestions[0].Confidence)
+			}
+			if recognized := classifier.recognizes(tt.title, ""); recognized != tt.wantRecognized {
+				t.Errorf("признак знакомых слов %v, ожидалось %v", recognized, tt.wantRecognized)
+			}
+		})
+	}
+
+	if suggestions := trainCategoryClassifier(nil).predict("Кофе", "", 100); len(suggestions) != 0 {
+		t.Errorf("необученная модель вернула %d подсказок", len(suggestions))
+	}
+}
+
+func TestClassifierCacheInvalidate(t *testing.T) {
+	cache := newClassifierCache()
+	classifier := trainCategoryClassifier(classifierHistory())
+
+	cache.put(1, classifier)
+	cache.put(2, classifier)
+	cache.invalidate(1)
+
+	if cache.get(1) != nil {
+		t.Error("модель пользователя 1 не сброшена")
+	}
+	if cache.get(2) != classifier {
+		t.Error("модель пользователя 2 сброшена вместе с чужой")
+	}
+}
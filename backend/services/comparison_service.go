@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ComparisonServiceImpl представляет реализацию сервиса сравнения периодов
+type ComparisonServiceImpl struct {
+	expenseRepo repositories.ExpenseRepository
+	incomeRepo  repositories.IncomeRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewComparisonService создает новый экземпляр сервиса сравнения периодов
+func NewComparisonService(
+	expenseRepo repositories.ExpenseRepository,
+	incomeRepo repositories.IncomeRepository,
+	userRepo repositories.UserRepository,
+) ComparisonService {
+	return &ComparisonServiceImpl{
+		expenseRepo: expenseRepo,
+		incomeRepo:  incomeRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// ComparePeriods сравнивает траты по категориям и накопления по источникам за два периода.
+// Без указанного периода берется текущий месяц по сегодняшний момент в часовом поясе пользователя,
+// а без режима он сравнивается с тем же отрезком предыдущего месяца
+func (s *ComparisonServiceImpl) ComparePeriods(ctx context.Context, userID int64, request *models.ComparisonRequest) (*models.PeriodComparison, error) {
+	// Получаем пользователя для определения часового пояса
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	now := time.Now().In(user.Location())
+	if request.Mode == "" {
+		request.Mode = models.ComparePrevious
+	}
+	if request.StartDate.IsZero() {
+		request.StartDate, _ = utils.CurrentMonthPeriod(now)
+	}
+	if request.EndDate.IsZero() {
+		request.EndDate = now
+	}
+	if !request.EndDate.After(request.StartDate) {
+		return nil, errors.New("дата окончания периода должна быть позже даты начала")
+	}
+
+	previousStart, previousEnd, err := comparisonPeriod(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var expensesByCategory, previousExpensesByCategory map[string]float64
+	var incomesBySource, previousIncomesBySource map[string]float64
+
+	// Сводки за оба периода запрашиваются параллельно, первая ошибка отменяет остальные
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() (err error) {
+		if expensesByCategory, err = s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(groupCtx, userID, request.StartDate, request.EndDate); err != nil {
+			return errors.New("ошибка при получении сводки трат по категориям")
+		}
+		return nil
+	})
+
+	group.Go(func() (err error) {
+		if previousExpensesByCategory, err = s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(groupCtx, userID, previousStart, previousEnd); err != nil {
+			return errors.New("ошибка при получении сводки трат по категориям за период сравнения")
+		}
+		return nil
+	})
+
+	group.Go(func() (err error) {
+		if incomesBySource, err = s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(groupCtx, userID, request.StartDate, request.EndDate); err != nil {
+			return errors.New("ошибка при получении сводки накоплений по источникам")
+		}
+		return nil
+	})
+
+	group.Go(func() (err error) {
+		if previousIncomesBySource, err = s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(groupCtx, userID, previousStart, previousEnd); err != nil {
+			return errors.New("ошибка при получении сводки накоплений по источникам за период сравнения")
+		}
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	expenses := sumValues(expensesByCategory)
+	previousExpenses := sumValues(previousExpensesByCategory)
+	incomes := sumValues(incomesBySource)
+	previousIncomes := sumValues(previousIncomesBySource)
+
+	return &models.PeriodComparison{
+		Mode:       request.Mode,
+		Current:    models.ComparisonPeriod{StartDate: request.StartDate, EndDate: request.EndDate},
+		Previous:   models.ComparisonPeriod{StartDate: previousStart, EndDate: previousEnd},
+		Expenses:   compareAmounts(expenses, previousExpenses),
+		Incomes:    compareAmounts(incomes, previousIncomes),
+		Balance:    compareAmounts(incomes-expenses, previousIncomes-previousExpenses),
+		Categories: compareSummaries(expensesByCategory, previousExpensesByCategory),
+		Sources:    compareSummaries(incomesBySource, previousIncomesBySource),
+	}, nil
+}
+
+// comparisonPeriod определяет границы периода сравнения по режиму запроса
+func comparisonPeriod(request *models.ComparisonRequest) (time.Time, time.Time, error) {
+	switch request.Mode {
+	case models.ComparePrevious:
+		start, end := previousPeriod(request.StartDate, request.EndDate)
+		return start, end, nil
+	case models.CompareYearAgo:
+		return request.StartDate.AddDate(-1, 0, 0), request.EndDate.AddDate(-1, 0, 0), nil
+	case models.CompareCustom:
+		if request.CompareStartDate.IsZero() || request.CompareEndDate.IsZero() {
+			return time.Time{}, time.Time{}, errors.New("не указан период сравнения")
+		}
+		if !request.CompareEndDate.After(request.CompareStartDate) {
+			return time.Time{}, time.Time{}, errors.New("дата окончания периода сравнения должна быть позже даты начала")
+		}
+		return request.CompareStartDate, request.CompareEndDate, nil
+	default:
+		return time.Time{}, time.Time{}, errors.New("неизвестный режим сравнения")
+	}
+}
+
+// previousPeriod возвращает период, предшествующий указанному. Период, начинающийся с первого
+// числа месяца, сдвигается на целое число месяцев, чтобы неполный текущий месяц сравнивался
+// с тем же отрезком прошлого месяца. Остальные периоды сдвигаются на свою длительность
+func previousPeriod(start, end time.Time) (time.Time, time.Time) {
+	monthStart, _ := utils.CurrentMonthPeriod(start)
+	if !start.Equal(monthStart) {
+		duration := end.Sub(start)
+		return start.Add(-duration), start
+	}
+
+	months := 1
+	for start.AddDate(0, months, 0).Before(end) {
+		months++
+	}
+
+	previousEnd := end.AddDate(0, -months, 0)
+	if previousEnd.After(start) {
+		previousEnd = start
+	}
+	return start.AddDate(0, -months, 0), previousEnd
+}
+
+// compareAmounts рассчитывает изменение суммы относительно периода сравнения
+func compareAmounts(current, previous float64) models.ComparisonDelta {
+	change := roundMoney(current - previous)
+	return models.ComparisonDelta{
+		Current:       roundMoney(current),
+		Previous:      roundMoney(previous),
+		Change:        change,
+		ChangePercent: changePercent(change, previous),
+	}
+}
+
+// compareSummaries сравнивает суммы по ключам двух сводок и упорядочивает их
+// по убыванию абсолютного изменения
+func compareSummaries(current, previous map[string]float64) []models.ComparisonItem {
+	items := make([]models.ComparisonItem, 0, len(current)+len(previous))
+	for name, amount := range current {
+		change := roundMoney(amount - previous[name])
+		items = append(items, models.ComparisonItem{
+			Name:          name,
+			Current:       roundMoney(amount),
+			Previous:      roundMoney(previous[name]),
+			Change:        change,
+			ChangePercent: changePercent(change, previous[name]),
+		})
+	}
+	for name, amount := range previous {
+		if _, ok := current[name]; ok {
+			continue
+		}
+		change := roundMoney(-amount)
+		items = append(items, models.ComparisonItem{
+			Name:          name,
+			Previous:      roundMoney(amount),
+			Change:        change,
+			ChangePercent: changePercent(change, amount),
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		ci, cj := math.Abs(items[i].Change), math.Abs(items[j].Change)
+		if ci != cj {
+			return ci > cj
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	return items
+}
+
+// changePercent возвращает изменение в процентах от суммы периода сравнения
+// или nil, если эта сумма нулевая
+func changePercent(change, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percent := roundMoney(change / math.Abs(previous) * 100)
+	return &percent
+}
+
+// sumValues возвращает сумму значений сводки
+func sumValues(summary map[string]float64) float64 {
+	total := 0.0
+	for _, amount := range summary {
+		total += amount
+	}
+	return total
+}
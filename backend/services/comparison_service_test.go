@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviousPeriod(t *testing.T) {
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "целый месяц",
+			start:     date(time.February, 1, 0),
+			end:       date(time.March, 1, 0),
+			wantStart: date(time.January, 1, 0),
+			wantEnd:   date(time.February, 1, 0),
+		},
+		{
+			name:      "неполный текущий месяц",
+			start:     date(time.March, 1, 0),
+			end:       date(time.March, 15, 10),
+			wantStart: date(time.February, 1, 0),
+			wantEnd:   date(time.February, 15, 10),
+		},
+		{
+			name:      "конец короткого месяца не выходит за период",
+			start:     date(time.March, 1, 0),
+			end:       date(time.March, 31, 0),
+			wantStart: date(time.February, 1, 0),
+			wantEnd:   date(time.March, 1, 0),
+		},
+		{
+			name:      "квартал",
+			start:     date(time.January, 1, 0),
+			end:       date(time.April, 1, 0),
+			wantStart: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   date(time.January, 1, 0),
+		},
+		{
+			name:      "произвольная неделя",
+			start:     date(time.March, 10, 0),
+			end:       date(time.March, 17, 0),
+			wantStart: date(time.March, 3, 0),
+			wantEnd:   date(time.March, 10, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := previousPeriod(tt.start, tt.end)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("получен период [%v, %v), ожидался [%v, %v)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCompareSummaries(t *testing.T) {
+	current := map[string]float64{"food": 100, "transport": 50}
+	previous := map[string]float64{"food": 80, "entertainment": 70}
+
+	want := []struct {
+		name          string
+		change        float64
+		changePercent *float64
+	}{
+		{name: "entertainment", change: -70, changePercent: floatPointer(-100)},
+		{name: "transport", change: 50},
+		{name: "food", change: 20, changePercent: floatPointer(25)},
+	}
+
+	items := compareSummaries(current, previous)
+	if len(items) != len(want) {
+		t.Fatalf("получено %d строк, ожидалось %d", len(items), len(want))
+	}
+	for i, item := range items {
+		if item.Name != want[i].name || item.Change != want[i].change {
+			t.Errorf("строка %d: получено %s %.2f, ожидалось %s %.2f", i, item.Name, item.Change, want[i].name, want[i].change)
+		}
+		switch {
+		case want[i].changePercent == nil && item.ChangePercent != nil:
+			t.Errorf("строка %d: процент %.2f, ожидалось отсутствие", i, *item.ChangePercent)
+		case want[i].changePercent != nil && (item.ChangePercent == nil || *item.ChangePercent != *want[i].changePercent):
+			t.Errorf("строка %d: процент %v, ожидалось %.2f", i, item.ChangePercent, *want[i].changePercent)
+		}
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"errors"
 	"time"
 
+	"cz.Finance/backend/models"
 	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // DashboardServiceImpl представляет реализацию сервиса информационной панели
@@ -13,6 +17,9 @@ type DashboardServiceImpl struct {
 	expenseRepo repositories.ExpenseRepository
 	incomeRepo  repositories.IncomeRepository
 	userRepo    repositories.UserRepository
+	goalRepo    repositories.GoalRepository
+	loanRepo    repositories.LoanRepository
+	calculator  CalculatorService
 }
 
 // NewDashboardService создает новый экземпляр сервиса информационной панели
@@ -20,11 +27,17 @@ func NewDashboardService(
 	expenseRepo repositories.ExpenseRepository,
 	incomeRepo repositories.IncomeRepository,
 	userRepo repositories.UserRepository,
+	goalRepo repositories.GoalRepository,
+	loanRepo repositories.LoanRepository,
+	calculator CalculatorService,
 ) DashboardService {
 	return &DashboardServiceImpl{
 		expenseRepo: expenseRepo,
 		incomeRepo:  incomeRepo,
 		userRepo:    userRepo,
+		goalRepo:    goalRepo,
+		loanRepo:    loanRepo,
+		calculator:  calculator,
 	}
 }
 
@@ -36,61 +49,112 @@ func (s *DashboardServiceImpl) GetDashboardSummary(ctx context.Context, userID i
 		return nil, errors.New("пользователь не найден")
 	}
 
-	// Определяем начало и конец текущего месяца
-	now := time.Now()
-	currentMonthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	currentMonthEnd := currentMonthStart.AddDate(0, 1, -1).Add(time.Hour * 23).Add(time.Minute * 59).Add(time.Second * 59)
+	// Определяем границы текущего месяца в часовом поясе пользователя
+	now := time.Now().In(user.Location())
+	currentMonthStart, currentMonthEnd := utils.CurrentMonthPeriod(now)
+
+	// Определяем начало и конец всего периода (используем очень раннюю дату и текущую дату)
+	allTimeStart := utils.AllTimeStart(now.Location())
+	allTimeEnd := now
+
+	var (
+		currentMonthExpenses, currentMonthIncomes float64
+		allTimeExpenses, allTimeIncomes           float64
+		expensesByCategory, incomesBySource       map[string]float64
+		recentExpenses                            []models.Expense
+		recentIncomes                             []models.Income
+		goals                                     []models.Goal
+		loans                                     []models.Loan
+	)
+
+	// Независимые запросы выполняются параллельно, первая ошибка отменяет остальные
+	group, groupCtx := errgroup.WithContext(ctx)
 
 	// Получаем общую сумму трат за текущий месяц
-	currentMonthExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, currentMonthStart, currentMonthEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении трат за текущий месяц")
-	}
+	group.Go(func() (err error) {
+		if currentMonthExpenses, err = s.expenseRepo.GetTotalAmountByUserIDAndPeriod(groupCtx, userID, currentMonthStart, currentMonthEnd); err != nil {
+			return errors.New("ошибка при получении трат за текущий месяц")
+		}
+		return nil
+	})
 
 	// Получаем общую сумму накоплений за текущий месяц
-	currentMonthIncomes, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, currentMonthStart, currentMonthEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении накоплений за текущий месяц")
-	}
-
-	// Определяем начало и конец всего периода (используем очень раннюю дату и текущую дату)
-	allTimeStart := time.Date(2000, 1, 1, 0, 0, 0, 0, now.Location())
-	allTimeEnd := now
+	group.Go(func() (err error) {
+		if currentMonthIncomes, err = s.incomeRepo.GetTotalAmountByUserIDAndPeriod(groupCtx, userID, currentMonthStart, currentMonthEnd); err != nil {
+			return errors.New("ошибка при получении накоплений за текущий месяц")
+		}
+		return nil
+	})
 
 	// Получаем общую сумму трат за все время
-	allTimeExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, allTimeStart, allTimeEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении трат за все время")
-	}
+	group.Go(func() (err error) {
+		if allTimeExpenses, err = s.expenseRepo.GetTotalAmountByUserIDAndPeriod(groupCtx, userID, allTimeStart, allTimeEnd); err != nil {
+			return errors.New("ошибка при получении трат за все время")
+		}
+		return nil
+	})
 
 	// Получаем общую сумму накоплений за все время
-	allTimeIncomes, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, allTimeStart, allTimeEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении накоплений за все время")
-	}
+	group.Go(func() (err error) {
+		if allTimeIncomes, err = s.incomeRepo.GetTotalAmountByUserIDAndPeriod(groupCtx, userID, allTimeStart, allTimeEnd); err != nil {
+			return errors.New("ошибка при получении накоплений за все время")
+		}
+		return nil
+	})
 
 	// Получаем сводку трат по категориям за текущий месяц
-	expensesByCategory, err := s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(ctx, userID, currentMonthStart, currentMonthEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении сводки трат по категориям")
-	}
+	group.Go(func() (err error) {
+		if expensesByCategory, err = s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(groupCtx, userID, currentMonthStart, currentMonthEnd); err != nil {
+			return errors.New("ошибка при получении сводки трат по категориям")
+		}
+		return nil
+	})
 
 	// Получаем сводку накоплений по источникам за текущий месяц
-	incomesBySource, err := s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(ctx, userID, currentMonthStart, currentMonthEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении сводки накоплений по источникам")
-	}
+	group.Go(func() (err error) {
+		if incomesBySource, err = s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(groupCtx, userID, currentMonthStart, currentMonthEnd); err != nil {
+			return errors.New("ошибка при получении сводки накоплений по источникам")
+		}
+		return nil
+	})
 
 	// Получаем последние 5 трат
-	recentExpenses, err := s.expenseRepo.GetByUserID(ctx, userID, 5, 0)
-	if err != nil {
-		return nil, errors.New("ошибка при получении последних трат")
-	}
+	group.Go(func() (err error) {
+		if recentExpenses, err = s.expenseRepo.GetByUserID(groupCtx, userID, 5, 0); err != nil {
+			return errors.New("ошибка при получении последних трат")
+		}
+		return nil
+	})
 
 	// Получаем последние 5 накоплений
-	recentIncomes, err := s.incomeRepo.GetByUserID(ctx, userID, 5, 0)
-	if err != nil {
-		return nil, errors.New("ошибка при получении последних накоплений")
+	group.Go(func() (err error) {
+		if recentIncomes, err = s.incomeRepo.GetByUserID(groupCtx, userID, 5, 0); err != nil {
+			return errors.New("ошибка при получении последних накоплений")
+		}
+		return nil
+	})
+
+	// Получаем цели накоплений
+	group.Go(func() (err error) {
+		if goals, err = s.goalRepo.GetByUserID(groupCtx, userID); err != nil {
+			return errors.New("ошибка при получении целей накоплений")
+		}
+		for i := range goals {
+			applyGoalProgress(&goals[i], now)
+		}
+		return nil
+	})
+
+	// Получаем кредиты с остатком долга и ближайшими платежами
+	group.Go(func() (err error) {
+		if loans, err = s.loanRepo.GetByUserID(groupCtx, userID); err != nil {
+			return errors.New("ошибка при получении кредитов")
+		}
+		return loadLoanStatuses(groupCtx, s.loanRepo, s.calculator, loans)
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
 	}
 
 	// Формируем ответ
@@ -115,6 +179,8 @@ func (s *DashboardServiceImpl) GetDashboardSummary(ctx context.Context, userID i
 		"incomes_by_source":    incomesBySource,
 		"recent_expenses":      recentExpenses,
 		"recent_incomes":       recentIncomes,
+		"goals":                summarizeGoals(goals),
+		"debts":                summarizeLoans(loans),
 	}
 
 	return result, nil
@@ -122,9 +188,14 @@ func (s *DashboardServiceImpl) GetDashboardSummary(ctx context.Context, userID i
 
 // GetMonthlyStats получает статистику за указанный месяц
 func (s *DashboardServiceImpl) GetMonthlyStats(ctx context.Context, userID int64, year int, month int) (map[string]interface{}, error) {
-	// Определяем начало и конец указанного месяца
-	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Now().Location())
-	monthEnd := monthStart.AddDate(0, 1, -1).Add(time.Hour * 23).Add(time.Minute * 59).Add(time.Second * 59)
+	// Получаем пользователя для получения лимитов и часового пояса
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Определяем границы указанного месяца в часовом поясе пользователя
+	monthStart, monthEnd := utils.MonthPeriod(year, time.Month(month), user.Location())
 
 	// Получаем общую сумму трат за указанный месяц
 	monthlyExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd)
@@ -162,12 +233,6 @@ func (s *DashboardServiceImpl) GetMonthlyStats(ctx context.Context, userID int64
 		return nil, errors.New("ошибка при получении сводки накоплений по источникам")
 	}
 
-	// Получаем пользователя для получения лимитов
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return nil, errors.New("пользователь не найден")
-	}
-
 	// Формируем ответ
 	result := map[string]interface{}{
 		"period": map[string]interface{}{
@@ -194,51 +259,70 @@ func (s *DashboardServiceImpl) GetMonthlyStats(ctx context.Context, userID int64
 
 // GetYearlyStats получает статистику за указанный год
 func (s *DashboardServiceImpl) GetYearlyStats(ctx context.Context, userID int64, year int) (map[string]interface{}, error) {
-	// Определяем начало и конец указанного года
-	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.Now().Location())
-	yearEnd := time.Date(year, 12, 31, 23, 59, 59, 0, time.Now().Location())
-
-	// Получаем общую сумму трат за указанный год
-	yearlyExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, yearStart, yearEnd)
+	// Получаем пользователя для определения часового пояса
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, errors.New("ошибка при получении трат за указанный год")
+		return nil, errors.New("пользователь не найден")
 	}
 
-	// Получаем общую сумму накоплений за указанный год
-	yearlyIncomes, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, yearStart, yearEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении накоплений за указанный год")
-	}
+	// Определяем границы указанного года в часовом поясе пользователя
+	yearStart, yearEnd := utils.YearPeriod(year, user.Location())
+
+	var (
+		monthlyExpenses, monthlyIncomes     map[string]float64
+		expensesByCategory, incomesBySource map[string]float64
+	)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	timezone := user.TimezoneName()
+
+	// Получаем суммы трат по месяцам одним запросом
+	group.Go(func() (err error) {
+		if monthlyExpenses, err = s.expenseRepo.GetMonthlyTotalsByUserIDAndPeriod(groupCtx, userID, yearStart, yearEnd, timezone); err != nil {
+			return errors.New("ошибка при получении трат по месяцам")
+		}
+		return nil
+	})
+
+	// Получаем суммы накоплений по месяцам одним запросом
+	group.Go(func() (err error) {
+		if monthlyIncomes, err = s.incomeRepo.GetMonthlyTotalsByUserIDAndPeriod(groupCtx, userID, yearStart, yearEnd, timezone); err != nil {
+			return errors.New("ошибка при получении накоплений по месяцам")
+		}
+		return nil
+	})
 
 	// Получаем сводку трат по категориям за указанный год
-	expensesByCategory, err := s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(ctx, userID, yearStart, yearEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении сводки трат по категориям")
-	}
+	group.Go(func() (err error) {
+		if expensesByCategory, err = s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(groupCtx, userID, yearStart, yearEnd); err != nil {
+			return errors.New("ошибка при получении сводки трат по категориям")
+		}
+		return nil
+	})
 
 	// Получаем сводку накоплений по источникам за указанный год
-	incomesBySource, err := s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(ctx, userID, yearStart, yearEnd)
-	if err != nil {
-		return nil, errors.New("ошибка при получении сводки накоплений по источникам")
+	group.Go(func() (err error) {
+		if incomesBySource, err = s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(groupCtx, userID, yearStart, yearEnd); err != nil {
+			return errors.New("ошибка при получении сводки накоплений по источникам")
+		}
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
 	}
 
-	// Получаем данные по месяцам
+	// Раскладываем суммы по месяцам, итоги за год складываются из месячных сумм
+	var yearlyExpenses, yearlyIncomes float64
 	monthlyData := make([]map[string]interface{}, 12)
 	for month := 1; month <= 12; month++ {
-		monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Now().Location())
-		monthEnd := monthStart.AddDate(0, 1, -1).Add(time.Hour * 23).Add(time.Minute * 59).Add(time.Second * 59)
+		monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, yearStart.Location())
+		key := monthStart.Format("2006-01")
 
-		// Расходы за месяц
-		monthExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd)
-		if err != nil {
-			monthExpenses = 0
-		}
-
-		// Доходы за месяц
-		monthIncomes, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd)
-		if err != nil {
-			monthIncomes = 0
-		}
+		monthExpenses := monthlyExpenses[key]
+		monthIncomes := monthlyIncomes[key]
+		yearlyExpenses += monthExpenses
+		yearlyIncomes += monthIncomes
 
 		monthlyData[month-1] = map[string]interface{}{
 			"month":     month,
@@ -248,6 +332,8 @@ func (s *DashboardServiceImpl) GetYearlyStats(ctx context.Context, userID int64,
 			"month_str": monthStart.Month().String(),
 		}
 	}
+	yearlyExpenses = roundMoney(yearlyExpenses)
+	yearlyIncomes = roundMoney(yearlyIncomes)
 
 	// Формируем ответ
 	result := map[string]interface{}{
@@ -284,15 +370,13 @@ func calculatePercentage(value, total float64) float64 {
 // GetBudgetGoals получает бюджетные цели пользователя
 func (s *DashboardServiceImpl) GetBudgetGoals(ctx context.Context, userID int64) (map[string]interface{}, error) {
 	// Получаем пользователя для проверки
-	_, err := s.userRepo.GetByID(ctx, userID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, errors.New("пользователь не найден")
 	}
 
-	// Получаем текущий месяц для расчёта прогресса
-	now := time.Now()
-	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Now().Location())
-	monthEnd := monthStart.AddDate(0, 1, -1).Add(time.Hour * 23).Add(time.Minute * 59).Add(time.Second * 59)
+	// Получаем текущий месяц в часовом поясе пользователя для расчёта прогресса
+	monthStart, monthEnd := utils.CurrentMonthPeriod(time.Now().In(user.Location()))
 
 	// Получаем сводку трат по категориям за текущий месяц
 	expensesByCategory, err := s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(ctx, userID, monthStart, monthEnd)
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/repositories"
+)
+
+// Бенчмарки сравнивают прежнюю загрузку панели (последовательные запросы и по два запроса
+// на каждый месяц года) с текущей (группировка по месяцам в SQL и параллельные запросы).
+// Запуск: TEST_DATABASE_URL=postgres://... go test ./services -run '^$' -bench Dashboard -benchmem
+
+const (
+	benchExpenses = 50000
+	benchIncomes  = 5000
+)
+
+// benchDashboard подготавливает сервис панели и пользователя с историей за два года
+func benchDashboard(b *testing.B) (*DashboardServiceImpl, int64, int) {
+	db := openTestDB(b)
+	year := time.Now().Year() - 1
+	userID := seedTestUser(b, db, time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), benchExpenses, benchIncomes)
+
+	service := NewDashboardService(
+		repositories.NewExpenseRepository(db),
+		repositories.NewIncomeRepository(db),
+		repositories.NewUserRepository(db),
+		repositories.NewGoalRepository(db),
+		repositories.NewLoanRepository(db),
+		NewCalculatorService(),
+	).(*DashboardServiceImpl)
+
+	return service, userID, year
+}
+
+// legacyYearlyStats повторяет прежний расчет годовой статистики: итоги и сводки за год
+// и отдельные запросы сумм трат и накоплений для каждого месяца
+func legacyYearlyStats(ctx context.Context, s *DashboardServiceImpl, userID int64, year int) error {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	if _, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, yearStart, yearEnd); err != nil {
+		return err
+	}
+	if _, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, yearStart, yearEnd); err != nil {
+		return err
+	}
+	if _, err := s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(ctx, userID, yearStart, yearEnd); err != nil {
+		return err
+	}
+	if _, err := s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(ctx, userID, yearStart, yearEnd); err != nil {
+		return err
+	}
+
+	for month := 1; month <= 12; month++ {
+		monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		monthEnd := monthStart.AddDate(0, 1, 0)
+		if _, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd); err != nil {
+			return err
+		}
+		if _, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// legacyDashboardSummary повторяет прежнюю последовательную загрузку сводки панели
+func legacyDashboardSummary(ctx context.Context, s *DashboardServiceImpl, userID int64) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	allTimeStart := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd); err != nil {
+		return err
+	}
+	if _, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, monthStart, monthEnd); err != nil {
+		return err
+	}
+	if _, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, allTimeStart, now); err != nil {
+		return err
+	}
+	if _, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, allTimeStart, now); err != nil {
+		return err
+	}
+	if _, err := s.expenseRepo.GetCategorySummaryByUserIDAndPeriod(ctx, userID, monthStart, monthEnd); err != nil {
+		return err
+	}
+	if _, err := s.incomeRepo.GetSourceSummaryByUserIDAndPeriod(ctx, userID, monthStart, monthEnd); err != nil {
+		return err
+	}
+	if _, err := s.expenseRepo.GetByUserID(ctx, userID, 5, 0); err != nil {
+		return err
+	}
+	if _, err := s.incomeRepo.GetByUserID(ctx, userID, 5, 0); err != nil {
+		return err
+	}
+	if _, err := s.goalRepo.GetByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if _, err := s.loanRepo.GetByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func BenchmarkYearlyStatsPerMonthQueries(b *testing.B) {
+	service, userID, year := benchDashboard(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := legacyYearlyStats(ctx, service, userID, year); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkYearlyStatsMonthlyAggregate(b *testing.B) {
+	service, userID, year := benchDashboard(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := service.GetYearlyStats(ctx, userID, year); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDashboardSummarySequential(b *testing.B) {
+	service, userID, _ := benchDashboard(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := legacyDashboardSummary(ctx, service, userID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDashboardSummaryParallel(b *testing.B) {
+	service, userID, _ := benchDashboard(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := service.GetDashboardSummary(ctx, userID, 5); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
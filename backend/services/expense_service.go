@@ -3,34 +3,51 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"cz.Finance/backend/models"
 	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
 )
 
 // ExpenseServiceImpl представляет реализацию сервиса трат
 type ExpenseServiceImpl struct {
-	expenseRepo repositories.ExpenseRepository
-	userRepo    repositories.UserRepository
+	expenseRepo   repositories.ExpenseRepository
+	userRepo      repositories.UserRepository
+	householdRepo repositories.HouseholdRepository
+	ruleRepo      repositories.RuleRepository
+	payeeRepo     repositories.PayeeRepository
+	classifiers   *classifierCache
 }
 
 // NewExpenseService создает новый экземпляр сервиса трат
-func NewExpenseService(expenseRepo repositories.ExpenseRepository, userRepo repositories.UserRepository) ExpenseService {
+func NewExpenseService(expenseRepo repositories.ExpenseRepository, userRepo repositories.UserRepository, householdRepo repositories.HouseholdRepository, ruleRepo repositories.RuleRepository, payeeRepo repositories.PayeeRepository) ExpenseService {
 	return &ExpenseServiceImpl{
-		expenseRepo: expenseRepo,
-		userRepo:    userRepo,
+		expenseRepo:   expenseRepo,
+		userRepo:      userRepo,
+		householdRepo: householdRepo,
+		ruleRepo:      ruleRepo,
+		payeeRepo:     payeeRepo,
+		classifiers:   expenseClassifiers,
 	}
 }
 
 // CreateExpense создает новую трату
 func (s *ExpenseServiceImpl) CreateExpense(ctx context.Context, userID int64, request *models.CreateExpenseRequest) (*models.Expense, error) {
 	// Проверяем существование пользователя
-	_, err := s.userRepo.GetByID(ctx, userID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, errors.New("пользователь не найден")
 	}
 
+	// Проверяем права на добавление записей в домохозяйство
+	if request.HouseholdID != nil {
+		if err := checkHouseholdWriteAccess(ctx, s.householdRepo, *request.HouseholdID, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Создаем новую трату
 	expense := &models.Expense{
 		UserID:      userID,
@@ -39,6 +56,9 @@ func (s *ExpenseServiceImpl) CreateExpense(ctx context.Context, userID int64, re
 		Category:    request.Category,
 		Date:        request.Date,
 		Description: request.Description,
+		Account:     strings.TrimSpace(request.Account),
+		Tags:        normalizeTags(request.Tags),
+		HouseholdID: request.HouseholdID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -48,6 +68,11 @@ func (s *ExpenseServiceImpl) CreateExpense(ctx context.Context, userID int64, re
 		expense.Date = time.Now()
 	}
 
+	// Применяем правила категоризации и связываем трату с получателем
+	if err := prepareExpense(ctx, s.ruleRepo, s.payeeRepo, expense, user.Location()); err != nil {
+		return nil, err
+	}
+
 	// Сохраняем трату в базе данных
 	expenseID, err := s.expenseRepo.Create(ctx, expense)
 	if err != nil {
@@ -56,10 +81,46 @@ func (s *ExpenseServiceImpl) CreateExpense(ctx context.Context, userID int64, re
 
 	// Устанавливаем ID траты
 	expense.ID = expenseID
+	s.classifiers.invalidate(userID)
 
 	return expense, nil
 }
 
+// prepareExpense применяет к новой трате правила категоризации и связывает ее с получателем.
+// Категорию правило или получатель назначают, только если она не указана, иначе используется «Прочее».
+// Функция общая для всех мест, где пользователь создает трату. Дни недели в правилах
+// проверяются в часовом поясе пользователя location
+func prepareExpense(ctx context.Context, ruleRepo repositories.RuleRepository, payeeRepo repositories.PayeeRepository, expense *models.Expense, location *time.Location) error {
+	// Получатель ищется по исходному названию, так как правило может его переименовать
+	title := expense.Title
+
+	rules, err := loadRuleSet(ctx, ruleRepo, expense.UserID, location)
+	if err != nil {
+		return errors.New("ошибка при получении правил категоризации")
+	}
+	rules.apply(expense, expense.Category == "")
+
+	payees, err := loadPayeeSet(ctx, payeeRepo, expense.UserID)
+	if err != nil {
+		return errors.New("ошибка при получении получателей")
+	}
+	payee := payees.match(title)
+	if payee == nil {
+		payee = payees.match(expense.Title)
+	}
+	if payee != nil {
+		expense.PayeeID = &payee.ID
+		if expense.Category == "" {
+			expense.Category = payee.DefaultCategory
+		}
+	}
+	if expense.Category == "" {
+		expense.Category = models.CategoryOther
+	}
+
+	return nil
+}
+
 // GetExpense получает трату по ID
 func (s *ExpenseServiceImpl) GetExpense(ctx context.Context, id int64, userID int64) (*models.Expense, error) {
 	expense, err := s.expenseRepo.GetByID(ctx, id)
@@ -92,6 +153,11 @@ func (s *ExpenseServiceImpl) GetUserExpensesByPeriod(ctx context.Context, userID
 	return s.expenseRepo.GetByUserIDAndPeriod(ctx, userID, startDate, endDate)
 }
 
+// StreamUserExpenses передает траты пользователя, подходящие под фильтр, в функцию fn по одной
+func (s *ExpenseServiceImpl) StreamUserExpenses(ctx context.Context, userID int64, filter *models.ExpenseFilter, fn func(expense *models.Expense) error) error {
+	return s.expenseRepo.StreamByFilter(ctx, userID, filter, fn)
+}
+
 // GetExpenseSummary получает сводку по тратам пользователя за период
 func (s *ExpenseServiceImpl) GetExpenseSummary(ctx context.Context, userID int64, startDate, endDate time.Time) (*models.ExpenseSummary, error) {
 	// Получаем информацию о пользователе для получения месячного лимита
@@ -100,6 +166,11 @@ func (s *ExpenseServiceImpl) GetExpenseSummary(ctx context.Context, userID int64
 		return nil, errors.New("пользователь не найден")
 	}
 
+	// Без указанного периода берется текущий месяц в часовом поясе пользователя
+	if startDate.IsZero() || endDate.IsZero() {
+		startDate, endDate = utils.CurrentMonthPeriod(time.Now().In(user.Location()))
+	}
+
 	// Получаем общую сумму трат за период
 	totalAmount, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, startDate, endDate)
 	if err != nil {
@@ -154,6 +225,16 @@ func (s *ExpenseServiceImpl) UpdateExpense(ctx context.Context, id int64, userID
 	// Обновляем поля, если они указаны в запросе
 	if request.Title != nil {
 		expense.Title = *request.Title
+
+		// Новое название может относиться к другому получателю
+		payees, err := loadPayeeSet(ctx, s.payeeRepo, userID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении получателей")
+		}
+		expense.PayeeID = nil
+		if payee := payees.match(expense.Title); payee != nil {
+			expense.PayeeID = &payee.ID
+		}
 	}
 	if request.Amount != nil {
 		expense.Amount = *request.Amount
@@ -167,6 +248,23 @@ func (s *ExpenseServiceImpl) UpdateExpense(ctx context.Context, id int64, userID
 	if request.Description != nil {
 		expense.Description = *request.Description
 	}
+	if request.Account != nil {
+		expense.Account = strings.TrimSpace(*request.Account)
+	}
+	if request.Tags != nil {
+		expense.Tags = normalizeTags(*request.Tags)
+	}
+	if request.HouseholdID != nil {
+		// Нулевой ID убирает запись из домохозяйства
+		if *request.HouseholdID == 0 {
+			expense.HouseholdID = nil
+		} else {
+			if err := checkHouseholdWriteAccess(ctx, s.householdRepo, *request.HouseholdID, userID); err != nil {
+				return nil, err
+			}
+			expense.HouseholdID = request.HouseholdID
+		}
+	}
 
 	// Обновляем время изменения
 	expense.UpdatedAt = time.Now()
@@ -176,6 +274,7 @@ func (s *ExpenseServiceImpl) UpdateExpense(ctx context.Context, id int64, userID
 	if err != nil {
 		return nil, errors.New("ошибка при обновлении траты")
 	}
+	s.classifiers.invalidate(userID)
 
 	return expense, nil
 }
@@ -193,5 +292,91 @@ func (s *ExpenseServiceImpl) DeleteExpense(ctx context.Context, id int64, userID
 		return errors.New("у вас нет прав на удаление этой траты")
 	}
 
-	return s.expenseRepo.Delete(ctx, id, userID)
+	if err := s.expenseRepo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+	s.classifiers.invalidate(userID)
+
+	return nil
+}
+
+// SuggestCategory предлагает категорию для новой траты. Сначала проверяются правила
+// категоризации и получатели, затем используется модель, обученная на истории трат пользователя
+func (s *ExpenseServiceImpl) SuggestCategory(ctx context.Context, userID int64, request *models.SuggestCategoryRequest) (*models.CategorySuggestions, error) {
+	expense := &models.Expense{
+		Title:       request.Title,
+		Description: request.Description,
+		Amount:      request.Amount,
+		Date:        time.Now(),
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Правило с категорией дает однозначный ответ
+	rules, err := loadRuleSet(ctx, s.ruleRepo, userID, user.Location())
+	if err != nil {
+		return nil, errors.New("ошибка при получении правил категоризации")
+	}
+	if rule := rules.apply(expense, true); rule != nil && rule.Category != "" {
+		return &models.CategorySuggestions{
+			Suggestions: []models.CategorySuggestion{{
+				Category:   rule.Category,
+				Title:      models.ExpenseCategoryTitles[rule.Category],
+				Confidence: 1,
+			}},
+			Confident: true,
+			Source:    "rule",
+			RuleID:    &rule.ID,
+		}, nil
+	}
+
+	// Категория по умолчанию известного получателя тоже однозначна
+	payees, err := loadPayeeSet(ctx, s.payeeRepo, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении получателей")
+	}
+	if payee := payees.match(request.Title); payee != nil && payee.DefaultCategory != "" {
+		return &models.CategorySuggestions{
+			Suggestions: []models.CategorySuggestion{{
+				Category:   payee.DefaultCategory,
+				Title:      models.ExpenseCategoryTitles[payee.DefaultCategory],
+				Confidence: 1,
+			}},
+			Confident: true,
+			Source:    "payee",
+			PayeeID:   &payee.ID,
+		}, nil
+	}
+
+	// Обучаем модель на истории трат или берем ее из кэша
+	classifier := s.classifiers.get(userID)
+	if classifier == nil {
+		expenses, err := s.expenseRepo.GetAllByUserID(ctx, userID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении трат")
+		}
+		classifier = trainCategoryClassifier(expenses)
+		s.classifiers.put(userID, classifier)
+	}
+
+	result := &models.CategorySuggestions{
+		Suggestions: classifier.predict(request.Title, request.Description, request.Amount),
+		Source:      "model",
+		TrainedOn:   classifier.samples,
+	}
+	if len(result.Suggestions) == 0 {
+		result.Source = "none"
+		return result, nil
+	}
+	if len(result.Suggestions) > classifierMaxSuggestions {
+		result.Suggestions = result.Suggestions[:classifierMaxSuggestions]
+	}
+	result.Confident = classifier.samples >= classifierMinSamples &&
+		result.Suggestions[0].Confidence >= classifierConfidenceThreshold &&
+		classifier.recognizes(request.Title, request.Description)
+
+	return result, nil
 }
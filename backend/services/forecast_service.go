@@ -0,0 +1,431 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+const (
+	// forecastRunRateDays задает глубину истории для расчета среднедневных трат и накоплений
+	forecastRunRateDays = 90
+	// forecastMinRunRateDays не дает завышать средние значения при короткой истории
+	forecastMinRunRateDays = 14
+	// forecastMaxMonths ограничивает горизонт прогноза
+	forecastMaxMonths = 12
+	// forecastChargeTolerance задает, насколько раньше срока может пройти регулярное списание,
+	// чтобы считаться уже оплаченным
+	forecastChargeTolerance = 3 * 24 * time.Hour
+)
+
+// ForecastServiceImpl представляет реализацию сервиса прогноза денежного потока
+type ForecastServiceImpl struct {
+	expenseRepo   repositories.ExpenseRepository
+	incomeRepo    repositories.IncomeRepository
+	userRepo      repositories.UserRepository
+	loanRepo      repositories.LoanRepository
+	recurringRepo repositories.RecurringRuleRepository
+	calculator    CalculatorService
+}
+
+// NewForecastService создает новый экземпляр сервиса прогноза
+func NewForecastService(
+	expenseRepo repositories.ExpenseRepository,
+	incomeRepo repositories.IncomeRepository,
+	userRepo repositories.UserRepository,
+	loanRepo repositories.LoanRepository,
+	recurringRepo repositories.RecurringRuleRepository,
+	calculator CalculatorService,
+) ForecastService {
+	return &ForecastServiceImpl{
+		expenseRepo:   expenseRepo,
+		incomeRepo:    incomeRepo,
+		userRepo:      userRepo,
+		loanRepo:      loanRepo,
+		recurringRepo: recurringRepo,
+		calculator:    calculator,
+	}
+}
+
+// forecastInput содержит данные, по которым строится прогноз
+type forecastInput struct {
+	now            time.Time
+	months         int
+	monthlyLimit   float64
+	openingBalance float64
+	expenses       []models.Expense
+	incomes        []models.Income
+	rules          []models.RecurringRule
+	loans          []models.Loan
+}
+
+// GetForecast строит прогноз баланса и трат по категориям до конца текущего месяца
+// и на указанное количество следующих месяцев
+func (s *ForecastServiceImpl) GetForecast(ctx context.Context, userID int64, months int) (*models.CashFlowForecast, error) {
+	if months < 0 || months > forecastMaxMonths {
+		return nil, fmt.Errorf("горизонт прогноза должен быть от 0 до %d месяцев", forecastMaxMonths)
+	}
+
+	// Получаем пользователя
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	now := time.Now().In(user.Location())
+	today := utils.StartOfDay(now)
+	monthStart, _ := utils.CurrentMonthPeriod(now)
+	historyStart := today.AddDate(0, 0, -forecastRunRateDays)
+	if monthStart.Before(historyStart) {
+		historyStart = monthStart
+	}
+
+	// Текущий баланс считается так же, как баланс за все время на панели мониторинга
+	allTimeStart := utils.AllTimeStart(now.Location())
+	allTimeExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, allTimeStart, now)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат за все время")
+	}
+	allTimeIncomes, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, allTimeStart, now)
+	if err != nil {
+		return nil, errors.New("ошибка при получении накоплений за все время")
+	}
+
+	// Получаем историю трат и накоплений для расчета среднедневных значений
+	expenses, err := s.expenseRepo.GetByUserIDAndPeriod(ctx, userID, historyStart, now)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+	incomes, err := s.incomeRepo.GetByUserIDAndPeriod(ctx, userID, historyStart, now)
+	if err != nil {
+		return nil, errors.New("ошибка при получении накоплений")
+	}
+
+	// Получаем регулярные списания
+	rules, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении регулярных списаний")
+	}
+
+	// Получаем кредиты с графиком оставшихся платежей
+	loans, err := s.loanRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении кредитов")
+	}
+	for i := range loans {
+		payments, err := s.loanRepo.GetPayments(ctx, loans[i].ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении платежей")
+		}
+		applyLoanStatus(s.calculator, &loans[i], payments, true)
+	}
+
+	return buildForecast(&forecastInput{
+		now:            now,
+		months:         months,
+		monthlyLimit:   user.MonthlyLimit,
+		openingBalance: allTimeIncomes - allTimeExpenses,
+		expenses:       expenses,
+		incomes:        incomes,
+		rules:          rules,
+		loans:          loans,
+	}), nil
+}
+
+// buildForecast рассчитывает прогноз: известные списания по регулярным правилам и графикам
+// кредитов ставятся на свои даты, остальные траты и накопления распределяются по дням
+// по среднедневным значениям за последние месяцы
+func buildForecast(input *forecastInput) *models.CashFlowForecast {
+	now := input.now
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := monthStart.AddDate(0, input.months+1, 0)
+	historyStart := today.AddDate(0, 0, -forecastRunRateDays)
+
+	// Траты по кредитам и регулярным списаниям прогнозируются по графику,
+	// поэтому исключаются из среднедневных трат
+	loanExpenses := make(map[int64]bool)
+	for _, loan := range input.loans {
+		for _, payment := range loan.Payments {
+			if payment.ExpenseID != nil {
+				loanExpenses[*payment.ExpenseID] = true
+			}
+		}
+	}
+
+	rulesByKey := make(map[string]bool)
+	for _, rule := range input.rules {
+		if rule.Active {
+			rulesByKey[rule.MatchKey] = true
+		}
+	}
+
+	lastCharges := make(map[string]time.Time)
+	categoryRates := make(map[models.ExpenseCategory]float64)
+	actualExpenses := make(map[models.ExpenseCategory]float64)
+	actualIncomes := 0.0
+	incomeRate := 0.0
+	firstRecord := today
+
+	for i := range input.expenses {
+		expense := &input.expenses[i]
+		if !expense.Date.Before(monthStart) {
+			actualExpenses[expense.Category] += expense.Amount
+		}
+
+		key := subscriptionKey(expense)
+		recurring := key != "" && rulesByKey[key]
+		if recurring && expense.Date.After(lastCharges[key]) {
+			lastCharges[key] = expense.Date
+		}
+
+		if expense.Date.Before(historyStart) || !expense.Date.Before(today) {
+			continue
+		}
+		if expense.Date.Before(firstRecord) {
+			firstRecord = expense.Date
+		}
+		if recurring || loanExpenses[expense.ID] {
+			continue
+		}
+		categoryRates[expense.Category] += expense.Amount
+	}
+
+	for _, income := range input.incomes {
+		if !income.Date.Before(monthStart) {
+			actualIncomes += income.Amount
+		}
+		if income.Date.Before(historyStart) || !income.Date.Before(today) {
+			continue
+		}
+		if income.Date.Before(firstRecord) {
+			firstRecord = income.Date
+		}
+		incomeRate += income.Amount
+	}
+
+	// При короткой истории среднее считается только по дням, когда она уже велась
+	runRateDays := forecastRunRateDays
+	if historyDays := daysBetween(firstRecord, today); historyDays < runRateDays {
+		runRateDays = int(math.Max(float64(historyDays), forecastMinRunRateDays))
+	}
+
+	expenseRate := 0.0
+	for category := range categoryRates {
+		categoryRates[category] /= float64(runRateDays)
+		expenseRate += categoryRates[category]
+	}
+	incomeRate /= float64(runRateDays)
+
+	scheduled := scheduleForecastItems(input.rules, input.loans, lastCharges, today, end)
+
+	forecast := &models.CashFlowForecast{
+		StartDate:        today,
+		EndDate:          end.AddDate(0, 0, -1),
+		OpeningBalance:   roundMoney(input.openingBalance),
+		DailyExpenseRate: roundMoney(expenseRate),
+		DailyIncomeRate:  roundMoney(incomeRate),
+		RunRateDays:      runRateDays,
+		Days:             []models.ForecastDay{},
+		Scheduled:        scheduled,
+		Warnings:         []string{},
+	}
+
+	months := make([]models.ForecastMonth, input.months+1)
+	projected := make([]map[models.ExpenseCategory]*models.ForecastCategory, len(months))
+	for i := range months {
+		months[i] = models.ForecastMonth{
+			Month:        monthStart.AddDate(0, i, 0),
+			MonthlyLimit: input.monthlyLimit,
+		}
+		projected[i] = make(map[models.ExpenseCategory]*models.ForecastCategory)
+	}
+
+	category := func(month int, name models.ExpenseCategory) *models.ForecastCategory {
+		item, ok := projected[month][name]
+		if !ok {
+			item = &models.ForecastCategory{Category: name}
+			projected[month][name] = item
+		}
+		return item
+	}
+
+	// Текущий месяц начинается с уже совершенных трат и полученных накоплений
+	for name, amount := range actualExpenses {
+		category(0, name).Actual += amount
+		months[0].Expenses += amount
+	}
+	months[0].Incomes = actualIncomes
+	alreadyExceeded := input.monthlyLimit > 0 && months[0].Expenses > input.monthlyLimit
+
+	balance := input.openingBalance
+	var negativeOn *time.Time
+	next := 0
+	for day := today; day.Before(end); day = day.AddDate(0, 0, 1) {
+		month := (day.Year()-monthStart.Year())*12 + int(day.Month()) - int(monthStart.Month())
+		point := models.ForecastDay{Date: day}
+
+		// Сегодняшние траты уже частично внесены, поэтому средние значения учитываются со следующего дня
+		if day.After(today) {
+			point.Incomes = incomeRate
+			point.Expenses = expenseRate
+			for name, rate := range categoryRates {
+				category(month, name).RunRate += rate
+			}
+		}
+
+		for ; next < len(scheduled) && scheduled[next].Date.Equal(day); next++ {
+			point.Scheduled += scheduled[next].Amount
+			category(month, scheduled[next].Category).Scheduled += scheduled[next].Amount
+		}
+		point.Expenses += point.Scheduled
+
+		balance += point.Incomes - point.Expenses
+		months[month].Incomes += point.Incomes
+		months[month].Expenses += point.Expenses
+		months[month].ClosingBalance = balance
+
+		if input.monthlyLimit > 0 && months[month].LimitExceededOn == nil && months[month].Expenses > input.monthlyLimit {
+			exceededOn := day
+			months[month].LimitExceededOn = &exceededOn
+		}
+		if negativeOn == nil && balance < 0 && input.openingBalance >= 0 {
+			negativeOn = &point.Date
+		}
+
+		point.Incomes = roundMoney(point.Incomes)
+		point.Expenses = roundMoney(point.Expenses)
+		point.Scheduled = roundMoney(point.Scheduled)
+		point.Balance = roundMoney(balance)
+		forecast.Days = append(forecast.Days, point)
+	}
+	forecast.ClosingBalance = roundMoney(balance)
+
+	for i := range months {
+		month := &months[i]
+		month.Expenses = roundMoney(month.Expenses)
+		month.Incomes = roundMoney(month.Incomes)
+		month.ClosingBalance = roundMoney(month.ClosingBalance)
+		month.LimitPercent = roundMoney(calculatePercentage(month.Expenses, month.MonthlyLimit))
+		month.LimitExceeded = month.MonthlyLimit > 0 && month.Expenses > month.MonthlyLimit
+
+		month.Categories = make([]models.ForecastCategory, 0, len(projected[i]))
+		for _, item := range projected[i] {
+			item.Actual = roundMoney(item.Actual)
+			item.RunRate = roundMoney(item.RunRate)
+			item.Scheduled = roundMoney(item.Scheduled)
+			item.Projected = roundMoney(item.Actual + item.RunRate + item.Scheduled)
+			month.Categories = append(month.Categories, *item)
+		}
+		sort.Slice(month.Categories, func(a, b int) bool {
+			if month.Categories[a].Projected != month.Categories[b].Projected {
+				return month.Categories[a].Projected > month.Categories[b].Projected
+			}
+			return month.Categories[a].Category < month.Categories[b].Category
+		})
+
+		if !month.LimitExceeded {
+			continue
+		}
+		forecast.LimitExceeded = true
+
+		label := month.Month.Format("01.2006")
+		if i == 0 && alreadyExceeded {
+			forecast.Warnings = append(forecast.Warnings, fmt.Sprintf(
+				"Лимит трат за %s уже превышен: к концу месяца траты составят около %.2f руб. при лимите %.2f руб.",
+				label, month.Expenses, month.MonthlyLimit))
+			continue
+		}
+		forecast.Warnings = append(forecast.Warnings, fmt.Sprintf(
+			"По прогнозу траты за %s составят %.2f руб. и превысят лимит %.2f руб. примерно %s",
+			label, month.Expenses, month.MonthlyLimit, month.LimitExceededOn.Format("02.01.2006")))
+	}
+
+	if negativeOn != nil {
+		forecast.Warnings = append(forecast.Warnings, fmt.Sprintf(
+			"По прогнозу баланс станет отрицательным примерно %s", negativeOn.Format("02.01.2006")))
+	}
+
+	forecast.Months = months
+
+	return forecast
+}
+
+// scheduleForecastItems раскладывает по датам регулярные списания и платежи по кредитам
+// в пределах горизонта прогноза. Просроченные платежи по кредитам переносятся на сегодня,
+// а регулярное списание пропускается, если оно уже прошло незадолго до срока
+func scheduleForecastItems(rules []models.RecurringRule, loans []models.Loan, lastCharges map[string]time.Time, today, end time.Time) []models.ForecastItem {
+	items := []models.ForecastItem{}
+
+	for _, rule := range rules {
+		if !rule.Active || rule.NextDate.IsZero() {
+			continue
+		}
+
+		ruleID := rule.ID
+		date := time.Date(rule.NextDate.Year(), rule.NextDate.Month(), rule.NextDate.Day(), 0, 0, 0, 0, today.Location())
+		for date.Before(today) {
+			date = nextRecurringDate(date, rule.Cadence)
+		}
+		if last, ok := lastCharges[rule.MatchKey]; ok && !last.Before(date.Add(-forecastChargeTolerance)) {
+			date = nextRecurringDate(date, rule.Cadence)
+		}
+
+		for ; date.Before(end); date = nextRecurringDate(date, rule.Cadence) {
+			items = append(items, models.ForecastItem{
+				Date:            date,
+				Kind:            models.ForecastItemRecurring,
+				Title:           rule.Title,
+				Category:        rule.Category,
+				Amount:          rule.Amount,
+				RecurringRuleID: &ruleID,
+			})
+		}
+	}
+
+	for _, loan := range loans {
+		if loan.Closed {
+			continue
+		}
+
+		loanID := loan.ID
+		for _, entry := range loan.Schedule {
+			date := time.Date(entry.Date.Year(), entry.Date.Month(), entry.Date.Day(), 0, 0, 0, 0, today.Location())
+			if date.Before(today) {
+				date = today
+			}
+			if !date.Before(end) {
+				break
+			}
+
+			items = append(items, models.ForecastItem{
+				Date:     date,
+				Kind:     models.ForecastItemLoan,
+				Title:    loan.Name,
+				Category: loan.Category,
+				Amount:   entry.Payment,
+				LoanID:   &loanID,
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Date.Before(items[j].Date)
+	})
+
+	return items
+}
+
+// daysBetween возвращает количество календарных дней между датами без учета времени
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, to.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location())
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestScheduleForecastItems(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	today := date(time.March, 10)
+	end := date(time.May, 1)
+
+	rules := []models.RecurringRule{
+		{ID: 1, MatchKey: "title:netflix", Title: "Netflix", Amount: 299, Cadence: models.CadenceMonthly, NextDate: date(time.February, 15), Active: true},
+		{ID: 2, MatchKey: "title:spotify", Title: "Spotify", Amount: 169, Cadence: models.CadenceMonthly, NextDate: date(time.March, 12), Active: true},
+		{ID: 3, MatchKey: "title:ivi", Title: "Ivi", Amount: 399, Cadence: models.CadenceMonthly, NextDate: date(time.March, 20)},
+		{ID: 4, MatchKey: "title:фитнес", Title: "Фитнес", Amount: 500, Cadence: models.CadenceWeekly, NextDate: date(time.April, 20), Active: true},
+	}
+	loans := []models.Loan{
+		{ID: 10, Name: "Ипотека", Schedule: []models.LoanScheduleEntry{
+			{Date: date(time.February, 28), Payment: 30000},
+			{Date: date(time.March, 28), Payment: 30000},
+			{Date: date(time.April, 28), Payment: 30000},
+			{Date: date(time.May, 28), Payment: 30000},
+		}},
+		{ID: 11, Name: "Автокредит", Closed: true, Schedule: []models.LoanScheduleEntry{
+			{Date: date(time.March, 20), Payment: 15000},
+		}},
+	}
+	// Spotify уже списан за два дня до срока, поэтому следующее списание переносится на апрель
+	lastCharges := map[string]time.Time{"title:spotify": date(time.March, 10)}
+
+	type scheduled struct {
+		date   time.Time
+		title  string
+		amount float64
+	}
+	want := []scheduled{
+		{date: date(time.March, 10), title: "Ипотека", amount: 30000},
+		{date: date(time.March, 15), title: "Netflix", amount: 299},
+		{date: date(time.March, 28), title: "Ипотека", amount: 30000},
+		{date: date(time.April, 12), title: "Spotify", amount: 169},
+		{date: date(time.April, 15), title: "Netflix", amount: 299},
+		{date: date(time.April, 20), title: "Фитнес", amount: 500},
+		{date: date(time.April, 27), title: "Фитнес", amount: 500},
+		{date: date(time.April, 28), title: "Ипотека", amount: 30000},
+	}
+
+	items := scheduleForecastItems(rules, loans, lastCharges, today, end)
+
+	got := make([]scheduled, 0, len(items))
+	for _, item := range items {
+		got = append(got, scheduled{date: item.Date, title: item.Title, amount: item.Amount})
+		if (item.Kind == models.ForecastItemLoan) != (item.LoanID != nil) || (item.Kind == models.ForecastItemRecurring) != (item.RecurringRuleID != nil) {
+			t.Errorf("списание %q %v: вид %q не соответствует ссылке на источник", item.Title, item.Date, item.Kind)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("получено:\n%+v\nожидалось:\n%+v", got, want)
+	}
+}
+
+func TestBuildForecast(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	exceededOn := date(2024, time.March, 29)
+
+	tests := []struct {
+		name            string
+		input           forecastInput
+		wantRunRateDays int
+		wantExpenseRate float64
+		wantIncomeRate  float64
+		wantDays        int
+		wantClosing     float64
+		wantMonths      []models.ForecastMonth
+		wantWarnings    []string
+	}{
+		{
+			name: "полная история, подписка по графику и превышение лимита",
+			input: forecastInput{
+				now:            now,
+				months:         1,
+				monthlyLimit:   7500,
+				openingBalance: 10000,
+				expenses: []models.Expense{
+					{ID: 1, Title: "Продукты", Amount: 4500, Category: models.CategoryFood, Date: date(2023, time.December, 11)},
+					{ID: 2, Title: "Netflix", Amount: 1000, Category: models.CategoryEntertainment, Date: date(2024, time.February, 15)},
+					{ID: 3, Title: "Продукты", Amount: 4500, Category: models.CategoryFood, Date: date(2024, time.March, 5)},
+					// Сегодняшняя трата входит в факт месяца, но не в среднедневные траты
+					{ID: 4, Title: "Обед", Amount: 200, Category: models.CategoryFood, Date: date(2024, time.March, 10)},
+				},
+				incomes: []models.Income{
+					{ID: 1, Amount: 45000, Source: models.SourceSalary, Date: date(2024, time.March, 1)},
+				},
+				rules: []models.RecurringRule{
+					{ID: 1, MatchKey: "title:netflix", Title: "Netflix", Amount: 1000, Category: models.CategoryEntertainment,
+						Cadence: models.CadenceMonthly, NextDate: date(2024, time.March, 15), Active: true},
+				},
+			},
+			wantRunRateDays: 90,
+			wantExpenseRate: 100,
+			wantIncomeRate:  500,
+			wantDays:        52,
+			wantClosing:     28400,
+			wantMonths: []models.ForecastMonth{
+				{
+					Month: date(2024, time.March, 1), Expenses: 7800, Incomes: 55500, ClosingBalance: 17400,
+					MonthlyLimit: 7500, LimitPercent: 104, LimitExceeded: true, LimitExceededOn: &exceededOn,
+					Categories: []models.ForecastCategory{
+						{Category: models.CategoryFood, Actual: 4700, RunRate: 2100, Projected: 6800},
+						{Category: models.CategoryEntertainment, Scheduled: 1000, Projected: 1000},
+					},
+				},
+				{
+					Month: date(2024, time.April, 1), Expenses: 4000, Incomes: 15000, ClosingBalance: 28400,
+					MonthlyLimit: 7500, LimitPercent: 53.33,
+					Categories: []models.ForecastCategory{
+						{Category: models.CategoryFood, RunRate: 3000, Projected: 3000},
+						{Category: models.CategoryEntertainment, Scheduled: 1000, Projected: 1000},
+					},
+				},
+			},
+			wantWarnings: []string{
+				"По прогнозу траты за 03.2024 составят 7800.00 руб. и превысят лимит 7500.00 руб. примерно 29.03.2024",
+			},
+		},
+		{
+			name: "короткая история и отрицательный баланс",
+			input: forecastInput{
+				now:            now,
+				openingBalance: 100,
+				expenses: []models.Expense{
+					{ID: 1, Title: "Продукты", Amount: 700, Category: models.CategoryFood, Date: date(2024, time.March, 3)},
+				},
+			},
+			wantRunRateDays: 14,
+			wantExpenseRate: 50,
+			wantDays:        22,
+			wantClosing:     -950,
+			wantMonths: []models.ForecastMonth{
+				{
+					Month: date(2024, time.March, 1), Expenses: 1750, ClosingBalance: -950,
+					Categories: []models.ForecastCategory{
+						{Category: models.CategoryFood, Actual: 700, RunRate: 1050, Projected: 1750},
+					},
+				},
+			},
+			wantWarnings: []string{
+				"По прогнозу баланс станет отрицательным примерно 13.03.2024",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecast := buildForecast(&tt.input)
+
+			if forecast.RunRateDays != tt.wantRunRateDays {
+				t.Errorf("глубина истории %d дней, ожидалось %d", forecast.RunRateDays, tt.wantRunRateDays)
+			}
+			if forecast.DailyExpenseRate != tt.wantExpenseRate || forecast.DailyIncomeRate != tt.wantIncomeRate {
+				t.Errorf("в день траты %.2f и накопления %.2f, ожидалось %.2f и %.2f",
+					forecast.DailyExpenseRate, forecast.DailyIncomeRate, tt.wantExpenseRate, tt.wantIncomeRate)
+			}
+			if len(forecast.Days) != tt.wantDays {
+				t.Errorf("прогноз на %d дней, ожидалось %d", len(forecast.Days), tt.wantDays)
+			}
+			if forecast.ClosingBalance != tt.wantClosing {
+				t.Errorf("итоговый баланс %.2f, ожидалось %.2f", forecast.ClosingBalance, tt.wantClosing)
+			}
+
+			if len(forecast.Months) != len(tt.wantMonths) {
+				t.Fatalf("получено %d месяцев, ожидалось %d", len(forecast.Months), len(tt.wantMonths))
+			}
+			for i, month := range forecast.Months {
+				if !reflect.DeepEqual(month, tt.wantMonths[i]) {
+					t.Errorf("месяц %d:\nполучено %+v\nожидалось %+v", i, month, tt.wantMonths[i])
+				}
+			}
+			if !reflect.DeepEqual(forecast.Warnings, tt.wantWarnings) {
+				t.Errorf("предупреждения %q, ожидались %q", forecast.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// GoalServiceImpl представляет реализацию сервиса целей накоплений
+type GoalServiceImpl struct {
+	goalRepo repositories.GoalRepository
+	userRepo repositories.UserRepository
+}
+
+// NewGoalService создает новый экземпляр сервиса целей накоплений
+func NewGoalService(goalRepo repositories.GoalRepository, userRepo repositories.UserRepository) GoalService {
+	return &GoalServiceImpl{
+		goalRepo: goalRepo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateGoal создает новую цель накоплений
+func (s *GoalServiceImpl) CreateGoal(ctx context.Context, userID int64, request *models.CreateGoalRequest) (*models.Goal, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	goal := &models.Goal{
+		UserID:       userID,
+		Name:         request.Name,
+		TargetAmount: request.TargetAmount,
+		Deadline:     request.Deadline,
+		Account:      request.Account,
+		Description:  request.Description,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	id, err := s.goalRepo.Create(ctx, goal)
+	if err != nil {
+		return nil, errors.New("ошибка при создании цели")
+	}
+
+	goal.ID = id
+	applyGoalProgress(goal, time.Now())
+	return goal, nil
+}
+
+// GetGoal получает цель накоплений вместе со взносами
+func (s *GoalServiceImpl) GetGoal(ctx context.Context, id int64, userID int64) (*models.Goal, error) {
+	goal, err := s.getUserGoal(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := s.goalRepo.GetContributions(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении взносов")
+	}
+	goal.Contributions = contributions
+
+	return goal, nil
+}
+
+// GetUserGoals получает все цели накоплений пользователя с рассчитанным прогрессом
+func (s *GoalServiceImpl) GetUserGoals(ctx context.Context, userID int64) ([]models.Goal, error) {
+	goals, err := s.goalRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении целей")
+	}
+
+	now := time.Now()
+	for i := range goals {
+		applyGoalProgress(&goals[i], now)
+	}
+
+	return goals, nil
+}
+
+// UpdateGoal обновляет цель накоплений
+func (s *GoalServiceImpl) UpdateGoal(ctx context.Context, id int64, userID int64, request *models.UpdateGoalRequest) (*models.Goal, error) {
+	goal, err := s.getUserGoal(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Обновляем поля, если они указаны в запросе
+	if request.Name != nil {
+		goal.Name = *request.Name
+	}
+	if request.TargetAmount != nil {
+		goal.TargetAmount = *request.TargetAmount
+	}
+	if request.Deadline != nil {
+		goal.Deadline = request.Deadline
+	}
+	if request.Account != nil {
+		goal.Account = *request.Account
+	}
+	if request.Description != nil {
+		goal.Description = *request.Description
+	}
+
+	if err := s.goalRepo.Update(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	goal.UpdatedAt = time.Now()
+	applyGoalProgress(goal, time.Now())
+	return goal, nil
+}
+
+// DeleteGoal удаляет цель накоплений
+func (s *GoalServiceImpl) DeleteGoal(ctx context.Context, id int64, userID int64) error {
+	return s.goalRepo.Delete(ctx, id, userID)
+}
+
+// AddContribution добавляет взнос в цель и возвращает цель с обновленным прогрессом
+func (s *GoalServiceImpl) AddContribution(ctx context.Context, goalID int64, userID int64, request *models.CreateGoalContributionRequest) (*models.Goal, error) {
+	goal, err := s.getUserGoal(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Изъятие не может превышать накопленную сумму
+	if goal.CurrentAmount+request.Amount < 0 {
+		return nil, errors.New("сумма изъятия превышает накопленную сумму")
+	}
+
+	// Если дата не указана, используем текущую
+	date := request.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	contribution := &models.GoalContribution{
+		GoalID:      goalID,
+		Amount:      request.Amount,
+		Date:        date,
+		Description: request.Description,
+	}
+	if _, err := s.goalRepo.AddContribution(ctx, contribution); err != nil {
+		return nil, errors.New("ошибка при добавлении взноса")
+	}
+
+	return s.GetGoal(ctx, goalID, userID)
+}
+
+// DeleteContribution удаляет взнос из цели
+func (s *GoalServiceImpl) DeleteContribution(ctx context.Context, goalID int64, contributionID int64, userID int64) error {
+	return s.goalRepo.DeleteContribution(ctx, contributionID, goalID, userID)
+}
+
+// getUserGoal получает цель с проверкой принадлежности пользователю и рассчитывает прогресс
+func (s *GoalServiceImpl) getUserGoal(ctx context.Context, id int64, userID int64) (*models.Goal, error) {
+	goal, err := s.goalRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.UserID != userID {
+		return nil, errors.New("цель не принадлежит пользователю")
+	}
+
+	applyGoalProgress(goal, time.Now())
+	return goal, nil
+}
+
+// applyGoalProgress рассчитывает прогресс цели и ежемесячный взнос, необходимый для достижения цели к сроку.
+// Количество оставшихся взносов считается по календарным месяцам, включая текущий.
+func applyGoalProgress(goal *models.Goal, now time.Time) {
+	goal.Progress = calculatePercentage(goal.CurrentAmount, goal.TargetAmount)
+	goal.RemainingAmount = math.Max(goal.TargetAmount-goal.CurrentAmount, 0)
+	goal.Completed = goal.RemainingAmount == 0
+	goal.MonthsLeft = 0
+	goal.RequiredMonthly = 0
+	goal.Overdue = false
+
+	if goal.Deadline == nil || goal.Completed {
+		return
+	}
+
+	deadline := *goal.Deadline
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, deadline.Location())
+	if deadline.Before(today) {
+		// Срок прошел: оставшуюся сумму нужно внести сразу
+		goal.Overdue = true
+		goal.RequiredMonthly = goal.RemainingAmount
+		return
+	}
+
+	months := (deadline.Year()-now.Year())*12 + int(deadline.Month()-now.Month())
+	if deadline.Day() >= now.Day() {
+		months++
+	}
+	if months < 1 {
+		months = 1
+	}
+
+	goal.MonthsLeft = months
+	goal.RequiredMonthly = math.Ceil(goal.RemainingAmount/float64(months)*100) / 100
+}
+
+// summarizeGoals формирует сводку по целям накоплений для панели мониторинга
+func summarizeGoals(goals []models.Goal) *models.GoalsSummary {
+	summary := &models.GoalsSummary{Goals: goals}
+	if summary.Goals == nil {
+		summary.Goals = []models.Goal{}
+	}
+
+	for _, goal := range goals {
+		summary.TotalTarget += goal.TargetAmount
+		summary.TotalSaved += goal.CurrentAmount
+		summary.RequiredMonthly += goal.RequiredMonthly
+		if goal.Completed {
+			summary.Completed++
+		} else {
+			summary.Active++
+		}
+	}
+	summary.Progress = calculatePercentage(summary.TotalSaved, summary.TotalTarget)
+
+	return summary
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestApplyGoalProgress(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	deadline := func(month time.Month, day int) *time.Time {
+		date := time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+
+	tests := []struct {
+		name           string
+		target         float64
+		current        float64
+		deadline       *time.Time
+		wantProgress   float64
+		wantRemaining  float64
+		wantMonthsLeft int
+		wantMonthly    float64
+		wantCompleted  bool
+		wantOverdue    bool
+	}{
+		{
+			name:          "без срока",
+			target:        100000,
+			current:       25000,
+			wantProgress:  25,
+			wantRemaining: 75000,
+		},
+		{
+			name:          "цель достигнута со сроком",
+			target:        100000,
+			current:       120000,
+			deadline:      deadline(time.June, 15),
+			wantProgress:  120,
+			wantCompleted: true,
+		},
+		{
+			name:           "срок в тот же день месяца включает его",
+			target:         100000,
+			current:        25000,
+			deadline:       deadline(time.June, 15),
+			wantProgress:   25,
+			wantRemaining:  75000,
+			wantMonthsLeft: 4,
+			wantMonthly:    18750,
+		},
+		{
+			name:           "срок раньше дня месяца",
+			target:         100000,
+			current:        25000,
+			deadline:       deadline(time.June, 10),
+			wantProgress:   25,
+			wantRemaining:  75000,
+			wantMonthsLeft: 3,
+			wantMonthly:    25000,
+		},
+		{
+			name:           "срок сегодня",
+			target:         100000,
+			current:        25000,
+			deadline:       deadline(time.March, 15),
+			wantProgress:   25,
+			wantRemaining:  75000,
+			wantMonthsLeft: 1,
+			wantMonthly:    75000,
+		},
+		{
+			name:          "срок прошел",
+			target:        100000,
+			current:       25000,
+			deadline:      deadline(time.March, 14),
+			wantProgress:  25,
+			wantRemaining: 75000,
+			wantMonthly:   75000,
+			wantOverdue:   true,
+		},
+		{
+			name:           "взнос округляется вверх до копейки",
+			target:         100,
+			deadline:       deadline(time.June, 1),
+			wantRemaining:  100,
+			wantMonthsLeft: 3,
+			wantMonthly:    33.34,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := &models.Goal{TargetAmount: tt.target, CurrentAmount: tt.current, Deadline: tt.deadline}
+			applyGoalProgress(goal, now)
+
+			if goal.Progress != tt.wantProgress {
+				t.Errorf("прогресс %.2f, ожидалось %.2f", goal.Progress, tt.wantProgress)
+			}
+			if goal.RemainingAmount != tt.wantRemaining {
+				t.Errorf("осталось накопить %.2f, ожидалось %.2f", goal.RemainingAmount, tt.wantRemaining)
+			}
+			if goal.MonthsLeft != tt.wantMonthsLeft {
+				t.Errorf("осталось месяцев %d, ожидалось %d", goal.MonthsLeft, tt.wantMonthsLeft)
+			}
+			if goal.RequiredMonthly != tt.wantMonthly {
+				t.Errorf("ежемесячный взнос %.2f, ожидалось %.2f", goal.RequiredMonthly, tt.wantMonthly)
+			}
+			if goal.Completed != tt.wantCompleted {
+				t.Errorf("признак достижения %v, ожидалось %v", goal.Completed, tt.wantCompleted)
+			}
+			if goal.Overdue != tt.wantOverdue {
+				t.Errorf("признак просрочки %v, ожидалось %v", goal.Overdue, tt.wantOverdue)
+			}
+		})
+	}
+}
@@ -0,0 +1,534 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+// HouseholdServiceImpl представляет реализацию сервиса домохозяйств
+type HouseholdServiceImpl struct {
+	householdRepo repositories.HouseholdRepository
+	userRepo      repositories.UserRepository
+	telegramRepo  repositories.TelegramUserRepository
+	notifier      NotificationService
+}
+
+// NewHouseholdService создает новый экземпляр сервиса домохозяйств
+func NewHouseholdService(
+	householdRepo repositories.HouseholdRepository,
+	userRepo repositories.UserRepository,
+	telegramRepo repositories.TelegramUserRepository,
+	notifier NotificationService,
+) HouseholdService {
+	return &HouseholdServiceImpl{
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+		telegramRepo:  telegramRepo,
+		notifier:      notifier,
+	}
+}
+
+// CreateHousehold создает домохозяйство, создатель становится его владельцем
+func (s *HouseholdServiceImpl) CreateHousehold(ctx context.Context, userID int64, request *models.CreateHouseholdRequest) (*models.Household, error) {
+	household := &models.Household{
+		Name:      request.Name,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	id, err := s.householdRepo.Create(ctx, household)
+	if err != nil {
+		return nil, errors.New("ошибка при создании домохозяйства")
+	}
+
+	household.ID = id
+	household.Role = models.HouseholdRoleOwner
+	return household, nil
+}
+
+// GetUserHouseholds получает домохозяйства, в которых состоит пользователь
+func (s *HouseholdServiceImpl) GetUserHouseholds(ctx context.Context, userID int64) ([]models.Household, error) {
+	households, err := s.householdRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении домохозяйств")
+	}
+	if households == nil {
+		households = []models.Household{}
+	}
+
+	return households, nil
+}
+
+// GetHousehold получает домохозяйство вместе с участниками
+func (s *HouseholdServiceImpl) GetHousehold(ctx context.Context, id int64, userID int64) (*models.Household, error) {
+	household, _, err := s.getMemberHousehold(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	household.Members, err = s.householdRepo.GetMembers(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении участников")
+	}
+
+	return household, nil
+}
+
+// UpdateHousehold переименовывает домохозяйство
+func (s *HouseholdServiceImpl) UpdateHousehold(ctx context.Context, id int64, userID int64, request *models.UpdateHouseholdRequest) (*models.Household, error) {
+	household, err := s.requireRole(ctx, id, userID, models.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	household.Name = request.Name
+	if err := s.householdRepo.Update(ctx, household); err != nil {
+		return nil, err
+	}
+
+	household.UpdatedAt = time.Now()
+	return household, nil
+}
+
+// DeleteHousehold удаляет домохозяйство
+func (s *HouseholdServiceImpl) DeleteHousehold(ctx context.Context, id int64, userID int64) error {
+	if _, err := s.requireRole(ctx, id, userID, models.HouseholdRoleOwner); err != nil {
+		return err
+	}
+
+	return s.householdRepo.Delete(ctx, id)
+}
+
+// InviteMember приглашает пользователя по email или имени пользователя в Telegram.
+// Если у приглашенного связан Telegram, ему отправляется уведомление
+func (s *HouseholdServiceImpl) InviteMember(ctx context.Context, id int64, userID int64, request *models.CreateHouseholdInvitationRequest) (*models.HouseholdInvitation, error) {
+	household, err := s.requireRole(ctx, id, userID, models.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Приглашаемый указывается ровно одним способом
+	email := strings.TrimSpace(request.Email)
+	telegramUsername := strings.TrimPrefix(strings.TrimSpace(request.TelegramUsername), "@")
+	if (email == "") == (telegramUsername == "") {
+		return nil, errors.New("укажите email или имя пользователя в Telegram")
+	}
+
+	// Находим приглашаемого пользователя
+	var inviteeID int64
+	if email != "" {
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, errors.New("пользователь с таким email не найден")
+		}
+		inviteeID = user.ID
+	} else {
+		telegramUser, err := s.telegramRepo.GetByUsername(ctx, telegramUsername)
+		if err != nil {
+			return nil, errors.New("пользователь с таким именем в Telegram не найден")
+		}
+		inviteeID = telegramUser.UserID
+	}
+
+	if _, err := s.householdRepo.GetMember(ctx, id, inviteeID); err == nil {
+		return nil, errors.New("пользователь уже состоит в домохозяйстве")
+	}
+
+	pending, err := s.householdRepo.HasPendingInvitation(ctx, id, inviteeID)
+	if err != nil {
+		return nil, errors.New("ошибка при проверке приглашений")
+	}
+	if pending {
+		return nil, errors.New("пользователь уже приглашен")
+	}
+
+	invitation := &models.HouseholdInvitation{
+		HouseholdID:      id,
+		HouseholdName:    household.Name,
+		InvitedBy:        userID,
+		InviteeID:        inviteeID,
+		Email:            email,
+		TelegramUsername: telegramUsername,
+		Role:             request.Role,
+		Status:           models.InvitationPending,
+		CreatedAt:        time.Now(),
+	}
+
+	invitation.ID, err = s.householdRepo.CreateInvitation(ctx, invitation)
+	if err != nil {
+		return nil, errors.New("ошибка при создании приглашения")
+	}
+
+	if s.notifier.Enabled() {
+		text := fmt.Sprintf("Вас пригласили в домохозяйство «%s». Откройте приложение, чтобы принять приглашение.", household.Name)
+		if err := s.notifier.NotifyUser(ctx, inviteeID, text); err != nil {
+			fmt.Printf("Не удалось отправить приглашение пользователю ID=%d: %v\n", inviteeID, err)
+		}
+	}
+
+	return invitation, nil
+}
+
+// GetHouseholdInvitations получает приглашения домохозяйства
+func (s *HouseholdServiceImpl) GetHouseholdInvitations(ctx context.Context, id int64, userID int64) ([]models.HouseholdInvitation, error) {
+	if _, err := s.requireRole(ctx, id, userID, models.HouseholdRoleOwner); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.householdRepo.GetInvitationsByHousehold(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении приглашений")
+	}
+	if invitations == nil {
+		invitations = []models.HouseholdInvitation{}
+	}
+
+	return invitations, nil
+}
+
+// RevokeInvitation отзывает ожидающее приглашение
+func (s *HouseholdServiceImpl) RevokeInvitation(ctx context.Context, id int64, invitationID int64, userID int64) error {
+	if _, err := s.requireRole(ctx, id, userID, models.HouseholdRoleOwner); err != nil {
+		return err
+	}
+
+	invitation, err := s.householdRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.HouseholdID != id {
+		return errors.New("приглашение не найдено")
+	}
+
+	return s.householdRepo.RespondToInvitation(ctx, invitation, models.InvitationRevoked)
+}
+
+// GetMyInvitations получает ожидающие ответа приглашения пользователя
+func (s *HouseholdServiceImpl) GetMyInvitations(ctx context.Context, userID int64) ([]models.HouseholdInvitation, error) {
+	invitations, err := s.householdRepo.GetPendingInvitationsForUser(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении приглашений")
+	}
+	if invitations == nil {
+		invitations = []models.HouseholdInvitation{}
+	}
+
+	return invitations, nil
+}
+
+// RespondToInvitation принимает или отклоняет приглашение. При принятии возвращает домохозяйство
+func (s *HouseholdServiceImpl) RespondToInvitation(ctx context.Context, invitationID int64, userID int64, accept bool) (*models.Household, error) {
+	invitation, err := s.householdRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.InviteeID != userID {
+		return nil, errors.New("приглашение не найдено")
+	}
+
+	status := models.InvitationDeclined
+	if accept {
+		status = models.InvitationAccepted
+	}
+
+	if err := s.householdRepo.RespondToInvitation(ctx, invitation, status); err != nil {
+		return nil, err
+	}
+
+	if !accept {
+		return nil, nil
+	}
+
+	return s.GetHousehold(ctx, invitation.HouseholdID, userID)
+}
+
+// UpdateMemberRole изменяет роль участника. В домохозяйстве всегда остается хотя бы один владелец
+func (s *HouseholdServiceImpl) UpdateMemberRole(ctx context.Context, id int64, memberID int64, userID int64, role models.HouseholdRole) error {
+	if _, err := s.requireRole(ctx, id, userID, models.HouseholdRoleOwner); err != nil {
+		return err
+	}
+
+	member, err := s.householdRepo.GetMember(ctx, id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == models.HouseholdRoleOwner && role != models.HouseholdRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, id, memberID); err != nil {
+			return err
+		}
+	}
+
+	return s.householdRepo.UpdateMemberRole(ctx, id, memberID, role)
+}
+
+// RemoveMember исключает участника. Владелец может исключить любого, остальные — только выйти сами
+func (s *HouseholdServiceImpl) RemoveMember(ctx context.Context, id int64, memberID int64, userID int64) error {
+	_, role, err := s.getMemberHousehold(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID && role != models.HouseholdRoleOwner {
+		return errors.New("недостаточно прав")
+	}
+
+	member, err := s.householdRepo.GetMember(ctx, id, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == models.HouseholdRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, id, memberID); err != nil {
+			return err
+		}
+	}
+
+	return s.householdRepo.RemoveMember(ctx, id, memberID)
+}
+
+// GetHouseholdExpenses получает общие траты домохозяйства
+func (s *HouseholdServiceImpl) GetHouseholdExpenses(ctx context.Context, id int64, userID int64, limit, offset int) ([]models.Expense, error) {
+	if _, _, err := s.getMemberHousehold(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.householdRepo.GetExpenses(ctx, id, limit, offset)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+	if expenses == nil {
+		expenses = []models.Expense{}
+	}
+
+	return expenses, nil
+}
+
+// GetHouseholdIncomes получает общие накопления домохозяйства
+func (s *HouseholdServiceImpl) GetHouseholdIncomes(ctx context.Context, id int64, userID int64, limit, offset int) ([]models.Income, error) {
+	if _, _, err := s.getMemberHousehold(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	incomes, err := s.householdRepo.GetIncomes(ctx, id, limit, offset)
+	if err != nil {
+		return nil, errors.New("ошибка при получении накоплений")
+	}
+	if incomes == nil {
+		incomes = []models.Income{}
+	}
+
+	return incomes, nil
+}
+
+// GetBudgets получает бюджеты домохозяйства с тратами за текущий месяц
+func (s *HouseholdServiceImpl) GetBudgets(ctx context.Context, id int64, userID int64) ([]models.HouseholdBudget, error) {
+	if _, _, err := s.getMemberHousehold(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	// Текущий месяц определяется в часовом поясе запрашивающего участника
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	monthStart, monthEnd := utils.CurrentMonthPeriod(time.Now().In(user.Location()))
+
+	spent, err := s.householdRepo.GetCategorySummary(ctx, id, monthStart, monthEnd)
+	if err != nil {
+		return nil, errors.New("ошибка при получении сводки трат по категориям")
+	}
+
+	return s.buildBudgets(ctx, id, spent)
+}
+
+// SetBudget устанавливает бюджет домохозяйства по категории
+func (s *HouseholdServiceImpl) SetBudget(ctx context.Context, id int64, userID int64, request *models.SetHouseholdBudgetRequest) error {
+	if _, err := s.requireRole(ctx, id, userID, models.HouseholdRoleEditor); err != nil {
+		return err
+	}
+
+	if !isKnownCategory(request.Category) {
+		return errors.New("неизвестная категория трат")
+	}
+
+	if err := s.householdRepo.SetBudget(ctx, id, request.Category, request.Amount); err != nil {
+		return errors.New("ошибка при сохранении бюджета")
+	}
+
+	return nil
+}
+
+// GetDashboard формирует сводку домохозяйства за месяц: итоги, разбивку по категориям,
+// источникам и участникам, состояние бюджетов и последние общие записи
+func (s *HouseholdServiceImpl) GetDashboard(ctx context.Context, id int64, userID int64, year int, month int) (*models.HouseholdDashboard, error) {
+	household, err := s.GetHousehold(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Границы месяца определяются в часовом поясе запрашивающего участника
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	monthStart, monthEnd := utils.MonthPeriod(year, time.Month(month), user.Location())
+
+	expensesByCategory, err := s.householdRepo.GetCategorySummary(ctx, id, monthStart, monthEnd)
+	if err != nil {
+		return nil, errors.New("ошибка при получении сводки трат по категориям")
+	}
+
+	incomesBySource, err := s.householdRepo.GetSourceSummary(ctx, id, monthStart, monthEnd)
+	if err != nil {
+		return nil, errors.New("ошибка при получении сводки накоплений по источникам")
+	}
+
+	members, err := s.householdRepo.GetMemberTotals(ctx, id, monthStart, monthEnd)
+	if err != nil {
+		return nil, errors.New("ошибка при получении вклада участников")
+	}
+
+	budgets, err := s.buildBudgets(ctx, id, expensesByCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	recentExpenses, err := s.householdRepo.GetExpenses(ctx, id, 5, 0)
+	if err != nil {
+		return nil, errors.New("ошибка при получении последних трат")
+	}
+
+	recentIncomes, err := s.householdRepo.GetIncomes(ctx, id, 5, 0)
+	if err != nil {
+		return nil, errors.New("ошибка при получении последних накоплений")
+	}
+
+	dashboard := &models.HouseholdDashboard{
+		Household:          household,
+		Year:               year,
+		Month:              month,
+		ExpensesByCategory: expensesByCategory,
+		IncomesBySource:    incomesBySource,
+		Members:            members,
+		Budgets:            budgets,
+		RecentExpenses:     recentExpenses,
+		RecentIncomes:      recentIncomes,
+	}
+	for _, amount := range expensesByCategory {
+		dashboard.Expenses += amount
+	}
+	for _, amount := range incomesBySource {
+		dashboard.Incomes += amount
+	}
+	dashboard.Balance = dashboard.Incomes - dashboard.Expenses
+
+	return dashboard, nil
+}
+
+// buildBudgets сопоставляет бюджеты домохозяйства с тратами по категориям
+func (s *HouseholdServiceImpl) buildBudgets(ctx context.Context, id int64, spent map[string]float64) ([]models.HouseholdBudget, error) {
+	amounts, err := s.householdRepo.GetBudgets(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении бюджетов")
+	}
+
+	budgets := []models.HouseholdBudget{}
+	for _, category := range models.ExpenseCategories {
+		amount, ok := amounts[category]
+		if !ok {
+			continue
+		}
+		budgets = append(budgets, models.HouseholdBudget{
+			Category: category,
+			Title:    models.ExpenseCategoryTitles[category],
+			Amount:   amount,
+			Spent:    spent[string(category)],
+			Percent:  calculatePercentage(spent[string(category)], amount),
+		})
+	}
+
+	return budgets, nil
+}
+
+// getMemberHousehold получает домохозяйство и роль пользователя в нем
+func (s *HouseholdServiceImpl) getMemberHousehold(ctx context.Context, id int64, userID int64) (*models.Household, models.HouseholdRole, error) {
+	household, err := s.householdRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	member, err := s.householdRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		return nil, "", errors.New("домохозяйство не найдено")
+	}
+
+	household.Role = member.Role
+	return household, member.Role, nil
+}
+
+// requireRole получает домохозяйство, проверяя, что роль пользователя не ниже требуемой
+func (s *HouseholdServiceImpl) requireRole(ctx context.Context, id int64, userID int64, required models.HouseholdRole) (*models.Household, error) {
+	household, role, err := s.getMemberHousehold(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if householdRoleRank(role) < householdRoleRank(required) {
+		return nil, errors.New("недостаточно прав")
+	}
+
+	return household, nil
+}
+
+// ensureAnotherOwner проверяет, что кроме указанного участника в домохозяйстве есть другой владелец
+func (s *HouseholdServiceImpl) ensureAnotherOwner(ctx context.Context, id int64, memberID int64) error {
+	members, err := s.householdRepo.GetMembers(ctx, id)
+	if err != nil {
+		return errors.New("ошибка при получении участников")
+	}
+
+	for _, member := range members {
+		if member.UserID != memberID && member.Role == models.HouseholdRoleOwner {
+			return nil
+		}
+	}
+
+	return errors.New("в домохозяйстве должен остаться хотя бы один владелец")
+}
+
+// householdRoleRank возвращает уровень прав роли: чем больше, тем больше прав
+func householdRoleRank(role models.HouseholdRole) int {
+	switch role {
+	case models.HouseholdRoleOwner:
+		return 3
+	case models.HouseholdRoleEditor:
+		return 2
+	case models.HouseholdRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// checkHouseholdWriteAccess проверяет, что пользователь может добавлять общие записи в домохозяйство
+func checkHouseholdWriteAccess(ctx context.Context, householdRepo repositories.HouseholdRepository, householdID int64, userID int64) error {
+	member, err := householdRepo.GetMember(ctx, householdID, userID)
+	if err != nil {
+		return errors.New("домохозяйство не найдено")
+	}
+
+	if householdRoleRank(member.Role) < householdRoleRank(models.HouseholdRoleEditor) {
+		return errors.New("недостаточно прав для добавления записей в домохозяйство")
+	}
+
+	return nil
+}
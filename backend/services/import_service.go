@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cz.Finance/backend/importers"
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// ImportServiceImpl представляет реализацию сервиса импорта банковских выписок
+type ImportServiceImpl struct {
+	importRepo repositories.ImportRepository
+	userRepo   repositories.UserRepository
+	ruleRepo   repositories.RuleRepository
+	payeeRepo  repositories.PayeeRepository
+}
+
+// NewImportService создает новый экземпляр сервиса импорта
+func NewImportService(importRepo repositories.ImportRepository, userRepo repositories.UserRepository, ruleRepo repositories.RuleRepository, payeeRepo repositories.PayeeRepository) ImportService {
+	return &ImportServiceImpl{
+		importRepo: importRepo,
+		userRepo:   userRepo,
+		ruleRepo:   ruleRepo,
+		payeeRepo:  payeeRepo,
+	}
+}
+
+// PreviewImport разбирает выписку и сохраняет ее в виде предварительного просмотра
+func (s *ImportServiceImpl) PreviewImport(ctx context.Context, userID int64, format models.ImportFormat, fileName string, file io.Reader) (*models.ImportPreview, error) {
+	// Проверяем существование пользователя
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Разбираем выписку
+	statement, err := importers.Parse(format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	// Загружаем правила категоризации для трат из выписки
+	rules, err := loadRuleSet(ctx, s.ruleRepo, userID, user.Location())
+	if err != nil {
+		return nil, errors.New("ошибка при получении правил категоризации")
+	}
+
+	// Загружаем получателей для связывания с ними трат из выписки
+	payees, err := loadPayeeSet(ctx, s.payeeRepo, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении получателей")
+	}
+
+	// Преобразуем операции выписки в строки импорта
+	rows := make([]models.ImportRow, 0, len(statement.Transactions))
+	externalIDs := make([]string, 0, len(statement.Transactions))
+	seen := make(map[string]bool)
+	for _, transaction := range statement.Transactions {
+		if transaction.Amount == 0 {
+			continue
+		}
+
+		row := buildImportRow(format, statement.Account, transaction)
+		if row.Kind == models.ImportRowExpense {
+			applyImportRules(rules, statement.Account, &row)
+			applyImportPayee(payees, &row)
+		}
+
+		// Повторяющиеся идентификаторы внутри одного файла импортируем один раз
+		if seen[row.ExternalID] {
+			row.Duplicate = true
+		}
+		seen[row.ExternalID] = true
+
+		rows = append(rows, row)
+		externalIDs = append(externalIDs, row.ExternalID)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("в выписке нет операций с ненулевой суммой")
+	}
+
+	// Отмечаем операции, которые уже были импортированы ранее
+	existing, err := s.importRepo.GetExistingExternalIDs(ctx, userID, externalIDs)
+	if err != nil {
+		return nil, errors.New("ошибка при проверке ранее импортированных операций")
+	}
+
+	duplicates := 0
+	for i := range rows {
+		if existing[rows[i].ExternalID] {
+			rows[i].Duplicate = true
+		}
+		if rows[i].Duplicate {
+			duplicates++
+		}
+	}
+
+	imp := &models.Import{
+		UserID:        userID,
+		Format:        format,
+		FileName:      fileName,
+		Account:       statement.Account,
+		Status:        models.ImportStatusPreview,
+		TotalRows:     len(rows),
+		DuplicateRows: duplicates,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	// Сохраняем импорт
+	id, err := s.importRepo.Create(ctx, imp, rows)
+	if err != nil {
+		return nil, errors.New("ошибка при сохранении импорта")
+	}
+
+	return s.GetImport(ctx, id, userID)
+}
+
+// GetImport получает импорт вместе со строками
+func (s *ImportServiceImpl) GetImport(ctx context.Context, id int64, userID int64) (*models.ImportPreview, error) {
+	imp, err := s.importRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем, что импорт принадлежит пользователю
+	if imp.UserID != userID {
+		return nil, errors.New("у вас нет прав на просмотр этого импорта")
+	}
+
+	rows, err := s.importRepo.GetRows(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении строк импорта")
+	}
+
+	return &models.ImportPreview{
+		Import: *imp,
+		Rows:   rows,
+	}, nil
+}
+
+// GetUserImports получает список импортов пользователя
+func (s *ImportServiceImpl) GetUserImports(ctx context.Context, userID int64) ([]models.Import, error) {
+	return s.importRepo.GetByUserID(ctx, userID)
+}
+
+// CommitImport подтверждает импорт и создает траты и накопления
+func (s *ImportServiceImpl) CommitImport(ctx context.Context, id int64, userID int64) (*models.Import, error) {
+	if _, err := s.importRepo.Commit(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	expenseClassifiers.invalidate(userID)
+
+	return s.importRepo.GetByID(ctx, id)
+}
+
+// RollbackImport отменяет импорт и удаляет созданные им записи
+func (s *ImportServiceImpl) RollbackImport(ctx context.Context, id int64, userID int64) (*models.Import, error) {
+	if _, err := s.importRepo.Rollback(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	expenseClassifiers.invalidate(userID)
+
+	return s.importRepo.GetByID(ctx, id)
+}
+
+// buildImportRow преобразует операцию выписки в строку импорта.
+// Списания становятся тратами, зачисления - накоплениями.
+func buildImportRow(format models.ImportFormat, account string, transaction importers.Transaction) models.ImportRow {
+	title := strings.TrimSpace(transaction.Payee)
+	description := strings.TrimSpace(transaction.Memo)
+	if title == "" {
+		title, description = description, ""
+	}
+	if utf8.RuneCountInString(title) < 2 {
+		title = "Импорт"
+	}
+	if utf8.RuneCountInString(title) > 100 {
+		title = string([]rune(title)[:100])
+	}
+
+	row := models.ImportRow{
+		ExternalID:  fmt.Sprintf("%s:%s:%s", format, account, transaction.ExternalID),
+		Title:       title,
+		Date:        transaction.Date,
+		Description: description,
+	}
+
+	if transaction.Amount < 0 {
+		row.Kind = models.ImportRowExpense
+		row.Amount = -transaction.Amount
+		row.Category = models.CategoryOther
+	} else {
+		row.Kind = models.ImportRowIncome
+		row.Amount = transaction.Amount
+		row.Source = models.SourceOther
+	}
+
+	return row
+}
+
+// applyImportRules применяет к строке траты первое подходящее правило категоризации
+func applyImportRules(rules *ruleSet, account string, row *models.ImportRow) {
+	expense := &models.Expense{
+		Title:       row.Title,
+		Amount:      row.Amount,
+		Category:    row.Category,
+		Date:        row.Date,
+		Description: row.Description,
+		Account:     account,
+		Tags:        row.Tags,
+	}
+
+	rule := rules.apply(expense, true)
+	if rule == nil {
+		return
+	}
+
+	row.Title = expense.Title
+	row.Category = expense.Category
+	row.Tags = expense.Tags
+	row.RuleID = &rule.ID
+}
+
+// applyImportPayee связывает строку траты с получателем. Категория получателя заменяет
+// категорию по умолчанию, если ее не назначило правило
+func applyImportPayee(payees payeeSet, row *models.ImportRow) {
+	payee := payees.match(row.Title)
+	if payee == nil {
+		return
+	}
+
+	row.PayeeID = &payee.ID
+	if row.RuleID == nil && payee.DefaultCategory != "" {
+		row.Category = payee.DefaultCategory
+	}
+}
@@ -7,19 +7,22 @@ import (
 
 	"cz.Finance/backend/models"
 	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
 )
 
 // IncomeServiceImpl представляет реализацию сервиса накоплений
 type IncomeServiceImpl struct {
-	incomeRepo repositories.IncomeRepository
-	userRepo   repositories.UserRepository
+	incomeRepo    repositories.IncomeRepository
+	userRepo      repositories.UserRepository
+	householdRepo repositories.HouseholdRepository
 }
 
 // NewIncomeService создает новый экземпляр сервиса накоплений
-func NewIncomeService(incomeRepo repositories.IncomeRepository, userRepo repositories.UserRepository) IncomeService {
+func NewIncomeService(incomeRepo repositories.IncomeRepository, userRepo repositories.UserRepository, householdRepo repositories.HouseholdRepository) IncomeService {
 	return &IncomeServiceImpl{
-		incomeRepo: incomeRepo,
-		userRepo:   userRepo,
+		incomeRepo:    incomeRepo,
+		userRepo:      userRepo,
+		householdRepo: householdRepo,
 	}
 }
 
@@ -31,6 +34,13 @@ func (s *IncomeServiceImpl) CreateIncome(ctx context.Context, userID int64, requ
 		return nil, errors.New("пользователь не найден")
 	}
 
+	// Проверяем права на добавление записей в домохозяйство
+	if request.HouseholdID != nil {
+		if err := checkHouseholdWriteAccess(ctx, s.householdRepo, *request.HouseholdID, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Создаем новое накопление
 	income := &models.Income{
 		UserID:      userID,
@@ -38,6 +48,7 @@ func (s *IncomeServiceImpl) CreateIncome(ctx context.Context, userID int64, requ
 		Source:      request.Source,
 		Date:        request.Date,
 		Description: request.Description,
+		HouseholdID: request.HouseholdID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -91,6 +102,11 @@ func (s *IncomeServiceImpl) GetUserIncomesByPeriod(ctx context.Context, userID i
 	return s.incomeRepo.GetByUserIDAndPeriod(ctx, userID, startDate, endDate)
 }
 
+// StreamUserIncomes передает накопления пользователя, подходящие под фильтр, в функцию fn по одной
+func (s *IncomeServiceImpl) StreamUserIncomes(ctx context.Context, userID int64, filter *models.IncomeFilter, fn func(income *models.Income) error) error {
+	return s.incomeRepo.StreamByFilter(ctx, userID, filter, fn)
+}
+
 // GetIncomeSummary получает сводку по накоплениям пользователя за период
 func (s *IncomeServiceImpl) GetIncomeSummary(ctx context.Context, userID int64, startDate, endDate time.Time) (*models.IncomeSummary, error) {
 	// Получаем информацию о пользователе для получения цели накоплений
@@ -99,6 +115,11 @@ func (s *IncomeServiceImpl) GetIncomeSummary(ctx context.Context, userID int64,
 		return nil, errors.New("пользователь не найден")
 	}
 
+	// Без указанного периода берется текущий месяц в часовом поясе пользователя
+	if startDate.IsZero() || endDate.IsZero() {
+		startDate, endDate = utils.CurrentMonthPeriod(time.Now().In(user.Location()))
+	}
+
 	// Получаем общую сумму накоплений за период
 	totalAmount, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, startDate, endDate)
 	if err != nil {
@@ -163,6 +184,17 @@ func (s *IncomeServiceImpl) UpdateIncome(ctx context.Context, id int64, userID i
 	if request.Description != nil {
 		income.Description = *request.Description
 	}
+	if request.HouseholdID != nil {
+		// Нулевой ID убирает запись из домохозяйства
+		if *request.HouseholdID == 0 {
+			income.HouseholdID = nil
+		} else {
+			if err := checkHouseholdWriteAccess(ctx, s.householdRepo, *request.HouseholdID, userID); err != nil {
+				return nil, err
+			}
+			income.HouseholdID = request.HouseholdID
+		}
+	}
 
 	// Обновляем время изменения
 	income.UpdatedAt = time.Now()
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 	"time"
 
@@ -25,9 +26,11 @@ type ExpenseService interface {
 	GetExpense(ctx context.Context, id int64, userID int64) (*models.Expense, error)
 	GetUserExpenses(ctx context.Context, userID int64, limit, offset int) ([]models.Expense, error)
 	GetUserExpensesByPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) ([]models.Expense, error)
+	StreamUserExpenses(ctx context.Context, userID int64, filter *models.ExpenseFilter, fn func(expense *models.Expense) error) error
 	GetExpenseSummary(ctx context.Context, userID int64, startDate, endDate time.Time) (*models.ExpenseSummary, error)
 	UpdateExpense(ctx context.Context, id int64, userID int64, request *models.UpdateExpenseRequest) (*models.Expense, error)
 	DeleteExpense(ctx context.Context, id int64, userID int64) error
+	SuggestCategory(ctx context.Context, userID int64, request *models.SuggestCategoryRequest) (*models.CategorySuggestions, error)
 }
 
 // IncomeService интерфейс для работы с накоплениями
@@ -36,6 +39,7 @@ type IncomeService interface {
 	GetIncome(ctx context.Context, id int64, userID int64) (*models.Income, error)
 	GetUserIncomes(ctx context.Context, userID int64, limit, offset int) ([]models.Income, error)
 	GetUserIncomesByPeriod(ctx context.Context, userID int64, startDate, endDate time.Time) ([]models.Income, error)
+	StreamUserIncomes(ctx context.Context, userID int64, filter *models.IncomeFilter, fn func(income *models.Income) error) error
 	GetIncomeSummary(ctx context.Context, userID int64, startDate, endDate time.Time) (*models.IncomeSummary, error)
 	UpdateIncome(ctx context.Context, id int64, userID int64, request *models.UpdateIncomeRequest) (*models.Income, error)
 	DeleteIncome(ctx context.Context, id int64, userID int64) error
@@ -60,9 +64,24 @@ type DashboardService interface {
 type WishlistService interface {
 	CreateWishlistItem(ctx context.Context, userID int64, request *models.CreateWishlistItemRequest) (*models.WishlistItem, error)
 	GetWishlistItem(ctx context.Context, id int64, userID int64) (*models.WishlistItem, error)
-	GetUserWishlist(ctx context.Context, userID int64) ([]models.WishlistItem, error)
+	GetUserWishlist(ctx context.Context, userID int64, status models.WishlistStatus) ([]models.WishlistItem, error)
 	UpdateWishlistItem(ctx context.Context, id int64, userID int64, request *models.UpdateWishlistItemRequest) (*models.WishlistItem, error)
 	DeleteWishlistItem(ctx context.Context, id int64, userID int64) error
+	PurchaseWishlistItem(ctx context.Context, id int64, userID int64, request *models.PurchaseWishlistItemRequest) (*models.WishlistPurchase, error)
+	RecordPrice(ctx context.Context, id int64, userID int64, request *models.RecordWishlistPriceRequest) (*models.WishlistPriceResult, error)
+	AddAllocation(ctx context.Context, id int64, userID int64, request *models.CreateWishlistAllocationRequest) (*models.WishlistItem, error)
+	DeleteAllocation(ctx context.Context, id int64, allocationID int64, userID int64) error
+	GetAffordabilityPlan(ctx context.Context, userID int64, months int) (*models.AffordabilityPlan, error)
+}
+
+// WishlistShareService интерфейс для работы с публичными ссылками на список желаний
+type WishlistShareService interface {
+	GetShare(ctx context.Context, userID int64) (*models.WishlistShare, error)
+	UpdateShare(ctx context.Context, userID int64, request *models.UpdateWishlistShareRequest) (*models.WishlistShare, error)
+	RevokeShare(ctx context.Context, userID int64) error
+	GetPublicWishlist(ctx context.Context, token string) (*models.PublicWishlist, error)
+	ReserveItem(ctx context.Context, token string, itemID int64, request *models.ReserveWishlistItemRequest) (*models.WishlistReservation, error)
+	CancelReservation(ctx context.Context, token string, itemID int64, cancelToken string) error
 }
 
 // TelegramService интерфейс для работы с Telegram пользователями
@@ -71,3 +90,178 @@ type TelegramService interface {
 	GetUserByTelegramID(ctx context.Context, telegramID int64) (*models.User, error)
 	UnlinkAccount(ctx context.Context, telegramID int64) error
 }
+
+// ImportService интерфейс для импорта банковских выписок
+type ImportService interface {
+	PreviewImport(ctx context.Context, userID int64, format models.ImportFormat, fileName string, file io.Reader) (*models.ImportPreview, error)
+	GetImport(ctx context.Context, id int64, userID int64) (*models.ImportPreview, error)
+	GetUserImports(ctx context.Context, userID int64) ([]models.Import, error)
+	CommitImport(ctx context.Context, id int64, userID int64) (*models.Import, error)
+	RollbackImport(ctx context.Context, id int64, userID int64) (*models.Import, error)
+}
+
+// ArchiveService интерфейс для выгрузки и восстановления всех данных пользователя
+type ArchiveService interface {
+	ExportArchive(ctx context.Context, userID int64) (*models.Archive, error)
+	ImportArchive(ctx context.Context, userID int64, archive *models.Archive, replace bool) (*models.ArchiveRestoreResult, error)
+}
+
+// LedgerService интерфейс для выгрузки данных в текстовую бухгалтерию (hledger, Beancount)
+type LedgerService interface {
+	GetLedgerAccounts(ctx context.Context, userID int64) (*models.LedgerAccountMap, error)
+	UpdateLedgerAccounts(ctx context.Context, userID int64, request *models.UpdateLedgerAccountsRequest) (*models.LedgerAccountMap, error)
+	ExportLedger(ctx context.Context, userID int64, format models.LedgerFormat, startDate, endDate *time.Time, w io.Writer) error
+}
+
+// ReportService интерфейс для формирования печатных отчетов
+type ReportService interface {
+	WriteMonthlyReport(ctx context.Context, userID int64, year int, month int, w io.Writer) error
+}
+
+// NotificationService интерфейс для отправки уведомлений пользователям
+type NotificationService interface {
+	Enabled() bool
+	NotifyUser(ctx context.Context, userID int64, text string) error
+}
+
+// GoalService интерфейс для работы с целями накоплений
+type GoalService interface {
+	CreateGoal(ctx context.Context, userID int64, request *models.CreateGoalRequest) (*models.Goal, error)
+	GetGoal(ctx context.Context, id int64, userID int64) (*models.Goal, error)
+	GetUserGoals(ctx context.Context, userID int64) ([]models.Goal, error)
+	UpdateGoal(ctx context.Context, id int64, userID int64, request *models.UpdateGoalRequest) (*models.Goal, error)
+	DeleteGoal(ctx context.Context, id int64, userID int64) error
+	AddContribution(ctx context.Context, goalID int64, userID int64, request *models.CreateGoalContributionRequest) (*models.Goal, error)
+	DeleteContribution(ctx context.Context, goalID int64, contributionID int64, userID int64) error
+}
+
+// HouseholdService интерфейс для работы с домохозяйствами и общим бюджетом
+type HouseholdService interface {
+	CreateHousehold(ctx context.Context, userID int64, request *models.CreateHouseholdRequest) (*models.Household, error)
+	GetUserHouseholds(ctx context.Context, userID int64) ([]models.Household, error)
+	GetHousehold(ctx context.Context, id int64, userID int64) (*models.Household, error)
+	UpdateHousehold(ctx context.Context, id int64, userID int64, request *models.UpdateHouseholdRequest) (*models.Household, error)
+	DeleteHousehold(ctx context.Context, id int64, userID int64) error
+	InviteMember(ctx context.Context, id int64, userID int64, request *models.CreateHouseholdInvitationRequest) (*models.HouseholdInvitation, error)
+	GetHouseholdInvitations(ctx context.Context, id int64, userID int64) ([]models.HouseholdInvitation, error)
+	RevokeInvitation(ctx context.Context, id int64, invitationID int64, userID int64) error
+	GetMyInvitations(ctx context.Context, userID int64) ([]models.HouseholdInvitation, error)
+	RespondToInvitation(ctx context.Context, invitationID int64, userID int64, accept bool) (*models.Household, error)
+	UpdateMemberRole(ctx context.Context, id int64, memberID int64, userID int64, role models.HouseholdRole) error
+	RemoveMember(ctx context.Context, id int64, memberID int64, userID int64) error
+	GetHouseholdExpenses(ctx context.Context, id int64, userID int64, limit, offset int) ([]models.Expense, error)
+	GetHouseholdIncomes(ctx context.Context, id int64, userID int64, limit, offset int) ([]models.Income, error)
+	GetBudgets(ctx context.Context, id int64, userID int64) ([]models.HouseholdBudget, error)
+	SetBudget(ctx context.Context, id int64, userID int64, request *models.SetHouseholdBudgetRequest) error
+	GetDashboard(ctx context.Context, id int64, userID int64, year int, month int) (*models.HouseholdDashboard, error)
+}
+
+// SplitService интерфейс для разделения общих трат и расчета долгов между участниками
+type SplitService interface {
+	CreateGroup(ctx context.Context, userID int64, request *models.CreateSplitGroupRequest) (*models.SplitGroup, error)
+	GetUserGroups(ctx context.Context, userID int64) ([]models.SplitGroup, error)
+	GetGroup(ctx context.Context, id int64, userID int64) (*models.SplitGroup, error)
+	DeleteGroup(ctx context.Context, id int64, userID int64) error
+	AddParticipant(ctx context.Context, id int64, userID int64, request *models.AddSplitParticipantRequest) (*models.SplitParticipant, error)
+	RemoveParticipant(ctx context.Context, id int64, participantID int64, userID int64) error
+	CreateExpense(ctx context.Context, id int64, userID int64, request *models.CreateSplitExpenseRequest) (*models.SplitExpense, error)
+	GetExpenses(ctx context.Context, id int64, userID int64) ([]models.SplitExpense, error)
+	DeleteExpense(ctx context.Context, id int64, expenseID int64, userID int64) error
+	CreateSettlement(ctx context.Context, id int64, userID int64, request *models.CreateSplitSettlementRequest) (*models.SplitSettlement, error)
+	GetSettlements(ctx context.Context, id int64, userID int64) ([]models.SplitSettlement, error)
+	DeleteSettlement(ctx context.Context, id int64, settlementID int64, userID int64) error
+	GetBalances(ctx context.Context, id int64, userID int64, simplify bool) (*models.SplitBalances, error)
+}
+
+// LoanService интерфейс для учета кредитов, графиков и платежей по ним
+type LoanService interface {
+	CreateLoan(ctx context.Context, userID int64, request *models.CreateLoanRequest) (*models.Loan, error)
+	GetLoan(ctx context.Context, id int64, userID int64) (*models.Loan, error)
+	GetUserLoans(ctx context.Context, userID int64) ([]models.Loan, error)
+	UpdateLoan(ctx context.Context, id int64, userID int64, request *models.UpdateLoanRequest) (*models.Loan, error)
+	DeleteLoan(ctx context.Context, id int64, userID int64) error
+	AddPayment(ctx context.Context, id int64, userID int64, request *models.CreateLoanPaymentRequest) (*models.Loan, error)
+	DeletePayment(ctx context.Context, id int64, paymentID int64, userID int64) (*models.Loan, error)
+}
+
+// NetWorthService интерфейс для учета активов, обязательств и чистой стоимости капитала
+type NetWorthService interface {
+	CreateAsset(ctx context.Context, userID int64, request *models.CreateAssetRequest) (*models.Asset, error)
+	GetAsset(ctx context.Context, id int64, userID int64) (*models.Asset, error)
+	GetUserAssets(ctx context.Context, userID int64) ([]models.Asset, error)
+	UpdateAsset(ctx context.Context, id int64, userID int64, request *models.UpdateAssetRequest) (*models.Asset, error)
+	DeleteAsset(ctx context.Context, id int64, userID int64) error
+	AddValuation(ctx context.Context, id int64, userID int64, request *models.CreateAssetValuationRequest) (*models.Asset, error)
+	DeleteValuation(ctx context.Context, id int64, valuationID int64, userID int64) error
+	GetNetWorth(ctx context.Context, userID int64, months int) (*models.NetWorth, error)
+	SnapshotUser(ctx context.Context, userID int64) (*models.NetWorthSnapshot, error)
+	SnapshotAll(ctx context.Context) (int, error)
+}
+
+// InvestmentService интерфейс для учета инвестиционного портфеля, цен и прибыли по методу FIFO
+type InvestmentService interface {
+	GetPortfolio(ctx context.Context, userID int64) (*models.Portfolio, error)
+	AddTransaction(ctx context.Context, userID int64, request *models.CreateInvestmentTransactionRequest) (*models.InvestmentTransaction, error)
+	GetTransactions(ctx context.Context, userID int64, ticker string) ([]models.InvestmentTransaction, error)
+	DeleteTransaction(ctx context.Context, id int64, userID int64) error
+	UpdatePrices(ctx context.Context, userID int64, request *models.UpdateSecurityPricesRequest) (int, error)
+	ImportPrices(ctx context.Context, userID int64, file io.Reader) (int, error)
+	GetPrices(ctx context.Context, userID int64, ticker string) ([]models.SecurityPrice, error)
+	DeletePrice(ctx context.Context, userID int64, ticker string, date time.Time) error
+}
+
+// RuleService интерфейс для правил автоматической категоризации трат
+type RuleService interface {
+	CreateRule(ctx context.Context, userID int64, request *models.CategorizationRuleRequest) (*models.CategorizationRule, error)
+	GetRule(ctx context.Context, id int64, userID int64) (*models.CategorizationRule, error)
+	GetUserRules(ctx context.Context, userID int64) ([]models.CategorizationRule, error)
+	UpdateRule(ctx context.Context, id int64, userID int64, request *models.CategorizationRuleRequest) (*models.CategorizationRule, error)
+	DeleteRule(ctx context.Context, id int64, userID int64) error
+	ReorderRules(ctx context.Context, userID int64, request *models.ReorderRulesRequest) ([]models.CategorizationRule, error)
+	TestRule(ctx context.Context, userID int64, request *models.CategorizationRuleRequest) (*models.RuleTestResult, error)
+}
+
+// PayeeService интерфейс для работы с получателями платежей
+type PayeeService interface {
+	CreatePayee(ctx context.Context, userID int64, request *models.PayeeRequest) (*models.Payee, error)
+	GetPayee(ctx context.Context, id int64, userID int64) (*models.Payee, error)
+	GetUserPayees(ctx context.Context, userID int64) ([]models.Payee, error)
+	UpdatePayee(ctx context.Context, id int64, userID int64, request *models.PayeeRequest) (*models.Payee, error)
+	DeletePayee(ctx context.Context, id int64, userID int64) error
+	LinkExpenses(ctx context.Context, userID int64) (*models.PayeeLinkResult, error)
+	GetTopPayees(ctx context.Context, userID int64, startDate, endDate time.Time, limit int) (*models.TopPayees, error)
+}
+
+// SubscriptionService интерфейс для поиска подписок и работы с регулярными списаниями
+type SubscriptionService interface {
+	DetectSubscriptions(ctx context.Context, userID int64) (*models.SubscriptionReport, error)
+	ConfirmSubscription(ctx context.Context, userID int64, request *models.ConfirmSubscriptionRequest) (*models.RecurringRule, error)
+	GetRecurringRule(ctx context.Context, id int64, userID int64) (*models.RecurringRule, error)
+	GetRecurringRules(ctx context.Context, userID int64) ([]models.RecurringRule, error)
+	UpdateRecurringRule(ctx context.Context, id int64, userID int64, request *models.UpdateRecurringRuleRequest) (*models.RecurringRule, error)
+	DeleteRecurringRule(ctx context.Context, id int64, userID int64) error
+}
+
+// AlertService интерфейс для поиска необычных трат и работы с предупреждениями
+type AlertService interface {
+	CheckUser(ctx context.Context, userID int64) ([]models.Alert, error)
+	CheckAll(ctx context.Context) (int, error)
+	GetAlerts(ctx context.Context, userID int64, unreadOnly bool) ([]models.Alert, error)
+	MarkAlertRead(ctx context.Context, id int64, userID int64) error
+	MarkAllAlertsRead(ctx context.Context, userID int64) error
+}
+
+// ForecastService интерфейс для прогноза денежного потока
+type ForecastService interface {
+	GetForecast(ctx context.Context, userID int64, months int) (*models.CashFlowForecast, error)
+}
+
+// ComparisonService интерфейс для сравнения периодов
+type ComparisonService interface {
+	ComparePeriods(ctx context.Context, userID int64, request *models.ComparisonRequest) (*models.PeriodComparison, error)
+}
+
+// AnalyticsService интерфейс для гибких аналитических запросов
+type AnalyticsService interface {
+	Query(ctx context.Context, userID int64, query *models.AnalyticsQuery) (*models.AnalyticsResult, error)
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/importers"
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// quantityEpsilon задает точность сравнения количества бумаг
+const quantityEpsilon = 1e-9
+
+// InvestmentServiceImpl представляет реализацию сервиса инвестиционного портфеля
+type InvestmentServiceImpl struct {
+	investmentRepo repositories.InvestmentRepository
+	userRepo       repositories.UserRepository
+}
+
+// NewInvestmentService создает новый экземпляр сервиса инвестиционного портфеля
+func NewInvestmentService(investmentRepo repositories.InvestmentRepository, userRepo repositories.UserRepository) InvestmentService {
+	return &InvestmentServiceImpl{
+		investmentRepo: investmentRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// GetPortfolio получает позиции пользователя с оценкой по последним известным ценам
+// и прибылью по методу FIFO
+func (s *InvestmentServiceImpl) GetPortfolio(ctx context.Context, userID int64) (*models.Portfolio, error) {
+	transactions, err := s.investmentRepo.GetTransactions(ctx, userID, "")
+	if err != nil {
+		return nil, errors.New("ошибка при получении операций")
+	}
+
+	prices, err := s.investmentRepo.GetPrices(ctx, userID, "")
+	if err != nil {
+		return nil, errors.New("ошибка при получении цен")
+	}
+
+	portfolio, _ := buildPortfolio(transactions, prices, time.Now())
+	return portfolio, nil
+}
+
+// AddTransaction записывает покупку, продажу или дивиденд.
+// Продажа не может превышать количество бумаг, купленных к дате продажи
+func (s *InvestmentServiceImpl) AddTransaction(ctx context.Context, userID int64, request *models.CreateInvestmentTransactionRequest) (*models.InvestmentTransaction, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	transaction := &models.InvestmentTransaction{
+		UserID:    userID,
+		Ticker:    normalizeTicker(request.Ticker),
+		Type:      request.Type,
+		Date:      request.Date,
+		Fee:       roundMoney(request.Fee),
+		Note:      request.Note,
+		CreatedAt: time.Now(),
+	}
+
+	// Если дата не указана, используем текущую
+	if transaction.Date.IsZero() {
+		transaction.Date = time.Now()
+	}
+
+	switch request.Type {
+	case models.InvestmentBuy, models.InvestmentSell:
+		if request.Quantity <= 0 || request.Price <= 0 {
+			return nil, errors.New("для покупки и продажи нужно указать количество и цену")
+		}
+		transaction.Quantity = roundQuantity(request.Quantity)
+		transaction.Price = request.Price
+		if request.Type == models.InvestmentBuy {
+			transaction.Amount = roundMoney(transaction.Quantity*transaction.Price + transaction.Fee)
+		} else {
+			transaction.Amount = roundMoney(transaction.Quantity*transaction.Price - transaction.Fee)
+		}
+	case models.InvestmentDividend:
+		if request.Amount <= 0 {
+			return nil, errors.New("для дивиденда нужно указать сумму")
+		}
+		transaction.Amount = roundMoney(request.Amount - transaction.Fee)
+	}
+
+	// Проверяем, что продажи не превышают позицию с учетом новой операции
+	if transaction.Type == models.InvestmentSell {
+		transactions, err := s.investmentRepo.GetTransactions(ctx, userID, transaction.Ticker)
+		if err != nil {
+			return nil, errors.New("ошибка при получении операций")
+		}
+		if err := validateInvestmentSequence(append(transactions, *transaction)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Для дивиденда при необходимости создаем накопление
+	var income *models.Income
+	if transaction.Type == models.InvestmentDividend && request.CreateIncome {
+		income = &models.Income{
+			UserID:      userID,
+			Amount:      transaction.Amount,
+			Source:      models.SourceInvestment,
+			Date:        transaction.Date,
+			Description: fmt.Sprintf("Дивиденды %s", transaction.Ticker),
+		}
+	}
+
+	transaction.ID, err = s.investmentRepo.AddTransaction(ctx, transaction, income)
+	if err != nil {
+		return nil, errors.New("ошибка при сохранении операции")
+	}
+
+	return transaction, nil
+}
+
+// GetTransactions получает операции пользователя с результатом продаж по FIFO
+func (s *InvestmentServiceImpl) GetTransactions(ctx context.Context, userID int64, ticker string) ([]models.InvestmentTransaction, error) {
+	transactions, err := s.investmentRepo.GetTransactions(ctx, userID, normalizeTicker(ticker))
+	if err != nil {
+		return nil, errors.New("ошибка при получении операций")
+	}
+	if transactions == nil {
+		return []models.InvestmentTransaction{}, nil
+	}
+
+	_, realized := buildPortfolio(transactions, nil, time.Now())
+	for i := range transactions {
+		transactions[i].RealizedPnL = realized[transactions[i].ID]
+	}
+
+	return transactions, nil
+}
+
+// DeleteTransaction удаляет операцию, если без нее последующие продажи остаются корректными
+func (s *InvestmentServiceImpl) DeleteTransaction(ctx context.Context, id int64, userID int64) error {
+	transaction, err := s.investmentRepo.GetTransaction(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if transaction.UserID != userID {
+		return errors.New("операция не принадлежит пользователю")
+	}
+
+	if transaction.Type == models.InvestmentBuy {
+		transactions, err := s.investmentRepo.GetTransactions(ctx, userID, transaction.Ticker)
+		if err != nil {
+			return errors.New("ошибка при получении операций")
+		}
+
+		remaining := make([]models.InvestmentTransaction, 0, len(transactions))
+		for _, t := range transactions {
+			if t.ID != id {
+				remaining = append(remaining, t)
+			}
+		}
+		if err := validateInvestmentSequence(remaining); err != nil {
+			return errors.New("покупку нельзя удалить, пока по ней есть продажи")
+		}
+	}
+
+	return s.investmentRepo.DeleteTransaction(ctx, transaction)
+}
+
+// UpdatePrices сохраняет цены, введенные пользователем
+func (s *InvestmentServiceImpl) UpdatePrices(ctx context.Context, userID int64, request *models.UpdateSecurityPricesRequest) (int, error) {
+	prices := make([]models.SecurityPrice, 0, len(request.Prices))
+	for _, item := range request.Prices {
+		price := models.SecurityPrice{
+			Ticker: normalizeTicker(item.Ticker),
+			Date:   item.Date,
+			Price:  item.Price,
+		}
+		if price.Date.IsZero() {
+			price.Date = time.Now()
+		}
+		prices = append(prices, price)
+	}
+
+	return s.savePrices(ctx, userID, prices)
+}
+
+// ImportPrices загружает цены из CSV-файла
+func (s *InvestmentServiceImpl) ImportPrices(ctx context.Context, userID int64, file io.Reader) (int, error) {
+	prices, err := importers.ParsePrices(file)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.savePrices(ctx, userID, prices)
+}
+
+// GetPrices получает цены пользователя
+func (s *InvestmentServiceImpl) GetPrices(ctx context.Context, userID int64, ticker string) ([]models.SecurityPrice, error) {
+	prices, err := s.investmentRepo.GetPrices(ctx, userID, normalizeTicker(ticker))
+	if err != nil {
+		return nil, errors.New("ошибка при получении цен")
+	}
+	if prices == nil {
+		prices = []models.SecurityPrice{}
+	}
+
+	return prices, nil
+}
+
+// DeletePrice удаляет цену бумаги на дату
+func (s *InvestmentServiceImpl) DeletePrice(ctx context.Context, userID int64, ticker string, date time.Time) error {
+	return s.investmentRepo.DeletePrice(ctx, userID, normalizeTicker(ticker), date)
+}
+
+// savePrices сохраняет цены и возвращает их количество
+func (s *InvestmentServiceImpl) savePrices(ctx context.Context, userID int64, prices []models.SecurityPrice) (int, error) {
+	if err := s.investmentRepo.SavePrices(ctx, userID, prices); err != nil {
+		return 0, errors.New("ошибка при сохранении цен")
+	}
+
+	return len(prices), nil
+}
+
+// normalizeTicker приводит тикер к единому виду
+func normalizeTicker(ticker string) string {
+	return strings.ToUpper(strings.TrimSpace(ticker))
+}
+
+// roundQuantity округляет количество бумаг до шести знаков, как оно хранится в базе
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*1e6) / 1e6
+}
+
+// validateInvestmentSequence проверяет, что ни одна продажа не превышает позицию на свою дату
+func validateInvestmentSequence(transactions []models.InvestmentTransaction) error {
+	sorted := sortInvestmentTransactions(transactions)
+
+	quantities := make(map[string]float64)
+	for _, t := range sorted {
+		switch t.Type {
+		case models.InvestmentBuy:
+			quantities[t.Ticker] += t.Quantity
+		case models.InvestmentSell:
+			if t.Quantity > quantities[t.Ticker]+quantityEpsilon {
+				return fmt.Errorf("недостаточно бумаг %s для продажи на %s: доступно %g",
+					t.Ticker, t.Date.Format("02.01.2006"), roundQuantity(quantities[t.Ticker]))
+			}
+			quantities[t.Ticker] -= t.Quantity
+		}
+	}
+
+	return nil
+}
+
+// sortInvestmentTransactions возвращает копию операций, упорядоченную по дате.
+// Операции без ID (еще не сохраненные) идут после сохраненных с той же датой
+func sortInvestmentTransactions(transactions []models.InvestmentTransaction) []models.InvestmentTransaction {
+	sorted := make([]models.InvestmentTransaction, len(transactions))
+	copy(sorted, transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	return sorted
+}
+
+// buildPortfolio восстанавливает позиции на указанный момент по операциям не позже него.
+// Продажи списывают самые ранние покупки (FIFO), бумаги оцениваются по последней цене
+// не позже момента, а при ее отсутствии — по цене последней сделки. Также возвращает
+// реализованный результат каждой продажи по ее ID
+func buildPortfolio(transactions []models.InvestmentTransaction, prices []models.SecurityPrice, moment time.Time) (*models.Portfolio, map[int64]float64) {
+	holdings := make(map[string]*models.Holding)
+	lastTrade := make(map[string]models.InvestmentTransaction)
+	realized := make(map[int64]float64)
+	var tickers []string
+
+	for _, t := range sortInvestmentTransactions(transactions) {
+		if t.Date.After(moment) {
+			break
+		}
+
+		holding, ok := holdings[t.Ticker]
+		if !ok {
+			holding = &models.Holding{Ticker: t.Ticker, Lots: []models.InvestmentLot{}}
+			holdings[t.Ticker] = holding
+			tickers = append(tickers, t.Ticker)
+		}
+
+		switch t.Type {
+		case models.InvestmentBuy:
+			holding.Lots = append(holding.Lots, models.InvestmentLot{
+				Date:      t.Date,
+				Quantity:  t.Quantity,
+				Price:     t.Price,
+				CostBasis: t.Quantity*t.Price + t.Fee,
+			})
+			lastTrade[t.Ticker] = t
+		case models.InvestmentSell:
+			cost := consumeLots(holding, t.Quantity)
+			pnl := roundMoney(t.Quantity*t.Price - t.Fee - cost)
+			holding.RealizedPnL += pnl
+			realized[t.ID] = pnl
+			lastTrade[t.Ticker] = t
+		case models.InvestmentDividend:
+			holding.Dividends += t.Amount
+		}
+	}
+
+	// Последняя известная цена по каждой бумаге
+	latestPrices := make(map[string]models.SecurityPrice)
+	for _, price := range prices {
+		if price.Date.After(moment) {
+			continue
+		}
+		if latest, ok := latestPrices[price.Ticker]; !ok || !price.Date.Before(latest.Date) {
+			latestPrices[price.Ticker] = price
+		}
+	}
+
+	sort.Strings(tickers)
+	portfolio := &models.Portfolio{Date: moment, Holdings: make([]models.Holding, 0, len(tickers))}
+	for _, ticker := range tickers {
+		holding := holdings[ticker]
+		for i := range holding.Lots {
+			holding.Quantity += holding.Lots[i].Quantity
+			holding.CostBasis += holding.Lots[i].CostBasis
+			holding.Lots[i].CostBasis = roundMoney(holding.Lots[i].CostBasis)
+		}
+		holding.Quantity = roundQuantity(holding.Quantity)
+		holding.CostBasis = roundMoney(holding.CostBasis)
+
+		if price, ok := latestPrices[ticker]; ok {
+			holding.Price = price.Price
+			date := price.Date
+			holding.PriceDate = &date
+		} else if trade, ok := lastTrade[ticker]; ok {
+			holding.Price = trade.Price
+			date := trade.Date
+			holding.PriceDate = &date
+		}
+
+		if holding.Quantity > quantityEpsilon {
+			holding.AveragePrice = holding.CostBasis / holding.Quantity
+			holding.MarketValue = roundMoney(holding.Quantity * holding.Price)
+			holding.UnrealizedPnL = roundMoney(holding.MarketValue - holding.CostBasis)
+			holding.UnrealizedPct = roundMoney(calculatePercentage(holding.UnrealizedPnL, holding.CostBasis))
+		}
+		holding.RealizedPnL = roundMoney(holding.RealizedPnL)
+		holding.Dividends = roundMoney(holding.Dividends)
+
+		portfolio.CostBasis += holding.CostBasis
+		portfolio.MarketValue += holding.MarketValue
+		portfolio.UnrealizedPnL += holding.UnrealizedPnL
+		portfolio.RealizedPnL += holding.RealizedPnL
+		portfolio.Dividends += holding.Dividends
+		portfolio.Holdings = append(portfolio.Holdings, *holding)
+	}
+
+	portfolio.CostBasis = roundMoney(portfolio.CostBasis)
+	portfolio.MarketValue = roundMoney(portfolio.MarketValue)
+	portfolio.UnrealizedPnL = roundMoney(portfolio.UnrealizedPnL)
+	portfolio.UnrealizedPct = roundMoney(calculatePercentage(portfolio.UnrealizedPnL, portfolio.CostBasis))
+	portfolio.RealizedPnL = roundMoney(portfolio.RealizedPnL)
+	portfolio.Dividends = roundMoney(portfolio.Dividends)
+
+	return portfolio, realized
+}
+
+// consumeLots списывает количество с самых ранних лотов и возвращает их списанную стоимость
+func consumeLots(holding *models.Holding, quantity float64) float64 {
+	cost := 0.0
+	for quantity > quantityEpsilon && len(holding.Lots) > 0 {
+		lot := &holding.Lots[0]
+		if lot.Quantity <= quantity+quantityEpsilon {
+			cost += lot.CostBasis
+			quantity -= lot.Quantity
+			holding.Lots = holding.Lots[1:]
+			continue
+		}
+
+		part := lot.CostBasis * quantity / lot.Quantity
+		cost += part
+		lot.CostBasis -= part
+		lot.Quantity -= quantity
+		quantity = 0
+	}
+
+	return cost
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestBuildPortfolioFIFO(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	transactions := []models.InvestmentTransaction{
+		{ID: 3, Ticker: "SBER", Type: models.InvestmentSell, Date: date(time.March, 1), Quantity: 15, Price: 130, Fee: 5},
+		{ID: 1, Ticker: "SBER", Type: models.InvestmentBuy, Date: date(time.January, 1), Quantity: 10, Price: 100, Fee: 10},
+		{ID: 2, Ticker: "SBER", Type: models.InvestmentBuy, Date: date(time.February, 1), Quantity: 10, Price: 120},
+		{ID: 4, Ticker: "SBER", Type: models.InvestmentDividend, Date: date(time.April, 1), Amount: 50},
+	}
+	prices := []models.SecurityPrice{
+		{Ticker: "SBER", Date: date(time.March, 15), Price: 140},
+		{Ticker: "SBER", Date: date(time.March, 5), Price: 135},
+	}
+
+	tests := []struct {
+		name           string
+		moment         time.Time
+		wantQuantity   float64
+		wantCostBasis  float64
+		wantPrice      float64
+		wantMarket     float64
+		wantUnrealized float64
+		wantRealized   float64
+		wantDividends  float64
+		wantLots       int
+	}{
+		{
+			name:           "до продажи по цене последней сделки",
+			moment:         date(time.February, 15),
+			wantQuantity:   20,
+			wantCostBasis:  2210,
+			wantPrice:      120,
+			wantMarket:     2400,
+			wantUnrealized: 190,
+			wantLots:       2,
+		},
+		{
+			name:           "продажа списывает первый лот целиком и половину второго",
+			moment:         date(time.March, 2),
+			wantQuantity:   5,
+			wantCostBasis:  600,
+			wantPrice:      130,
+			wantMarket:     650,
+			wantUnrealized: 50,
+			wantRealized:   335,
+			wantLots:       1,
+		},
+		{
+			name:           "по последней цене не позже момента и с дивидендами",
+			moment:         date(time.April, 30),
+			wantQuantity:   5,
+			wantCostBasis:  600,
+			wantPrice:      140,
+			wantMarket:     700,
+			wantUnrealized: 100,
+			wantRealized:   335,
+			wantDividends:  50,
+			wantLots:       1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			portfolio, realized := buildPortfolio(transactions, prices, tt.moment)
+			if len(portfolio.Holdings) != 1 {
+				t.Fatalf("получено %d позиций, ожидалась одна", len(portfolio.Holdings))
+			}
+
+			holding := portfolio.Holdings[0]
+			if holding.Quantity != tt.wantQuantity {
+				t.Errorf("количество %g, ожидалось %g", holding.Quantity, tt.wantQuantity)
+			}
+			if holding.CostBasis != tt.wantCostBasis {
+				t.Errorf("стоимость покупки %.2f, ожидалось %.2f", holding.CostBasis, tt.wantCostBasis)
+			}
+			if holding.Price != tt.wantPrice {
+				t.Errorf("цена %.2f, ожидалось %.2f", holding.Price, tt.wantPrice)
+			}
+			if holding.MarketValue != tt.wantMarket {
+				t.Errorf("рыночная стоимость %.2f, ожидалось %.2f", holding.MarketValue, tt.wantMarket)
+			}
+			if holding.UnrealizedPnL != tt.wantUnrealized {
+				t.Errorf("нереализованный результат %.2f, ожидалось %.2f", holding.UnrealizedPnL, tt.wantUnrealized)
+			}
+			if holding.RealizedPnL != tt.wantRealized || portfolio.RealizedPnL != tt.wantRealized {
+				t.Errorf("реализованный результат %.2f (портфель %.2f), ожидалось %.2f", holding.RealizedPnL, portfolio.RealizedPnL, tt.wantRealized)
+			}
+			if holding.Dividends != tt.wantDividends {
+				t.Errorf("дивиденды %.2f, ожидалось %.2f", holding.Dividends, tt.wantDividends)
+			}
+			if len(holding.Lots) != tt.wantLots {
+				t.Errorf("осталось лотов %d, ожидалось %d", len(holding.Lots), tt.wantLots)
+			}
+			if tt.wantRealized != 0 && realized[3] != tt.wantRealized {
+				t.Errorf("результат продажи %.2f, ожидалось %.2f", realized[3], tt.wantRealized)
+			}
+		})
+	}
+}
+
+func TestValidateInvestmentSequence(t *testing.T) {
+	buy := models.InvestmentTransaction{Ticker: "GAZP", Type: models.InvestmentBuy, Date: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), Quantity: 10}
+
+	tests := []struct {
+		name    string
+		sell    models.InvestmentTransaction
+		wantErr bool
+	}{
+		{
+			name: "продажа всей позиции",
+			sell: models.InvestmentTransaction{Ticker: "GAZP", Type: models.InvestmentSell, Date: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Quantity: 10},
+		},
+		{
+			name:    "продажа больше позиции",
+			sell:    models.InvestmentTransaction{Ticker: "GAZP", Type: models.InvestmentSell, Date: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Quantity: 10.5},
+			wantErr: true,
+		},
+		{
+			name:    "продажа раньше покупки",
+			sell:    models.InvestmentTransaction{Ticker: "GAZP", Type: models.InvestmentSell, Date: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), Quantity: 1},
+			wantErr: true,
+		},
+		{
+			name:    "продажа другой бумаги",
+			sell:    models.InvestmentTransaction{Ticker: "LKOH", Type: models.InvestmentSell, Date: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Quantity: 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInvestmentSequence([]models.InvestmentTransaction{buy, tt.sell})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
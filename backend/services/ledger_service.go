@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/exporters"
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// ledgerCurrency валюта, в которой ведется учет
+const ledgerCurrency = "RUB"
+
+// ledgerAccountPattern описывает допустимое название счета: корневой счет и хотя бы один подсчет,
+// каждый компонент начинается с заглавной буквы или цифры (требование Beancount)
+var ledgerAccountPattern = regexp.MustCompile(`^(Assets|Liabilities|Equity|Income|Expenses)(:[\p{Lu}\p{N}][\p{L}\p{N}-]*)+$`)
+
+// LedgerServiceImpl представляет реализацию сервиса выгрузки в текстовую бухгалтерию
+type LedgerServiceImpl struct {
+	ledgerRepo  repositories.LedgerRepository
+	expenseRepo repositories.ExpenseRepository
+	incomeRepo  repositories.IncomeRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewLedgerService создает новый экземпляр сервиса текстовой бухгалтерии
+func NewLedgerService(
+	ledgerRepo repositories.LedgerRepository,
+	expenseRepo repositories.ExpenseRepository,
+	incomeRepo repositories.IncomeRepository,
+	userRepo repositories.UserRepository,
+) LedgerService {
+	return &LedgerServiceImpl{
+		ledgerRepo:  ledgerRepo,
+		expenseRepo: expenseRepo,
+		incomeRepo:  incomeRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// GetLedgerAccounts получает сопоставление категорий и источников со счетами с учетом значений по умолчанию
+func (s *LedgerServiceImpl) GetLedgerAccounts(ctx context.Context, userID int64) (*models.LedgerAccountMap, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	accounts, err := s.ledgerRepo.GetAccounts(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении счетов")
+	}
+
+	// Заполняем значения по умолчанию
+	accountMap := &models.LedgerAccountMap{
+		Categories: make(map[string]string),
+		Sources:    make(map[string]string),
+		Asset:      "Assets:Cash",
+		Currency:   ledgerCurrency,
+	}
+	for _, category := range models.ExpenseCategories {
+		accountMap.Categories[string(category)] = "Expenses:" + ledgerComponent(string(category))
+	}
+	for _, source := range models.IncomeSources {
+		accountMap.Sources[string(source)] = "Income:" + ledgerComponent(string(source))
+	}
+
+	// Применяем настройки пользователя
+	for _, account := range accounts {
+		switch account.Kind {
+		case models.LedgerAccountCategory:
+			accountMap.Categories[account.Key] = account.Account
+		case models.LedgerAccountSource:
+			accountMap.Sources[account.Key] = account.Account
+		case models.LedgerAccountAsset:
+			if account.Key == models.LedgerDefaultAsset {
+				accountMap.Asset = account.Account
+			}
+		}
+	}
+
+	return accountMap, nil
+}
+
+// UpdateLedgerAccounts сохраняет сопоставление счетов
+func (s *LedgerServiceImpl) UpdateLedgerAccounts(ctx context.Context, userID int64, request *models.UpdateLedgerAccountsRequest) (*models.LedgerAccountMap, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Проверяем ключи и названия счетов
+	for _, account := range request.Accounts {
+		if !ledgerAccountPattern.MatchString(account.Account) {
+			return nil, fmt.Errorf("некорректное название счета: %s", account.Account)
+		}
+
+		switch account.Kind {
+		case models.LedgerAccountCategory:
+			if !isKnownCategory(models.ExpenseCategory(account.Key)) {
+				return nil, fmt.Errorf("неизвестная категория: %s", account.Key)
+			}
+		case models.LedgerAccountSource:
+			if !isKnownSource(models.IncomeSource(account.Key)) {
+				return nil, fmt.Errorf("неизвестный источник: %s", account.Key)
+			}
+		case models.LedgerAccountAsset:
+			if account.Key != models.LedgerDefaultAsset {
+				return nil, fmt.Errorf("неизвестный актив: %s", account.Key)
+			}
+		}
+	}
+
+	if err := s.ledgerRepo.SaveAccounts(ctx, userID, request.Accounts); err != nil {
+		return nil, errors.New("ошибка при сохранении счетов")
+	}
+
+	return s.GetLedgerAccounts(ctx, userID)
+}
+
+// ExportLedger записывает траты и накопления пользователя за период в формате hledger или Beancount
+func (s *LedgerServiceImpl) ExportLedger(ctx context.Context, userID int64, format models.LedgerFormat, startDate, endDate *time.Time, w io.Writer) error {
+	// Даты операций выгружаются в часовом поясе пользователя
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.New("пользователь не найден")
+	}
+
+	accountMap, err := s.GetLedgerAccounts(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var entries []exporters.LedgerEntry
+
+	// Траты переходят с актива на счет расходов
+	expenseFilter := &models.ExpenseFilter{StartDate: startDate, EndDate: endDate}
+	err = s.expenseRepo.StreamByFilter(ctx, userID, expenseFilter, func(expense *models.Expense) error {
+		entries = append(entries, exporters.LedgerEntry{
+			ID:        expense.ID,
+			Date:      expense.Date,
+			Payee:     expense.Title,
+			Narration: expense.Description,
+			From:      accountMap.Asset,
+			To:        ledgerAccountOrDefault(accountMap.Categories, string(expense.Category), "Expenses:Other"),
+			Amount:    expense.Amount,
+		})
+		return nil
+	})
+	if err != nil {
+		return errors.New("ошибка при получении трат")
+	}
+
+	// Накопления переходят со счета доходов на актив
+	incomeFilter := &models.IncomeFilter{StartDate: startDate, EndDate: endDate}
+	err = s.incomeRepo.StreamByFilter(ctx, userID, incomeFilter, func(income *models.Income) error {
+		entries = append(entries, exporters.LedgerEntry{
+			ID:        income.ID,
+			Date:      income.Date,
+			Payee:     string(income.Source),
+			Narration: income.Description,
+			From:      ledgerAccountOrDefault(accountMap.Sources, string(income.Source), "Income:Other"),
+			To:        accountMap.Asset,
+			Amount:    income.Amount,
+		})
+		return nil
+	})
+	if err != nil {
+		return errors.New("ошибка при получении накоплений")
+	}
+
+	return exporters.WriteLedger(w, format, accountMap.Currency, user.Location(), entries)
+}
+
+// ledgerAccountOrDefault возвращает счет для ключа или запасной счет, если ключ не сопоставлен
+func ledgerAccountOrDefault(accounts map[string]string, key, fallback string) string {
+	if account, ok := accounts[key]; ok {
+		return account
+	}
+	return fallback
+}
+
+// ledgerComponent превращает ключ категории в компонент названия счета (food -> Food)
+func ledgerComponent(key string) string {
+	if key == "" {
+		return key
+	}
+	return strings.ToUpper(key[:1]) + key[1:]
+}
+
+// isKnownCategory проверяет, что категория трат существует
+func isKnownCategory(category models.ExpenseCategory) bool {
+	for _, known := range models.ExpenseCategories {
+		if known == category {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownSource проверяет, что источник дохода существует
+func isKnownSource(source models.IncomeSource) bool {
+	for _, known := range models.IncomeSources {
+		if known == source {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// LoanServiceImpl представляет реализацию сервиса кредитов
+type LoanServiceImpl struct {
+	loanRepo    repositories.LoanRepository
+	expenseRepo repositories.ExpenseRepository
+	userRepo    repositories.UserRepository
+	calculator  CalculatorService
+}
+
+// NewLoanService создает новый экземпляр сервиса кредитов
+func NewLoanService(
+	loanRepo repositories.LoanRepository,
+	expenseRepo repositories.ExpenseRepository,
+	userRepo repositories.UserRepository,
+	calculator CalculatorService,
+) LoanService {
+	return &LoanServiceImpl{
+		loanRepo:    loanRepo,
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+		calculator:  calculator,
+	}
+}
+
+// CreateLoan добавляет кредит и возвращает его с графиком платежей
+func (s *LoanServiceImpl) CreateLoan(ctx context.Context, userID int64, request *models.CreateLoanRequest) (*models.Loan, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	category := request.Category
+	if category == "" {
+		category = models.CategoryOther
+	}
+	if !isKnownCategory(category) {
+		return nil, errors.New("неизвестная категория трат")
+	}
+
+	loan := &models.Loan{
+		UserID:      userID,
+		Name:        request.Name,
+		Lender:      request.Lender,
+		Principal:   roundMoney(request.Principal),
+		Rate:        request.Rate,
+		TermMonths:  request.TermMonths,
+		StartDate:   request.StartDate,
+		Type:        request.Type,
+		Category:    category,
+		Description: request.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	loan.ID, err = s.loanRepo.Create(ctx, loan)
+	if err != nil {
+		return nil, errors.New("ошибка при создании кредита")
+	}
+
+	applyLoanStatus(s.calculator, loan, nil, true)
+	return loan, nil
+}
+
+// GetLoan получает кредит с фактическими платежами и графиком оставшихся платежей
+func (s *LoanServiceImpl) GetLoan(ctx context.Context, id int64, userID int64) (*models.Loan, error) {
+	loan, payments, err := s.getUserLoan(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyLoanStatus(s.calculator, loan, payments, true)
+	return loan, nil
+}
+
+// GetUserLoans получает кредиты пользователя с остатком долга и ближайшим платежом
+func (s *LoanServiceImpl) GetUserLoans(ctx context.Context, userID int64) ([]models.Loan, error) {
+	loans, err := s.loanRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении кредитов")
+	}
+
+	if err := loadLoanStatuses(ctx, s.loanRepo, s.calculator, loans); err != nil {
+		return nil, err
+	}
+	if loans == nil {
+		loans = []models.Loan{}
+	}
+
+	return loans, nil
+}
+
+// UpdateLoan обновляет описательные поля кредита
+func (s *LoanServiceImpl) UpdateLoan(ctx context.Context, id int64, userID int64, request *models.UpdateLoanRequest) (*models.Loan, error) {
+	loan, payments, err := s.getUserLoan(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Обновляем поля, если они указаны в запросе
+	if request.Name != nil {
+		loan.Name = *request.Name
+	}
+	if request.Lender != nil {
+		loan.Lender = *request.Lender
+	}
+	if request.Category != nil {
+		if !isKnownCategory(*request.Category) {
+			return nil, errors.New("неизвестная категория трат")
+		}
+		loan.Category = *request.Category
+	}
+	if request.Description != nil {
+		loan.Description = *request.Description
+	}
+
+	if err := s.loanRepo.Update(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	loan.UpdatedAt = time.Now()
+	applyLoanStatus(s.calculator, loan, payments, true)
+	return loan, nil
+}
+
+// DeleteLoan удаляет кредит
+func (s *LoanServiceImpl) DeleteLoan(ctx context.Context, id int64, userID int64) error {
+	return s.loanRepo.Delete(ctx, id, userID)
+}
+
+// AddPayment записывает фактический платеж по кредиту. Регулярный платеж сначала гасит проценты
+// за месяц, досрочный целиком идет в основной долг, после чего график пересчитывается
+// с сокращением срока или платежа. Платеж связывается с указанной тратой или создает новую
+func (s *LoanServiceImpl) AddPayment(ctx context.Context, id int64, userID int64, request *models.CreateLoanPaymentRequest) (*models.Loan, error) {
+	loan, payments, err := s.getUserLoan(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := replayLoan(loan, payments)
+	if state.closed() {
+		return nil, errors.New("кредит уже погашен")
+	}
+
+	payment := &models.LoanPayment{
+		LoanID:    loan.ID,
+		Date:      request.Date,
+		Amount:    roundMoney(request.Amount),
+		Early:     request.Early,
+		CreatedAt: time.Now(),
+	}
+
+	// Если дата не указана, используем текущую
+	if payment.Date.IsZero() {
+		payment.Date = time.Now()
+	}
+
+	if payment.Early {
+		payment.Strategy = request.Strategy
+		if payment.Strategy == "" {
+			payment.Strategy = models.EarlyReduceTerm
+		}
+	}
+
+	payment.Principal, payment.Interest, err = state.split(loan, payment.Amount, payment.Early)
+	if err != nil {
+		return nil, err
+	}
+
+	// Связываем платеж с существующей тратой или создаем новую
+	var expense *models.Expense
+	if request.ExpenseID != nil {
+		existing, err := s.expenseRepo.GetByID(ctx, *request.ExpenseID)
+		if err != nil || existing.UserID != userID {
+			return nil, errors.New("трата не найдена")
+		}
+		payment.ExpenseID = &existing.ID
+	} else {
+		expense = &models.Expense{
+			UserID:      userID,
+			Title:       truncateTitle("Платеж по кредиту: " + loan.Name),
+			Amount:      payment.Amount,
+			Category:    loan.Category,
+			Date:        payment.Date,
+			Description: loan.Lender,
+		}
+	}
+
+	payment.ID, err = s.loanRepo.AddPayment(ctx, payment, expense)
+	if err != nil {
+		return nil, errors.New("ошибка при сохранении платежа")
+	}
+	if expense != nil {
+		expenseClassifiers.invalidate(userID)
+	}
+
+	applyLoanStatus(s.calculator, loan, append(payments, *payment), true)
+	return loan, nil
+}
+
+// DeletePayment удаляет последний платеж по кредиту вместе с созданной по нему тратой.
+// Более ранние платежи удалять нельзя, так как от них зависит разбивка последующих
+func (s *LoanServiceImpl) DeletePayment(ctx context.Context, id int64, paymentID int64, userID int64) (*models.Loan, error) {
+	loan, payments, err := s.getUserLoan(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payments) == 0 || payments[len(payments)-1].ID != paymentID {
+		for _, payment := range payments {
+			if payment.ID == paymentID {
+				return nil, errors.New("удалить можно только последний платеж")
+			}
+		}
+		return nil, errors.New("платеж не найден")
+	}
+
+	if err := s.loanRepo.DeletePayment(ctx, &payments[len(payments)-1]); err != nil {
+		return nil, err
+	}
+	expenseClassifiers.invalidate(userID)
+
+	applyLoanStatus(s.calculator, loan, payments[:len(payments)-1], true)
+	return loan, nil
+}
+
+// getUserLoan получает кредит пользователя вместе с его платежами
+func (s *LoanServiceImpl) getUserLoan(ctx context.Context, id int64, userID int64) (*models.Loan, []models.LoanPayment, error) {
+	loan, err := s.loanRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if loan.UserID != userID {
+		return nil, nil, errors.New("кредит не принадлежит пользователю")
+	}
+
+	payments, err := s.loanRepo.GetPayments(ctx, id)
+	if err != nil {
+		return nil, nil, errors.New("ошибка при получении платежей")
+	}
+
+	return loan, payments, nil
+}
+
+// loadLoanStatuses рассчитывает остаток долга и ближайший платеж для списка кредитов
+func loadLoanStatuses(ctx context.Context, loanRepo repositories.LoanRepository, calculator CalculatorService, loans []models.Loan) error {
+	for i := range loans {
+		payments, err := loanRepo.GetPayments(ctx, loans[i].ID)
+		if err != nil {
+			return errors.New("ошибка при получении платежей")
+		}
+		applyLoanStatus(calculator, &loans[i], payments, false)
+	}
+
+	return nil
+}
+
+// applyLoanStatus заполняет вычисляемые поля кредита по фактическим платежам.
+// При detailed к кредиту добавляются платежи и график оставшихся платежей
+func applyLoanStatus(calculator CalculatorService, loan *models.Loan, payments []models.LoanPayment, detailed bool) {
+	state := replayLoan(loan, payments)
+
+	loan.PaidPrincipal = 0
+	loan.PaidInterest = 0
+	for _, payment := range payments {
+		loan.PaidPrincipal += payment.Principal
+		loan.PaidInterest += payment.Interest
+	}
+	loan.PaidPrincipal = roundMoney(loan.PaidPrincipal)
+	loan.PaidInterest = roundMoney(loan.PaidInterest)
+	loan.RemainingBalance = state.balance
+	loan.Closed = state.closed()
+
+	// Оставшиеся платежи начинаются с месяца, следующего за последним регулярным платежом
+	schedule := []models.LoanScheduleEntry{}
+	if !loan.Closed {
+		firstPaymentDate := loan.StartDate.AddDate(0, state.regularPaid+1, 0)
+		schedule = calculator.CalculateLoanSchedule(state.balance, loan.Rate, state.monthsLeft, loan.Type, firstPaymentDate)
+	}
+
+	loan.RemainingMonths = len(schedule)
+	loan.MonthlyPayment = 0
+	loan.NextPaymentDate = nil
+	if len(schedule) > 0 {
+		loan.MonthlyPayment = schedule[0].Payment
+		loan.NextPaymentDate = &schedule[0].Date
+	}
+
+	if detailed {
+		loan.Payments = payments
+		if loan.Payments == nil {
+			loan.Payments = []models.LoanPayment{}
+		}
+		loan.Schedule = schedule
+	}
+}
+
+// loanState описывает состояние кредита после учета фактических платежей
+type loanState struct {
+	balance       float64
+	monthsLeft    int
+	regularPaid   int
+	payment       float64
+	principalPart float64
+}
+
+// replayLoan последовательно применяет платежи к исходным условиям кредита
+func replayLoan(loan *models.Loan, payments []models.LoanPayment) *loanState {
+	state := &loanState{
+		balance:       loan.Principal,
+		monthsLeft:    loan.TermMonths,
+		payment:       calculateAnnuityPayment(loan.Principal, loan.Rate/100/12, loan.TermMonths),
+		principalPart: loan.Principal / float64(loan.TermMonths),
+	}
+
+	for _, payment := range payments {
+		state.apply(loan, &payment)
+	}
+
+	return state
+}
+
+// closed сообщает, погашен ли кредит полностью
+func (st *loanState) closed() bool {
+	return st.balance < 0.01 || st.monthsLeft <= 0
+}
+
+// split делит сумму платежа на основной долг и проценты с учетом текущего остатка
+func (st *loanState) split(loan *models.Loan, amount float64, early bool) (float64, float64, error) {
+	if early {
+		if amount > st.balance+0.005 {
+			return 0, 0, errors.New("сумма досрочного погашения превышает остаток долга")
+		}
+		return amount, 0, nil
+	}
+
+	interest := roundMoney(st.balance * loan.Rate / 100 / 12)
+	if amount <= interest {
+		return 0, amount, nil
+	}
+
+	principal := roundMoney(amount - interest)
+	if principal > st.balance+0.005 {
+		return 0, 0, errors.New("сумма платежа превышает остаток долга с процентами")
+	}
+
+	return principal, interest, nil
+}
+
+// apply учитывает платеж и при досрочном погашении пересчитывает платеж или срок
+func (st *loanState) apply(loan *models.Loan, payment *models.LoanPayment) {
+	st.balance = math.Max(0, roundMoney(st.balance-payment.Principal))
+
+	if !payment.Early {
+		st.monthsLeft--
+		st.regularPaid++
+		return
+	}
+
+	if st.closed() {
+		return
+	}
+
+	monthlyRate := loan.Rate / 100 / 12
+	switch payment.Strategy {
+	case models.EarlyReducePayment:
+		st.payment = calculateAnnuityPayment(st.balance, monthlyRate, st.monthsLeft)
+		st.principalPart = st.balance / float64(st.monthsLeft)
+	default:
+		if loan.Type == models.LoanDifferentiated {
+			st.monthsLeft = int(math.Ceil(st.balance / st.principalPart))
+		} else {
+			st.monthsLeft = annuityTermMonths(st.balance, monthlyRate, st.payment, st.monthsLeft)
+		}
+	}
+}
+
+// annuityTermMonths вычисляет, за сколько месяцев аннуитетный платеж погасит остаток долга.
+// Если платеж не покрывает проценты, срок не меняется
+func annuityTermMonths(balance float64, monthlyRate float64, payment float64, fallback int) int {
+	if payment <= 0 {
+		return fallback
+	}
+
+	if monthlyRate == 0 {
+		return int(math.Ceil(balance / payment))
+	}
+
+	if payment <= balance*monthlyRate {
+		return fallback
+	}
+
+	months := -math.Log(1-monthlyRate*balance/payment) / math.Log(1+monthlyRate)
+	return int(math.Ceil(months - 1e-9))
+}
+
+// summarizeLoans формирует сводку по кредитам для панели мониторинга
+func summarizeLoans(loans []models.Loan) *models.DebtsSummary {
+	summary := &models.DebtsSummary{Loans: loans}
+	if summary.Loans == nil {
+		summary.Loans = []models.Loan{}
+	}
+
+	for _, loan := range loans {
+		summary.TotalPrincipal += loan.Principal
+		summary.TotalRemaining += loan.RemainingBalance
+		if loan.Closed {
+			summary.Closed++
+			continue
+		}
+
+		summary.Active++
+		summary.MonthlyPayments += loan.MonthlyPayment
+		if loan.NextPaymentDate != nil && (summary.NextPaymentDate == nil || loan.NextPaymentDate.Before(*summary.NextPaymentDate)) {
+			summary.NextPaymentDate = loan.NextPaymentDate
+		}
+	}
+	summary.TotalRemaining = roundMoney(summary.TotalRemaining)
+	summary.MonthlyPayments = roundMoney(summary.MonthlyPayments)
+
+	return summary
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestCalculateLoanSchedule(t *testing.T) {
+	calculator := NewCalculatorService()
+	firstPayment := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		principal float64
+		rate      float64
+		months    int
+		loanType  models.LoanType
+		first     models.LoanScheduleEntry
+		last      models.LoanScheduleEntry
+	}{
+		{
+			name:      "аннуитет",
+			principal: 120000,
+			rate:      12,
+			months:    12,
+			loanType:  models.LoanAnnuity,
+			first:     models.LoanScheduleEntry{Number: 1, Payment: 10661.85, Principal: 9461.85, Interest: 1200, Remaining: 110538.15},
+			last:      models.LoanScheduleEntry{Number: 12, Payment: 10661.91, Principal: 10556.35, Interest: 105.56, Remaining: 0},
+		},
+		{
+			name:      "дифференцированный",
+			principal: 120000,
+			rate:      12,
+			months:    12,
+			loanType:  models.LoanDifferentiated,
+			first:     models.LoanScheduleEntry{Number: 1, Payment: 11200, Principal: 10000, Interest: 1200, Remaining: 110000},
+			last:      models.LoanScheduleEntry{Number: 12, Payment: 10100, Principal: 10000, Interest: 100, Remaining: 0},
+		},
+		{
+			name:      "без процентов с остатком от округления",
+			principal: 1000,
+			rate:      0,
+			months:    3,
+			loanType:  models.LoanAnnuity,
+			first:     models.LoanScheduleEntry{Number: 1, Payment: 333.33, Principal: 333.33, Remaining: 666.67},
+			last:      models.LoanScheduleEntry{Number: 3, Payment: 333.34, Principal: 333.34, Remaining: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := calculator.CalculateLoanSchedule(tt.principal, tt.rate, tt.months, tt.loanType, firstPayment)
+			if len(schedule) != tt.months {
+				t.Fatalf("получено %d платежей, ожидалось %d", len(schedule), tt.months)
+			}
+
+			checkScheduleEntry(t, schedule[0], tt.first)
+			checkScheduleEntry(t, schedule[len(schedule)-1], tt.last)
+
+			// Основной долг гасится полностью, до копейки
+			var principal int64
+			for _, entry := range schedule {
+				principal += toCents(entry.Principal)
+			}
+			if principal != toCents(tt.principal) {
+				t.Errorf("сумма основного долга %.2f, ожидалось %.2f", fromCents(principal), tt.principal)
+			}
+
+			if want := firstPayment.AddDate(0, tt.months-1, 0); !schedule[len(schedule)-1].Date.Equal(want) {
+				t.Errorf("дата последнего платежа %v, ожидалась %v", schedule[len(schedule)-1].Date, want)
+			}
+		})
+	}
+
+	if schedule := calculator.CalculateLoanSchedule(1000, 10, 0, models.LoanAnnuity, firstPayment); len(schedule) != 0 {
+		t.Errorf("для нулевого срока ожидался пустой график, получено %d платежей", len(schedule))
+	}
+}
+
+// checkScheduleEntry сравнивает суммы платежа графика с ожидаемыми
+func checkScheduleEntry(t *testing.T, got, want models.LoanScheduleEntry) {
+	t.Helper()
+
+	if got.Number != want.Number || got.Payment != want.Payment || got.Principal != want.Principal ||
+		got.Interest != want.Interest || got.Remaining != want.Remaining {
+		t.Errorf("платеж %d: получено %.2f (долг %.2f, проценты %.2f, остаток %.2f), ожидалось %.2f (долг %.2f, проценты %.2f, остаток %.2f)",
+			got.Number, got.Payment, got.Principal, got.Interest, got.Remaining,
+			want.Payment, want.Principal, want.Interest, want.Remaining)
+	}
+}
+
+func TestReplayLoanEarlyRepayment(t *testing.T) {
+	tests := []struct {
+		name           string
+		loanType       models.LoanType
+		regular        float64
+		early          float64
+		strategy       models.EarlyRepaymentStrategy
+		wantBalance    float64
+		wantMonthsLeft int
+		wantPayment    float64
+		wantPrincipal  float64
+	}{
+		{
+			name:           "аннуитет с сокращением срока",
+			loanType:       models.LoanAnnuity,
+			regular:        9461.85,
+			early:          50000,
+			strategy:       models.EarlyReduceTerm,
+			wantBalance:    60538.15,
+			wantMonthsLeft: 6,
+			wantPayment:    10661.85,
+			wantPrincipal:  10000,
+		},
+		{
+			name:           "аннуитет с уменьшением платежа",
+			loanType:       models.LoanAnnuity,
+			regular:        9461.85,
+			early:          50000,
+			strategy:       models.EarlyReducePayment,
+			wantBalance:    60538.15,
+			wantMonthsLeft: 11,
+			wantPayment:    5839.15,
+			wantPrincipal:  5503.47,
+		},
+		{
+			name:           "дифференцированный с сокращением срока",
+			loanType:       models.LoanDifferentiated,
+			regular:        10000,
+			early:          50000,
+			strategy:       models.EarlyReduceTerm,
+			wantBalance:    60000,
+			wantMonthsLeft: 6,
+			wantPayment:    10661.85,
+			wantPrincipal:  10000,
+		},
+		{
+			name:           "дифференцированный с уменьшением платежа",
+			loanType:       models.LoanDifferentiated,
+			regular:        10000,
+			early:          50000,
+			strategy:       models.EarlyReducePayment,
+			wantBalance:    60000,
+			wantMonthsLeft: 11,
+			wantPayment:    5787.24,
+			wantPrincipal:  5454.55,
+		},
+		{
+			name:           "полное досрочное погашение",
+			loanType:       models.LoanAnnuity,
+			regular:        9461.85,
+			early:          110538.15,
+			strategy:       models.EarlyReduceTerm,
+			wantBalance:    0,
+			wantMonthsLeft: 11,
+			wantPayment:    10661.85,
+			wantPrincipal:  10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := &models.Loan{Principal: 120000, Rate: 12, TermMonths: 12, Type: tt.loanType}
+			payments := []models.LoanPayment{
+				{Principal: tt.regular},
+				{Principal: tt.early, Early: true, Strategy: tt.strategy},
+			}
+
+			state := replayLoan(loan, payments)
+			if state.balance != tt.wantBalance {
+				t.Errorf("остаток %.2f, ожидалось %.2f", state.balance, tt.wantBalance)
+			}
+			if state.monthsLeft != tt.wantMonthsLeft {
+				t.Errorf("осталось месяцев %d, ожидалось %d", state.monthsLeft, tt.wantMonthsLeft)
+			}
+			if state.regularPaid != 1 {
+				t.Errorf("учтено регулярных платежей %d, ожидался 1", state.regularPaid)
+			}
+			if roundMoney(state.payment) != tt.wantPayment {
+				t.Errorf("платеж %.2f, ожидалось %.2f", state.payment, tt.wantPayment)
+			}
+			if roundMoney(state.principalPart) != tt.wantPrincipal {
+				t.Errorf("доля основного долга %.2f, ожидалось %.2f", state.principalPart, tt.wantPrincipal)
+			}
+			if closed := tt.wantBalance == 0; state.closed() != closed {
+				t.Errorf("признак погашения %v, ожидалось %v", state.closed(), closed)
+			}
+		})
+	}
+}
+
+func TestLoanStateSplit(t *testing.T) {
+	loan := &models.Loan{Principal: 120000, Rate: 12, TermMonths: 12, Type: models.LoanAnnuity}
+
+	tests := []struct {
+		name          string
+		amount        float64
+		early         bool
+		wantPrincipal float64
+		wantInterest  float64
+		wantErr       bool
+	}{
+		{name: "регулярный платеж", amount: 10661.85, wantPrincipal: 9461.85, wantInterest: 1200},
+		{name: "платеж меньше процентов", amount: 1000, wantPrincipal: 0, wantInterest: 1000},
+		{name: "досрочное погашение без процентов", amount: 50000, early: true, wantPrincipal: 50000},
+		{name: "досрочное погашение больше остатка", amount: 120000.01, early: true, wantErr: true},
+		{name: "платеж больше остатка с процентами", amount: 121200.01, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := replayLoan(loan, nil)
+			principal, interest, err := state.split(loan, tt.amount, tt.early)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получено %.2f + %.2f", principal, interest)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if principal != tt.wantPrincipal || interest != tt.wantInterest {
+				t.Errorf("получено %.2f + %.2f, ожидалось %.2f + %.2f", principal, interest, tt.wantPrincipal, tt.wantInterest)
+			}
+		})
+	}
+}
+
+func TestAnnuityTermMonths(t *testing.T) {
+	tests := []struct {
+		name        string
+		balance     float64
+		monthlyRate float64
+		payment     float64
+		want        int
+	}{
+		{name: "без процентов", balance: 1000, payment: 300, want: 4},
+		{name: "точное число платежей", balance: 120000, monthlyRate: 0.01, payment: calculateAnnuityPayment(120000, 0.01, 12), want: 12},
+		{name: "платеж не покрывает проценты", balance: 120000, monthlyRate: 0.01, payment: 1200, want: 24},
+		{name: "нулевой платеж", balance: 1000, monthlyRate: 0.01, want: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := annuityTermMonths(tt.balance, tt.monthlyRate, tt.payment, 24); got != tt.want {
+				t.Errorf("получено %d месяцев, ожидалось %d", got, tt.want)
+			}
+		})
+	}
+
+	if payment := calculateAnnuityPayment(1000, 0, 4); math.Abs(payment-250) > 1e-9 {
+		t.Errorf("аннуитет без процентов %.4f, ожидалось 250", payment)
+	}
+}
@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+// NetWorthServiceImpl представляет реализацию сервиса чистой стоимости капитала
+type NetWorthServiceImpl struct {
+	assetRepo      repositories.AssetRepository
+	netWorthRepo   repositories.NetWorthRepository
+	loanRepo       repositories.LoanRepository
+	investmentRepo repositories.InvestmentRepository
+	userRepo       repositories.UserRepository
+}
+
+// NewNetWorthService создает новый экземпляр сервиса чистой стоимости капитала
+func NewNetWorthService(
+	assetRepo repositories.AssetRepository,
+	netWorthRepo repositories.NetWorthRepository,
+	loanRepo repositories.LoanRepository,
+	investmentRepo repositories.InvestmentRepository,
+	userRepo repositories.UserRepository,
+) NetWorthService {
+	return &NetWorthServiceImpl{
+		assetRepo:      assetRepo,
+		netWorthRepo:   netWorthRepo,
+		loanRepo:       loanRepo,
+		investmentRepo: investmentRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateAsset добавляет актив или обязательство с начальной оценкой стоимости
+func (s *NetWorthServiceImpl) CreateAsset(ctx context.Context, userID int64, request *models.CreateAssetRequest) (*models.Asset, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	asset := &models.Asset{
+		UserID:      userID,
+		Name:        request.Name,
+		Kind:        request.Kind,
+		Liability:   request.Liability,
+		Description: request.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	var valuation *models.AssetValuation
+	if request.Value != nil {
+		valuation = &models.AssetValuation{
+			Value:     roundMoney(*request.Value),
+			Date:      time.Now(),
+			CreatedAt: time.Now(),
+		}
+		if request.Date != nil {
+			valuation.Date = *request.Date
+		}
+	}
+
+	asset.ID, err = s.assetRepo.Create(ctx, asset, valuation)
+	if err != nil {
+		return nil, errors.New("ошибка при создании актива")
+	}
+
+	asset.Valuations = []models.AssetValuation{}
+	if valuation != nil {
+		asset.CurrentValue = valuation.Value
+		asset.ValuedAt = &valuation.Date
+		asset.Valuations = append(asset.Valuations, *valuation)
+	}
+
+	return asset, nil
+}
+
+// GetAsset получает актив вместе с историей оценок
+func (s *NetWorthServiceImpl) GetAsset(ctx context.Context, id int64, userID int64) (*models.Asset, error) {
+	asset, err := s.getUserAsset(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset.Valuations, err = s.assetRepo.GetValuations(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении оценок")
+	}
+	if asset.Valuations == nil {
+		asset.Valuations = []models.AssetValuation{}
+	}
+
+	return asset, nil
+}
+
+// GetUserAssets получает активы и обязательства пользователя с последней оценкой
+func (s *NetWorthServiceImpl) GetUserAssets(ctx context.Context, userID int64) ([]models.Asset, error) {
+	assets, err := s.assetRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении активов")
+	}
+	if assets == nil {
+		assets = []models.Asset{}
+	}
+
+	return assets, nil
+}
+
+// UpdateAsset обновляет актив или обязательство
+func (s *NetWorthServiceImpl) UpdateAsset(ctx context.Context, id int64, userID int64, request *models.UpdateAssetRequest) (*models.Asset, error) {
+	asset, err := s.getUserAsset(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Обновляем поля, если они указаны в запросе
+	if request.Name != nil {
+		asset.Name = *request.Name
+	}
+	if request.Kind != nil {
+		asset.Kind = *request.Kind
+	}
+	if request.Liability != nil {
+		asset.Liability = *request.Liability
+	}
+	if request.Description != nil {
+		asset.Description = *request.Description
+	}
+
+	if err := s.assetRepo.Update(ctx, asset); err != nil {
+		return nil, err
+	}
+
+	asset.UpdatedAt = time.Now()
+	return asset, nil
+}
+
+// DeleteAsset удаляет актив или обязательство
+func (s *NetWorthServiceImpl) DeleteAsset(ctx context.Context, id int64, userID int64) error {
+	return s.assetRepo.Delete(ctx, id, userID)
+}
+
+// AddValuation добавляет оценку стоимости и возвращает актив с обновленной историей
+func (s *NetWorthServiceImpl) AddValuation(ctx context.Context, id int64, userID int64, request *models.CreateAssetValuationRequest) (*models.Asset, error) {
+	if _, err := s.getUserAsset(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	valuation := &models.AssetValuation{
+		AssetID:   id,
+		Value:     roundMoney(request.Value),
+		Date:      request.Date,
+		Note:      request.Note,
+		CreatedAt: time.Now(),
+	}
+
+	// Если дата не указана, используем текущую
+	if valuation.Date.IsZero() {
+		valuation.Date = time.Now()
+	}
+
+	if _, err := s.assetRepo.AddValuation(ctx, valuation); err != nil {
+		return nil, errors.New("ошибка при сохранении оценки")
+	}
+
+	return s.GetAsset(ctx, id, userID)
+}
+
+// DeleteValuation удаляет оценку стоимости актива
+func (s *NetWorthServiceImpl) DeleteValuation(ctx context.Context, id int64, valuationID int64, userID int64) error {
+	return s.assetRepo.DeleteValuation(ctx, valuationID, id, userID)
+}
+
+// GetNetWorth рассчитывает текущую чистую стоимость капитала и ее помесячную динамику.
+// Для прошедших месяцев используются сохраненные снимки, а при их отсутствии значение
+// восстанавливается по истории оценок и платежей по кредитам
+func (s *NetWorthServiceImpl) GetNetWorth(ctx context.Context, userID int64, months int) (*models.NetWorth, error) {
+	// Ограничиваем глубину истории
+	if months <= 0 {
+		months = 12
+	} else if months > 120 {
+		months = 120
+	}
+
+	// Месяцы определяются в часовом поясе пользователя
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	history, err := s.loadHistory(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(user.Location())
+	currentMonth, _ := utils.CurrentMonthPeriod(now)
+	firstMonth := currentMonth.AddDate(0, -(months - 1), 0)
+
+	stored, err := s.netWorthRepo.GetSnapshots(ctx, userID, firstMonth)
+	if err != nil {
+		return nil, errors.New("ошибка при получении снимков капитала")
+	}
+	storedByMonth := make(map[string]models.NetWorthSnapshot, len(stored))
+	for _, snapshot := range stored {
+		storedByMonth[snapshot.Date.Format("2006-01")] = snapshot
+	}
+
+	result := &models.NetWorth{Date: now}
+	result.Assets, result.Liabilities, result.Items = history.at(now)
+	result.NetWorth = roundMoney(result.Assets - result.Liabilities)
+
+	result.Series = make([]models.NetWorthSnapshot, 0, months)
+	for month := firstMonth; !month.After(currentMonth); month = month.AddDate(0, 1, 0) {
+		if snapshot, ok := storedByMonth[month.Format("2006-01")]; ok && month.Before(currentMonth) {
+			snapshot.Date = month
+			result.Series = append(result.Series, snapshot)
+			continue
+		}
+
+		snapshot := history.snapshot(userID, month, now)
+		result.Series = append(result.Series, *snapshot)
+	}
+
+	return result, nil
+}
+
+// SnapshotUser сохраняет снимок чистой стоимости капитала пользователя за текущий месяц
+func (s *NetWorthServiceImpl) SnapshotUser(ctx context.Context, userID int64) (*models.NetWorthSnapshot, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	history, err := s.loadHistory(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(user.Location())
+	monthStart, _ := utils.CurrentMonthPeriod(now)
+	snapshot := history.snapshot(userID, monthStart, now)
+	if err := s.netWorthRepo.SaveSnapshot(ctx, snapshot); err != nil {
+		return nil, errors.New("ошибка при сохранении снимка капитала")
+	}
+
+	snapshot.Stored = true
+	return snapshot, nil
+}
+
+// SnapshotAll сохраняет снимки за текущий месяц для всех пользователей с активами или кредитами.
+// Используется фоновой задачей и возвращает количество сохраненных снимков
+func (s *NetWorthServiceImpl) SnapshotAll(ctx context.Context) (int, error) {
+	userIDs, err := s.netWorthRepo.GetTrackedUserIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	saved := 0
+	for _, userID := range userIDs {
+		if _, err := s.SnapshotUser(ctx, userID); err != nil {
+			fmt.Printf("Не удалось сохранить снимок капитала пользователя ID=%d: %v\n", userID, err)
+			continue
+		}
+		saved++
+	}
+
+	return saved, nil
+}
+
+// getUserAsset получает актив, проверяя его принадлежность пользователю
+func (s *NetWorthServiceImpl) getUserAsset(ctx context.Context, id int64, userID int64) (*models.Asset, error) {
+	asset, err := s.assetRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if asset.UserID != userID {
+		return nil, errors.New("актив не принадлежит пользователю")
+	}
+
+	return asset, nil
+}
+
+// loadHistory загружает активы с оценками, кредиты с платежами и инвестиции пользователя
+func (s *NetWorthServiceImpl) loadHistory(ctx context.Context, userID int64) (*netWorthHistory, error) {
+	history := &netWorthHistory{
+		valuations: make(map[int64][]models.AssetValuation),
+		payments:   make(map[int64][]models.LoanPayment),
+	}
+
+	var err error
+	history.assets, err = s.assetRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении активов")
+	}
+
+	valuations, err := s.assetRepo.GetValuationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении оценок")
+	}
+	for _, valuation := range valuations {
+		history.valuations[valuation.AssetID] = append(history.valuations[valuation.AssetID], valuation)
+	}
+
+	history.loans, err = s.loanRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении кредитов")
+	}
+	for _, loan := range history.loans {
+		history.payments[loan.ID], err = s.loanRepo.GetPayments(ctx, loan.ID)
+		if err != nil {
+			return nil, errors.New("ошибка при получении платежей")
+		}
+	}
+
+	history.investments, err = s.investmentRepo.GetTransactions(ctx, userID, "")
+	if err != nil {
+		return nil, errors.New("ошибка при получении операций")
+	}
+	history.prices, err = s.investmentRepo.GetPrices(ctx, userID, "")
+	if err != nil {
+		return nil, errors.New("ошибка при получении цен")
+	}
+
+	return history, nil
+}
+
+// netWorthHistory содержит данные, по которым восстанавливается капитал на любую дату
+type netWorthHistory struct {
+	assets      []models.Asset
+	valuations  map[int64][]models.AssetValuation
+	loans       []models.Loan
+	payments    map[int64][]models.LoanPayment
+	investments []models.InvestmentTransaction
+	prices      []models.SecurityPrice
+}
+
+// at рассчитывает активы, обязательства и их состав на указанный момент.
+// Актив учитывается по последней оценке не позже этого момента, кредит — по остатку долга,
+// ценные бумаги — по рыночной стоимости позиций
+func (h *netWorthHistory) at(moment time.Time) (float64, float64, []models.NetWorthItem) {
+	var assets, liabilities float64
+	items := []models.NetWorthItem{}
+
+	for _, asset := range h.assets {
+		valued := false
+		value := 0.0
+		for _, valuation := range h.valuations[asset.ID] {
+			if valuation.Date.After(moment) {
+				break
+			}
+			value = valuation.Value
+			valued = true
+		}
+		if !valued {
+			continue
+		}
+
+		if asset.Liability {
+			liabilities += value
+		} else {
+			assets += value
+		}
+		items = append(items, models.NetWorthItem{
+			Source:    "asset",
+			ID:        asset.ID,
+			Name:      asset.Name,
+			Kind:      string(asset.Kind),
+			Liability: asset.Liability,
+			Value:     value,
+		})
+	}
+
+	for i := range h.loans {
+		loan := &h.loans[i]
+		if loan.StartDate.After(moment) {
+			continue
+		}
+
+		var payments []models.LoanPayment
+		for _, payment := range h.payments[loan.ID] {
+			if !payment.Date.After(moment) {
+				payments = append(payments, payment)
+			}
+		}
+
+		state := replayLoan(loan, payments)
+		if state.closed() {
+			continue
+		}
+
+		liabilities += state.balance
+		items = append(items, models.NetWorthItem{
+			Source:    "loan",
+			ID:        loan.ID,
+			Name:      loan.Name,
+			Kind:      string(loan.Type),
+			Liability: true,
+			Value:     state.balance,
+		})
+	}
+
+	if len(h.investments) > 0 {
+		portfolio, _ := buildPortfolio(h.investments, h.prices, moment)
+		for _, holding := range portfolio.Holdings {
+			if holding.MarketValue == 0 {
+				continue
+			}
+
+			assets += holding.MarketValue
+			items = append(items, models.NetWorthItem{
+				Source: "investment",
+				Name:   holding.Ticker,
+				Kind:   string(models.AssetInvestment),
+				Value:  holding.MarketValue,
+			})
+		}
+	}
+
+	return roundMoney(assets), roundMoney(liabilities), items
+}
+
+// snapshot рассчитывает снимок капитала за месяц на его конец, но не позже now
+func (h *netWorthHistory) snapshot(userID int64, month time.Time, now time.Time) *models.NetWorthSnapshot {
+	moment := month.AddDate(0, 1, 0).Add(-time.Second)
+	if moment.After(now) {
+		moment = now
+	}
+
+	assets, liabilities, _ := h.at(moment)
+	return &models.NetWorthSnapshot{
+		UserID:      userID,
+		Date:        month,
+		Assets:      assets,
+		Liabilities: liabilities,
+		NetWorth:    roundMoney(assets - liabilities),
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/configs"
+	"cz.Finance/backend/repositories"
+)
+
+// NotificationServiceImpl представляет реализацию сервиса уведомлений через Telegram Bot API
+type NotificationServiceImpl struct {
+	config       configs.TelegramConfig
+	telegramRepo repositories.TelegramUserRepository
+	httpClient   *http.Client
+}
+
+// NewNotificationService создает новый экземпляр сервиса уведомлений
+func NewNotificationService(config configs.TelegramConfig, telegramRepo repositories.TelegramUserRepository) NotificationService {
+	return &NotificationServiceImpl{
+		config:       config,
+		telegramRepo: telegramRepo,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Enabled сообщает, настроена ли отправка уведомлений
+func (s *NotificationServiceImpl) Enabled() bool {
+	return s.config.BotToken != ""
+}
+
+// NotifyUser отправляет сообщение пользователю в связанный аккаунт Telegram
+func (s *NotificationServiceImpl) NotifyUser(ctx context.Context, userID int64, text string) error {
+	if !s.Enabled() {
+		return errors.New("уведомления в Telegram не настроены")
+	}
+
+	// Получаем связанный аккаунт Telegram
+	telegramUser, err := s.telegramRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return errors.New("аккаунт Telegram не связан")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id": telegramUser.TelegramID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimRight(s.config.APIURL, "/"), s.config.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка при отправке уведомления: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Telegram возвращает описание ошибки в поле description
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Description string `json:"description"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiError); err == nil && apiError.Description != "" {
+			return fmt.Errorf("telegram отклонил уведомление: %s", apiError.Description)
+		}
+		return fmt.Errorf("telegram отклонил уведомление: статус %d", resp.StatusCode)
+	}
+
+	return nil
+}
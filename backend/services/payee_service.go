@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+// topPayeesDefaultLimit и topPayeesMaxLimit ограничивают количество получателей в аналитике
+const (
+	topPayeesDefaultLimit = 10
+	topPayeesMaxLimit     = 100
+)
+
+// PayeeServiceImpl представляет реализацию сервиса получателей платежей
+type PayeeServiceImpl struct {
+	payeeRepo   repositories.PayeeRepository
+	expenseRepo repositories.ExpenseRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewPayeeService создает новый экземпляр сервиса получателей платежей
+func NewPayeeService(payeeRepo repositories.PayeeRepository, expenseRepo repositories.ExpenseRepository, userRepo repositories.UserRepository) PayeeService {
+	return &PayeeServiceImpl{
+		payeeRepo:   payeeRepo,
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// CreatePayee создает получателя и связывает с ним подходящие траты без получателя
+func (s *PayeeServiceImpl) CreatePayee(ctx context.Context, userID int64, request *models.PayeeRequest) (*models.Payee, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	payee := &models.Payee{
+		UserID:    userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := s.applyPayeeRequest(ctx, payee, request); err != nil {
+		return nil, err
+	}
+
+	payee.ID, err = s.payeeRepo.Create(ctx, payee)
+	if err != nil {
+		return nil, errors.New("ошибка при создании получателя")
+	}
+
+	if _, err := s.LinkExpenses(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+// GetPayee получает получателя пользователя по ID
+func (s *PayeeServiceImpl) GetPayee(ctx context.Context, id int64, userID int64) (*models.Payee, error) {
+	payee, err := s.payeeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if payee.UserID != userID {
+		return nil, errors.New("получатель не принадлежит пользователю")
+	}
+
+	return payee, nil
+}
+
+// GetUserPayees получает всех получателей пользователя
+func (s *PayeeServiceImpl) GetUserPayees(ctx context.Context, userID int64) ([]models.Payee, error) {
+	payees, err := s.payeeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении получателей")
+	}
+	if payees == nil {
+		payees = []models.Payee{}
+	}
+
+	return payees, nil
+}
+
+// UpdatePayee полностью заменяет название, категорию и псевдонимы получателя
+func (s *PayeeServiceImpl) UpdatePayee(ctx context.Context, id int64, userID int64, request *models.PayeeRequest) (*models.Payee, error) {
+	payee, err := s.GetPayee(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyPayeeRequest(ctx, payee, request); err != nil {
+		return nil, err
+	}
+
+	if err := s.payeeRepo.Update(ctx, payee); err != nil {
+		return nil, err
+	}
+	payee.UpdatedAt = time.Now()
+
+	if _, err := s.LinkExpenses(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+// DeletePayee удаляет получателя, траты остаются без получателя
+func (s *PayeeServiceImpl) DeletePayee(ctx context.Context, id int64, userID int64) error {
+	return s.payeeRepo.Delete(ctx, id, userID)
+}
+
+// LinkExpenses связывает траты без получателя с получателями по нормализованному названию
+func (s *PayeeServiceImpl) LinkExpenses(ctx context.Context, userID int64) (*models.PayeeLinkResult, error) {
+	payees, err := loadPayeeSet(ctx, s.payeeRepo, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении получателей")
+	}
+
+	expenses, err := s.expenseRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+
+	links := make(map[int64]int64)
+	for _, expense := range expenses {
+		if expense.PayeeID != nil {
+			continue
+		}
+		if payee := payees.match(expense.Title); payee != nil {
+			links[expense.ID] = payee.ID
+		}
+	}
+
+	if len(links) > 0 {
+		if err := s.payeeRepo.LinkExpenses(ctx, userID, links); err != nil {
+			return nil, errors.New("ошибка при связывании трат с получателями")
+		}
+	}
+
+	return &models.PayeeLinkResult{Linked: len(links)}, nil
+}
+
+// GetTopPayees получает получателей с наибольшей суммой трат за период
+func (s *PayeeServiceImpl) GetTopPayees(ctx context.Context, userID int64, startDate, endDate time.Time, limit int) (*models.TopPayees, error) {
+	// Неуказанные границы берутся из текущего месяца в часовом поясе пользователя
+	if startDate.IsZero() || endDate.IsZero() {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, errors.New("пользователь не найден")
+		}
+
+		monthStart, monthEnd := utils.CurrentMonthPeriod(time.Now().In(user.Location()))
+		if startDate.IsZero() {
+			startDate = monthStart
+		}
+		if endDate.IsZero() {
+			endDate = monthEnd
+		}
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errors.New("дата окончания периода раньше даты начала")
+	}
+
+	if limit <= 0 {
+		limit = topPayeesDefaultLimit
+	} else if limit > topPayeesMaxLimit {
+		limit = topPayeesMaxLimit
+	}
+
+	total, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, errors.New("ошибка при получении общей суммы трат")
+	}
+
+	stats, err := s.payeeRepo.GetTopPayees(ctx, userID, startDate, endDate, limit)
+	if err != nil {
+		return nil, errors.New("ошибка при получении статистики по получателям")
+	}
+
+	unassignedCount, unassignedTotal, err := s.payeeRepo.GetUnassignedSummary(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат без получателя")
+	}
+
+	result := &models.TopPayees{
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Total:           roundMoney(total),
+		Payees:          make([]models.PayeeStat, 0, len(stats)),
+		UnassignedCount: unassignedCount,
+		UnassignedTotal: roundMoney(unassignedTotal),
+	}
+	for _, stat := range stats {
+		stat.Total = roundMoney(stat.Total)
+		stat.Average = roundMoney(stat.Total / float64(stat.Count))
+		stat.Percentage = roundMoney(calculatePercentage(stat.Total, total))
+		result.Payees = append(result.Payees, stat)
+	}
+
+	return result, nil
+}
+
+// applyPayeeRequest проверяет запрос и переносит его в получателя.
+// Псевдонимы нормализуются и не должны совпадать с псевдонимами других получателей
+func (s *PayeeServiceImpl) applyPayeeRequest(ctx context.Context, payee *models.Payee, request *models.PayeeRequest) error {
+	if request.DefaultCategory != "" && !isKnownCategory(request.DefaultCategory) {
+		return errors.New("неизвестная категория трат")
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if normalizePayeeKey(name) == "" {
+		return errors.New("название получателя должно содержать буквы")
+	}
+
+	aliases := make([]string, 0, len(request.Aliases))
+	seen := make(map[string]bool, len(request.Aliases))
+	for _, alias := range request.Aliases {
+		key := normalizePayeeKey(alias)
+		if key == "" {
+			return fmt.Errorf("псевдоним %q не содержит букв", alias)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		aliases = append(aliases, key)
+	}
+
+	// Проверяем, что название и псевдонимы не заняты другими получателями
+	existing, err := s.payeeRepo.GetByUserID(ctx, payee.UserID)
+	if err != nil {
+		return errors.New("ошибка при получении получателей")
+	}
+	for _, other := range existing {
+		if other.ID == payee.ID {
+			continue
+		}
+		if strings.EqualFold(other.Name, name) {
+			return fmt.Errorf("получатель %q уже существует", other.Name)
+		}
+		for _, alias := range other.Aliases {
+			if seen[alias] {
+				return fmt.Errorf("псевдоним %q уже используется получателем %q", alias, other.Name)
+			}
+		}
+	}
+
+	payee.Name = name
+	payee.DefaultCategory = request.DefaultCategory
+	payee.Aliases = aliases
+	return nil
+}
+
+// payeeKey связывает нормализованное название или псевдоним с получателем
+type payeeKey struct {
+	key   string
+	payee *models.Payee
+}
+
+// payeeSet содержит ключи получателей пользователя для сопоставления с названиями трат
+type payeeSet []payeeKey
+
+// loadPayeeSet загружает получателей пользователя и их нормализованные ключи
+func loadPayeeSet(ctx context.Context, payeeRepo repositories.PayeeRepository, userID int64) (payeeSet, error) {
+	payees, err := payeeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(payeeSet, 0, len(payees))
+	for i := range payees {
+		if key := normalizePayeeKey(payees[i].Name); key != "" {
+			set = append(set, payeeKey{key: key, payee: &payees[i]})
+		}
+		for _, alias := range payees[i].Aliases {
+			set = append(set, payeeKey{key: alias, payee: &payees[i]})
+		}
+	}
+
+	return set, nil
+}
+
+// match находит получателя, ключ которого встречается в названии траты как последовательность слов.
+// При нескольких совпадениях выбирается самый длинный ключ как самый точный
+func (set payeeSet) match(title string) *models.Payee {
+	normalized := normalizePayeeKey(title)
+	if normalized == "" {
+		return nil
+	}
+	normalized = " " + normalized + " "
+
+	var best *payeeKey
+	for i := range set {
+		if !strings.Contains(normalized, " "+set[i].key+" ") {
+			continue
+		}
+		if best == nil || len(set[i].key) > len(best.key) {
+			best = &set[i]
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+	return best.payee
+}
+
+// normalizePayeeKey приводит название к виду для сравнения: нижний регистр, «е» вместо «ё»,
+// без знаков препинания и слов только из цифр (номеров магазинов и терминалов)
+func normalizePayeeKey(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if strings.IndexFunc(field, unicode.IsLetter) < 0 {
+			continue
+		}
+		words = append(words, strings.ReplaceAll(field, "ё", "е"))
+	}
+
+	return strings.Join(words, " ")
+}
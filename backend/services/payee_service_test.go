@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"cz.Finance/backend/models"
+)
+
+func TestNormalizePayeeKey(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "ПЯТЕРОЧКА 1234", want: "пятерочка"},
+		{title: "Пятёрочка №5678 Москва", want: "пятерочка москва"},
+		{title: "YANDEX*TAXI  12.03", want: "yandex taxi"},
+		{title: "Магазин 7-Eleven", want: "магазин eleven"},
+		{title: "4G-связь", want: "4g связь"},
+		{title: "  --- 000123 ---  ", want: ""},
+		{title: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := normalizePayeeKey(tt.title); got != tt.want {
+				t.Errorf("получено %q, ожидалось %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayeeSetMatch(t *testing.T) {
+	pyaterochka := &models.Payee{ID: 1, Name: "Пятёрочка"}
+	yandex := &models.Payee{ID: 2, Name: "Яндекс"}
+	yandexTaxi := &models.Payee{ID: 3, Name: "Яндекс Такси"}
+
+	set := payeeSet{
+		{key: normalizePayeeKey(pyaterochka.Name), payee: pyaterochka},
+		{key: "5ka", payee: pyaterochka},
+		{key: normalizePayeeKey(yandex.Name), payee: yandex},
+		{key: normalizePayeeKey(yandexTaxi.Name), payee: yandexTaxi},
+	}
+
+	tests := []struct {
+		title string
+		want  *models.Payee
+	}{
+		{title: "ПЯТЕРОЧКА 1234 МОСКВА", want: pyaterochka},
+		{title: "Оплата 5KA.RU", want: pyaterochka},
+		{title: "Яндекс Такси поездка", want: yandexTaxi},
+		{title: "Яндекс Плюс", want: yandex},
+		// Ключ должен совпадать с целыми словами, а не с частью слова
+		{title: "Яндексмаркет", want: nil},
+		{title: "12345", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := set.match(tt.title); got != tt.want {
+				t.Errorf("получен получатель %v, ожидался %v", got, tt.want)
+			}
+		})
+	}
+}
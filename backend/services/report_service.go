@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/configs"
+	"cz.Finance/backend/exporters"
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// reportTopExpensesLimit количество крупнейших трат в отчете
+const reportTopExpensesLimit = 10
+
+// ReportServiceImpl представляет реализацию сервиса PDF-отчетов
+type ReportServiceImpl struct {
+	dashboardService DashboardService
+	userRepo         repositories.UserRepository
+	config           configs.ReportsConfig
+}
+
+// NewReportService создает новый экземпляр сервиса отчетов
+func NewReportService(dashboardService DashboardService, userRepo repositories.UserRepository, config configs.ReportsConfig) ReportService {
+	return &ReportServiceImpl{
+		dashboardService: dashboardService,
+		userRepo:         userRepo,
+		config:           config,
+	}
+}
+
+// WriteMonthlyReport формирует PDF-отчет за месяц по данным месячной статистики
+func (s *ReportServiceImpl) WriteMonthlyReport(ctx context.Context, userID int64, year int, month int, w io.Writer) error {
+	if month < 1 || month > 12 {
+		return errors.New("некорректный месяц")
+	}
+
+	report, err := s.buildMonthlyReport(ctx, userID, year, month)
+	if err != nil {
+		return err
+	}
+
+	return exporters.WriteMonthlyReportPDF(w, report, exporters.PDFFonts{
+		Regular: s.config.FontPath,
+		Bold:    s.config.BoldFontPath,
+	})
+}
+
+// buildMonthlyReport собирает данные отчета из месячной статистики
+func (s *ReportServiceImpl) buildMonthlyReport(ctx context.Context, userID int64, year int, month int) (*models.MonthlyReport, error) {
+	// Получаем пользователя для лимитов и подписи отчета
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// Получаем статистику за месяц
+	stats, err := s.dashboardService.GetMonthlyStats(ctx, userID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, _ := stats["summary"].(map[string]interface{})
+	expensesByCategory, _ := stats["expenses_by_category"].(map[string]float64)
+	expenses, _ := stats["expenses"].([]models.Expense)
+
+	report := &models.MonthlyReport{
+		Year:         year,
+		Month:        month,
+		UserName:     strings.TrimSpace(user.FirstName + " " + user.LastName),
+		MonthlyLimit: user.MonthlyLimit,
+		SavingsGoal:  user.SavingsGoal,
+	}
+	if report.UserName == "" {
+		report.UserName = user.Username
+	}
+	report.Expenses, _ = summary["expenses"].(float64)
+	report.Incomes, _ = summary["incomes"].(float64)
+	report.Balance, _ = summary["balance"].(float64)
+
+	// Траты по категориям в порядке убывания суммы
+	for category, amount := range expensesByCategory {
+		share := 0.0
+		if report.Expenses > 0 {
+			share = amount / report.Expenses * 100
+		}
+		report.Categories = append(report.Categories, models.ReportCategory{
+			Category: models.ExpenseCategory(category),
+			Amount:   amount,
+			Share:    share,
+		})
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if report.Categories[i].Amount != report.Categories[j].Amount {
+			return report.Categories[i].Amount > report.Categories[j].Amount
+		}
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	// Траты по дням месяца
+	daysInMonth := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	report.DailyExpenses = make([]float64, daysInMonth)
+	for _, expense := range expenses {
+		if day := expense.Date.Day(); day >= 1 && day <= daysInMonth {
+			report.DailyExpenses[day-1] += expense.Amount
+		}
+	}
+
+	// Крупнейшие траты
+	top := make([]models.Expense, len(expenses))
+	copy(top, expenses)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Amount > top[j].Amount
+	})
+	if len(top) > reportTopExpensesLimit {
+		top = top[:reportTopExpensesLimit]
+	}
+	report.TopExpenses = top
+
+	return report, nil
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// ruleTestMatchLimit ограничивает количество примеров в результате проверки правила
+const ruleTestMatchLimit = 100
+
+// RuleServiceImpl представляет реализацию сервиса правил категоризации
+type RuleServiceImpl struct {
+	ruleRepo    repositories.RuleRepository
+	expenseRepo repositories.ExpenseRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewRuleService создает новый экземпляр сервиса правил категоризации
+func NewRuleService(ruleRepo repositories.RuleRepository, expenseRepo repositories.ExpenseRepository, userRepo repositories.UserRepository) RuleService {
+	return &RuleServiceImpl{
+		ruleRepo:    ruleRepo,
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// CreateRule создает правило категоризации
+func (s *RuleServiceImpl) CreateRule(ctx context.Context, userID int64, request *models.CategorizationRuleRequest) (*models.CategorizationRule, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	rule := &models.CategorizationRule{
+		UserID:    userID,
+		Enabled:   true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	applyRuleRequest(rule, request)
+
+	if _, err := compileRule(rule); err != nil {
+		return nil, err
+	}
+
+	rule.ID, err = s.ruleRepo.Create(ctx, rule)
+	if err != nil {
+		return nil, errors.New("ошибка при создании правила")
+	}
+
+	return rule, nil
+}
+
+// GetRule получает правило пользователя по ID
+func (s *RuleServiceImpl) GetRule(ctx context.Context, id int64, userID int64) (*models.CategorizationRule, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.UserID != userID {
+		return nil, errors.New("правило не принадлежит пользователю")
+	}
+
+	return rule, nil
+}
+
+// GetUserRules получает правила пользователя в порядке приоритета
+func (s *RuleServiceImpl) GetUserRules(ctx context.Context, userID int64) ([]models.CategorizationRule, error) {
+	rules, err := s.ruleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении правил")
+	}
+	if rules == nil {
+		rules = []models.CategorizationRule{}
+	}
+
+	return rules, nil
+}
+
+// UpdateRule полностью заменяет условия и действия правила
+func (s *RuleServiceImpl) UpdateRule(ctx context.Context, id int64, userID int64, request *models.CategorizationRuleRequest) (*models.CategorizationRule, error) {
+	rule, err := s.GetRule(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyRuleRequest(rule, request)
+	if _, err := compileRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	rule.UpdatedAt = time.Now()
+	return rule, nil
+}
+
+// DeleteRule удаляет правило
+func (s *RuleServiceImpl) DeleteRule(ctx context.Context, id int64, userID int64) error {
+	return s.ruleRepo.Delete(ctx, id, userID)
+}
+
+// ReorderRules меняет порядок применения правил
+func (s *RuleServiceImpl) ReorderRules(ctx context.Context, userID int64, request *models.ReorderRulesRequest) ([]models.CategorizationRule, error) {
+	seen := make(map[int64]bool, len(request.RuleIDs))
+	for _, id := range request.RuleIDs {
+		if seen[id] {
+			return nil, errors.New("ID правил не должны повторяться")
+		}
+		seen[id] = true
+	}
+
+	if err := s.ruleRepo.Reorder(ctx, userID, request.RuleIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetUserRules(ctx, userID)
+}
+
+// TestRule проверяет правило на истории трат пользователя, ничего не изменяя
+func (s *RuleServiceImpl) TestRule(ctx context.Context, userID int64, request *models.CategorizationRuleRequest) (*models.RuleTestResult, error) {
+	rule := &models.CategorizationRule{UserID: userID, Enabled: true}
+	applyRuleRequest(rule, request)
+
+	matcher, err := compileRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	expenses, err := s.expenseRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+
+	result := &models.RuleTestResult{Total: len(expenses), Matches: []models.RuleMatch{}}
+
+	// Показываем сначала самые свежие траты
+	for i := len(expenses) - 1; i >= 0; i-- {
+		expense := expenses[i]
+		if !matcher.matches(&expense, user.Location()) {
+			continue
+		}
+		result.Matched++
+
+		changed := expense
+		changed.Tags = append([]string{}, expense.Tags...)
+		if !matcher.apply(&changed, true) {
+			continue
+		}
+		result.Changed++
+
+		if len(result.Matches) < ruleTestMatchLimit {
+			result.Matches = append(result.Matches, models.RuleMatch{
+				ExpenseID:   expense.ID,
+				Date:        expense.Date,
+				Title:       expense.Title,
+				Amount:      expense.Amount,
+				Category:    expense.Category,
+				NewTitle:    changed.Title,
+				NewCategory: changed.Category,
+				NewTags:     changed.Tags,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// applyRuleRequest переносит условия и действия из запроса в правило
+func applyRuleRequest(rule *models.CategorizationRule, request *models.CategorizationRuleRequest) {
+	rule.Name = request.Name
+	if request.Priority != nil {
+		rule.Priority = *request.Priority
+	}
+	if request.Enabled != nil {
+		rule.Enabled = *request.Enabled
+	}
+	rule.TitlePattern = strings.TrimSpace(request.TitlePattern)
+	rule.DescriptionPattern = strings.TrimSpace(request.DescriptionPattern)
+	rule.MinAmount = request.MinAmount
+	rule.MaxAmount = request.MaxAmount
+	rule.Account = strings.TrimSpace(request.Account)
+	rule.Weekdays = request.Weekdays
+	rule.Category = request.Category
+	rule.Tags = normalizeTags(request.Tags)
+	rule.CleanTitle = strings.TrimSpace(request.CleanTitle)
+}
+
+// normalizeTags приводит метки к нижнему регистру и убирает пустые и повторяющиеся
+func normalizeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// ruleMatcher содержит правило с подготовленными регулярными выражениями
+type ruleMatcher struct {
+	rule        models.CategorizationRule
+	title       *regexp.Regexp
+	description *regexp.Regexp
+}
+
+// compileRule проверяет правило и подготавливает его к применению
+func compileRule(rule *models.CategorizationRule) (*ruleMatcher, error) {
+	if rule.TitlePattern == "" && rule.DescriptionPattern == "" && rule.MinAmount == nil &&
+		rule.MaxAmount == nil && rule.Account == "" && len(rule.Weekdays) == 0 {
+		return nil, errors.New("правило должно содержать хотя бы одно условие")
+	}
+	if rule.Category == "" && len(rule.Tags) == 0 && rule.CleanTitle == "" {
+		return nil, errors.New("правило должно назначать категорию, метки или название")
+	}
+	if rule.Category != "" && !isKnownCategory(rule.Category) {
+		return nil, fmt.Errorf("неизвестная категория: %s", rule.Category)
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && *rule.MinAmount > *rule.MaxAmount {
+		return nil, errors.New("минимальная сумма больше максимальной")
+	}
+
+	matcher := &ruleMatcher{rule: *rule}
+
+	var err error
+	if rule.TitlePattern != "" {
+		matcher.title, err = regexp.Compile("(?i)" + rule.TitlePattern)
+		if err != nil {
+			return nil, fmt.Errorf("неверное выражение для названия: %v", err)
+		}
+	}
+	if rule.DescriptionPattern != "" {
+		matcher.description, err = regexp.Compile("(?i)" + rule.DescriptionPattern)
+		if err != nil {
+			return nil, fmt.Errorf("неверное выражение для описания: %v", err)
+		}
+	}
+
+	return matcher, nil
+}
+
+// matches проверяет, что трата удовлетворяет всем условиям правила.
+// День недели определяется в часовом поясе пользователя
+func (m *ruleMatcher) matches(expense *models.Expense, location *time.Location) bool {
+	if m.title != nil && !m.title.MatchString(expense.Title) {
+		return false
+	}
+	if m.description != nil && !m.description.MatchString(expense.Description) {
+		return false
+	}
+	if m.rule.MinAmount != nil && expense.Amount < *m.rule.MinAmount {
+		return false
+	}
+	if m.rule.MaxAmount != nil && expense.Amount > *m.rule.MaxAmount {
+		return false
+	}
+	if m.rule.Account != "" && !strings.EqualFold(m.rule.Account, expense.Account) {
+		return false
+	}
+	if len(m.rule.Weekdays) > 0 {
+		// Дни недели считаются с понедельника: 1 - понедельник, 7 - воскресенье
+		weekday := int(expense.Date.In(location).Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+
+		found := false
+		for _, day := range m.rule.Weekdays {
+			if day == weekday {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// apply применяет действия правила к трате и сообщает, изменилась ли она.
+// Категория меняется только при setCategory, чтобы не перезаписывать выбор пользователя
+func (m *ruleMatcher) apply(expense *models.Expense, setCategory bool) bool {
+	changed := false
+
+	if setCategory && m.rule.Category != "" && expense.Category != m.rule.Category {
+		expense.Category = m.rule.Category
+		changed = true
+	}
+	if m.rule.CleanTitle != "" && expense.Title != m.rule.CleanTitle {
+		expense.Title = m.rule.CleanTitle
+		changed = true
+	}
+
+	tags := normalizeTags(append(append([]string{}, expense.Tags...), m.rule.Tags...))
+	if len(tags) != len(expense.Tags) {
+		changed = true
+	}
+	expense.Tags = tags
+
+	return changed
+}
+
+// ruleSet содержит включенные правила пользователя в порядке приоритета
+// и часовой пояс, в котором проверяются дни недели
+type ruleSet struct {
+	matchers []*ruleMatcher
+	location *time.Location
+}
+
+// loadRuleSet загружает включенные правила пользователя. Правила с ошибками пропускаются
+func loadRuleSet(ctx context.Context, ruleRepo repositories.RuleRepository, userID int64, location *time.Location) (*ruleSet, error) {
+	rules, err := ruleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &ruleSet{matchers: make([]*ruleMatcher, 0, len(rules)), location: location}
+	for i := range rules {
+		if !rules[i].Enabled {
+			continue
+		}
+
+		matcher, err := compileRule(&rules[i])
+		if err != nil {
+			fmt.Printf("Пропущено правило ID=%d: %v\n", rules[i].ID, err)
+			continue
+		}
+		set.matchers = append(set.matchers, matcher)
+	}
+
+	return set, nil
+}
+
+// apply применяет к трате первое подходящее правило и возвращает его
+func (rs *ruleSet) apply(expense *models.Expense, setCategory bool) *models.CategorizationRule {
+	for _, matcher := range rs.matchers {
+		if matcher.matches(expense, rs.location) {
+			matcher.apply(expense, setCategory)
+			return &matcher.rule
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestCompileRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    models.CategorizationRule
+		wantErr bool
+	}{
+		{
+			name: "название и категория",
+			rule: models.CategorizationRule{TitlePattern: "^яндекс\\s*go", Category: models.CategoryTransport},
+		},
+		{
+			name: "только сумма и метки",
+			rule: models.CategorizationRule{MinAmount: floatPointer(100), Tags: []string{"крупное"}},
+		},
+		{
+			name:    "без условий",
+			rule:    models.CategorizationRule{Category: models.CategoryFood},
+			wantErr: true,
+		},
+		{
+			name:    "без действий",
+			rule:    models.CategorizationRule{TitlePattern: "кофе"},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестная категория",
+			rule:    models.CategorizationRule{TitlePattern: "кофе", Category: models.ExpenseCategory("coffee")},
+			wantErr: true,
+		},
+		{
+			name:    "минимальная сумма больше максимальной",
+			rule:    models.CategorizationRule{MinAmount: floatPointer(500), MaxAmount: floatPointer(100), Tags: []string{"x"}},
+			wantErr: true,
+		},
+		{
+			name:    "неверное выражение для описания",
+			rule:    models.CategorizationRule{DescriptionPattern: "(кофе", Category: models.CategoryFood},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileRule(&tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ошибка %v, ожидалась ошибка: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleMatcherMatches(t *testing.T) {
+	// 16 марта 2024 года - суббота, 18 марта - понедельник
+	saturday := time.Date(2024, time.March, 16, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+	// Вечер воскресенья по UTC в Москве уже понедельник
+	sundayNight := time.Date(2024, time.March, 17, 22, 30, 0, 0, time.UTC)
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("не удалось загрузить часовой пояс: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rule     models.CategorizationRule
+		expense  models.Expense
+		location *time.Location
+		want     bool
+	}{
+		{
+			name:    "название без учета регистра",
+			rule:    models.CategorizationRule{TitlePattern: "яндекс\\s*go", Category: models.CategoryTransport},
+			expense: models.Expense{Title: "ЯНДЕКС GO поездка", Amount: 350, Date: monday},
+			want:    true,
+		},
+		{
+			name:    "описание не совпадает",
+			rule:    models.CategorizationRule{DescriptionPattern: "такси", Category: models.CategoryTransport},
+			expense: models.Expense{Title: "Яндекс", Description: "доставка", Amount: 350, Date: monday},
+		},
+		{
+			name:    "сумма в границах включительно",
+			rule:    models.CategorizationRule{MinAmount: floatPointer(100), MaxAmount: floatPointer(350), Tags: []string{"мелочи"}},
+			expense: models.Expense{Title: "Кофе", Amount: 350, Date: monday},
+			want:    true,
+		},
+		{
+			name:    "сумма больше максимальной",
+			rule:    models.CategorizationRule{MaxAmount: floatPointer(349.99), Tags: []string{"мелочи"}},
+			expense: models.Expense{Title: "Кофе", Amount: 350, Date: monday},
+		},
+		{
+			name:    "счет без учета регистра",
+			rule:    models.CategorizationRule{Account: "Tinkoff", Tags: []string{"карта"}},
+			expense: models.Expense{Title: "Кофе", Amount: 350, Account: "tinkoff", Date: monday},
+			want:    true,
+		},
+		{
+			name:    "выходные",
+			rule:    models.CategorizationRule{Weekdays: []int{6, 7}, Category: models.CategoryEntertainment},
+			expense: models.Expense{Title: "Кино", Amount: 600, Date: saturday},
+			want:    true,
+		},
+		{
+			name:    "будний день не входит в выходные",
+			rule:    models.CategorizationRule{Weekdays: []int{6, 7}, Category: models.CategoryEntertainment},
+			expense: models.Expense{Title: "Кино", Amount: 600, Date: monday},
+		},
+		{
+			name:     "день недели в часовом поясе пользователя",
+			rule:     models.CategorizationRule{Weekdays: []int{1}, Tags: []string{"понедельник"}},
+			expense:  models.Expense{Title: "Кофе", Amount: 200, Date: sundayNight},
+			location: moscow,
+			want:     true,
+		},
+		{
+			name:     "тот же момент по UTC еще воскресенье",
+			rule:     models.CategorizationRule{Weekdays: []int{1}, Tags: []string{"понедельник"}},
+			expense:  models.Expense{Title: "Кофе", Amount: 200, Date: sundayNight},
+			location: time.UTC,
+		},
+		{
+			name:    "все условия должны выполняться",
+			rule:    models.CategorizationRule{TitlePattern: "кино", MinAmount: floatPointer(1000), Category: models.CategoryEntertainment},
+			expense: models.Expense{Title: "Кино", Amount: 600, Date: saturday},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := compileRule(&tt.rule)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			location := tt.location
+			if location == nil {
+				location = time.UTC
+			}
+			if got := matcher.matches(&tt.expense, location); got != tt.want {
+				t.Errorf("получено %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleSetApply(t *testing.T) {
+	compile := func(rule models.CategorizationRule) *ruleMatcher {
+		matcher, err := compileRule(&rule)
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		return matcher
+	}
+
+	rules := &ruleSet{
+		matchers: []*ruleMatcher{
+			compile(models.CategorizationRule{ID: 1, TitlePattern: "^uber", Category: models.CategoryTransport, Tags: []string{"Такси", "поездки"}, CleanTitle: "Uber"}),
+			compile(models.CategorizationRule{ID: 2, TitlePattern: "uber", Category: models.CategoryFood, Tags: []string{"еда"}}),
+		},
+		location: time.UTC,
+	}
+
+	tests := []struct {
+		name         string
+		expense      models.Expense
+		setCategory  bool
+		wantRule     int64
+		wantTitle    string
+		wantCategory models.ExpenseCategory
+		wantTags     []string
+	}{
+		{
+			name:         "первое подходящее правило с категорией",
+			expense:      models.Expense{Title: "UBER *TRIP 1234", Category: models.CategoryOther, Tags: []string{"такси"}},
+			setCategory:  true,
+			wantRule:     1,
+			wantTitle:    "Uber",
+			wantCategory: models.CategoryTransport,
+			wantTags:     []string{"такси", "поездки"},
+		},
+		{
+			name:         "категория пользователя сохраняется",
+			expense:      models.Expense{Title: "Uber Eats", Category: models.CategoryOther},
+			wantRule:     1,
+			wantTitle:    "Uber",
+			wantCategory: models.CategoryOther,
+			wantTags:     []string{"такси", "поездки"},
+		},
+		{
+			name:         "следующее по приоритету правило",
+			expense:      models.Expense{Title: "Eats by Uber", Category: models.CategoryOther},
+			setCategory:  true,
+			wantRule:     2,
+			wantTitle:    "Eats by Uber",
+			wantCategory: models.CategoryFood,
+			wantTags:     []string{"еда"},
+		},
+		{
+			name:         "ни одно правило не подходит",
+			expense:      models.Expense{Title: "Кофе", Category: models.CategoryOther},
+			setCategory:  true,
+			wantTitle:    "Кофе",
+			wantCategory: models.CategoryOther,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := rules.apply(&tt.expense, tt.setCategory)
+			switch {
+			case tt.wantRule == 0 && rule != nil:
+				t.Fatalf("применено правило %d, ожидалось без правила", rule.ID)
+			case tt.wantRule != 0 && (rule == nil || rule.ID != tt.wantRule):
+				t.Fatalf("применено правило %v, ожидалось %d", rule, tt.wantRule)
+			}
+
+			if tt.expense.Title != tt.wantTitle {
+				t.Errorf("название %q, ожидалось %q", tt.expense.Title, tt.wantTitle)
+			}
+			if tt.expense.Category != tt.wantCategory {
+				t.Errorf("категория %q, ожидалась %q", tt.expense.Category, tt.wantCategory)
+			}
+			if len(tt.expense.Tags) != 0 || len(tt.wantTags) != 0 {
+				if !reflect.DeepEqual(tt.expense.Tags, tt.wantTags) {
+					t.Errorf("метки %v, ожидались %v", tt.expense.Tags, tt.wantTags)
+				}
+			}
+		})
+	}
+}
+
+// floatPointer возвращает указатель на значение для полей с необязательной суммой
+func floatPointer(value float64) *float64 {
+	return &value
+}
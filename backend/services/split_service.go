@@ -0,0 +1,692 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+// SplitServiceImpl представляет реализацию сервиса разделения общих трат
+type SplitServiceImpl struct {
+	splitRepo repositories.SplitRepository
+	userRepo  repositories.UserRepository
+}
+
+// NewSplitService создает новый экземпляр сервиса разделения общих трат
+func NewSplitService(splitRepo repositories.SplitRepository, userRepo repositories.UserRepository) SplitService {
+	return &SplitServiceImpl{
+		splitRepo: splitRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// CreateGroup создает группу, добавляя создателя первым участником
+func (s *SplitServiceImpl) CreateGroup(ctx context.Context, userID int64, request *models.CreateSplitGroupRequest) (*models.SplitGroup, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	group := &models.SplitGroup{
+		Name:      request.Name,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Participants: []models.SplitParticipant{
+			{UserID: &user.ID, Name: displayName(user)},
+		},
+	}
+
+	for i := range request.Participants {
+		participant, err := s.resolveParticipant(ctx, &request.Participants[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := checkDuplicateParticipant(group.Participants, participant); err != nil {
+			return nil, err
+		}
+		group.Participants = append(group.Participants, *participant)
+	}
+
+	group.ID, err = s.splitRepo.CreateGroup(ctx, group)
+	if err != nil {
+		return nil, errors.New("ошибка при создании группы")
+	}
+
+	return group, nil
+}
+
+// GetUserGroups получает группы, в которых участвует пользователь
+func (s *SplitServiceImpl) GetUserGroups(ctx context.Context, userID int64) ([]models.SplitGroup, error) {
+	groups, err := s.splitRepo.GetGroupsByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении групп")
+	}
+	if groups == nil {
+		groups = []models.SplitGroup{}
+	}
+
+	return groups, nil
+}
+
+// GetGroup получает группу вместе с участниками
+func (s *SplitServiceImpl) GetGroup(ctx context.Context, id int64, userID int64) (*models.SplitGroup, error) {
+	return s.getMemberGroup(ctx, id, userID)
+}
+
+// DeleteGroup удаляет группу. Удалить группу может только ее создатель
+func (s *SplitServiceImpl) DeleteGroup(ctx context.Context, id int64, userID int64) error {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if group.CreatedBy != userID {
+		return errors.New("удалить группу может только ее создатель")
+	}
+
+	return s.splitRepo.DeleteGroup(ctx, id)
+}
+
+// AddParticipant добавляет участника в группу
+func (s *SplitServiceImpl) AddParticipant(ctx context.Context, id int64, userID int64, request *models.AddSplitParticipantRequest) (*models.SplitParticipant, error) {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.resolveParticipant(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDuplicateParticipant(group.Participants, participant); err != nil {
+		return nil, err
+	}
+
+	participant.GroupID = id
+	participant.CreatedAt = time.Now()
+	participant.ID, err = s.splitRepo.AddParticipant(ctx, participant)
+	if err != nil {
+		return nil, errors.New("ошибка при добавлении участника")
+	}
+
+	return participant, nil
+}
+
+// RemoveParticipant удаляет участника, который еще не участвует в тратах и погашениях группы
+func (s *SplitServiceImpl) RemoveParticipant(ctx context.Context, id int64, participantID int64, userID int64) error {
+	if _, err := s.getMemberGroup(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.splitRepo.RemoveParticipant(ctx, id, participantID)
+}
+
+// CreateExpense добавляет общую трату и рассчитывает доли участников выбранным способом
+func (s *SplitServiceImpl) CreateExpense(ctx context.Context, id int64, userID int64, request *models.CreateSplitExpenseRequest) (*models.SplitExpense, error) {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if findParticipant(group.Participants, request.PaidBy) == nil {
+		return nil, errors.New("плательщик не является участником группы")
+	}
+
+	shares, err := splitAmount(request.Method, request.Amount, request.Shares, group.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	expense := &models.SplitExpense{
+		GroupID:     id,
+		PaidBy:      request.PaidBy,
+		Title:       request.Title,
+		Amount:      roundMoney(request.Amount),
+		Method:      request.Method,
+		Date:        request.Date,
+		Description: request.Description,
+		CreatedBy:   userID,
+		CreatedAt:   time.Now(),
+		Shares:      shares,
+	}
+
+	// Если дата не указана, используем текущую
+	if expense.Date.IsZero() {
+		expense.Date = time.Now()
+	}
+
+	expense.ID, err = s.splitRepo.CreateExpense(ctx, expense)
+	if err != nil {
+		return nil, errors.New("ошибка при сохранении траты группы")
+	}
+
+	return expense, nil
+}
+
+// GetExpenses получает общие траты группы
+func (s *SplitServiceImpl) GetExpenses(ctx context.Context, id int64, userID int64) ([]models.SplitExpense, error) {
+	if _, err := s.getMemberGroup(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.splitRepo.GetExpenses(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат группы")
+	}
+	if expenses == nil {
+		expenses = []models.SplitExpense{}
+	}
+
+	return expenses, nil
+}
+
+// DeleteExpense удаляет общую трату. Удалить трату может ее автор или создатель группы
+func (s *SplitServiceImpl) DeleteExpense(ctx context.Context, id int64, expenseID int64, userID int64) error {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	expense, err := s.splitRepo.GetExpense(ctx, expenseID)
+	if err != nil || expense.GroupID != id {
+		return errors.New("трата группы не найдена")
+	}
+
+	if expense.CreatedBy != userID && group.CreatedBy != userID {
+		return errors.New("у вас нет прав на удаление этой траты")
+	}
+
+	return s.splitRepo.DeleteExpense(ctx, expenseID, id)
+}
+
+// CreateSettlement записывает погашение долга. Зарегистрированному плательщику создается трата,
+// а зарегистрированному получателю — накопление на ту же сумму. Поскольку записи появляются
+// в учете других пользователей, погашение может записать только его плательщик, получатель
+// или создатель группы
+func (s *SplitServiceImpl) CreateSettlement(ctx context.Context, id int64, userID int64, request *models.CreateSplitSettlementRequest) (*models.SplitSettlement, error) {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.FromParticipant == request.ToParticipant {
+		return nil, errors.New("плательщик и получатель должны различаться")
+	}
+
+	from := findParticipant(group.Participants, request.FromParticipant)
+	to := findParticipant(group.Participants, request.ToParticipant)
+	if from == nil || to == nil {
+		return nil, errors.New("участник не найден в группе")
+	}
+
+	if !isParticipantUser(from, userID) && !isParticipantUser(to, userID) && group.CreatedBy != userID {
+		return nil, errors.New("записать погашение может только его участник или создатель группы")
+	}
+
+	settlement := &models.SplitSettlement{
+		GroupID:         id,
+		FromParticipant: from.ID,
+		ToParticipant:   to.ID,
+		Amount:          roundMoney(request.Amount),
+		Date:            request.Date,
+		Note:            request.Note,
+		CreatedBy:       userID,
+		CreatedAt:       time.Now(),
+	}
+
+	// Если дата не указана, используем текущую
+	if settlement.Date.IsZero() {
+		settlement.Date = time.Now()
+	}
+
+	description := fmt.Sprintf("Группа «%s»", group.Name)
+	if settlement.Note != "" {
+		description += ": " + settlement.Note
+	}
+
+	var expense *models.Expense
+	if from.UserID != nil {
+		expense = &models.Expense{
+			UserID:      *from.UserID,
+			Title:       truncateTitle("Возврат долга: " + to.Name),
+			Amount:      settlement.Amount,
+			Category:    models.CategoryOther,
+			Date:        settlement.Date,
+			Description: description,
+		}
+	}
+
+	var income *models.Income
+	if to.UserID != nil {
+		income = &models.Income{
+			UserID:      *to.UserID,
+			Amount:      settlement.Amount,
+			Source:      models.SourceOther,
+			Date:        settlement.Date,
+			Description: fmt.Sprintf("Возврат долга от %s. %s", from.Name, description),
+		}
+	}
+
+	settlement.ID, err = s.splitRepo.CreateSettlement(ctx, settlement, expense, income)
+	if err != nil {
+		return nil, errors.New("ошибка при сохранении погашения")
+	}
+	if expense != nil {
+		expenseClassifiers.invalidate(expense.UserID)
+	}
+
+	return settlement, nil
+}
+
+// GetSettlements получает погашения долгов группы
+func (s *SplitServiceImpl) GetSettlements(ctx context.Context, id int64, userID int64) ([]models.SplitSettlement, error) {
+	if _, err := s.getMemberGroup(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.splitRepo.GetSettlements(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении погашений")
+	}
+	if settlements == nil {
+		settlements = []models.SplitSettlement{}
+	}
+
+	return settlements, nil
+}
+
+// DeleteSettlement удаляет погашение вместе с созданными по нему записями.
+// Удалить погашение может его автор или создатель группы
+func (s *SplitServiceImpl) DeleteSettlement(ctx context.Context, id int64, settlementID int64, userID int64) error {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	settlement, err := s.splitRepo.GetSettlement(ctx, settlementID)
+	if err != nil || settlement.GroupID != id {
+		return errors.New("погашение не найдено")
+	}
+
+	if settlement.CreatedBy != userID && group.CreatedBy != userID {
+		return errors.New("у вас нет прав на удаление этого погашения")
+	}
+
+	if err := s.splitRepo.DeleteSettlement(ctx, settlement); err != nil {
+		return err
+	}
+
+	// Вместе с погашением удалена трата плательщика
+	if from := findParticipant(group.Participants, settlement.FromParticipant); from != nil && from.UserID != nil {
+		expenseClassifiers.invalidate(*from.UserID)
+	}
+
+	return nil
+}
+
+// GetBalances рассчитывает сальдо участников и долги «кто кому должен».
+// При simplify долги упрощаются до минимального числа переводов
+func (s *SplitServiceImpl) GetBalances(ctx context.Context, id int64, userID int64, simplify bool) (*models.SplitBalances, error) {
+	group, err := s.getMemberGroup(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.splitRepo.GetExpenses(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат группы")
+	}
+
+	settlements, err := s.splitRepo.GetSettlements(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении погашений")
+	}
+
+	balances := calculateSplitBalances(group.Participants, expenses, settlements)
+
+	var debts []models.SplitDebt
+	if simplify {
+		debts = simplifySplitDebts(balances)
+	} else {
+		debts = pairwiseSplitDebts(group.Participants, expenses, settlements)
+	}
+
+	return &models.SplitBalances{
+		GroupID:    id,
+		Simplified: simplify,
+		Balances:   balances,
+		Debts:      debts,
+	}, nil
+}
+
+// getMemberGroup получает группу с участниками, проверяя, что пользователь в ней участвует
+func (s *SplitServiceImpl) getMemberGroup(ctx context.Context, id int64, userID int64) (*models.SplitGroup, error) {
+	group, err := s.splitRepo.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Participants, err = s.splitRepo.GetParticipants(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении участников группы")
+	}
+
+	for _, participant := range group.Participants {
+		if participant.UserID != nil && *participant.UserID == userID {
+			return group, nil
+		}
+	}
+
+	return nil, errors.New("группа не найдена")
+}
+
+// resolveParticipant находит зарегистрированного пользователя по email или имени пользователя
+// либо создает именованный контакт
+func (s *SplitServiceImpl) resolveParticipant(ctx context.Context, request *models.AddSplitParticipantRequest) (*models.SplitParticipant, error) {
+	email := strings.TrimSpace(request.Email)
+	username := strings.TrimSpace(request.Username)
+	name := strings.TrimSpace(request.Name)
+
+	var user *models.User
+	var err error
+	switch {
+	case email != "":
+		user, err = s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, errors.New("пользователь с таким email не найден")
+		}
+	case username != "":
+		user, err = s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return nil, errors.New("пользователь с таким именем не найден")
+		}
+	case name != "":
+		return &models.SplitParticipant{Name: name}, nil
+	default:
+		return nil, errors.New("укажите email, имя пользователя или имя контакта")
+	}
+
+	if name == "" {
+		name = displayName(user)
+	}
+
+	return &models.SplitParticipant{UserID: &user.ID, Name: name}, nil
+}
+
+// checkDuplicateParticipant проверяет, что зарегистрированный пользователь еще не участвует в группе
+func checkDuplicateParticipant(participants []models.SplitParticipant, participant *models.SplitParticipant) error {
+	if participant.UserID == nil {
+		return nil
+	}
+
+	for _, existing := range participants {
+		if existing.UserID != nil && *existing.UserID == *participant.UserID {
+			return errors.New("пользователь уже участвует в группе")
+		}
+	}
+
+	return nil
+}
+
+// findParticipant находит участника группы по ID
+func findParticipant(participants []models.SplitParticipant, id int64) *models.SplitParticipant {
+	for i := range participants {
+		if participants[i].ID == id {
+			return &participants[i]
+		}
+	}
+
+	return nil
+}
+
+// displayName возвращает имя пользователя для отображения другим участникам
+func displayName(user *models.User) string {
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if name == "" {
+		return user.Username
+	}
+
+	return name
+}
+
+// truncateTitle обрезает название записи до допустимых 100 символов
+func truncateTitle(title string) string {
+	runes := []rune(title)
+	if len(runes) > 100 {
+		return string(runes[:100])
+	}
+
+	return title
+}
+
+// toCents переводит сумму в копейки, чтобы делить ее без ошибок округления
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// fromCents переводит копейки обратно в сумму
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// splitAmount рассчитывает доли участников в трате. Копейки, оставшиеся после деления,
+// распределяются по одной между первыми участниками, чтобы сумма долей совпадала с тратой
+func splitAmount(method models.SplitMethod, amount float64, requested []models.SplitShareRequest, participants []models.SplitParticipant) ([]models.SplitShare, error) {
+	total := toCents(amount)
+
+	// Для деления поровну без списка долей трата делится на всех участников
+	if method == models.SplitEqual && len(requested) == 0 {
+		for _, participant := range participants {
+			requested = append(requested, models.SplitShareRequest{ParticipantID: participant.ID})
+		}
+	}
+
+	if len(requested) == 0 {
+		return nil, errors.New("укажите доли участников")
+	}
+
+	seen := make(map[int64]bool)
+	for _, share := range requested {
+		if findParticipant(participants, share.ParticipantID) == nil {
+			return nil, errors.New("участник не найден в группе")
+		}
+		if seen[share.ParticipantID] {
+			return nil, errors.New("участник указан несколько раз")
+		}
+		seen[share.ParticipantID] = true
+	}
+
+	cents := make([]int64, len(requested))
+	switch method {
+	case models.SplitEqual:
+		count := int64(len(requested))
+		for i := range cents {
+			cents[i] = total / count
+		}
+		distributeRemainder(cents, total)
+	case models.SplitShares:
+		weights := 0.0
+		for _, share := range requested {
+			if share.Value <= 0 {
+				return nil, errors.New("доля участника должна быть больше нуля")
+			}
+			weights += share.Value
+		}
+		for i, share := range requested {
+			cents[i] = int64(math.Floor(float64(total) * share.Value / weights))
+		}
+		distributeRemainder(cents, total)
+	case models.SplitExact:
+		var sum int64
+		for i, share := range requested {
+			cents[i] = toCents(share.Value)
+			sum += cents[i]
+		}
+		if sum != total {
+			return nil, fmt.Errorf("сумма долей (%.2f) не совпадает с суммой траты (%.2f)", fromCents(sum), fromCents(total))
+		}
+	default:
+		return nil, errors.New("неизвестный способ разделения")
+	}
+
+	shares := make([]models.SplitShare, len(requested))
+	for i, share := range requested {
+		shares[i] = models.SplitShare{
+			ParticipantID: share.ParticipantID,
+			Value:         share.Value,
+			Amount:        fromCents(cents[i]),
+		}
+	}
+
+	return shares, nil
+}
+
+// distributeRemainder раздает недостающие до total копейки по одной первым долям
+func distributeRemainder(cents []int64, total int64) {
+	var sum int64
+	for _, c := range cents {
+		sum += c
+	}
+
+	for i := 0; sum < total; i = (i + 1) % len(cents) {
+		cents[i]++
+		sum++
+	}
+}
+
+// calculateSplitBalances рассчитывает, сколько каждый участник заплатил и сколько должен.
+// Погашение увеличивает «заплачено» плательщика и «должен» получателя
+func calculateSplitBalances(participants []models.SplitParticipant, expenses []models.SplitExpense, settlements []models.SplitSettlement) []models.SplitBalance {
+	paid := make(map[int64]int64)
+	owed := make(map[int64]int64)
+
+	for _, expense := range expenses {
+		paid[expense.PaidBy] += toCents(expense.Amount)
+		for _, share := range expense.Shares {
+			owed[share.ParticipantID] += toCents(share.Amount)
+		}
+	}
+
+	for _, settlement := range settlements {
+		paid[settlement.FromParticipant] += toCents(settlement.Amount)
+		owed[settlement.ToParticipant] += toCents(settlement.Amount)
+	}
+
+	balances := make([]models.SplitBalance, 0, len(participants))
+	for _, participant := range participants {
+		balances = append(balances, models.SplitBalance{
+			ParticipantID: participant.ID,
+			Name:          participant.Name,
+			UserID:        participant.UserID,
+			Paid:          fromCents(paid[participant.ID]),
+			Owed:          fromCents(owed[participant.ID]),
+			Balance:       fromCents(paid[participant.ID] - owed[participant.ID]),
+		})
+	}
+
+	return balances
+}
+
+// simplifySplitDebts сводит сальдо участников к минимальному набору переводов:
+// крупнейший должник платит крупнейшему кредитору, пока все сальдо не обнулятся
+func simplifySplitDebts(balances []models.SplitBalance) []models.SplitDebt {
+	type position struct {
+		balance *models.SplitBalance
+		cents   int64
+	}
+
+	var debtors, creditors []position
+	for i := range balances {
+		cents := toCents(balances[i].Balance)
+		if cents < 0 {
+			debtors = append(debtors, position{&balances[i], -cents})
+		} else if cents > 0 {
+			creditors = append(creditors, position{&balances[i], cents})
+		}
+	}
+
+	sort.SliceStable(debtors, func(i, j int) bool { return debtors[i].cents > debtors[j].cents })
+	sort.SliceStable(creditors, func(i, j int) bool { return creditors[i].cents > creditors[j].cents })
+
+	debts := []models.SplitDebt{}
+	for d, c := 0, 0; d < len(debtors) && c < len(creditors); {
+		amount := debtors[d].cents
+		if creditors[c].cents < amount {
+			amount = creditors[c].cents
+		}
+
+		debts = append(debts, models.SplitDebt{
+			FromParticipant: debtors[d].balance.ParticipantID,
+			FromName:        debtors[d].balance.Name,
+			ToParticipant:   creditors[c].balance.ParticipantID,
+			ToName:          creditors[c].balance.Name,
+			Amount:          fromCents(amount),
+		})
+
+		debtors[d].cents -= amount
+		creditors[c].cents -= amount
+		if debtors[d].cents == 0 {
+			d++
+		}
+		if creditors[c].cents == 0 {
+			c++
+		}
+	}
+
+	return debts
+}
+
+// pairwiseSplitDebts рассчитывает долги без упрощения: каждый участник траты должен ее плательщику,
+// встречные долги одной пары взаимно зачитываются
+func pairwiseSplitDebts(participants []models.SplitParticipant, expenses []models.SplitExpense, settlements []models.SplitSettlement) []models.SplitDebt {
+	type pair struct{ from, to int64 }
+	amounts := make(map[pair]int64)
+
+	for _, expense := range expenses {
+		for _, share := range expense.Shares {
+			if share.ParticipantID != expense.PaidBy {
+				amounts[pair{share.ParticipantID, expense.PaidBy}] += toCents(share.Amount)
+			}
+		}
+	}
+
+	for _, settlement := range settlements {
+		amounts[pair{settlement.FromParticipant, settlement.ToParticipant}] -= toCents(settlement.Amount)
+	}
+
+	debts := []models.SplitDebt{}
+	for i, from := range participants {
+		for _, to := range participants[i+1:] {
+			net := amounts[pair{from.ID, to.ID}] - amounts[pair{to.ID, from.ID}]
+			switch {
+			case net > 0:
+				debts = append(debts, models.SplitDebt{
+					FromParticipant: from.ID, FromName: from.Name,
+					ToParticipant: to.ID, ToName: to.Name,
+					Amount: fromCents(net),
+				})
+			case net < 0:
+				debts = append(debts, models.SplitDebt{
+					FromParticipant: to.ID, FromName: to.Name,
+					ToParticipant: from.ID, ToName: from.Name,
+					Amount: fromCents(-net),
+				})
+			}
+		}
+	}
+
+	return debts
+}
+
+// isParticipantUser проверяет, что участник группы — указанный зарегистрированный пользователь
+func isParticipantUser(participant *models.SplitParticipant, userID int64) bool {
+	return participant.UserID != nil && *participant.UserID == userID
+}
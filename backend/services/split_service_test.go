@@ -0,0 +1,203 @@
+package services
+
+import (
+	"testing"
+
+	"cz.Finance/backend/models"
+)
+
+func TestSplitAmount(t *testing.T) {
+	participants := []models.SplitParticipant{
+		{ID: 1, Name: "Анна"},
+		{ID: 2, Name: "Борис"},
+		{ID: 3, Name: "Вера"},
+	}
+
+	tests := []struct {
+		name      string
+		method    models.SplitMethod
+		amount    float64
+		requested []models.SplitShareRequest
+		want      []float64
+		wantErr   bool
+	}{
+		{
+			name:   "поровну на всех с остатком",
+			method: models.SplitEqual,
+			amount: 100,
+			want:   []float64{33.34, 33.33, 33.33},
+		},
+		{
+			name:   "поровну на всех копейки",
+			method: models.SplitEqual,
+			amount: 0.05,
+			want:   []float64{0.02, 0.02, 0.01},
+		},
+		{
+			name:      "поровну на выбранных",
+			method:    models.SplitEqual,
+			amount:    10.01,
+			requested: []models.SplitShareRequest{{ParticipantID: 2}, {ParticipantID: 3}},
+			want:      []float64{5.01, 5},
+		},
+		{
+			name:      "по долям с остатком",
+			method:    models.SplitShares,
+			amount:    10,
+			requested: []models.SplitShareRequest{{ParticipantID: 1, Value: 1}, {ParticipantID: 2, Value: 2}},
+			want:      []float64{3.34, 6.66},
+		},
+		{
+			name:   "по долям три участника",
+			method: models.SplitShares,
+			amount: 100,
+			requested: []models.SplitShareRequest{
+				{ParticipantID: 1, Value: 1}, {ParticipantID: 2, Value: 1}, {ParticipantID: 3, Value: 1},
+			},
+			want: []float64{33.34, 33.33, 33.33},
+		},
+		{
+			name:      "точные суммы",
+			method:    models.SplitExact,
+			amount:    150.5,
+			requested: []models.SplitShareRequest{{ParticipantID: 1, Value: 100.25}, {ParticipantID: 3, Value: 50.25}},
+			want:      []float64{100.25, 50.25},
+		},
+		{
+			name:      "точные суммы не сходятся",
+			method:    models.SplitExact,
+			amount:    150,
+			requested: []models.SplitShareRequest{{ParticipantID: 1, Value: 100}, {ParticipantID: 2, Value: 49.99}},
+			wantErr:   true,
+		},
+		{
+			name:      "нулевая доля",
+			method:    models.SplitShares,
+			amount:    10,
+			requested: []models.SplitShareRequest{{ParticipantID: 1, Value: 0}, {ParticipantID: 2, Value: 1}},
+			wantErr:   true,
+		},
+		{
+			name:      "участник не из группы",
+			method:    models.SplitEqual,
+			amount:    10,
+			requested: []models.SplitShareRequest{{ParticipantID: 4}},
+			wantErr:   true,
+		},
+		{
+			name:      "участник указан дважды",
+			method:    models.SplitEqual,
+			amount:    10,
+			requested: []models.SplitShareRequest{{ParticipantID: 1}, {ParticipantID: 1}},
+			wantErr:   true,
+		},
+		{
+			name:      "неизвестный способ",
+			method:    models.SplitMethod("percent"),
+			amount:    10,
+			requested: []models.SplitShareRequest{{ParticipantID: 1, Value: 50}, {ParticipantID: 2, Value: 50}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := splitAmount(tt.method, tt.amount, tt.requested, participants)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получены доли %+v", shares)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if len(shares) != len(tt.want) {
+				t.Fatalf("получено %d долей, ожидалось %d", len(shares), len(tt.want))
+			}
+
+			var sum int64
+			for i, share := range shares {
+				if share.Amount != tt.want[i] {
+					t.Errorf("доля %d: получено %.2f, ожидалось %.2f", i, share.Amount, tt.want[i])
+				}
+				sum += toCents(share.Amount)
+			}
+			if sum != toCents(tt.amount) {
+				t.Errorf("сумма долей %.2f не совпадает с тратой %.2f", fromCents(sum), tt.amount)
+			}
+		})
+	}
+}
+
+func TestSimplifySplitDebts(t *testing.T) {
+	tests := []struct {
+		name     string
+		balances []models.SplitBalance
+		want     []models.SplitDebt
+	}{
+		{
+			name: "все в расчете",
+			balances: []models.SplitBalance{
+				{ParticipantID: 1, Balance: 0},
+				{ParticipantID: 2, Balance: 0},
+			},
+			want: []models.SplitDebt{},
+		},
+		{
+			name: "один кредитор",
+			balances: []models.SplitBalance{
+				{ParticipantID: 1, Balance: 30},
+				{ParticipantID: 2, Balance: -20},
+				{ParticipantID: 3, Balance: -10},
+			},
+			want: []models.SplitDebt{
+				{FromParticipant: 2, ToParticipant: 1, Amount: 20},
+				{FromParticipant: 3, ToParticipant: 1, Amount: 10},
+			},
+		},
+		{
+			name: "крупнейший должник платит крупнейшему кредитору",
+			balances: []models.SplitBalance{
+				{ParticipantID: 1, Balance: 50},
+				{ParticipantID: 2, Balance: 10},
+				{ParticipantID: 3, Balance: -40},
+				{ParticipantID: 4, Balance: -20},
+			},
+			want: []models.SplitDebt{
+				{FromParticipant: 3, ToParticipant: 1, Amount: 40},
+				{FromParticipant: 4, ToParticipant: 1, Amount: 10},
+				{FromParticipant: 4, ToParticipant: 2, Amount: 10},
+			},
+		},
+		{
+			name: "копейки после деления",
+			balances: []models.SplitBalance{
+				{ParticipantID: 1, Balance: 66.67},
+				{ParticipantID: 2, Balance: -33.34},
+				{ParticipantID: 3, Balance: -33.33},
+			},
+			want: []models.SplitDebt{
+				{FromParticipant: 2, ToParticipant: 1, Amount: 33.34},
+				{FromParticipant: 3, ToParticipant: 1, Amount: 33.33},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debts := simplifySplitDebts(tt.balances)
+			if len(debts) != len(tt.want) {
+				t.Fatalf("получено %d переводов, ожидалось %d: %+v", len(debts), len(tt.want), debts)
+			}
+			for i, debt := range debts {
+				want := tt.want[i]
+				if debt.FromParticipant != want.FromParticipant || debt.ToParticipant != want.ToParticipant || debt.Amount != want.Amount {
+					t.Errorf("перевод %d: получено %d -> %d %.2f, ожидалось %d -> %d %.2f", i,
+						debt.FromParticipant, debt.ToParticipant, debt.Amount,
+						want.FromParticipant, want.ToParticipant, want.Amount)
+				}
+			}
+		})
+	}
+}
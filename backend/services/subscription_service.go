@@ -0,0 +1,497 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+)
+
+const (
+	// subscriptionAmountTolerance задает допустимое отклонение суммы списания от медианной
+	subscriptionAmountTolerance = 0.2
+	// subscriptionRegularShare задает долю интервалов, которые должны соответствовать периодичности
+	subscriptionRegularShare = 0.75
+	// subscriptionPriceIncreaseThreshold задает минимальный рост суммы, считающийся подорожанием
+	subscriptionPriceIncreaseThreshold = 0.01
+	// subscriptionPriceIncreaseWindow задает срок, в течение которого подорожание считается новым
+	subscriptionPriceIncreaseWindow = 365 * 24 * time.Hour
+)
+
+// subscriptionCadence описывает допустимый интервал между списаниями для периодичности
+type subscriptionCadence struct {
+	cadence        models.RecurringCadence
+	minDays        float64
+	maxDays        float64
+	perYear        float64
+	minOccurrences int
+}
+
+// subscriptionCadences перечисляет распознаваемые периодичности списаний
+var subscriptionCadences = []subscriptionCadence{
+	{cadence: models.CadenceWeekly, minDays: 6, maxDays: 8, perYear: 52, minOccurrences: 3},
+	{cadence: models.CadenceMonthly, minDays: 26, maxDays: 35, perYear: 12, minOccurrences: 3},
+	{cadence: models.CadenceQuarterly, minDays: 84, maxDays: 98, perYear: 4, minOccurrences: 3},
+	{cadence: models.CadenceYearly, minDays: 350, maxDays: 380, perYear: 1, minOccurrences: 2},
+}
+
+// SubscriptionServiceImpl представляет реализацию сервиса поиска подписок и регулярных списаний
+type SubscriptionServiceImpl struct {
+	recurringRepo repositories.RecurringRuleRepository
+	expenseRepo   repositories.ExpenseRepository
+	payeeRepo     repositories.PayeeRepository
+	userRepo      repositories.UserRepository
+}
+
+// NewSubscriptionService создает новый экземпляр сервиса подписок
+func NewSubscriptionService(recurringRepo repositories.RecurringRuleRepository, expenseRepo repositories.ExpenseRepository, payeeRepo repositories.PayeeRepository, userRepo repositories.UserRepository) SubscriptionService {
+	return &SubscriptionServiceImpl{
+		recurringRepo: recurringRepo,
+		expenseRepo:   expenseRepo,
+		payeeRepo:     payeeRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// DetectSubscriptions ищет в истории трат регулярные списания одному получателю
+// с похожей суммой и отмечает подтвержденные подписки и подорожания
+func (s *SubscriptionServiceImpl) DetectSubscriptions(ctx context.Context, userID int64) (*models.SubscriptionReport, error) {
+	// Проверяем существование пользователя
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	subscriptions, err := s.detect(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SubscriptionReport{Subscriptions: subscriptions}
+	for _, subscription := range subscriptions {
+		if subscription.PriceIncrease != nil {
+			report.PriceIncreases++
+		}
+		if subscription.Active {
+			report.AnnualCost += subscription.AnnualCost
+		}
+	}
+	report.AnnualCost = roundMoney(report.AnnualCost)
+	report.MonthlyCost = roundMoney(report.AnnualCost / 12)
+
+	return report, nil
+}
+
+// ConfirmSubscription сохраняет найденную подписку как регулярное списание
+func (s *SubscriptionServiceImpl) ConfirmSubscription(ctx context.Context, userID int64, request *models.ConfirmSubscriptionRequest) (*models.RecurringRule, error) {
+	if request.Category != "" && !isKnownCategory(request.Category) {
+		return nil, errors.New("неизвестная категория трат")
+	}
+
+	subscriptions, err := s.detect(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var detected *models.DetectedSubscription
+	for i := range subscriptions {
+		if subscriptions[i].Key == request.Key {
+			detected = &subscriptions[i]
+			break
+		}
+	}
+	if detected == nil {
+		return nil, errors.New("подписка не найдена среди регулярных списаний")
+	}
+	if detected.RecurringRuleID != nil {
+		return nil, errors.New("подписка уже подтверждена")
+	}
+
+	rule := &models.RecurringRule{
+		UserID:    userID,
+		MatchKey:  detected.Key,
+		Title:     detected.Title,
+		PayeeID:   detected.PayeeID,
+		Category:  detected.Category,
+		Amount:    detected.Amount,
+		Cadence:   detected.Cadence,
+		NextDate:  detected.NextDate,
+		Active:    true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if request.Title != "" {
+		rule.Title = request.Title
+	}
+	if request.Amount != nil {
+		rule.Amount = roundMoney(*request.Amount)
+	}
+	if request.Category != "" {
+		rule.Category = request.Category
+	}
+
+	rule.ID, err = s.recurringRepo.Create(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetRecurringRule получает регулярное списание пользователя по ID
+func (s *SubscriptionServiceImpl) GetRecurringRule(ctx context.Context, id int64, userID int64) (*models.RecurringRule, error) {
+	rule, err := s.recurringRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.UserID != userID {
+		return nil, errors.New("регулярное списание не принадлежит пользователю")
+	}
+
+	return rule, nil
+}
+
+// GetRecurringRules получает регулярные списания пользователя
+func (s *SubscriptionServiceImpl) GetRecurringRules(ctx context.Context, userID int64) ([]models.RecurringRule, error) {
+	rules, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении регулярных списаний")
+	}
+	if rules == nil {
+		rules = []models.RecurringRule{}
+	}
+
+	return rules, nil
+}
+
+// UpdateRecurringRule обновляет регулярное списание
+func (s *SubscriptionServiceImpl) UpdateRecurringRule(ctx context.Context, id int64, userID int64, request *models.UpdateRecurringRuleRequest) (*models.RecurringRule, error) {
+	rule, err := s.GetRecurringRule(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Обновляем поля, если они указаны в запросе
+	if request.Title != nil {
+		rule.Title = *request.Title
+	}
+	if request.Amount != nil {
+		rule.Amount = roundMoney(*request.Amount)
+	}
+	if request.Category != nil {
+		if !isKnownCategory(*request.Category) {
+			return nil, errors.New("неизвестная категория трат")
+		}
+		rule.Category = *request.Category
+	}
+	if request.Cadence != nil {
+		rule.Cadence = *request.Cadence
+	}
+	if request.NextDate != nil {
+		rule.NextDate = *request.NextDate
+	}
+	if request.Active != nil {
+		rule.Active = *request.Active
+	}
+
+	if err := s.recurringRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now()
+
+	return rule, nil
+}
+
+// DeleteRecurringRule удаляет регулярное списание
+func (s *SubscriptionServiceImpl) DeleteRecurringRule(ctx context.Context, id int64, userID int64) error {
+	return s.recurringRepo.Delete(ctx, id, userID)
+}
+
+// detect находит подписки пользователя и связывает их с подтвержденными регулярными списаниями
+func (s *SubscriptionServiceImpl) detect(ctx context.Context, userID int64) ([]models.DetectedSubscription, error) {
+	expenses, err := s.expenseRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат")
+	}
+
+	payees, err := s.payeeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении получателей")
+	}
+	payeeNames := make(map[int64]string, len(payees))
+	for _, payee := range payees {
+		payeeNames[payee.ID] = payee.Name
+	}
+
+	rules, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении регулярных списаний")
+	}
+	rulesByKey := make(map[string]*models.RecurringRule, len(rules))
+	for i := range rules {
+		rulesByKey[rules[i].MatchKey] = &rules[i]
+	}
+
+	subscriptions := detectSubscriptions(expenses, time.Now())
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		if subscription.PayeeID != nil {
+			if name, ok := payeeNames[*subscription.PayeeID]; ok {
+				subscription.Title = name
+			}
+		}
+
+		rule, ok := rulesByKey[subscription.Key]
+		if !ok {
+			continue
+		}
+		subscription.RecurringRuleID = &rule.ID
+		subscription.Title = rule.Title
+
+		// Для подтвержденной подписки подорожание считается от подтвержденной суммы
+		subscription.PriceIncrease = nil
+		if subscription.Amount > rule.Amount*(1+subscriptionPriceIncreaseThreshold) {
+			subscription.PriceIncrease = newSubscriptionPriceChange(rule.Amount, subscription.Amount, subscription.LastDate)
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// detectSubscriptions группирует траты по получателю или нормализованному названию и ищет
+// в группах списания с похожей суммой через равные промежутки времени.
+// Траты должны быть упорядочены по дате
+func detectSubscriptions(expenses []models.Expense, now time.Time) []models.DetectedSubscription {
+	groups := make(map[string][]models.Expense)
+	var keys []string
+	for _, expense := range expenses {
+		key := subscriptionKey(&expense)
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], expense)
+	}
+
+	subscriptions := make([]models.DetectedSubscription, 0)
+	for _, key := range keys {
+		if subscription := detectSubscription(key, groups[key], now); subscription != nil {
+			subscriptions = append(subscriptions, *subscription)
+		}
+	}
+
+	// Сначала самые дорогие активные подписки
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		if subscriptions[i].Active != subscriptions[j].Active {
+			return subscriptions[i].Active
+		}
+		return subscriptions[i].AnnualCost > subscriptions[j].AnnualCost
+	})
+
+	return subscriptions
+}
+
+// detectSubscription проверяет, образуют ли траты одной группы регулярные списания
+func detectSubscription(key string, expenses []models.Expense, now time.Time) *models.DetectedSubscription {
+	if len(expenses) < 2 {
+		return nil
+	}
+
+	// Оставляем списания с суммой, близкой к медианной
+	amounts := make([]float64, 0, len(expenses))
+	for _, expense := range expenses {
+		amounts = append(amounts, expense.Amount)
+	}
+	median := medianValue(amounts)
+
+	charges := make([]models.Expense, 0, len(expenses))
+	for _, expense := range expenses {
+		if median > 0 && math.Abs(expense.Amount-median)/median <= subscriptionAmountTolerance {
+			charges = append(charges, expense)
+		}
+	}
+	if len(charges) < 2 {
+		return nil
+	}
+
+	// Определяем периодичность по медианному интервалу между списаниями
+	intervals := make([]float64, 0, len(charges)-1)
+	for i := 1; i < len(charges); i++ {
+		intervals = append(intervals, charges[i].Date.Sub(charges[i-1].Date).Hours()/24)
+	}
+	interval := medianValue(intervals)
+
+	var cadence *subscriptionCadence
+	for i := range subscriptionCadences {
+		if interval >= subscriptionCadences[i].minDays && interval <= subscriptionCadences[i].maxDays {
+			cadence = &subscriptionCadences[i]
+			break
+		}
+	}
+	if cadence == nil || len(charges) < cadence.minOccurrences {
+		return nil
+	}
+
+	// Большинство интервалов должно соответствовать периодичности
+	regular := 0
+	for _, value := range intervals {
+		if value >= cadence.minDays && value <= cadence.maxDays {
+			regular++
+		}
+	}
+	if float64(regular) < float64(len(intervals))*subscriptionRegularShare {
+		return nil
+	}
+
+	// Периодичность определена по похожим суммам, но после подорожания сумма может выйти
+	// за допуск. Поэтому последовательность списаний восстанавливается по всем тратам группы
+	charges = scheduledCharges(expenses, charges, cadence)
+
+	first := charges[0]
+	last := charges[len(charges)-1]
+
+	total := 0.0
+	expenseIDs := make([]int64, 0, len(charges))
+	for _, charge := range charges {
+		total += charge.Amount
+		expenseIDs = append(expenseIDs, charge.ID)
+	}
+
+	subscription := &models.DetectedSubscription{
+		Key:           key,
+		Title:         last.Title,
+		PayeeID:       last.PayeeID,
+		Category:      last.Category,
+		Cadence:       cadence.cadence,
+		IntervalDays:  roundMoney(interval),
+		Amount:        last.Amount,
+		AverageAmount: roundMoney(total / float64(len(charges))),
+		AnnualCost:    roundMoney(last.Amount * cadence.perYear),
+		Occurrences:   len(charges),
+		FirstDate:     first.Date,
+		LastDate:      last.Date,
+		NextDate:      nextRecurringDate(last.Date, cadence.cadence),
+		Active:        now.Sub(last.Date).Hours()/24 <= 2*cadence.maxDays,
+		ExpenseIDs:    expenseIDs,
+	}
+
+	// Ищем последнее изменение суммы: если это рост за последний год, отмечаем подорожание
+	for i := len(charges) - 1; i > 0; i-- {
+		previous := charges[i-1].Amount
+		if math.Abs(charges[i].Amount-previous) <= previous*subscriptionPriceIncreaseThreshold {
+			continue
+		}
+		if charges[i].Amount > previous && now.Sub(charges[i].Date) <= subscriptionPriceIncreaseWindow {
+			subscription.PriceIncrease = newSubscriptionPriceChange(previous, last.Amount, charges[i].Date)
+		}
+		break
+	}
+
+	return subscription
+}
+
+// scheduledCharges дополняет списания с похожей суммой остальными тратами группы, которые
+// приходятся на график: промежуток до предыдущего списания соответствует периодичности.
+// Траты должны быть упорядочены по дате
+func scheduledCharges(expenses, charges []models.Expense, cadence *subscriptionCadence) []models.Expense {
+	matched := make(map[int64]bool, len(charges))
+	for _, charge := range charges {
+		matched[charge.ID] = true
+	}
+	onSchedule := func(from, to time.Time) bool {
+		days := to.Sub(from).Hours() / 24
+		return days >= cadence.minDays && days <= cadence.maxDays
+	}
+
+	start := 0
+	for start < len(expenses) && !matched[expenses[start].ID] {
+		start++
+	}
+	if start == len(expenses) {
+		return charges
+	}
+
+	// Более ранние траты добавляются, только если идут по графику до первого списания
+	var earlier []models.Expense
+	for i, next := start-1, expenses[start].Date; i >= 0; i-- {
+		if onSchedule(expenses[i].Date, next) {
+			earlier = append(earlier, expenses[i])
+			next = expenses[i].Date
+		}
+	}
+
+	sequence := make([]models.Expense, 0, len(earlier)+len(expenses)-start)
+	for i := len(earlier) - 1; i >= 0; i-- {
+		sequence = append(sequence, earlier[i])
+	}
+	sequence = append(sequence, expenses[start])
+	for _, expense := range expenses[start+1:] {
+		if matched[expense.ID] || onSchedule(sequence[len(sequence)-1].Date, expense.Date) {
+			sequence = append(sequence, expense)
+		}
+	}
+
+	return sequence
+}
+
+// subscriptionKey возвращает ключ группы трат: получатель или нормализованное название
+func subscriptionKey(expense *models.Expense) string {
+	if expense.PayeeID != nil {
+		return fmt.Sprintf("payee:%d", *expense.PayeeID)
+	}
+
+	key := normalizePayeeKey(expense.Title)
+	if key == "" {
+		return ""
+	}
+	return "title:" + key
+}
+
+// nextRecurringDate рассчитывает дату следующего списания
+func nextRecurringDate(date time.Time, cadence models.RecurringCadence) time.Time {
+	switch cadence {
+	case models.CadenceWeekly:
+		return date.AddDate(0, 0, 7)
+	case models.CadenceQuarterly:
+		return date.AddDate(0, 3, 0)
+	case models.CadenceYearly:
+		return date.AddDate(1, 0, 0)
+	default:
+		return date.AddDate(0, 1, 0)
+	}
+}
+
+// newSubscriptionPriceChange описывает рост суммы подписки
+func newSubscriptionPriceChange(previous, current float64, changedAt time.Time) *models.SubscriptionPriceChange {
+	return &models.SubscriptionPriceChange{
+		PreviousAmount: previous,
+		CurrentAmount:  current,
+		Change:         roundMoney(current - previous),
+		ChangePercent:  roundMoney(calculatePercentage(current-previous, previous)),
+		ChangedAt:      changedAt,
+	}
+}
+
+// medianValue возвращает медиану значений
+func medianValue(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
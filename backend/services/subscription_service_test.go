@@ -0,0 +1,199 @@
+package services
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestDetectSubscriptions(t *testing.T) {
+	now := time.Date(2024, time.June, 20, 12, 0, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	payeeID := int64(7)
+
+	var expenses []models.Expense
+	add := func(title string, amount float64, day time.Time, payee *int64) {
+		expenses = append(expenses, models.Expense{
+			ID: int64(len(expenses) + 1), Title: title, Amount: amount, Date: day,
+			Category: models.CategoryEntertainment, PayeeID: payee,
+		})
+	}
+
+	// Ежемесячная подписка с подорожанием в мае и разовой покупкой у того же сервиса
+	for month := time.January; month <= time.April; month++ {
+		add("NETFLIX.COM 1234", 299, date(2024, month, 15), nil)
+	}
+	add("NETFLIX.COM 5678", 329, date(2024, time.May, 15), nil)
+	add("Netflix.com", 2990, date(2024, time.June, 1), nil)
+	add("NETFLIX.COM 9012", 329, date(2024, time.June, 15), nil)
+
+	// Подорожание больше допуска по сумме не должно обрывать подписку
+	for month := time.January; month <= time.April; month++ {
+		add("Кинопоиск", 299, date(2024, month, 10), nil)
+	}
+	add("Кинопоиск", 399, date(2024, time.May, 10), nil)
+	add("Кинопоиск", 399, date(2024, time.June, 10), nil)
+
+	// Один получатель с разными названиями в выписке
+	add("СБЕРПРАЙМ", 199, date(2024, time.March, 1), &payeeID)
+	add("СберПрайм подписка", 199, date(2024, time.April, 1), &payeeID)
+	add("СБЕРПРАЙМ", 199, date(2024, time.May, 1), &payeeID)
+	add("СБЕРПРАЙМ", 199, date(2024, time.June, 1), &payeeID)
+
+	// Еженедельные занятия, которые закончились больше месяца назад
+	for day := 1; day <= 22; day += 7 {
+		add("Фитнес", 500, date(2024, time.May, day), nil)
+	}
+
+	// Ежегодное продление домена
+	add("Домен example.ru", 1200, date(2023, time.June, 1), nil)
+	add("Домен example.ru", 1200, date(2024, time.June, 1), nil)
+
+	// Нерегулярные траты и единичная трата не являются подписками
+	add("Кафе", 300, date(2024, time.January, 1), nil)
+	add("Кафе", 300, date(2024, time.January, 3), nil)
+	add("Кафе", 300, date(2024, time.February, 20), nil)
+	add("Кафе", 300, date(2024, time.April, 1), nil)
+	add("Театр", 3000, date(2024, time.March, 8), nil)
+
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].Date.Before(expenses[j].Date)
+	})
+
+	tests := []struct {
+		key           string
+		cadence       models.RecurringCadence
+		amount        float64
+		average       float64
+		annual        float64
+		occurrences   int
+		lastDate      time.Time
+		nextDate      time.Time
+		active        bool
+		priceIncrease *models.SubscriptionPriceChange
+	}{
+		{
+			key:         "title:кинопоиск",
+			cadence:     models.CadenceMonthly,
+			amount:      399,
+			average:     332.33,
+			annual:      4788,
+			occurrences: 6,
+			lastDate:    date(2024, time.June, 10),
+			nextDate:    date(2024, time.July, 10),
+			active:      true,
+			priceIncrease: &models.SubscriptionPriceChange{
+				PreviousAmount: 299, CurrentAmount: 399, Change: 100, ChangePercent: 33.44, ChangedAt: date(2024, time.May, 10),
+			},
+		},
+		{
+			key:         "title:netflix com",
+			cadence:     models.CadenceMonthly,
+			amount:      329,
+			average:     309,
+			annual:      3948,
+			occurrences: 6,
+			lastDate:    date(2024, time.June, 15),
+			nextDate:    date(2024, time.July, 15),
+			active:      true,
+			priceIncrease: &models.SubscriptionPriceChange{
+				PreviousAmount: 299, CurrentAmount: 329, Change: 30, ChangePercent: 10.03, ChangedAt: date(2024, time.May, 15),
+			},
+		},
+		{
+			key:         "payee:7",
+			cadence:     models.CadenceMonthly,
+			amount:      199,
+			average:     199,
+			annual:      2388,
+			occurrences: 4,
+			lastDate:    date(2024, time.June, 1),
+			nextDate:    date(2024, time.July, 1),
+			active:      true,
+		},
+		{
+			key:         "title:домен example ru",
+			cadence:     models.CadenceYearly,
+			amount:      1200,
+			average:     1200,
+			annual:      1200,
+			occurrences: 2,
+			lastDate:    date(2024, time.June, 1),
+			nextDate:    date(2025, time.June, 1),
+			active:      true,
+		},
+		{
+			key:         "title:фитнес",
+			cadence:     models.CadenceWeekly,
+			amount:      500,
+			average:     500,
+			annual:      26000,
+			occurrences: 4,
+			lastDate:    date(2024, time.May, 22),
+			nextDate:    date(2024, time.May, 29),
+		},
+	}
+
+	subscriptions := detectSubscriptions(expenses, now)
+	if len(subscriptions) != len(tests) {
+		t.Fatalf("найдено %d подписок, ожидалось %d: %+v", len(subscriptions), len(tests), subscriptions)
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := subscriptions[i]
+			if got.Key != tt.key {
+				t.Fatalf("на позиции %d подписка %q, ожидалась %q", i, got.Key, tt.key)
+			}
+			if got.Cadence != tt.cadence {
+				t.Errorf("периодичность %q, ожидалась %q", got.Cadence, tt.cadence)
+			}
+			if got.Amount != tt.amount || got.AverageAmount != tt.average || got.AnnualCost != tt.annual {
+				t.Errorf("сумма %.2f, средняя %.2f, за год %.2f, ожидалось %.2f, %.2f, %.2f",
+					got.Amount, got.AverageAmount, got.AnnualCost, tt.amount, tt.average, tt.annual)
+			}
+			if got.Occurrences != tt.occurrences || len(got.ExpenseIDs) != tt.occurrences {
+				t.Errorf("списаний %d (ID %v), ожидалось %d", got.Occurrences, got.ExpenseIDs, tt.occurrences)
+			}
+			if !got.LastDate.Equal(tt.lastDate) || !got.NextDate.Equal(tt.nextDate) {
+				t.Errorf("последнее списание %v, следующее %v, ожидалось %v и %v", got.LastDate, got.NextDate, tt.lastDate, tt.nextDate)
+			}
+			if got.Active != tt.active {
+				t.Errorf("признак активности %v, ожидалось %v", got.Active, tt.active)
+			}
+
+			switch {
+			case tt.priceIncrease == nil && got.PriceIncrease != nil:
+				t.Errorf("неожиданное подорожание %+v", *got.PriceIncrease)
+			case tt.priceIncrease != nil && got.PriceIncrease == nil:
+				t.Errorf("подорожание не найдено, ожидалось %+v", *tt.priceIncrease)
+			case tt.priceIncrease != nil && *got.PriceIncrease != *tt.priceIncrease:
+				t.Errorf("подорожание %+v, ожидалось %+v", *got.PriceIncrease, *tt.priceIncrease)
+			}
+		})
+	}
+}
+
+func TestMedianValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{name: "пусто", want: 0},
+		{name: "нечетное количество", values: []float64{30, 10, 20}, want: 20},
+		{name: "четное количество", values: []float64{31, 28, 30, 29}, want: 29.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := medianValue(tt.values); got != tt.want {
+				t.Errorf("получено %g, ожидалось %g", got, tt.want)
+			}
+		})
+	}
+}
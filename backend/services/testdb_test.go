@@ -0,0 +1,85 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"cz.Finance/backend/database"
+)
+
+// openTestDB подключается к тестовой базе из TEST_DATABASE_URL и применяет миграции.
+// Без переменной окружения тест или бенчмарк пропускается
+func openTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL не задан, тесты с базой данных пропущены")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatalf("не удалось открыть тестовую базу: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	if err := database.CheckDatabaseConnection(db); err != nil {
+		tb.Fatal(err)
+	}
+	if err := database.RunMigrations(db); err != nil {
+		tb.Fatal(err)
+	}
+
+	return db
+}
+
+// seedTestUser создает пользователя с тратами и накоплениями, равномерно распределенными
+// по двум годам начиная с from. Пользователь и его записи удаляются после теста
+func seedTestUser(tb testing.TB, db *sql.DB, from time.Time, expenses, incomes int) int64 {
+	tb.Helper()
+
+	suffix := time.Now().UnixNano()
+	var userID int64
+	err := db.QueryRow(`
+		INSERT INTO users (email, username, password_hash, monthly_limit, timezone)
+		VALUES ($1, $2, 'hash', 50000, 'UTC')
+		RETURNING id
+	`, fmt.Sprintf("bench%d@example.com", suffix), fmt.Sprintf("bench%d", suffix)).Scan(&userID)
+	if err != nil {
+		tb.Fatalf("не удалось создать пользователя: %v", err)
+	}
+	tb.Cleanup(func() {
+		db.Exec(`DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	_, err = db.Exec(`
+		INSERT INTO expenses (user_id, title, amount, category, date)
+		SELECT $1, 'Трата ' || g, (g % 500) + 1,
+		       (ARRAY['food', 'transport', 'housing', 'shopping', 'other'])[1 + g % 5],
+		       $2::timestamptz + (g % 730) * INTERVAL '1 day' + (g % 24) * INTERVAL '1 hour'
+		FROM generate_series(1, $3) AS g
+	`, userID, from, expenses)
+	if err != nil {
+		tb.Fatalf("не удалось заполнить траты: %v", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO incomes (user_id, amount, source, date)
+		SELECT $1, (g % 5000) + 1000,
+		       (ARRAY['salary', 'freelance', 'other'])[1 + g % 3],
+		       $2::timestamptz + (g % 730) * INTERVAL '1 day'
+		FROM generate_series(1, $3) AS g
+	`, userID, from, incomes)
+	if err != nil {
+		tb.Fatalf("не удалось заполнить накопления: %v", err)
+	}
+
+	if _, err := db.Exec(`ANALYZE expenses; ANALYZE incomes`); err != nil {
+		tb.Fatalf("не удалось обновить статистику: %v", err)
+	}
+
+	return userID
+}
@@ -50,6 +50,15 @@ func (s *UserServiceImpl) SignUp(ctx context.Context, signup *models.UserSignup)
 		return nil, errors.New("ошибка при хешировании пароля")
 	}
 
+	// Часовой пояс можно указать при регистрации, иначе используется UTC.
+	// Валидатор пропускает "Local", поэтому пояс проверяется и здесь
+	if signup.Timezone == "" {
+		signup.Timezone = models.DefaultTimezone
+	}
+	if _, err := models.LoadTimezone(signup.Timezone); err != nil {
+		return nil, err
+	}
+
 	// Создаем нового пользователя
 	user := &models.User{
 		Email:        signup.Email,
@@ -59,6 +68,7 @@ func (s *UserServiceImpl) SignUp(ctx context.Context, signup *models.UserSignup)
 		LastName:     signup.LastName,
 		MonthlyLimit: 0,
 		SavingsGoal:  0,
+		Timezone:     signup.Timezone,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -193,6 +203,13 @@ func (s *UserServiceImpl) UpdateUser(ctx context.Context, id int64, updateReques
 	if updateRequest.SavingsGoal != nil {
 		user.SavingsGoal = *updateRequest.SavingsGoal
 	}
+	if updateRequest.Timezone != nil {
+		// omitempty пропускает пустую строку, поэтому пояс проверяется и здесь
+		if _, err := models.LoadTimezone(*updateRequest.Timezone); err != nil {
+			return nil, err
+		}
+		user.Timezone = *updateRequest.Timezone
+	}
 
 	// Обновляем время изменения
 	user.UpdatedAt = time.Now()
@@ -3,23 +3,49 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"cz.Finance/backend/models"
 	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
 )
 
 // WishlistServiceImpl реализация сервиса для работы со списком желаний
 type WishlistServiceImpl struct {
 	wishlistRepo repositories.WishlistRepository
 	userRepo     repositories.UserRepository
+	expenseRepo  repositories.ExpenseRepository
+	incomeRepo   repositories.IncomeRepository
+	goalRepo     repositories.GoalRepository
+	ruleRepo     repositories.RuleRepository
+	payeeRepo    repositories.PayeeRepository
+	notifier     NotificationService
 }
 
 // NewWishlistService создает новый экземпляр сервиса для работы со списком желаний
-func NewWishlistService(wishlistRepo repositories.WishlistRepository, userRepo repositories.UserRepository) WishlistService {
+func NewWishlistService(
+	wishlistRepo repositories.WishlistRepository,
+	userRepo repositories.UserRepository,
+	expenseRepo repositories.ExpenseRepository,
+	incomeRepo repositories.IncomeRepository,
+	goalRepo repositories.GoalRepository,
+	ruleRepo repositories.RuleRepository,
+	payeeRepo repositories.PayeeRepository,
+	notifier NotificationService,
+) WishlistService {
 	return &WishlistServiceImpl{
 		wishlistRepo: wishlistRepo,
 		userRepo:     userRepo,
+		expenseRepo:  expenseRepo,
+		incomeRepo:   incomeRepo,
+		goalRepo:     goalRepo,
+		ruleRepo:     ruleRepo,
+		payeeRepo:    payeeRepo,
+		notifier:     notifier,
 	}
 }
 
@@ -38,6 +64,8 @@ func (s *WishlistServiceImpl) CreateWishlistItem(ctx context.Context, userID int
 		Price:       request.Price,
 		Priority:    request.Priority,
 		Description: request.Description,
+		TargetPrice: request.TargetPrice,
+		Shared:      request.Shared,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -66,15 +94,45 @@ func (s *WishlistServiceImpl) GetWishlistItem(ctx context.Context, id int64, use
 		return nil, errors.New("элемент списка желаний не принадлежит пользователю")
 	}
 
+	// Получаем резервирования под элемент
+	item.Allocations, err = s.wishlistRepo.GetAllocations(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении резервирований")
+	}
+
+	// Получаем историю цен
+	item.PriceHistory, err = s.wishlistRepo.GetPrices(ctx, id)
+	if err != nil {
+		return nil, errors.New("ошибка при получении истории цен")
+	}
+
 	return item, nil
 }
 
-// GetUserWishlist получает список желаний пользователя
-func (s *WishlistServiceImpl) GetUserWishlist(ctx context.Context, userID int64) ([]models.WishlistItem, error) {
-	return s.wishlistRepo.GetByUserID(ctx, userID)
+// GetUserWishlist получает список желаний пользователя с отбором по признаку покупки
+func (s *WishlistServiceImpl) GetUserWishlist(ctx context.Context, userID int64, status models.WishlistStatus) ([]models.WishlistItem, error) {
+	items, err := s.wishlistRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == models.WishlistStatusAll || status == "" {
+		return items, nil
+	}
+
+	filtered := make([]models.WishlistItem, 0, len(items))
+	for _, item := range items {
+		purchased := item.PurchasedAt != nil
+		if purchased == (status == models.WishlistStatusPurchased) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered, nil
 }
 
-// UpdateWishlistItem обновляет элемент списка желаний
+// UpdateWishlistItem обновляет элемент списка желаний. Если после изменения цены или целевой цены
+// цена оказалась на уровне целевой или ниже, пользователю отправляется уведомление в Telegram
 func (s *WishlistServiceImpl) UpdateWishlistItem(ctx context.Context, id int64, userID int64, request *models.UpdateWishlistItemRequest) (*models.WishlistItem, error) {
 	// Получаем текущий элемент
 	item, err := s.GetWishlistItem(ctx, id, userID)
@@ -82,6 +140,10 @@ func (s *WishlistServiceImpl) UpdateWishlistItem(ctx context.Context, id int64,
 		return nil, err
 	}
 
+	// Запоминаем цену и то, была ли целевая цена достигнута до изменения
+	previousPrice := item.Price
+	wasReached := targetPriceReached(item)
+
 	// Обновляем поля, если они предоставлены
 	if request.Title != nil {
 		item.Title = *request.Title
@@ -95,6 +157,17 @@ func (s *WishlistServiceImpl) UpdateWishlistItem(ctx context.Context, id int64,
 	if request.Description != nil {
 		item.Description = *request.Description
 	}
+	if request.Shared != nil {
+		item.Shared = *request.Shared
+	}
+	if request.TargetPrice != nil {
+		// Нулевая целевая цена отключает отслеживание
+		if *request.TargetPrice == 0 {
+			item.TargetPrice = nil
+		} else {
+			item.TargetPrice = request.TargetPrice
+		}
+	}
 
 	// Обновляем время изменения
 	item.UpdatedAt = time.Now()
@@ -105,6 +178,30 @@ func (s *WishlistServiceImpl) UpdateWishlistItem(ctx context.Context, id int64,
 		return nil, err
 	}
 
+	if item.PurchasedAt != nil {
+		return item, nil
+	}
+
+	// Новая цена попадает в историю наравне с записанной через RecordPrice
+	if item.Price != previousPrice {
+		price := &models.WishlistPrice{
+			WishlistID: id,
+			Price:      item.Price,
+			Source:     models.PriceSourceManual,
+			ObservedAt: item.UpdatedAt,
+		}
+		price.ID, err = s.wishlistRepo.AddPrice(ctx, price)
+		if err != nil {
+			return nil, errors.New("ошибка при записи цены")
+		}
+		price.CreatedAt = time.Now()
+		item.PriceHistory = append([]models.WishlistPrice{*price}, item.PriceHistory...)
+	}
+
+	if !wasReached && targetPriceReached(item) {
+		s.notifyTargetPrice(ctx, userID, item, item.Price < previousPrice)
+	}
+
 	return item, nil
 }
 
@@ -112,3 +209,328 @@ func (s *WishlistServiceImpl) UpdateWishlistItem(ctx context.Context, id int64,
 func (s *WishlistServiceImpl) DeleteWishlistItem(ctx context.Context, id int64, userID int64) error {
 	return s.wishlistRepo.Delete(ctx, id, userID)
 }
+
+// PurchaseWishlistItem отмечает элемент списка желаний купленным и создает по нему трату.
+// Купленный элемент остается в списке как история покупок
+func (s *WishlistServiceImpl) PurchaseWishlistItem(ctx context.Context, id int64, userID int64, request *models.PurchaseWishlistItemRequest) (*models.WishlistPurchase, error) {
+	item, err := s.GetWishlistItem(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.PurchasedAt != nil {
+		return nil, errors.New("элемент списка желаний уже куплен")
+	}
+
+	if request.Category != "" && !isKnownCategory(request.Category) {
+		return nil, errors.New("неизвестная категория трат")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+
+	// По умолчанию трата повторяет название и цену элемента
+	expense := &models.Expense{
+		UserID:      userID,
+		Title:       item.Title,
+		Amount:      item.Price,
+		Category:    request.Category,
+		Date:        request.Date,
+		Description: request.Description,
+		Account:     strings.TrimSpace(request.Account),
+		Tags:        normalizeTags(request.Tags),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if request.Title != nil {
+		expense.Title = *request.Title
+	}
+	if request.Amount != nil {
+		expense.Amount = *request.Amount
+	}
+	if expense.Date.IsZero() {
+		expense.Date = time.Now()
+	}
+
+	// Трата проходит те же правила и привязку к получателю, что и созданная вручную.
+	// В транзакции остаются только вставка траты и отметка покупки
+	if err := prepareExpense(ctx, s.ruleRepo, s.payeeRepo, expense, user.Location()); err != nil {
+		return nil, err
+	}
+
+	// Создаем трату и отмечаем покупку в одной транзакции
+	expenseID, err := s.wishlistRepo.Purchase(ctx, item, expense)
+	if err != nil {
+		return nil, err
+	}
+	expense.ID = expenseID
+	expenseClassifiers.invalidate(userID)
+
+	item, err = s.GetWishlistItem(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WishlistPurchase{Item: item, Expense: expense}, nil
+}
+
+// RecordPrice записывает наблюдаемую цену элемента списка желаний. Если последняя цена опустилась
+// до целевой или ниже, пользователю отправляется уведомление в Telegram. Повторно уведомление
+// не отправляется, пока цена остается на уровне целевой
+func (s *WishlistServiceImpl) RecordPrice(ctx context.Context, id int64, userID int64, request *models.RecordWishlistPriceRequest) (*models.WishlistPriceResult, error) {
+	item, err := s.GetWishlistItem(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.PurchasedAt != nil {
+		return nil, errors.New("элемент списка желаний уже куплен")
+	}
+
+	// Запоминаем цену до записи, чтобы уведомлять только при пересечении целевой цены
+	previousPrice := item.Price
+
+	price := &models.WishlistPrice{
+		WishlistID: id,
+		Price:      request.Price,
+		Source:     request.Source,
+		ObservedAt: request.ObservedAt,
+	}
+	if price.Source == "" {
+		price.Source = models.PriceSourceManual
+	}
+	if price.ObservedAt.IsZero() {
+		price.ObservedAt = time.Now()
+	}
+
+	price.ID, err = s.wishlistRepo.AddPrice(ctx, price)
+	if err != nil {
+		return nil, errors.New("ошибка при записи цены")
+	}
+	price.CreatedAt = time.Now()
+
+	item, err = s.GetWishlistItem(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.WishlistPriceResult{Item: item, Price: price}
+	if item.TargetPrice == nil || item.Price > *item.TargetPrice {
+		return result, nil
+	}
+	result.TargetReached = true
+
+	if previousPrice > *item.TargetPrice {
+		result.Notified = s.notifyTargetPrice(ctx, userID, item, true)
+	}
+
+	return result, nil
+}
+
+// targetPriceReached проверяет, что у элемента задана целевая цена и текущая цена не выше нее
+func targetPriceReached(item *models.WishlistItem) bool {
+	return item.TargetPrice != nil && item.Price <= *item.TargetPrice
+}
+
+// notifyTargetPrice отправляет уведомление о достижении целевой цены и сообщает, удалось ли его отправить.
+// priceDropped различает снижение цены и повышение самой целевой цены
+func (s *WishlistServiceImpl) notifyTargetPrice(ctx context.Context, userID int64, item *models.WishlistItem, priceDropped bool) bool {
+	if !s.notifier.Enabled() {
+		return false
+	}
+
+	text := targetPriceMessage(item, priceDropped)
+	if err := s.notifier.NotifyUser(ctx, userID, text); err != nil {
+		fmt.Printf("Не удалось отправить уведомление о цене пользователю ID=%d: %v\n", userID, err)
+		return false
+	}
+
+	return true
+}
+
+// targetPriceMessage формирует текст уведомления о достижении целевой цены
+func targetPriceMessage(item *models.WishlistItem, priceDropped bool) string {
+	if priceDropped {
+		return fmt.Sprintf("Цена на «%s» снизилась до %.2f руб. (целевая цена %.2f руб.)", item.Title, item.Price, *item.TargetPrice)
+	}
+	return fmt.Sprintf("Целевая цена на «%s» повышена до %.2f руб. и уже достигнута: текущая цена %.2f руб.", item.Title, *item.TargetPrice, item.Price)
+}
+
+// AddAllocation резервирует деньги под элемент списка желаний и возвращает элемент с обновленной суммой резерва
+func (s *WishlistServiceImpl) AddAllocation(ctx context.Context, id int64, userID int64, request *models.CreateWishlistAllocationRequest) (*models.WishlistItem, error) {
+	item, err := s.GetWishlistItem(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.PurchasedAt != nil {
+		return nil, errors.New("элемент списка желаний уже куплен")
+	}
+
+	// Резерв не может быть отрицательным и не должен превышать стоимость
+	allocated := item.Allocated + request.Amount
+	if allocated < 0 {
+		return nil, errors.New("сумма снятия превышает зарезервированную сумму")
+	}
+	if allocated > item.Price {
+		return nil, errors.New("сумма резерва превышает стоимость желания")
+	}
+
+	// Если дата не указана, используем текущую
+	date := request.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	allocation := &models.WishlistAllocation{
+		WishlistID:  id,
+		Amount:      request.Amount,
+		Date:        date,
+		Description: request.Description,
+	}
+	if _, err := s.wishlistRepo.AddAllocation(ctx, allocation); err != nil {
+		return nil, errors.New("ошибка при резервировании средств")
+	}
+
+	return s.GetWishlistItem(ctx, id, userID)
+}
+
+// DeleteAllocation удаляет резервирование под элемент списка желаний
+func (s *WishlistServiceImpl) DeleteAllocation(ctx context.Context, id int64, allocationID int64, userID int64) error {
+	return s.wishlistRepo.DeleteAllocation(ctx, allocationID, id, userID)
+}
+
+// GetAffordabilityPlan строит план покупок из списка желаний. Средний свободный остаток считается
+// по последним полным месяцам, из него вычитаются ежемесячные взносы в цели накоплений.
+// Желания упорядочиваются по приоритету, и дата покупки каждого оценивается по накопленному остатку
+// с учетом уже зарезервированных сумм
+func (s *WishlistServiceImpl) GetAffordabilityPlan(ctx context.Context, userID int64, months int) (*models.AffordabilityPlan, error) {
+	if months < 1 {
+		return nil, errors.New("количество месяцев должно быть положительным")
+	}
+
+	// Определяем анализируемый период: последние полные месяцы до текущего
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("пользователь не найден")
+	}
+	now := time.Now().In(user.Location())
+	periodEnd, _ := utils.CurrentMonthPeriod(now)
+	periodStart := periodEnd.AddDate(0, -months, 0)
+
+	// Получаем доходы и расходы за период
+	totalIncome, err := s.incomeRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, periodStart, periodEnd)
+	if err != nil {
+		return nil, errors.New("ошибка при получении накоплений за период")
+	}
+	totalExpenses, err := s.expenseRepo.GetTotalAmountByUserIDAndPeriod(ctx, userID, periodStart, periodEnd)
+	if err != nil {
+		return nil, errors.New("ошибка при получении трат за период")
+	}
+
+	// Получаем цели накоплений, чтобы учесть обязательные ежемесячные взносы
+	goals, err := s.goalRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении целей")
+	}
+	for i := range goals {
+		applyGoalProgress(&goals[i], now)
+	}
+
+	// Получаем еще не купленные желания
+	items, err := s.GetUserWishlist(ctx, userID, models.WishlistStatusActive)
+	if err != nil {
+		return nil, errors.New("ошибка при получении списка желаний")
+	}
+
+	plan := &models.AffordabilityPlan{
+		MonthsAnalyzed:       months,
+		AverageIncome:        roundMoney(totalIncome / float64(months)),
+		AverageExpenses:      roundMoney(totalExpenses / float64(months)),
+		GoalsRequiredMonthly: roundMoney(summarizeGoals(goals).RequiredMonthly),
+	}
+	plan.AverageSurplus = roundMoney(plan.AverageIncome - plan.AverageExpenses)
+	plan.AvailableMonthly = roundMoney(plan.AverageSurplus - plan.GoalsRequiredMonthly)
+	plan.Items = planWishlistPurchases(items, plan.AvailableMonthly, now)
+
+	for _, item := range plan.Items {
+		plan.TotalRemaining += item.Remaining
+	}
+	plan.TotalRemaining = roundMoney(plan.TotalRemaining)
+
+	return plan, nil
+}
+
+// wishlistPriorityRank возвращает порядок приоритета для сортировки: чем меньше, тем важнее
+func wishlistPriorityRank(priority models.WishlistPriority) int {
+	switch priority {
+	case models.PriorityHigh:
+		return 0
+	case models.PriorityMedium:
+		return 1
+	case models.PriorityLow:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// planWishlistPurchases упорядочивает желания по приоритету и дате добавления и оценивает дату покупки каждого.
+// Свободный остаток копится помесячно, и желание покупается в начале месяца, к которому накоплена
+// стоимость его и всех более приоритетных желаний
+func planWishlistPurchases(items []models.WishlistItem, availableMonthly float64, now time.Time) []models.AffordabilityItem {
+	sort.SliceStable(items, func(i, j int) bool {
+		ri, rj := wishlistPriorityRank(items[i].Priority), wishlistPriorityRank(items[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.Before(items[j].CreatedAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	planned := make([]models.AffordabilityItem, 0, len(items))
+	cumulative := 0.0
+	for _, item := range items {
+		entry := models.AffordabilityItem{
+			Item:      item,
+			Remaining: roundMoney(math.Max(item.Price-item.Allocated, 0)),
+		}
+
+		if entry.Remaining == 0 {
+			// Стоимость полностью зарезервирована: покупать можно уже сейчас
+			date := today
+			entry.FullyFunded = true
+			entry.Affordable = true
+			entry.EstimatedDate = &date
+			entry.CumulativeCost = roundMoney(cumulative)
+			planned = append(planned, entry)
+			continue
+		}
+
+		cumulative += entry.Remaining
+		entry.CumulativeCost = roundMoney(cumulative)
+
+		if availableMonthly > 0 {
+			entry.MonthsNeeded = int(math.Ceil(cumulative / availableMonthly))
+			date := monthStart.AddDate(0, entry.MonthsNeeded, 0)
+			entry.Affordable = true
+			entry.EstimatedDate = &date
+		}
+
+		planned = append(planned, entry)
+	}
+
+	return planned
+}
+
+// roundMoney округляет денежную сумму до копеек
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
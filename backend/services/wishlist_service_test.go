@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"cz.Finance/backend/models"
+)
+
+func TestPlanWishlistPurchases(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	items := []models.WishlistItem{
+		{ID: 1, Title: "Наушники", Price: 5000, Priority: models.PriorityLow, CreatedAt: date(time.January, 1)},
+		{ID: 2, Title: "Велосипед", Price: 20000, Allocated: 5000, Priority: models.PriorityHigh, CreatedAt: date(time.February, 1)},
+		{ID: 3, Title: "Книга", Price: 3000, Allocated: 3000, Priority: models.PriorityHigh, CreatedAt: date(time.March, 1)},
+		{ID: 4, Title: "Палатка", Price: 12000, Priority: models.PriorityMedium, CreatedAt: date(time.January, 1)},
+	}
+
+	type planned struct {
+		id         int64
+		remaining  float64
+		cumulative float64
+		months     int
+		date       *time.Time
+		funded     bool
+	}
+	at := func(month time.Month, day int) *time.Time {
+		value := date(month, day)
+		return &value
+	}
+
+	tests := []struct {
+		name      string
+		available float64
+		want      []planned
+	}{
+		{
+			name:      "по приоритету и дате добавления",
+			available: 10000,
+			want: []planned{
+				{id: 2, remaining: 15000, cumulative: 15000, months: 2, date: at(time.May, 1)},
+				{id: 3, cumulative: 15000, date: at(time.March, 15), funded: true},
+				{id: 4, remaining: 12000, cumulative: 27000, months: 3, date: at(time.June, 1)},
+				{id: 1, remaining: 5000, cumulative: 32000, months: 4, date: at(time.July, 1)},
+			},
+		},
+		{
+			name:      "без свободного остатка доступны только зарезервированные",
+			available: 0,
+			want: []planned{
+				{id: 2, remaining: 15000, cumulative: 15000},
+				{id: 3, cumulative: 15000, date: at(time.March, 15), funded: true},
+				{id: 4, remaining: 12000, cumulative: 27000},
+				{id: 1, remaining: 5000, cumulative: 32000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planWishlistPurchases(append([]models.WishlistItem(nil), items...), tt.available, now)
+			if len(plan) != len(tt.want) {
+				t.Fatalf("получено %d желаний, ожидалось %d", len(plan), len(tt.want))
+			}
+
+			for i, entry := range plan {
+				want := tt.want[i]
+				if entry.Item.ID != want.id {
+					t.Fatalf("позиция %d: желание %d, ожидалось %d", i, entry.Item.ID, want.id)
+				}
+				if entry.Remaining != want.remaining || entry.CumulativeCost != want.cumulative || entry.MonthsNeeded != want.months {
+					t.Errorf("желание %d: осталось %.2f, нарастающим итогом %.2f, месяцев %d, ожидалось %.2f, %.2f, %d",
+						want.id, entry.Remaining, entry.CumulativeCost, entry.MonthsNeeded, want.remaining, want.cumulative, want.months)
+				}
+				if entry.FullyFunded != want.funded || entry.Affordable != (want.date != nil) {
+					t.Errorf("желание %d: зарезервировано %v, доступно %v", want.id, entry.FullyFunded, entry.Affordable)
+				}
+				switch {
+				case want.date == nil && entry.EstimatedDate != nil:
+					t.Errorf("желание %d: получена дата %v, ожидалось без даты", want.id, entry.EstimatedDate)
+				case want.date != nil && (entry.EstimatedDate == nil || !entry.EstimatedDate.Equal(*want.date)):
+					t.Errorf("желание %d: получена дата %v, ожидалась %v", want.id, entry.EstimatedDate, want.date)
+				}
+			}
+		})
+	}
+}
+
+func TestTargetPriceMessage(t *testing.T) {
+	target := 900.0
+	item := &models.WishlistItem{Title: "Наушники", Price: 850, TargetPrice: &target}
+
+	tests := []struct {
+		name         string
+		priceDropped bool
+		want         string
+	}{
+		{
+			name:         "снижение цены",
+			priceDropped: true,
+			want:         "Цена на «Наушники» снизилась до 850.00 руб. (целевая цена 900.00 руб.)",
+		},
+		{
+			name:         "повышение целевой цены",
+			priceDropped: false,
+			want:         "Целевая цена на «Наушники» повышена до 900.00 руб. и уже достигнута: текущая цена 850.00 руб.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := targetPriceMessage(item, tt.priceDropped); got != tt.want {
+				t.Errorf("получено %q, ожидалось %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/backend/repositories"
+	"cz.Finance/backend/utils"
+)
+
+// WishlistShareServiceImpl представляет реализацию сервиса публичных ссылок на список желаний
+type WishlistShareServiceImpl struct {
+	shareRepo repositories.WishlistShareRepository
+	userRepo  repositories.UserRepository
+}
+
+// NewWishlistShareService создает новый экземпляр сервиса публичных ссылок на список желаний
+func NewWishlistShareService(shareRepo repositories.WishlistShareRepository, userRepo repositories.UserRepository) WishlistShareService {
+	return &WishlistShareServiceImpl{
+		shareRepo: shareRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetShare получает настройки публичной ссылки пользователя
+func (s *WishlistShareServiceImpl) GetShare(ctx context.Context, userID int64) (*models.WishlistShare, error) {
+	return s.shareRepo.GetByUserID(ctx, userID)
+}
+
+// UpdateShare включает публичную ссылку или меняет ее настройки.
+// Токен сохраняется, пока пользователь явно не попросит выпустить новый
+func (s *WishlistShareServiceImpl) UpdateShare(ctx context.Context, userID int64, request *models.UpdateWishlistShareRequest) (*models.WishlistShare, error) {
+	share, err := s.shareRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		share = &models.WishlistShare{UserID: userID}
+	}
+
+	if share.Token == "" || request.Regenerate {
+		share.Token, err = utils.GenerateToken()
+		if err != nil {
+			return nil, errors.New("ошибка при создании ссылки")
+		}
+	}
+	share.HidePrices = request.HidePrices
+
+	if err := s.shareRepo.Save(ctx, share); err != nil {
+		return nil, errors.New("ошибка при сохранении ссылки")
+	}
+
+	return share, nil
+}
+
+// RevokeShare отзывает публичную ссылку. Бронирования подарков сохраняются
+func (s *WishlistShareServiceImpl) RevokeShare(ctx context.Context, userID int64) error {
+	return s.shareRepo.Delete(ctx, userID)
+}
+
+// GetPublicWishlist получает открытые элементы списка желаний по токену ссылки
+func (s *WishlistShareServiceImpl) GetPublicWishlist(ctx context.Context, token string) (*models.PublicWishlist, error) {
+	share, err := s.shareRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, share.UserID)
+	if err != nil {
+		return nil, errors.New("публичная ссылка не найдена")
+	}
+
+	items, err := s.shareRepo.GetSharedItems(ctx, share.UserID)
+	if err != nil {
+		return nil, errors.New("ошибка при получении списка желаний")
+	}
+	if items == nil {
+		items = []models.PublicWishlistItem{}
+	}
+
+	// Скрываем цены, если владелец так настроил ссылку
+	if share.HidePrices {
+		for i := range items {
+			items[i].Price = nil
+		}
+	}
+
+	ownerName := owner.FirstName
+	if ownerName == "" {
+		ownerName = owner.Username
+	}
+
+	return &models.PublicWishlist{
+		OwnerName:  ownerName,
+		HidePrices: share.HidePrices,
+		Items:      items,
+	}, nil
+}
+
+// ReserveItem анонимно бронирует подарок. Возвращает токен, по которому бронирование можно отменить
+func (s *WishlistShareServiceImpl) ReserveItem(ctx context.Context, token string, itemID int64, request *models.ReserveWishlistItemRequest) (*models.WishlistReservation, error) {
+	share, err := s.shareRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, errors.New("ошибка при бронировании")
+	}
+
+	reservation := &models.WishlistReservation{
+		WishlistID:   itemID,
+		ReserverName: request.Name,
+		CancelToken:  cancelToken,
+	}
+	if err := s.shareRepo.Reserve(ctx, share.UserID, reservation); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// CancelReservation снимает бронирование подарка по токену отмены
+func (s *WishlistShareServiceImpl) CancelReservation(ctx context.Context, token string, itemID int64, cancelToken string) error {
+	if cancelToken == "" {
+		return errors.New("не указан токен отмены")
+	}
+
+	share, err := s.shareRepo.GetByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return s.shareRepo.CancelReservation(ctx, share.UserID, itemID, cancelToken)
+}
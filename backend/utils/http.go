@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"cz.Finance/backend/models"
 
@@ -83,6 +84,21 @@ func GetIntQueryParam(r *http.Request, name string, defaultValue int) int {
 	return value
 }
 
+// GetListQueryParam извлекает список значений из строки запроса.
+// Значения можно передать через запятую или повторив параметр
+func GetListQueryParam(r *http.Request, name string) []string {
+	var values []string
+	for _, param := range r.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
 // GetUserIDFromContext извлекает ID пользователя из контекста запроса
 func GetUserIDFromContext(r *http.Request) (int64, error) {
 	userID, ok := r.Context().Value(UserIDKey).(int64)
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"time"
+)
+
+// Все периоды полуоткрытые: начало входит в период, конец — нет. Запросы к базе
+// выбирают записи по условию date >= start AND date < end, поэтому последняя секунда
+// месяца не теряется, а соседние периоды не пересекаются
+
+// StartOfDay возвращает начало дня в часовом поясе момента
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// MonthPeriod возвращает период [начало месяца, начало следующего месяца) в указанном часовом поясе
+func MonthPeriod(year int, month time.Month, location *time.Location) (time.Time, time.Time) {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, location)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// CurrentMonthPeriod возвращает период месяца, в который попадает момент, в его часовом поясе
+func CurrentMonthPeriod(now time.Time) (time.Time, time.Time) {
+	return MonthPeriod(now.Year(), now.Month(), now.Location())
+}
+
+// YearPeriod возвращает период [начало года, начало следующего года) в указанном часовом поясе
+func YearPeriod(year int, location *time.Location) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	return start, start.AddDate(1, 0, 0)
+}
+
+// AllTimeStart возвращает начало периода «за все время»
+func AllTimeStart(location *time.Location) time.Time {
+	return time.Date(2000, time.January, 1, 0, 0, 0, 0, location)
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriods(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("не удалось загрузить часовой пояс: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		period    func() (time.Time, time.Time)
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "месяц в UTC",
+			period:    func() (time.Time, time.Time) { return MonthPeriod(2024, time.February, time.UTC) },
+			wantStart: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "декабрь заканчивается в следующем году",
+			period:    func() (time.Time, time.Time) { return MonthPeriod(2023, time.December, moscow) },
+			wantStart: time.Date(2023, time.December, 1, 0, 0, 0, 0, moscow),
+			wantEnd:   time.Date(2024, time.January, 1, 0, 0, 0, 0, moscow),
+		},
+		{
+			// 31 марта 21:30 UTC по Москве уже 1 апреля
+			name: "текущий месяц в часовом поясе момента",
+			period: func() (time.Time, time.Time) {
+				return CurrentMonthPeriod(time.Date(2024, time.March, 31, 21, 30, 0, 0, time.UTC).In(moscow))
+			},
+			wantStart: time.Date(2024, time.April, 1, 0, 0, 0, 0, moscow),
+			wantEnd:   time.Date(2024, time.May, 1, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "последняя секунда месяца",
+			period: func() (time.Time, time.Time) {
+				return CurrentMonthPeriod(time.Date(2024, time.January, 31, 23, 59, 59, 999999999, time.UTC))
+			},
+			wantStart: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "год",
+			period:    func() (time.Time, time.Time) { return YearPeriod(2024, moscow) },
+			wantStart: time.Date(2024, time.January, 1, 0, 0, 0, 0, moscow),
+			wantEnd:   time.Date(2025, time.January, 1, 0, 0, 0, 0, moscow),
+		},
+		{
+			name: "начало дня",
+			period: func() (time.Time, time.Time) {
+				start := StartOfDay(time.Date(2024, time.May, 9, 18, 45, 10, 0, moscow))
+				return start, start.AddDate(0, 0, 1)
+			},
+			wantStart: time.Date(2024, time.May, 9, 0, 0, 0, 0, moscow),
+			wantEnd:   time.Date(2024, time.May, 10, 0, 0, 0, 0, moscow),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := tt.period()
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("получен период [%v, %v), ожидался [%v, %v)", start, end, tt.wantStart, tt.wantEnd)
+			}
+			if start.Location() != tt.wantStart.Location() {
+				t.Errorf("часовой пояс %v, ожидался %v", start.Location(), tt.wantStart.Location())
+			}
+		})
+	}
+}
@@ -47,6 +47,8 @@ func formatValidationError(err validator.FieldError) string {
 		return fmt.Sprintf("Поле '%s' должно быть не более %s", err.Field(), err.Param())
 	case "gt":
 		return fmt.Sprintf("Поле '%s' должно быть больше %s", err.Field(), err.Param())
+	case "timezone":
+		return fmt.Sprintf("Поле '%s' должно содержать часовой пояс, например Europe/Moscow", err.Field())
 	default:
 		return fmt.Sprintf("Поле '%s' не соответствует правилу '%s'", err.Field(), err.Tag())
 	}
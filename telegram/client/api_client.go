@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"cz.Finance/backend/models"
@@ -151,6 +152,35 @@ func (c *APIClient) CreateExpense(userID int64, request *models.CreateExpenseReq
 	return &expense, nil
 }
 
+// SuggestCategory подбирает категорию для траты по названию, описанию и сумме
+func (c *APIClient) SuggestCategory(request *models.SuggestCategoryRequest, telegramID int64) (*models.CategorySuggestions, error) {
+	// Кодируем данные в JSON
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при кодировании JSON: %v", err)
+	}
+
+	// Отправляем запрос
+	resp, err := c.doRequest("POST", "/expenses/suggest-category", jsonData, int(telegramID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp)
+	}
+
+	// Декодируем ответ
+	var suggestions models.CategorySuggestions
+	if err := json.NewDecoder(resp.Body).Decode(&suggestions); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании ответа: %v", err)
+	}
+
+	return &suggestions, nil
+}
+
 // CreateIncome создает новое поступление
 func (c *APIClient) CreateIncome(userID int64, request *models.CreateIncomeRequest, telegramID int64) (*models.Income, error) {
 	// Кодируем данные в JSON
@@ -316,6 +346,164 @@ func (c *APIClient) GetBudgetGoals(telegramID int64) (map[string]interface{}, er
 	return goals, nil
 }
 
+// GetMonthlyReport получает PDF-отчет за указанный месяц
+func (c *APIClient) GetMonthlyReport(year, month int, telegramID int64) ([]byte, error) {
+	// Отправляем запрос
+	resp, err := c.doRequest("GET", fmt.Sprintf("/reports/monthly/%d/%d.pdf", year, month), nil, int(telegramID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp)
+	}
+
+	// Читаем файл отчета
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при чтении ответа: %v", err)
+	}
+
+	return data, nil
+}
+
+// GetActiveWishlist получает еще не купленные элементы списка желаний
+func (c *APIClient) GetActiveWishlist(telegramID int64) ([]models.WishlistItem, error) {
+	// Отправляем запрос
+	resp, err := c.doRequest("GET", "/wishlist?status=active", nil, int(telegramID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp)
+	}
+
+	// Декодируем ответ
+	var items []models.WishlistItem
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании ответа: %v", err)
+	}
+
+	return items, nil
+}
+
+// RecordWishlistPrice записывает наблюдаемую цену элемента списка желаний
+func (c *APIClient) RecordWishlistPrice(id int64, price float64, telegramID int64) (*models.WishlistPriceResult, error) {
+	// Кодируем данные в JSON
+	jsonData, err := json.Marshal(&models.RecordWishlistPriceRequest{
+		Price:  price,
+		Source: models.PriceSourceTelegram,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при кодировании JSON: %v", err)
+	}
+
+	// Отправляем запрос
+	resp, err := c.doRequest("POST", fmt.Sprintf("/wishlist/%d/prices", id), jsonData, int(telegramID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusCreated {
+		return nil, c.handleErrorResponse(resp)
+	}
+
+	// Декодируем ответ
+	var result models.WishlistPriceResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании ответа: %v", err)
+	}
+
+	return &result, nil
+}
+
+// GetUnreadAlerts получает непрочитанные предупреждения о необычных тратах
+func (c *APIClient) GetUnreadAlerts(telegramID int64) ([]models.Alert, error) {
+	// Отправляем запрос
+	resp, err := c.doRequest("GET", "/alerts?unread=true", nil, int(telegramID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp)
+	}
+
+	// Декодируем ответ
+	var alerts []models.Alert
+	if err := json.NewDecoder(resp.Body).Decode(&alerts); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании ответа: %v", err)
+	}
+
+	return alerts, nil
+}
+
+// ComparePeriods сравнивает траты и накопления за период с предыдущим периодом
+// или, если yearOverYear, с тем же периодом год назад. Нулевые даты не передаются
+func (c *APIClient) ComparePeriods(startDate, endDate time.Time, yearOverYear bool, telegramID int64) (*models.PeriodComparison, error) {
+	path := "/dashboard/compare"
+	if yearOverYear {
+		path += "/yearly"
+	}
+
+	params := url.Values{}
+	if !startDate.IsZero() {
+		params.Set("start_date", startDate.Format(time.RFC3339))
+	}
+	if !endDate.IsZero() {
+		params.Set("end_date", endDate.Format(time.RFC3339))
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	// Отправляем запрос
+	resp, err := c.doRequest("GET", path, nil, int(telegramID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleErrorResponse(resp)
+	}
+
+	// Декодируем ответ
+	var comparison models.PeriodComparison
+	if err := json.NewDecoder(resp.Body).Decode(&comparison); err != nil {
+		return nil, fmt.Errorf("ошибка при декодировании ответа: %v", err)
+	}
+
+	return &comparison, nil
+}
+
+// MarkAlertsRead отмечает все предупреждения прочитанными
+func (c *APIClient) MarkAlertsRead(telegramID int64) error {
+	// Отправляем запрос
+	resp, err := c.doRequest("PUT", "/alerts/read", nil, int(telegramID))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Проверяем статус ответа
+	if resp.StatusCode != http.StatusOK {
+		return c.handleErrorResponse(resp)
+	}
+
+	return nil
+}
+
 // UnlinkAccount отвязывает аккаунт Telegram от аккаунта пользователя
 func (c *APIClient) UnlinkAccount(telegramID int64) error {
 	fmt.Printf("Отправляем запрос на отвязку аккаунта для Telegram ID %d\n", telegramID)
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"fmt"
 	"strconv"
 	"strings"
@@ -15,12 +16,14 @@ import (
 // BotHandlers структура для обработчиков бота
 type BotHandlers struct {
 	apiClient *client.APIClient
+	pending   *pendingExpenses
 }
 
 // NewBotHandlers создает новые обработчики для бота
 func NewBotHandlers(apiClient *client.APIClient) *BotHandlers {
 	return &BotHandlers{
 		apiClient: apiClient,
+		pending:   newPendingExpenses(),
 	}
 }
 
@@ -54,8 +57,23 @@ func (h *BotHandlers) RegisterHandlers(bot *telebot.Bot) {
 	bot.Handle("/budget", h.HandleBudgetGoals)
 	bot.Handle("/setbudget", h.HandleSetBudgetGoal)
 
+	// Обработчик команды /report
+	bot.Handle("/report", h.HandleReport)
+
+	// Обработчик команды /price
+	bot.Handle("/price", h.HandlePrice)
+
+	// Обработчик команды /alerts
+	bot.Handle("/alerts", h.HandleAlerts)
+
+	// Обработчик команды /compare
+	bot.Handle("/compare", h.HandleCompare)
+
 	// Обработчик для добавления траты
 	bot.Handle(telebot.OnText, h.HandleMessage)
+
+	// Обработчик выбора категории для траты без категории
+	bot.Handle(&telebot.Btn{Unique: expenseCategoryUnique}, h.HandleExpenseCategory)
 }
 
 // HandleStart обрабатывает команду /start
@@ -73,6 +91,10 @@ func (h *BotHandlers) HandleStart(c telebot.Context) error {
 /category - Расходы по категории
 /budget - Посмотреть бюджетные цели
 /setbudget - Установить бюджетную цель
+/report - PDF-отчет за месяц
+/price - Записать цену желания
+/alerts - Предупреждения о необычных тратах
+/compare - Сравнение трат с прошлым месяцем или годом
 
 Чтобы связать аккаунт, используйте команду /link и введите ваш email и пароль в формате:
 /link email@example.com password
@@ -156,6 +178,9 @@ func (h *BotHandlers) HandleExpenseCommand(c telebot.Context) error {
 - Образование
 - Путешествия
 - Другое
+
+Категорию можно не указывать: "Пятерочка 1300".
+Бот подберет ее по истории трат, а если не уверен, предложит выбрать.
 `
 	return c.Send(expenseTemplate)
 }
@@ -196,8 +221,8 @@ func (h *BotHandlers) HandleBalance(c telebot.Context) error {
 		return c.Send("Вы не связали аккаунт. Используйте команду /link")
 	}
 
-	// Получаем текущий месяц и год
-	now := time.Now()
+	// Получаем текущий месяц и год в часовом поясе пользователя
+	now := time.Now().In(user.Location())
 	year, month, _ := now.Date()
 
 	// Получаем статистику за текущий месяц через API
@@ -412,7 +437,7 @@ func (h *BotHandlers) HandleCategory(c telebot.Context) error {
 	telegramID := c.Sender().ID
 
 	// Проверяем, связан ли аккаунт
-	_, err := h.apiClient.GetUserByTelegramID(telegramID)
+	user, err := h.apiClient.GetUserByTelegramID(telegramID)
 	if err != nil {
 		return c.Send("Вы не связали аккаунт. Используйте команду /link")
 	}
@@ -468,7 +493,7 @@ func (h *BotHandlers) HandleCategory(c telebot.Context) error {
 
 	totalAmount := 0.0
 	for i := 0; i < limit; i++ {
-		date := expenses[i].CreatedAt.Format("2006-01-02")
+		date := expenses[i].CreatedAt.In(user.Location()).Format("2006-01-02")
 		message += fmt.Sprintf("- %s | %s | %.2f руб.\n",
 			date, expenses[i].Title, expenses[i].Amount)
 		totalAmount += expenses[i].Amount
@@ -589,6 +614,225 @@ func (h *BotHandlers) HandleSetBudgetGoal(c telebot.Context) error {
 	return c.Send(fmt.Sprintf("Бюджетная цель для категории '%s' установлена: %.2f руб.", category, amount))
 }
 
+// HandleReport обрабатывает команду /report и отправляет PDF-отчет за месяц
+func (h *BotHandlers) HandleReport(c telebot.Context) error {
+	telegramID := c.Sender().ID
+
+	// Проверяем, связан ли аккаунт
+	user, err := h.apiClient.GetUserByTelegramID(telegramID)
+	if err != nil {
+		return c.Send("Вы не связали аккаунт. Используйте команду /link")
+	}
+
+	// По умолчанию формируем отчет за текущий месяц в часовом поясе пользователя
+	period := time.Now().In(user.Location())
+	args := c.Args()
+	if len(args) > 0 {
+		period, err = time.Parse("2006-01", args[0])
+		if err != nil {
+			return c.Send("Неверный формат периода. Используйте: /report ГГГГ-ММ, например /report 2024-03")
+		}
+	}
+
+	// Получаем отчет через API
+	data, err := h.apiClient.GetMonthlyReport(period.Year(), int(period.Month()), telegramID)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Ошибка при формировании отчета: %s", err.Error()))
+	}
+
+	document := &telebot.Document{
+		File:     telebot.FromReader(bytes.NewReader(data)),
+		FileName: fmt.Sprintf("report-%s.pdf", period.Format("2006-01")),
+		MIME:     "application/pdf",
+		Caption:  fmt.Sprintf("Финансовый отчет за %s", period.Format("01.2006")),
+	}
+
+	return c.Send(document)
+}
+
+// HandlePrice обрабатывает команду /price для записи наблюдаемой цены элемента списка желаний
+func (h *BotHandlers) HandlePrice(c telebot.Context) error {
+	telegramID := c.Sender().ID
+
+	// Проверяем, связан ли аккаунт
+	_, err := h.apiClient.GetUserByTelegramID(telegramID)
+	if err != nil {
+		return c.Send("Вы не связали аккаунт. Используйте команду /link")
+	}
+
+	args := c.Args()
+	if len(args) != 2 {
+		// Если аргументы не указаны, выводим список желаний с номерами
+		items, err := h.apiClient.GetActiveWishlist(telegramID)
+		if err != nil {
+			return c.Send(fmt.Sprintf("Ошибка при получении списка желаний: %s", err.Error()))
+		}
+		if len(items) == 0 {
+			return c.Send("Список желаний пуст.")
+		}
+
+		message := "Укажите номер желания и новую цену.\nНапример: /price 12 4990\n\nСписок желаний:\n"
+		for _, item := range items {
+			message += fmt.Sprintf("%d. %s — %.2f руб.", item.ID, item.Title, item.Price)
+			if item.TargetPrice != nil {
+				message += fmt.Sprintf(" (цель %.2f руб.)", *item.TargetPrice)
+			}
+			message += "\n"
+		}
+
+		return c.Send(message)
+	}
+
+	// Получаем номер желания и цену
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return c.Send("Неверный номер желания. Используйте: /price номер цена")
+	}
+
+	price, err := strconv.ParseFloat(strings.Replace(args[1], ",", ".", 1), 64)
+	if err != nil || price <= 0 {
+		return c.Send("Неверный формат цены. Введите число, например: 4990")
+	}
+
+	// Записываем цену через API
+	result, err := h.apiClient.RecordWishlistPrice(id, price, telegramID)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Ошибка при записи цены: %s", err.Error()))
+	}
+
+	message := fmt.Sprintf("Цена на «%s» записана: %.2f руб.", result.Item.Title, result.Price.Price)
+	if result.TargetReached && !result.Notified {
+		message += fmt.Sprintf("\nЦена достигла целевой (%.2f руб.)!", *result.Item.TargetPrice)
+	}
+
+	return c.Send(message)
+}
+
+// HandleAlerts обрабатывает команду /alerts: показывает непрочитанные предупреждения
+// о необычных тратах и отмечает их прочитанными
+func (h *BotHandlers) HandleAlerts(c telebot.Context) error {
+	telegramID := c.Sender().ID
+
+	// Проверяем, связан ли аккаунт
+	user, err := h.apiClient.GetUserByTelegramID(telegramID)
+	if err != nil {
+		return c.Send("Вы не связали аккаунт. Используйте команду /link")
+	}
+
+	// Получаем предупреждения
+	alerts, err := h.apiClient.GetUnreadAlerts(telegramID)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Ошибка при получении предупреждений: %s", err.Error()))
+	}
+
+	if len(alerts) == 0 {
+		return c.Send("Новых предупреждений нет, траты выглядят обычно.")
+	}
+
+	// Форматируем сообщение
+	message := "Предупреждения о необычных тратах:\n\n"
+	for _, alert := range alerts {
+		message += fmt.Sprintf("⚠️ %s (%s)\n", alert.Message, alert.CreatedAt.In(user.Location()).Format("02.01.2006"))
+	}
+
+	if err := h.apiClient.MarkAlertsRead(telegramID); err != nil {
+		fmt.Printf("Не удалось отметить предупреждения прочитанными: %v\n", err)
+	}
+
+	return c.Send(message)
+}
+
+// compareTopMovers ограничивает количество категорий в ответе на команду /compare
+const compareTopMovers = 5
+
+// HandleCompare обрабатывает команду /compare: сравнивает траты с тем же отрезком прошлого месяца
+// или, с аргументом «год», с тем же периодом год назад и показывает категории с наибольшими изменениями.
+// Месяц для сравнения можно указать в формате ГГГГ-ММ, по умолчанию берется текущий
+func (h *BotHandlers) HandleCompare(c telebot.Context) error {
+	telegramID := c.Sender().ID
+
+	// Проверяем, связан ли аккаунт
+	user, err := h.apiClient.GetUserByTelegramID(telegramID)
+	if err != nil {
+		return c.Send("Вы не связали аккаунт. Используйте команду /link")
+	}
+
+	// Разбираем месяц и режим сравнения
+	var startDate, endDate time.Time
+	yearOverYear := false
+	for _, arg := range c.Args() {
+		switch strings.ToLower(arg) {
+		case "год", "year":
+			yearOverYear = true
+		default:
+			month, err := time.ParseInLocation("2006-01", arg, user.Location())
+			if err != nil {
+				return c.Send("Неверный формат команды. Используйте: /compare [ГГГГ-ММ] [год], например /compare 2024-03 год")
+			}
+			startDate = month
+			endDate = month.AddDate(0, 1, 0)
+		}
+	}
+
+	// Получаем сравнение через API
+	comparison, err := h.apiClient.ComparePeriods(startDate, endDate, yearOverYear, telegramID)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Ошибка при сравнении периодов: %s", err.Error()))
+	}
+
+	// Форматируем сообщение
+	location := user.Location()
+	message := fmt.Sprintf("Сравнение трат\n🗓 %s\nс периодом %s\n\n",
+		formatComparisonPeriod(comparison.Current, location), formatComparisonPeriod(comparison.Previous, location))
+	message += fmt.Sprintf("💸 Расходы: %.2f руб. (%s)\n", comparison.Expenses.Current,
+		formatComparisonChange(comparison.Expenses.Change, comparison.Expenses.ChangePercent))
+	message += fmt.Sprintf("💰 Поступления: %.2f руб. (%s)\n", comparison.Incomes.Current,
+		formatComparisonChange(comparison.Incomes.Change, comparison.Incomes.ChangePercent))
+
+	movers := make([]models.ComparisonItem, 0, compareTopMovers)
+	for _, item := range comparison.Categories {
+		if len(movers) == compareTopMovers {
+			break
+		}
+		if item.Change != 0 {
+			movers = append(movers, item)
+		}
+	}
+
+	if len(movers) == 0 {
+		message += "\nТраты по категориям не изменились."
+		return c.Send(message)
+	}
+
+	message += "\nСильнее всего изменились:\n"
+	for _, item := range movers {
+		icon := "📈"
+		if item.Change < 0 {
+			icon = "📉"
+		}
+		message += fmt.Sprintf("%s %s: %.2f руб. (%s)\n", icon, categoryTitle(models.ExpenseCategory(item.Name)), item.Current,
+			formatComparisonChange(item.Change, item.ChangePercent))
+	}
+
+	return c.Send(message)
+}
+
+// formatComparisonPeriod форматирует границы периода в часовом поясе пользователя.
+// Конец периода не входит в него, поэтому показывается предыдущая секунда
+func formatComparisonPeriod(period models.ComparisonPeriod, location *time.Location) string {
+	return fmt.Sprintf("%s – %s",
+		period.StartDate.In(location).Format("02.01.2006"),
+		period.EndDate.In(location).Add(-time.Second).Format("02.01.2006"))
+}
+
+// formatComparisonChange форматирует изменение суммы со знаком и, если он известен, процент изменения
+func formatComparisonChange(change float64, percent *float64) string {
+	if percent == nil {
+		return fmt.Sprintf("%+.2f руб.", change)
+	}
+	return fmt.Sprintf("%+.2f руб., %+.1f%%", change, *percent)
+}
+
 // HandleMessage обрабатывает текстовые сообщения
 func (h *BotHandlers) HandleMessage(c telebot.Context) error {
 	// Пропускаем команды
@@ -618,8 +862,8 @@ func (h *BotHandlers) HandleMessage(c telebot.Context) error {
 		// Парсим сообщение как поступление
 		return h.handleIncome(c, user)
 	default:
-		// Если не удалось определить, спрашиваем пользователя
-		return c.Send("Не удалось определить тип операции. Пожалуйста, используйте команды /expense или /income для добавления трат или поступлений.")
+		// Пробуем разобрать сообщение как трату без категории и подобрать ее по истории
+		return h.handleQuickExpense(c, user)
 	}
 }
 
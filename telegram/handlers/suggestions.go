@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"cz.Finance/backend/models"
+	"cz.Finance/telegram/parsers"
+	"gopkg.in/telebot.v3"
+)
+
+// pendingExpenseTTL определяет, сколько трата ждет подтверждения категории
+const pendingExpenseTTL = 30 * time.Minute
+
+// expenseCategoryUnique идентифицирует кнопки выбора категории траты
+const expenseCategoryUnique = "expcat"
+
+// pendingExpense хранит трату, ожидающую подтверждения категории
+type pendingExpense struct {
+	telegramID int64
+	userID     int64
+	request    *models.CreateExpenseRequest
+	expiresAt  time.Time
+}
+
+// pendingExpenses хранит траты, ожидающие выбора категории пользователем
+type pendingExpenses struct {
+	mu     sync.Mutex
+	nextID int64
+	items  map[int64]*pendingExpense
+}
+
+// newPendingExpenses создает пустое хранилище ожидающих трат
+func newPendingExpenses() *pendingExpenses {
+	return &pendingExpenses{items: make(map[int64]*pendingExpense)}
+}
+
+// add сохраняет трату и возвращает ее идентификатор
+func (p *pendingExpenses) add(expense *pendingExpense) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Удаляем устаревшие траты, чтобы хранилище не росло
+	now := time.Now()
+	for id, item := range p.items {
+		if now.After(item.expiresAt) {
+			delete(p.items, id)
+		}
+	}
+
+	p.nextID++
+	expense.expiresAt = now.Add(pendingExpenseTTL)
+	p.items[p.nextID] = expense
+	return p.nextID
+}
+
+// take извлекает трату пользователя, если она еще не устарела
+func (p *pendingExpenses) take(id, telegramID int64) *pendingExpense {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	expense, ok := p.items[id]
+	if !ok || expense.telegramID != telegramID {
+		return nil
+	}
+	delete(p.items, id)
+
+	if time.Now().After(expense.expiresAt) {
+		return nil
+	}
+	return expense
+}
+
+// categoryTitle возвращает название категории для отображения
+func categoryTitle(category models.ExpenseCategory) string {
+	if title, ok := models.ExpenseCategoryTitles[category]; ok {
+		return title
+	}
+	return string(category)
+}
+
+// handleQuickExpense обрабатывает трату без категории: категория подбирается по истории трат,
+// а при неуверенной подсказке пользователю предлагается выбрать ее кнопками
+func (h *BotHandlers) handleQuickExpense(c telebot.Context, user *models.User) error {
+	telegramID := c.Sender().ID
+
+	// Парсим сообщение
+	expenseRequest, err := parsers.ParseQuickExpense(c.Message().Text)
+	if err != nil {
+		return c.Send("Не удалось определить тип операции. Пожалуйста, используйте команды /expense или /income для добавления трат или поступлений.")
+	}
+
+	// Запрашиваем подсказку категории
+	suggestions, err := h.apiClient.SuggestCategory(&models.SuggestCategoryRequest{
+		Title:       expenseRequest.Title,
+		Description: expenseRequest.Description,
+		Amount:      expenseRequest.Amount,
+	}, telegramID)
+	if err != nil {
+		return c.Send(fmt.Sprintf("Ошибка при подборе категории: %s", err.Error()))
+	}
+
+	// Уверенную подсказку применяем сразу
+	if suggestions.Confident && len(suggestions.Suggestions) > 0 {
+		top := suggestions.Suggestions[0]
+		expenseRequest.Category = top.Category
+
+		expense, err := h.apiClient.CreateExpense(user.ID, expenseRequest, telegramID)
+		if err != nil {
+			return c.Send(fmt.Sprintf("Ошибка при добавлении траты: %s", err.Error()))
+		}
+
+		return c.Send(fmt.Sprintf("Трата успешно добавлена:\n- Категория: %s (определена автоматически, %.0f%%)\n- Наименование: %s\n- Сумма: %.2f руб.",
+			categoryTitle(expense.Category), top.Confidence*100, expense.Title, expense.Amount))
+	}
+
+	// Иначе сохраняем трату и просим выбрать категорию
+	pendingID := h.pending.add(&pendingExpense{
+		telegramID: telegramID,
+		userID:     user.ID,
+		request:    expenseRequest,
+	})
+	data := strconv.FormatInt(pendingID, 10)
+
+	markup := &telebot.ReplyMarkup{}
+	var rows []telebot.Row
+	offered := make(map[models.ExpenseCategory]bool)
+	for _, suggestion := range suggestions.Suggestions {
+		title := fmt.Sprintf("%s (%.0f%%)", categoryTitle(suggestion.Category), suggestion.Confidence*100)
+		rows = append(rows, markup.Row(markup.Data(title, expenseCategoryUnique, data, string(suggestion.Category))))
+		offered[suggestion.Category] = true
+	}
+	if !offered[models.CategoryOther] {
+		rows = append(rows, markup.Row(markup.Data(categoryTitle(models.CategoryOther), expenseCategoryUnique, data, string(models.CategoryOther))))
+	}
+	markup.Inline(rows...)
+
+	message := fmt.Sprintf("Выберите категорию для траты «%s» на %.2f руб.:", expenseRequest.Title, expenseRequest.Amount)
+	if len(suggestions.Suggestions) == 0 {
+		message = fmt.Sprintf("Недостаточно истории трат, чтобы подобрать категорию для «%s».\nОтправьте сообщение в формате /expense или выберите «Другое»:", expenseRequest.Title)
+	}
+
+	return c.Send(message, markup)
+}
+
+// HandleExpenseCategory обрабатывает выбор категории для ожидающей траты
+func (h *BotHandlers) HandleExpenseCategory(c telebot.Context) error {
+	telegramID := c.Sender().ID
+
+	// Разбираем данные кнопки: идентификатор траты и категория
+	args := c.Args()
+	if len(args) != 2 {
+		return c.Respond(&telebot.CallbackResponse{Text: "Некорректные данные кнопки"})
+	}
+
+	pendingID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return c.Respond(&telebot.CallbackResponse{Text: "Некорректные данные кнопки"})
+	}
+
+	expense := h.pending.take(pendingID, telegramID)
+	if expense == nil {
+		_ = c.Respond(&telebot.CallbackResponse{Text: "Трата устарела"})
+		return c.Edit("Время на выбор категории истекло. Отправьте трату еще раз.")
+	}
+
+	// Добавляем трату с выбранной категорией
+	expense.request.Category = models.ExpenseCategory(args[1])
+	created, err := h.apiClient.CreateExpense(expense.userID, expense.request, telegramID)
+	if err != nil {
+		_ = c.Respond(&telebot.CallbackResponse{Text: "Ошибка при добавлении траты"})
+		return c.Edit(fmt.Sprintf("Ошибка при добавлении траты: %s", err.Error()))
+	}
+
+	_ = c.Respond()
+	return c.Edit(fmt.Sprintf("Трата успешно добавлена:\n- Категория: %s\n- Наименование: %s\n- Сумма: %.2f руб.",
+		categoryTitle(created.Category), created.Title, created.Amount))
+}
@@ -92,3 +92,38 @@ func ParseExpense(message string) (*models.CreateExpenseRequest, error) {
 
 	return request, nil
 }
+
+// ParseQuickExpense парсит сообщение траты без категории и возвращает запрос с пустой категорией.
+// Формат сообщения: "Наименование Сумма [Описание]", наименование может состоять из нескольких слов
+func ParseQuickExpense(message string) (*models.CreateExpenseRequest, error) {
+	words := strings.Fields(message)
+
+	// Сумма — первое число после наименования
+	for i := 1; i < len(words); i++ {
+		amount, err := strconv.ParseFloat(strings.Replace(words[i], ",", ".", -1), 64)
+		if err != nil {
+			continue
+		}
+
+		if amount <= 0 {
+			return nil, fmt.Errorf("сумма должна быть положительным числом")
+		}
+
+		title := strings.Join(words[:i], " ")
+		if len([]rune(title)) < 2 {
+			return nil, fmt.Errorf("название должно содержать не менее 2 символов")
+		}
+		if len([]rune(title)) > 100 {
+			title = string([]rune(title)[:100])
+		}
+
+		return &models.CreateExpenseRequest{
+			Title:       title,
+			Amount:      amount,
+			Date:        time.Now(),
+			Description: strings.Join(words[i+1:], " "),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("неверный формат сообщения. Используйте: 'Наименование Сумма [Описание]'")
+}